@@ -16,6 +16,7 @@ package main
 
 import (
 	"flag"
+	"time"
 
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -34,6 +35,15 @@ var pProfPort int
 var maxConcurrentDeliveries int
 var maxConcurrentWorkloads int
 var maxConcurrentRunnables int
+var maxConcurrentResources int
+var minResyncPeriod time.Duration
+var cloudEventsEndpoint string
+var cloudEventsQueueSize int
+var cloudEventsProvenance bool
+var tracingExporter string
+var tracingOTLPEndpoint string
+var tracingOTLPInsecure bool
+var yttBinary string
 
 func init() {
 	flag.IntVar(&port, "Port", 9443, "Webhook server Port")
@@ -45,6 +55,15 @@ func init() {
 	flag.IntVar(&maxConcurrentDeliveries, "max-concurrent-deliveries", 2, "Maximum Concurrent Deliveries")
 	flag.IntVar(&maxConcurrentWorkloads, "max-concurrent-workloads", 2, "Maximum Concurrent Workloads")
 	flag.IntVar(&maxConcurrentRunnables, "max-concurrent-runnables", 2, "Maximum Concurrent Runnables")
+	flag.IntVar(&maxConcurrentResources, "max-concurrent-resources", 4, "Maximum Concurrent Resources realized per Workload or Deliverable")
+	flag.DurationVar(&minResyncPeriod, "min-resync-period", time.Minute, "Minimum resyncPeriod of a resource, shorter periods are raised to it")
+	flag.StringVar(&cloudEventsEndpoint, "cloudevents-endpoint", "", "HTTP endpoint to POST CloudEvents for resource output and health changes to, disabled if empty")
+	flag.IntVar(&cloudEventsQueueSize, "cloudevents-queue-size", 1000, "Maximum CloudEvents waiting for delivery, later events are dropped")
+	flag.BoolVar(&cloudEventsProvenance, "cloudevents-provenance", false, "Send an in-toto/SLSA provenance statement as a CloudEvent for every image produced by a resource")
+	flag.StringVar(&tracingExporter, "tracing-exporter", "none", "Exporter of OpenTelemetry traces, one of none, otlp or stdout")
+	flag.StringVar(&tracingOTLPEndpoint, "tracing-otlp-endpoint", "", "host:port of the OTLP gRPC collector, defaults to OTEL_EXPORTER_OTLP_ENDPOINT or localhost:4317")
+	flag.BoolVar(&tracingOTLPInsecure, "tracing-otlp-insecure", false, "Export traces to the OTLP collector without TLS")
+	flag.StringVar(&yttBinary, "ytt-binary", "", "Path of a ytt executable to evaluate ytt templates with, the embedded ytt is used if empty")
 	flag.Parse()
 }
 
@@ -63,6 +82,15 @@ func main() {
 		MaxConcurrentDeliveries: maxConcurrentDeliveries,
 		MaxConcurrentWorkloads:  maxConcurrentWorkloads,
 		MaxConcurrentRunnables:  maxConcurrentRunnables,
+		MaxConcurrentResources:  maxConcurrentResources,
+		MinResyncPeriod:         minResyncPeriod,
+		CloudEventsEndpoint:     cloudEventsEndpoint,
+		CloudEventsQueueSize:    cloudEventsQueueSize,
+		CloudEventsProvenance:   cloudEventsProvenance,
+		TracingExporter:         tracingExporter,
+		TracingOTLPEndpoint:     tracingOTLPEndpoint,
+		TracingOTLPInsecure:     tracingOTLPInsecure,
+		YttBinary:               yttBinary,
 	}
 
 	if err = c.Execute(ctrl.SetupSignalHandler()); err != nil {
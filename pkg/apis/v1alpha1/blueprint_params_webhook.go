@@ -0,0 +1,485 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// ClusterSupplyChainValidator runs the ClusterSupplyChain's own validations, expands the
+// sub-chains it references and then checks the params it passes to its templates against
+// the schemas those templates declare.
+// +kubebuilder:object:generate=false
+type ClusterSupplyChainValidator struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &ClusterSupplyChainValidator{}
+
+func NewClusterSupplyChainValidator(c client.Reader) *ClusterSupplyChainValidator {
+	return &ClusterSupplyChainValidator{Client: c}
+}
+
+func (v *ClusterSupplyChainValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	supplyChain, ok := obj.(*ClusterSupplyChain)
+	if !ok {
+		return nil, fmt.Errorf("expected a clustersupplychain, found %T", obj)
+	}
+
+	if warnings, err := supplyChain.ValidateCreate(); err != nil {
+		return warnings, err
+	}
+
+	return nil, v.validateReferences(ctx, supplyChain)
+}
+
+func (v *ClusterSupplyChainValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	supplyChain, ok := newObj.(*ClusterSupplyChain)
+	if !ok {
+		return nil, fmt.Errorf("expected a clustersupplychain, found %T", newObj)
+	}
+
+	if warnings, err := supplyChain.ValidateUpdate(oldObj); err != nil {
+		return warnings, err
+	}
+
+	return nil, v.validateReferences(ctx, supplyChain)
+}
+
+func (v *ClusterSupplyChainValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ClusterSupplyChainValidator) validateReferences(ctx context.Context, supplyChain *ClusterSupplyChain) error {
+	resources, err := validateSubChains(ctx, v.Client, supplyChain)
+	if err != nil {
+		return fmt.Errorf("error validating clustersupplychain [%s]: %w", supplyChain.Name, err)
+	}
+
+	var consumers []templateConsumer
+	for _, resource := range resources {
+		consumers = append(consumers, templateConsumer{
+			resourceName:  resource.Name,
+			templateKind:  resource.TemplateRef.Kind,
+			templateNames: templateNames(resource.TemplateRef.Name, resource.TemplateRef.Options),
+			params:        resource.Params,
+			outputs:       resource.Outputs,
+		})
+	}
+
+	if err := validateParamsAgainstTemplates(ctx, v.Client, supplyChain.Namespace, supplyChain.Spec.Params, consumers); err != nil {
+		return fmt.Errorf("error validating clustersupplychain [%s]: %w", supplyChain.Name, err)
+	}
+
+	if err := validateOutputsAgainstTemplates(ctx, v.Client, supplyChain.Namespace, consumers); err != nil {
+		return fmt.Errorf("error validating clustersupplychain [%s]: %w", supplyChain.Name, err)
+	}
+	return nil
+}
+
+// ClusterDeliveryValidator runs the ClusterDelivery's own validations and then checks
+// the params it passes to its templates against the schemas those templates declare.
+// +kubebuilder:object:generate=false
+type ClusterDeliveryValidator struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &ClusterDeliveryValidator{}
+
+func NewClusterDeliveryValidator(c client.Reader) *ClusterDeliveryValidator {
+	return &ClusterDeliveryValidator{Client: c}
+}
+
+func (v *ClusterDeliveryValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	delivery, ok := obj.(*ClusterDelivery)
+	if !ok {
+		return nil, fmt.Errorf("expected a clusterdelivery, found %T", obj)
+	}
+
+	if warnings, err := delivery.ValidateCreate(); err != nil {
+		return warnings, err
+	}
+
+	return nil, v.validateTemplateParams(ctx, delivery)
+}
+
+func (v *ClusterDeliveryValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	delivery, ok := newObj.(*ClusterDelivery)
+	if !ok {
+		return nil, fmt.Errorf("expected a clusterdelivery, found %T", newObj)
+	}
+
+	if warnings, err := delivery.ValidateUpdate(oldObj); err != nil {
+		return warnings, err
+	}
+
+	return nil, v.validateTemplateParams(ctx, delivery)
+}
+
+func (v *ClusterDeliveryValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *ClusterDeliveryValidator) validateTemplateParams(ctx context.Context, delivery *ClusterDelivery) error {
+	var consumers []templateConsumer
+	for _, resource := range delivery.Spec.Resources {
+		consumers = append(consumers, templateConsumer{
+			resourceName:  resource.Name,
+			templateKind:  resource.TemplateRef.Kind,
+			templateNames: templateNames(resource.TemplateRef.Name, resource.TemplateRef.Options),
+			params:        resource.Params,
+			outputs:       resource.Outputs,
+		})
+	}
+
+	if err := validateParamsAgainstTemplates(ctx, v.Client, delivery.Namespace, delivery.Spec.Params, consumers); err != nil {
+		return fmt.Errorf("error validating clusterdelivery [%s]: %w", delivery.Name, err)
+	}
+
+	if err := validateOutputsAgainstTemplates(ctx, v.Client, delivery.Namespace, consumers); err != nil {
+		return fmt.Errorf("error validating clusterdelivery [%s]: %w", delivery.Name, err)
+	}
+	return nil
+}
+
+// SupplyChainValidator runs the SupplyChain's own validations, expands the sub-chains it
+// references and then checks the params it passes to its templates against the schemas
+// those templates declare.
+// +kubebuilder:object:generate=false
+type SupplyChainValidator struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &SupplyChainValidator{}
+
+func NewSupplyChainValidator(c client.Reader) *SupplyChainValidator {
+	return &SupplyChainValidator{Client: c}
+}
+
+func (v *SupplyChainValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	supplyChain, ok := obj.(*SupplyChain)
+	if !ok {
+		return nil, fmt.Errorf("expected a supplychain, found %T", obj)
+	}
+
+	if warnings, err := supplyChain.ValidateCreate(); err != nil {
+		return warnings, err
+	}
+
+	return nil, v.validateReferences(ctx, supplyChain)
+}
+
+func (v *SupplyChainValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	supplyChain, ok := newObj.(*SupplyChain)
+	if !ok {
+		return nil, fmt.Errorf("expected a supplychain, found %T", newObj)
+	}
+
+	if warnings, err := supplyChain.ValidateUpdate(oldObj); err != nil {
+		return warnings, err
+	}
+
+	return nil, v.validateReferences(ctx, supplyChain)
+}
+
+func (v *SupplyChainValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *SupplyChainValidator) validateReferences(ctx context.Context, supplyChain *SupplyChain) error {
+	resources, err := validateSubChains(ctx, v.Client, supplyChain)
+	if err != nil {
+		return fmt.Errorf("error validating supplychain [%s]: %w", supplyChain.Name, err)
+	}
+
+	var consumers []templateConsumer
+	for _, resource := range resources {
+		consumers = append(consumers, templateConsumer{
+			resourceName:  resource.Name,
+			templateKind:  resource.TemplateRef.Kind,
+			templateNames: templateNames(resource.TemplateRef.Name, resource.TemplateRef.Options),
+			params:        resource.Params,
+			outputs:       resource.Outputs,
+		})
+	}
+
+	if err := validateParamsAgainstTemplates(ctx, v.Client, supplyChain.Namespace, supplyChain.Spec.Params, consumers); err != nil {
+		return fmt.Errorf("error validating supplychain [%s]: %w", supplyChain.Name, err)
+	}
+
+	if err := validateOutputsAgainstTemplates(ctx, v.Client, supplyChain.Namespace, consumers); err != nil {
+		return fmt.Errorf("error validating supplychain [%s]: %w", supplyChain.Name, err)
+	}
+	return nil
+}
+
+// DeliveryValidator runs the Delivery's own validations and then checks
+// the params it passes to its templates against the schemas those templates declare.
+// +kubebuilder:object:generate=false
+type DeliveryValidator struct {
+	Client client.Reader
+}
+
+var _ webhook.CustomValidator = &DeliveryValidator{}
+
+func NewDeliveryValidator(c client.Reader) *DeliveryValidator {
+	return &DeliveryValidator{Client: c}
+}
+
+func (v *DeliveryValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	delivery, ok := obj.(*Delivery)
+	if !ok {
+		return nil, fmt.Errorf("expected a delivery, found %T", obj)
+	}
+
+	if warnings, err := delivery.ValidateCreate(); err != nil {
+		return warnings, err
+	}
+
+	return nil, v.validateTemplateParams(ctx, delivery)
+}
+
+func (v *DeliveryValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	delivery, ok := newObj.(*Delivery)
+	if !ok {
+		return nil, fmt.Errorf("expected a delivery, found %T", newObj)
+	}
+
+	if warnings, err := delivery.ValidateUpdate(oldObj); err != nil {
+		return warnings, err
+	}
+
+	return nil, v.validateTemplateParams(ctx, delivery)
+}
+
+func (v *DeliveryValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *DeliveryValidator) validateTemplateParams(ctx context.Context, delivery *Delivery) error {
+	var consumers []templateConsumer
+	for _, resource := range delivery.Spec.Resources {
+		consumers = append(consumers, templateConsumer{
+			resourceName:  resource.Name,
+			templateKind:  resource.TemplateRef.Kind,
+			templateNames: templateNames(resource.TemplateRef.Name, resource.TemplateRef.Options),
+			params:        resource.Params,
+			outputs:       resource.Outputs,
+		})
+	}
+
+	if err := validateParamsAgainstTemplates(ctx, v.Client, delivery.Namespace, delivery.Spec.Params, consumers); err != nil {
+		return fmt.Errorf("error validating delivery [%s]: %w", delivery.Name, err)
+	}
+
+	if err := validateOutputsAgainstTemplates(ctx, v.Client, delivery.Namespace, consumers); err != nil {
+		return fmt.Errorf("error validating delivery [%s]: %w", delivery.Name, err)
+	}
+	return nil
+}
+
+// validateSubChains expands the sub-chains the supply chain references and checks that the
+// expanded resources have unique names and consume inputs of the right kind. It returns the
+// resources whose params are checked against their templates; when a sub-chain does not exist
+// yet, those are the resources which are not sub-chains and the expansion is not checked.
+func validateSubChains(ctx context.Context, reader client.Reader, supplyChain SupplyChainObject) ([]SupplyChainResource, error) {
+	resources, err := ExpandSubChains(ctx, clusterSupplyChainGetter(reader), supplyChain)
+	if err != nil {
+		var notFoundErr SubChainNotFoundError
+		if !errors.As(err, &notFoundErr) {
+			return nil, err
+		}
+
+		var templateResources []SupplyChainResource
+		for _, resource := range supplyChain.GetSpec().Resources {
+			if resource.TemplateRef.Kind != SubChainKind {
+				templateResources = append(templateResources, resource)
+			}
+		}
+		return templateResources, nil
+	}
+
+	expanded := &ClusterSupplyChain{Spec: SupplyChainSpec{Resources: resources}}
+
+	names := make(map[string]bool)
+	for _, resource := range resources {
+		if _, ok := names[resource.Name]; ok {
+			return nil, fmt.Errorf("duplicate resource name [%s] found after expanding sub-chains", resource.Name)
+		}
+		names[resource.Name] = true
+	}
+
+	for _, resource := range resources {
+		if err := expanded.validateResourceInputs(resource); err != nil {
+			return nil, err
+		}
+	}
+
+	return resources, nil
+}
+
+func clusterSupplyChainGetter(reader client.Reader) ClusterSupplyChainGetter {
+	return func(ctx context.Context, name string) (*ClusterSupplyChain, error) {
+		supplyChain := &ClusterSupplyChain{}
+		err := reader.Get(ctx, client.ObjectKey{Name: name}, supplyChain)
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return supplyChain, nil
+	}
+}
+
+// templateConsumer is a blueprint resource with the templates it may stamp, the params it passes
+// to them and the named outputs of other resources it consumes
+type templateConsumer struct {
+	resourceName  string
+	templateKind  string
+	templateNames []string
+	params        []BlueprintParam
+	outputs       []OutputReference
+}
+
+func templateNames(name string, options []TemplateOption) []string {
+	if name != "" {
+		return []string{name}
+	}
+
+	var names []string
+	for _, option := range options {
+		if option.Name != "" {
+			names = append(names, option.Name)
+		}
+	}
+	return names
+}
+
+// validateParamsAgainstTemplates checks each blueprint and resource param value against the
+// schema of the matching param on every template the resource may stamp. Templates which do
+// not exist yet are skipped; the realizer reports them when the blueprint is reconciled.
+// Namespaced templates are looked up in the namespace of the blueprint.
+func validateParamsAgainstTemplates(ctx context.Context, reader client.Reader, namespace string, blueprintParams []BlueprintParam, consumers []templateConsumer) error {
+	for _, consumer := range consumers {
+		for _, templateName := range consumer.templateNames {
+			templateParams, err := getTemplateParams(ctx, reader, consumer.templateKind, templateName, namespace)
+			if err != nil {
+				if kerrors.IsNotFound(err) {
+					continue
+				}
+				return fmt.Errorf("unable to get template [%s/%s] for resource [%s]: %w", consumer.templateKind, templateName, consumer.resourceName, err)
+			}
+
+			for _, param := range blueprintParams {
+				if err := validateBlueprintParam(templateParams, param); err != nil {
+					return fmt.Errorf("template [%s/%s] used by resource [%s] rejected %w", consumer.templateKind, templateName, consumer.resourceName, err)
+				}
+			}
+
+			for _, param := range consumer.params {
+				if err := validateBlueprintParam(templateParams, param); err != nil {
+					return fmt.Errorf("resource [%s] is invalid: template [%s/%s] rejected %w", consumer.resourceName, consumer.templateKind, templateName, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateOutputsAgainstTemplates checks that every template the providing resource may stamp declares
+// each named output consumed from it. As with params, templates which do not exist yet are skipped.
+func validateOutputsAgainstTemplates(ctx context.Context, reader client.Reader, namespace string, consumers []templateConsumer) error {
+	providers := make(map[string]templateConsumer)
+	for _, consumer := range consumers {
+		providers[consumer.resourceName] = consumer
+	}
+
+	for _, consumer := range consumers {
+		for _, output := range consumer.outputs {
+			provider, ok := providers[output.Resource]
+			if !ok {
+				continue
+			}
+
+			for _, templateName := range provider.templateNames {
+				spec, err := getTemplateSpec(ctx, reader, provider.templateKind, templateName, namespace)
+				if err != nil {
+					if kerrors.IsNotFound(err) {
+						continue
+					}
+					return fmt.Errorf("unable to get template [%s/%s] for resource [%s]: %w", provider.templateKind, templateName, provider.resourceName, err)
+				}
+
+				if _, ok := spec.Outputs[output.Name]; !ok {
+					return fmt.Errorf("resource [%s] is invalid: output [%s] is not declared by template [%s/%s] of resource [%s]", consumer.resourceName, output.Name, provider.templateKind, templateName, provider.resourceName)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateBlueprintParam(templateParams TemplateParams, param BlueprintParam) error {
+	templateParam := templateParams.find(param.Name)
+	if templateParam == nil {
+		return nil
+	}
+
+	value := param.Value
+	if value == nil {
+		value = param.DefaultValue
+	}
+	if value == nil {
+		return nil
+	}
+
+	return templateParam.ValidateValue(*value)
+}
+
+func getTemplateParams(ctx context.Context, reader client.Reader, kind, name, namespace string) (TemplateParams, error) {
+	spec, err := getTemplateSpec(ctx, reader, kind, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	return spec.Params, nil
+}
+
+func getTemplateSpec(ctx context.Context, reader client.Reader, kind, name, namespace string) (*TemplateSpec, error) {
+	key := client.ObjectKey{Name: name}
+	if IsNamespacedTemplateKind(kind) {
+		key.Namespace = namespace
+	}
+
+	template, err := GetAPITemplate(kind)
+	if err != nil {
+		return nil, fmt.Errorf("unknown template kind [%s]", kind)
+	}
+
+	if err := reader.Get(ctx, key, template); err != nil {
+		return nil, err
+	}
+	return GetTemplateSpec(template), nil
+}
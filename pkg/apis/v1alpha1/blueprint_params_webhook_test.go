@@ -0,0 +1,374 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+var _ = Describe("Blueprint param validation", func() {
+	var (
+		ctx          context.Context
+		reader       client.Reader
+		replicasSpec v1alpha1.TemplateSpec
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		replicasSpec = v1alpha1.TemplateSpec{
+			Params: v1alpha1.TemplateParams{
+				{
+					Name:         "replicas",
+					DefaultValue: apiextensionsv1.JSON{Raw: []byte(`1`)},
+					Schema:       &apiextensionsv1.JSON{Raw: []byte(`{"type":"integer"}`)},
+				},
+			},
+			Outputs: map[string]string{"digest": ".status.digest"},
+		}
+
+		scheme := runtime.NewScheme()
+		Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+		reader = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&v1alpha1.ClusterTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "deployment-template"},
+				Spec:       replicasSpec,
+			},
+			&v1alpha1.ClusterConfigTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "app-config"},
+				Spec:       v1alpha1.ConfigTemplateSpec{TemplateSpec: replicasSpec},
+			},
+			&v1alpha1.Template{
+				ObjectMeta: metav1.ObjectMeta{Name: "deployment-template", Namespace: "some-namespace"},
+				Spec:       replicasSpec,
+			},
+			&v1alpha1.ConfigTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "app-config", Namespace: "some-namespace"},
+				Spec:       v1alpha1.ConfigTemplateSpec{TemplateSpec: replicasSpec},
+			},
+		).Build()
+	})
+
+	Describe("ClusterSupplyChainValidator", func() {
+		var (
+			validator   *v1alpha1.ClusterSupplyChainValidator
+			supplyChain *v1alpha1.ClusterSupplyChain
+		)
+
+		BeforeEach(func() {
+			validator = v1alpha1.NewClusterSupplyChainValidator(reader)
+			supplyChain = &v1alpha1.ClusterSupplyChain{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-supply-chain"},
+				Spec: v1alpha1.SupplyChainSpec{
+					LegacySelector: v1alpha1.LegacySelector{
+						Selector: map[string]string{"some-key": "some-value"},
+					},
+					Resources: []v1alpha1.SupplyChainResource{
+						{
+							Name: "deployer",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{
+								Kind: "ClusterTemplate",
+								Name: "deployment-template",
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("accepts param values which satisfy the template's schema", func() {
+			supplyChain.Spec.Params = []v1alpha1.BlueprintParam{
+				{Name: "replicas", Value: &apiextensionsv1.JSON{Raw: []byte(`3`)}},
+			}
+			supplyChain.Spec.Resources[0].Params = []v1alpha1.BlueprintParam{
+				{Name: "replicas", DefaultValue: &apiextensionsv1.JSON{Raw: []byte(`2`)}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = validator.ValidateUpdate(ctx, supplyChain, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects a blueprint param which does not satisfy the template's schema", func() {
+			supplyChain.Spec.Params = []v1alpha1.BlueprintParam{
+				{Name: "replicas", Value: &apiextensionsv1.JSON{Raw: []byte(`"3"`)}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, supplyChain)
+			Expect(err).To(MatchError(ContainSubstring("error validating clustersupplychain [some-supply-chain]: template [ClusterTemplate/deployment-template] used by resource [deployer] rejected param [replicas]")))
+			Expect(err).To(MatchError(ContainSubstring("must be of type integer")))
+		})
+
+		It("rejects a resource param which does not satisfy the template's schema", func() {
+			supplyChain.Spec.Resources[0].Params = []v1alpha1.BlueprintParam{
+				{Name: "replicas", DefaultValue: &apiextensionsv1.JSON{Raw: []byte(`"2"`)}},
+			}
+
+			_, err := validator.ValidateUpdate(ctx, supplyChain, supplyChain)
+			Expect(err).To(MatchError(ContainSubstring("error validating clustersupplychain [some-supply-chain]: resource [deployer] is invalid: template [ClusterTemplate/deployment-template] rejected param [replicas]")))
+		})
+
+		It("validates against each template option", func() {
+			supplyChain.Spec.Resources[0].TemplateRef = v1alpha1.SupplyChainTemplateReference{
+				Kind: "ClusterTemplate",
+				Options: []v1alpha1.TemplateOption{
+					{
+						Name:     "some-other-template",
+						Selector: v1alpha1.Selector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"a": "b"}}},
+					},
+					{
+						Name:     "deployment-template",
+						Selector: v1alpha1.Selector{LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"a": "c"}}},
+					},
+				},
+			}
+			supplyChain.Spec.Params = []v1alpha1.BlueprintParam{
+				{Name: "replicas", Value: &apiextensionsv1.JSON{Raw: []byte(`"3"`)}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, supplyChain)
+			Expect(err).To(MatchError(ContainSubstring("template [ClusterTemplate/deployment-template] used by resource [deployer] rejected param [replicas]")))
+		})
+
+		It("skips templates which do not exist", func() {
+			supplyChain.Spec.Resources[0].TemplateRef.Name = "missing-template"
+			supplyChain.Spec.Params = []v1alpha1.BlueprintParam{
+				{Name: "replicas", Value: &apiextensionsv1.JSON{Raw: []byte(`"3"`)}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("a resource consumes a named output", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources = append([]v1alpha1.SupplyChainResource{
+					{
+						Name: "config-provider",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{
+							Kind: "ClusterConfigTemplate",
+							Name: "app-config",
+						},
+					},
+				}, supplyChain.Spec.Resources...)
+			})
+
+			It("accepts an output declared by the template of the providing resource", func() {
+				supplyChain.Spec.Resources[1].Outputs = []v1alpha1.OutputReference{{Resource: "config-provider", Name: "digest"}}
+
+				_, err := validator.ValidateCreate(ctx, supplyChain)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("rejects an output which the template of the providing resource does not declare", func() {
+				supplyChain.Spec.Resources[1].Outputs = []v1alpha1.OutputReference{{Resource: "config-provider", Name: "tag"}}
+
+				_, err := validator.ValidateCreate(ctx, supplyChain)
+				Expect(err).To(MatchError("error validating clustersupplychain [some-supply-chain]: resource [deployer] is invalid: output [tag] is not declared by template [ClusterConfigTemplate/app-config] of resource [config-provider]"))
+			})
+
+			It("rejects an output of an unknown resource", func() {
+				supplyChain.Spec.Resources[1].Outputs = []v1alpha1.OutputReference{{Resource: "some-other-resource", Name: "digest"}}
+
+				_, err := validator.ValidateCreate(ctx, supplyChain)
+				Expect(err).To(MatchError("error validating clustersupplychain [some-supply-chain]: invalid outputs for resource [deployer]: output [digest] is provided by unknown resource [some-other-resource]"))
+			})
+
+			It("skips providing templates which do not exist", func() {
+				supplyChain.Spec.Resources[0].TemplateRef.Name = "missing-template"
+				supplyChain.Spec.Resources[1].Outputs = []v1alpha1.OutputReference{{Resource: "config-provider", Name: "tag"}}
+
+				_, err := validator.ValidateCreate(ctx, supplyChain)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		It("runs the supply chain's own validations first", func() {
+			supplyChain.Spec.Selector = nil
+
+			_, err := validator.ValidateCreate(ctx, supplyChain)
+			Expect(err).To(MatchError("error validating clustersupplychain [some-supply-chain]: at least one selector, selectorMatchExpression, selectorMatchField must be specified"))
+		})
+	})
+
+	Describe("ClusterDeliveryValidator", func() {
+		var (
+			validator *v1alpha1.ClusterDeliveryValidator
+			delivery  *v1alpha1.ClusterDelivery
+		)
+
+		BeforeEach(func() {
+			validator = v1alpha1.NewClusterDeliveryValidator(reader)
+			delivery = &v1alpha1.ClusterDelivery{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-delivery"},
+				Spec: v1alpha1.DeliverySpec{
+					LegacySelector: v1alpha1.LegacySelector{
+						Selector: map[string]string{"some-key": "some-value"},
+					},
+					Resources: []v1alpha1.DeliveryResource{
+						{
+							Name: "deployer",
+							TemplateRef: v1alpha1.DeliveryTemplateReference{
+								Kind: "ClusterConfigTemplate",
+								Name: "app-config",
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("accepts param values which satisfy the template's schema", func() {
+			delivery.Spec.Params = []v1alpha1.BlueprintParam{
+				{Name: "replicas", Value: &apiextensionsv1.JSON{Raw: []byte(`3`)}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, delivery)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects a param which does not satisfy the template's schema", func() {
+			delivery.Spec.Resources[0].Params = []v1alpha1.BlueprintParam{
+				{Name: "replicas", Value: &apiextensionsv1.JSON{Raw: []byte(`true`)}},
+			}
+
+			_, err := validator.ValidateUpdate(ctx, delivery, delivery)
+			Expect(err).To(MatchError(ContainSubstring("error validating clusterdelivery [some-delivery]: resource [deployer] is invalid: template [ClusterConfigTemplate/app-config] rejected param [replicas]")))
+		})
+
+		It("rejects an output which the template of the providing resource does not declare", func() {
+			delivery.Spec.Resources = append(delivery.Spec.Resources, v1alpha1.DeliveryResource{
+				Name: "consumer",
+				TemplateRef: v1alpha1.DeliveryTemplateReference{
+					Kind: "ClusterTemplate",
+					Name: "deployment-template",
+				},
+				Outputs: []v1alpha1.OutputReference{{Resource: "deployer", Name: "tag"}},
+			})
+
+			_, err := validator.ValidateCreate(ctx, delivery)
+			Expect(err).To(MatchError("error validating clusterdelivery [some-delivery]: resource [consumer] is invalid: output [tag] is not declared by template [ClusterConfigTemplate/app-config] of resource [deployer]"))
+
+			delivery.Spec.Resources[1].Outputs[0].Name = "digest"
+			_, err = validator.ValidateCreate(ctx, delivery)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("SupplyChainValidator", func() {
+		var (
+			validator   *v1alpha1.SupplyChainValidator
+			supplyChain *v1alpha1.SupplyChain
+		)
+
+		BeforeEach(func() {
+			validator = v1alpha1.NewSupplyChainValidator(reader)
+			supplyChain = &v1alpha1.SupplyChain{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-supply-chain", Namespace: "some-namespace"},
+				Spec: v1alpha1.SupplyChainSpec{
+					LegacySelector: v1alpha1.LegacySelector{
+						Selector: map[string]string{"some-key": "some-value"},
+					},
+					Resources: []v1alpha1.SupplyChainResource{
+						{
+							Name: "deployer",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{
+								Kind: "Template",
+								Name: "deployment-template",
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("rejects a param which does not satisfy the schema of the template in its namespace", func() {
+			supplyChain.Spec.Params = []v1alpha1.BlueprintParam{
+				{Name: "replicas", Value: &apiextensionsv1.JSON{Raw: []byte(`"3"`)}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, supplyChain)
+			Expect(err).To(MatchError(ContainSubstring("error validating supplychain [some-supply-chain]: template [Template/deployment-template] used by resource [deployer] rejected param [replicas]")))
+		})
+
+		It("does not look for templates in other namespaces", func() {
+			supplyChain.Namespace = "other-namespace"
+			supplyChain.Spec.Params = []v1alpha1.BlueprintParam{
+				{Name: "replicas", Value: &apiextensionsv1.JSON{Raw: []byte(`"3"`)}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("validates against cluster templates too", func() {
+			supplyChain.Spec.Resources[0].TemplateRef.Kind = "ClusterTemplate"
+			supplyChain.Spec.Params = []v1alpha1.BlueprintParam{
+				{Name: "replicas", Value: &apiextensionsv1.JSON{Raw: []byte(`"3"`)}},
+			}
+
+			_, err := validator.ValidateUpdate(ctx, supplyChain, supplyChain)
+			Expect(err).To(MatchError(ContainSubstring("template [ClusterTemplate/deployment-template] used by resource [deployer] rejected param [replicas]")))
+		})
+	})
+
+	Describe("DeliveryValidator", func() {
+		var (
+			validator *v1alpha1.DeliveryValidator
+			delivery  *v1alpha1.Delivery
+		)
+
+		BeforeEach(func() {
+			validator = v1alpha1.NewDeliveryValidator(reader)
+			delivery = &v1alpha1.Delivery{
+				ObjectMeta: metav1.ObjectMeta{Name: "some-delivery", Namespace: "some-namespace"},
+				Spec: v1alpha1.DeliverySpec{
+					LegacySelector: v1alpha1.LegacySelector{
+						Selector: map[string]string{"some-key": "some-value"},
+					},
+					Resources: []v1alpha1.DeliveryResource{
+						{
+							Name: "deployer",
+							TemplateRef: v1alpha1.DeliveryTemplateReference{
+								Kind: "ConfigTemplate",
+								Name: "app-config",
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("rejects a param which does not satisfy the schema of the template in its namespace", func() {
+			delivery.Spec.Resources[0].Params = []v1alpha1.BlueprintParam{
+				{Name: "replicas", Value: &apiextensionsv1.JSON{Raw: []byte(`true`)}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, delivery)
+			Expect(err).To(MatchError(ContainSubstring("error validating delivery [some-delivery]: resource [deployer] is invalid: template [ConfigTemplate/app-config] rejected param [replicas]")))
+		})
+	})
+})
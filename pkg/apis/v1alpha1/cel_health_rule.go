@@ -0,0 +1,67 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+)
+
+// CELSelfVariable is the name by which CEL health rule expressions refer to the stamped object
+const CELSelfVariable = "self"
+
+// CELHealthRuleCostLimit bounds the cost of evaluating a single CEL health rule or resource condition
+// expression, so that an expensive expression fails instead of stalling the controller
+const CELHealthRuleCostLimit = 1000000
+
+var (
+	celEnvOnce sync.Once
+	celEnv     *cel.Env
+	celEnvErr  error
+)
+
+func celHealthRuleEnv() (*cel.Env, error) {
+	celEnvOnce.Do(func() {
+		celEnv, celEnvErr = cel.NewEnv(cel.Variable(CELSelfVariable, cel.DynType))
+	})
+	return celEnv, celEnvErr
+}
+
+// CompileCELHealthRuleExpression parses and type-checks a CEL health rule expression,
+// which must evaluate to the outputType (or dyn).
+func CompileCELHealthRuleExpression(expression string, outputType *cel.Type) (cel.Program, error) {
+	env, err := celHealthRuleEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cel environment: %w", err)
+	}
+
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("failed to compile expression [%s]: %w", expression, issues.Err())
+	}
+
+	if ast.OutputType() != cel.DynType && !outputType.IsAssignableType(ast.OutputType()) {
+		return nil, fmt.Errorf("expression [%s] must evaluate to %s, found %s", expression, outputType, ast.OutputType())
+	}
+
+	program, err := env.Program(ast, cel.CostLimit(CELHealthRuleCostLimit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build program for expression [%s]: %w", expression, err)
+	}
+
+	return program, nil
+}
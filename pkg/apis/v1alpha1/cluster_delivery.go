@@ -28,6 +28,10 @@ var ValidDeliveryTemplates = []client.Object{
 	&ClusterDeploymentTemplate{},
 	&ClusterTemplate{},
 	&ClusterConfigTemplate{},
+	&SourceTemplate{},
+	&DeploymentTemplate{},
+	&Template{},
+	&ConfigTemplate{},
 }
 
 // +kubebuilder:object:root=true
@@ -120,11 +124,39 @@ type DeliveryResource struct {
 	// If there is only one image, it can be consumed as:
 	//   $(config)$
 	Configs []ResourceReference `json:"configs,omitempty"`
+
+	// Outputs is a list of references to named outputs of other resources in this list.
+	// A named output is declared in the outputs of the template of the resource.
+	//
+	// In a template, outputs can be consumed as:
+	//   $(outputs.<resource>.<name>)$
+	// +optional
+	Outputs []OutputReference `json:"outputs,omitempty"`
+
+	// ProgressDeadline is how long this resource may take to become healthy or
+	// produce an output before its Healthy condition is set to False with reason
+	// ProgressDeadlineExceeded. Overrides the progressDeadline of the template.
+	// +optional
+	ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty"`
+
+	// ResyncPeriod is how often the owner is reconciled again so that this resource
+	// is stamped and its outputs are read even when nothing it watches has changed,
+	// e.g. to pick up external state. Overrides the resyncPeriod of the template.
+	// Periods below the controller's minimum resync period are raised to it.
+	// +optional
+	ResyncPeriod *metav1.Duration `json:"resyncPeriod,omitempty"`
+
+	// When is a condition on the deliverable, params and inputs of this resource. When it
+	// is not met the resource is skipped: nothing is stamped and the resource is
+	// marked Skipped in the deliverable status.
+	// +optional
+	When *ResourceCondition `json:"when,omitempty"`
 }
 
 type DeliveryTemplateReference struct {
-	// Kind of the template to apply
-	// +kubebuilder:validation:Enum=ClusterSourceTemplate;ClusterDeploymentTemplate;ClusterTemplate;ClusterConfigTemplate
+	// Kind of the template to apply.
+	// The namespaced template kinds may only be used by a Delivery.
+	// +kubebuilder:validation:Enum=ClusterSourceTemplate;ClusterDeploymentTemplate;ClusterTemplate;ClusterConfigTemplate;SourceTemplate;DeploymentTemplate;Template;ConfigTemplate
 	Kind string `json:"kind"`
 	// Name of the template to apply
 	// +kubebuilder:validation:MinLength=1
@@ -154,6 +186,14 @@ func (c *ClusterDelivery) GetSelectors() LegacySelector {
 	return c.Spec.LegacySelector
 }
 
+func (c *ClusterDelivery) GetSpec() *DeliverySpec {
+	return &c.Spec
+}
+
+func (c *ClusterDelivery) GetStatus() *DeliveryStatus {
+	return &c.Status
+}
+
 func init() {
 	SchemeBuilder.Register(
 		&ClusterDelivery{},
@@ -28,7 +28,7 @@ import (
 var _ webhook.Validator = &ClusterDelivery{}
 
 func (c *ClusterDelivery) ValidateCreate() (admission.Warnings, error) {
-	err := c.validateNewState()
+	err := c.validateClusterScopedState()
 	if err != nil {
 		return nil, fmt.Errorf("error validating clusterdelivery [%s]: %w", c.Name, err)
 	}
@@ -36,7 +36,7 @@ func (c *ClusterDelivery) ValidateCreate() (admission.Warnings, error) {
 }
 
 func (c *ClusterDelivery) ValidateUpdate(_ runtime.Object) (admission.Warnings, error) {
-	err := c.validateNewState()
+	err := c.validateClusterScopedState()
 	if err != nil {
 		return nil, fmt.Errorf("error validating clusterdelivery [%s]: %w", c.Name, err)
 	}
@@ -47,6 +47,22 @@ func (c *ClusterDelivery) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
+// validateClusterScopedState rejects references to namespaced templates, which a cluster
+// scoped delivery has no namespace to look up in.
+func (c *ClusterDelivery) validateClusterScopedState() error {
+	if err := c.validateNewState(); err != nil {
+		return err
+	}
+
+	for _, resource := range c.Spec.Resources {
+		if IsNamespacedTemplateKind(resource.TemplateRef.Kind) {
+			return fmt.Errorf("error validating resource [%s]: TemplateRef.Kind [%s] may only be used by a Delivery", resource.Name, resource.TemplateRef.Kind)
+		}
+	}
+
+	return nil
+}
+
 func (c *ClusterDelivery) validateNewState() error {
 	if len(c.Spec.Selector) == 0 && len(c.Spec.SelectorMatchExpressions) == 0 && len(c.Spec.SelectorMatchFields) == 0 {
 		return fmt.Errorf("at least one selector, selectorMatchExpression, selectorMatchField must be specified")
@@ -78,6 +94,15 @@ func (c *ClusterDelivery) validateNewState() error {
 		}
 	}
 
+	for _, resource := range c.Spec.Resources {
+		if resource.ProgressDeadline != nil && resource.ProgressDeadline.Duration <= 0 {
+			return fmt.Errorf("error validating resource [%s]: progressDeadline must be greater than zero", resource.Name)
+		}
+		if resource.ResyncPeriod != nil && resource.ResyncPeriod.Duration <= 0 {
+			return fmt.Errorf("error validating resource [%s]: resyncPeriod must be greater than zero", resource.Name)
+		}
+	}
+
 	for _, resource := range c.Spec.Resources {
 		if err := validateDeliveryTemplateRef(resource.TemplateRef); err != nil {
 			return fmt.Errorf("error validating resource [%s]: %w", resource.Name, err)
@@ -88,9 +113,10 @@ func (c *ClusterDelivery) validateNewState() error {
 		for _, option := range resource.TemplateRef.Options {
 			if option.PassThrough != "" {
 				var found bool
-				if resource.TemplateRef.Kind == "ClusterSourceTemplate" {
+				kind := ClusterScopedTemplateKind(resource.TemplateRef.Kind)
+				if kind == "ClusterSourceTemplate" {
 					found = isPassThroughInputFound(resource.Sources, option.PassThrough)
-				} else if resource.TemplateRef.Kind == "ClusterConfigTemplate" {
+				} else if kind == "ClusterConfigTemplate" {
 					found = isPassThroughInputFound(resource.Configs, option.PassThrough)
 				} else {
 					return fmt.Errorf("error validating resource [%s]: TemplateRef.Kind [%s] is not a known type", resource.Name, resource.TemplateRef.Kind)
@@ -107,16 +133,53 @@ func (c *ClusterDelivery) validateNewState() error {
 		return err
 	}
 
-	return c.validateDeploymentTemplateDidNotReceiveConfig()
+	if err := c.validateDeploymentTemplateDidNotReceiveConfig(); err != nil {
+		return err
+	}
+
+	if err := c.validateOutputReferences(); err != nil {
+		return err
+	}
+
+	return c.validateWhenConditions()
+}
+
+func (c *ClusterDelivery) validateWhenConditions() error {
+	var conditionalResources []conditionalResource
+	for _, resource := range c.Spec.Resources {
+		if resource.When != nil {
+			if err := resource.When.validate(ValidDeliverablePaths, ValidDeliverablePrefixes); err != nil {
+				return fmt.Errorf("error validating resource [%s] when: %w", resource.Name, err)
+			}
+		}
+
+		var deployment []string
+		if resource.Deployment != nil {
+			deployment = []string{resource.Deployment.Resource}
+		}
+
+		conditionalResources = append(conditionalResources, conditionalResource{
+			Name:        resource.Name,
+			Kind:        resource.TemplateRef.Kind,
+			Conditional: resource.When != nil,
+			Inputs: map[string][]string{
+				"sources":    referencedResourceNames(resource.Sources),
+				"configs":    referencedResourceNames(resource.Configs),
+				"deployment": deployment,
+			},
+		})
+	}
+
+	return validateSkippedResourcesAreNotOnlyProviders(conditionalResources)
 }
 
 func (c *ClusterDelivery) validateDeploymentPassedToProperReceivers() error {
 	for _, resource := range c.Spec.Resources {
-		if resource.TemplateRef.Kind == "ClusterDeploymentTemplate" && resource.Deployment == nil {
+		if ClusterScopedTemplateKind(resource.TemplateRef.Kind) == "ClusterDeploymentTemplate" && resource.Deployment == nil {
 			return fmt.Errorf("spec.resources[%s] is a ClusterDeploymentTemplate and must receive a deployment", resource.Name)
 		}
 
-		if resource.Deployment != nil && resource.TemplateRef.Kind != "ClusterDeploymentTemplate" {
+		if resource.Deployment != nil && ClusterScopedTemplateKind(resource.TemplateRef.Kind) != "ClusterDeploymentTemplate" {
 			return fmt.Errorf("spec.resources[%s] receives a deployment but is not a ClusterDeploymentTemplate", resource.Name)
 		}
 	}
@@ -137,13 +200,28 @@ func (c *ClusterDelivery) validateResourceNamesUnique() error {
 
 func (c *ClusterDelivery) validateDeploymentTemplateDidNotReceiveConfig() error {
 	for _, resource := range c.Spec.Resources {
-		if resource.TemplateRef.Kind == "ClusterDeploymentTemplate" && resource.Configs != nil {
+		if ClusterScopedTemplateKind(resource.TemplateRef.Kind) == "ClusterDeploymentTemplate" && resource.Configs != nil {
 			return fmt.Errorf("spec.resources[%s] is a ClusterDeploymentTemplate and must not receive config", resource.Name)
 		}
 	}
 	return nil
 }
 
+func (c *ClusterDelivery) validateOutputReferences() error {
+	names := map[string]bool{}
+	for _, resource := range c.Spec.Resources {
+		names[resource.Name] = true
+	}
+	isResource := func(name string) bool { return names[name] }
+
+	for _, resource := range c.Spec.Resources {
+		if err := validateOutputReferences(resource.Name, resource.Outputs, isResource); err != nil {
+			return fmt.Errorf("invalid outputs for resource [%s]: %w", resource.Name, err)
+		}
+	}
+	return nil
+}
+
 func (c *ClusterDelivery) validateParams() error {
 	for _, param := range c.Spec.Params {
 		err := param.validate()
@@ -185,5 +263,6 @@ func validateDeliveryTemplateRef(ref DeliveryTemplateReference) error {
 func (c *ClusterDelivery) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(c).
+		WithValidator(NewClusterDeliveryValidator(mgr.GetClient())).
 		Complete()
 }
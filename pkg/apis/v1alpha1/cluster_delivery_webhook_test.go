@@ -16,6 +16,7 @@ package v1alpha1_test
 
 import (
 	"reflect"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -76,6 +77,17 @@ var _ = Describe("Delivery Validation", func() {
 			})
 		})
 
+		Context("Delivery referencing a namespaced template kind", func() {
+			BeforeEach(func() {
+				delivery.Spec.Resources[1].TemplateRef.Kind = "SourceTemplate"
+			})
+
+			It("returns an error", func() {
+				_, err := delivery.ValidateCreate()
+				Expect(err).To(MatchError("error validating clusterdelivery [delivery-resource]: error validating resource [other-source-provider]: TemplateRef.Kind [SourceTemplate] may only be used by a Delivery"))
+			})
+		})
+
 		Context("Duplicate resource names", func() {
 			BeforeEach(func() {
 				for i := range delivery.Spec.Resources {
@@ -94,6 +106,70 @@ var _ = Describe("Delivery Validation", func() {
 			})
 		})
 
+		Context("Resource with a progress deadline that is not positive", func() {
+			BeforeEach(func() {
+				delivery.Spec.Resources[1].ProgressDeadline = &metav1.Duration{Duration: -time.Minute}
+			})
+
+			It("on create, it rejects the Resource", func() {
+				_, err := delivery.ValidateCreate()
+				Expect(err).To(MatchError("error validating clusterdelivery [delivery-resource]: error validating resource [other-source-provider]: progressDeadline must be greater than zero"))
+			})
+		})
+
+		Context("Resource with a resync period that is not positive", func() {
+			BeforeEach(func() {
+				delivery.Spec.Resources[1].ResyncPeriod = &metav1.Duration{Duration: -time.Minute}
+			})
+
+			It("on create, it rejects the Resource", func() {
+				_, err := delivery.ValidateCreate()
+				Expect(err).To(MatchError("error validating clusterdelivery [delivery-resource]: error validating resource [other-source-provider]: resyncPeriod must be greater than zero"))
+			})
+		})
+
+		Context("Resource with a when condition", func() {
+			BeforeEach(func() {
+				delivery.Spec.Resources = append(delivery.Spec.Resources, v1alpha1.DeliveryResource{
+					Name: "deployer",
+					TemplateRef: v1alpha1.DeliveryTemplateReference{
+						Kind: "ClusterDeploymentTemplate",
+						Name: "app-deploy",
+					},
+					Deployment: &v1alpha1.DeploymentReference{
+						Resource: "other-source-provider",
+					},
+				})
+				delivery.Spec.Resources[1].When = &v1alpha1.ResourceCondition{
+					Expression: "params.gitops == true",
+				}
+			})
+
+			It("on create, rejects a skipped resource which is the only provider of the deployment", func() {
+				_, err := delivery.ValidateCreate()
+				Expect(err).To(MatchError("error validating clusterdelivery [delivery-resource]: resource [other-source-provider] may be skipped and is the only provider of deployment for resource [deployer], which has no when condition"))
+			})
+
+			It("creates without error when the skipped resource can pass through its input", func() {
+				delivery.Spec.Resources[1].Sources = []v1alpha1.ResourceReference{
+					{
+						Name:     "source",
+						Resource: "source-provider",
+					},
+				}
+				_, err := delivery.ValidateCreate()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("on create, rejects an expression which does not evaluate to a bool", func() {
+				delivery.Spec.Resources[2].When = &v1alpha1.ResourceCondition{
+					Expression: "1 + 1",
+				}
+				_, err := delivery.ValidateCreate()
+				Expect(err).To(MatchError("error validating clusterdelivery [delivery-resource]: error validating resource [deployer] when: expression [1 + 1] must evaluate to bool, found int"))
+			})
+		})
+
 		Context("Delivery with malformed params", func() {
 			Context("Top level params are malformed", func() {
 				Context("param does not specify a value or default", func() {
@@ -1076,13 +1152,17 @@ var _ = Describe("Delivery Validation", func() {
 
 var _ = Describe("DeliveryTemplateReference", func() {
 	It("has valid references", func() {
-		Expect(v1alpha1.ValidDeliveryTemplates).To(HaveLen(4))
+		Expect(v1alpha1.ValidDeliveryTemplates).To(HaveLen(8))
 
 		Expect(v1alpha1.ValidDeliveryTemplates).To(ContainElements(
 			&v1alpha1.ClusterSourceTemplate{},
 			&v1alpha1.ClusterDeploymentTemplate{},
 			&v1alpha1.ClusterTemplate{},
 			&v1alpha1.ClusterConfigTemplate{},
+			&v1alpha1.SourceTemplate{},
+			&v1alpha1.DeploymentTemplate{},
+			&v1alpha1.Template{},
+			&v1alpha1.ConfigTemplate{},
 		))
 	})
 
@@ -28,6 +28,10 @@ var ValidSupplyChainTemplates = []client.Object{
 	&ClusterImageTemplate{},
 	&ClusterConfigTemplate{},
 	&ClusterTemplate{},
+	&SourceTemplate{},
+	&ImageTemplate{},
+	&ConfigTemplate{},
+	&Template{},
 }
 
 // +kubebuilder:object:root=true
@@ -71,6 +75,26 @@ type SupplyChainSpec struct {
 	// workload's namespace.
 	// +optional
 	ServiceAccountRef ServiceAccountRef `json:"serviceAccountRef,omitempty"`
+
+	// SubChain declares this supply chain a sub-chain, which is only realized as part of
+	// the supply chains with a resource referencing it and selects no workloads itself.
+	// A sub-chain may not specify a selector. Only a ClusterSupplyChain may be a sub-chain.
+	// +optional
+	SubChain *SubChain `json:"subChain,omitempty"`
+}
+
+// SubChain declares how the resources of a sub-chain are connected to the supply chain
+// resource referencing it.
+type SubChain struct {
+	// Entries are the names of the resources of the sub-chain which receive the sources,
+	// images, configs and outputs of the supply chain resource referencing the sub-chain,
+	// in addition to their own.
+	// +kubebuilder:validation:MinItems=1
+	Entries []string `json:"entries"`
+
+	// Export is the name of the resource of the sub-chain whose outputs are consumed by
+	// the resources consuming the supply chain resource referencing the sub-chain.
+	Export string `json:"export"`
 }
 
 type SupplyChainStatus struct {
@@ -123,11 +147,43 @@ type SupplyChainResource struct {
 	// If there is only one image, it can be consumed as:
 	//   $(config)$
 	Configs []ResourceReference `json:"configs,omitempty"`
+
+	// Outputs is a list of references to named outputs of other resources in this list.
+	// A named output is declared in the outputs of the template of the resource.
+	//
+	// In a template, outputs can be consumed as:
+	//   $(outputs.<resource>.<name>)$
+	// +optional
+	Outputs []OutputReference `json:"outputs,omitempty"`
+
+	// ProgressDeadline is how long this resource may take to become healthy or
+	// produce an output before its Healthy condition is set to False with reason
+	// ProgressDeadlineExceeded. Overrides the progressDeadline of the template.
+	// +optional
+	ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty"`
+
+	// ResyncPeriod is how often the owner is reconciled again so that this resource
+	// is stamped and its outputs are read even when nothing it watches has changed,
+	// e.g. to pick up external state. Overrides the resyncPeriod of the template.
+	// Periods below the controller's minimum resync period are raised to it.
+	// +optional
+	ResyncPeriod *metav1.Duration `json:"resyncPeriod,omitempty"`
+
+	// When is a condition on the workload, params and inputs of this resource. When it
+	// is not met the resource is skipped: nothing is stamped and the resource is
+	// marked Skipped in the workload status.
+	// +optional
+	When *ResourceCondition `json:"when,omitempty"`
 }
 
 type SupplyChainTemplateReference struct {
-	// Kind of the template to apply
-	//+kubebuilder:validation:Enum=ClusterSourceTemplate;ClusterImageTemplate;ClusterTemplate;ClusterConfigTemplate
+	// Kind of the template to apply.
+	// The namespaced template kinds may only be used by a SupplyChain.
+	//
+	// A Kind of ClusterSupplyChain references a sub-chain, whose resources are
+	// inlined in place of this resource. The outputs of the last resource of the
+	// sub-chain are the outputs of this resource.
+	//+kubebuilder:validation:Enum=ClusterSourceTemplate;ClusterImageTemplate;ClusterTemplate;ClusterConfigTemplate;SourceTemplate;ImageTemplate;Template;ConfigTemplate;ClusterSupplyChain
 	Kind string `json:"kind"`
 
 	// Name of the template to apply
@@ -158,6 +214,14 @@ func (c *ClusterSupplyChain) GetSelectors() LegacySelector {
 	return c.Spec.LegacySelector
 }
 
+func (c *ClusterSupplyChain) GetSpec() *SupplyChainSpec {
+	return &c.Spec
+}
+
+func (c *ClusterSupplyChain) GetStatus() *SupplyChainStatus {
+	return &c.Status
+}
+
 func init() {
 	SchemeBuilder.Register(
 		&ClusterSupplyChain{},
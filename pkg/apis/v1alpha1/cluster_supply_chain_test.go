@@ -112,14 +112,18 @@ var _ = Describe("ClusterSupplyChain", func() {
 	})
 
 	Describe("SupplyChainTemplateReference", func() {
-		It("has four valid references", func() {
-			Expect(v1alpha1.ValidSupplyChainTemplates).To(HaveLen(4))
+		It("has eight valid references", func() {
+			Expect(v1alpha1.ValidSupplyChainTemplates).To(HaveLen(8))
 
 			Expect(v1alpha1.ValidSupplyChainTemplates).To(ContainElements(
 				&v1alpha1.ClusterSourceTemplate{},
 				&v1alpha1.ClusterConfigTemplate{},
 				&v1alpha1.ClusterImageTemplate{},
 				&v1alpha1.ClusterTemplate{},
+				&v1alpha1.SourceTemplate{},
+				&v1alpha1.ConfigTemplate{},
+				&v1alpha1.ImageTemplate{},
+				&v1alpha1.Template{},
 			))
 		})
 
@@ -137,12 +141,13 @@ var _ = Describe("ClusterSupplyChain", func() {
 			enumMarkers, ok := mrkrs.(crdmarkers.Enum)
 			Expect(ok).To(BeTrue())
 
-			Expect(enumMarkers).To(HaveLen(len(v1alpha1.ValidSupplyChainTemplates)))
+			Expect(enumMarkers).To(HaveLen(len(v1alpha1.ValidSupplyChainTemplates) + 1))
 			for _, validTemplate := range v1alpha1.ValidSupplyChainTemplates {
 				typ := reflect.TypeOf(validTemplate)
 				templateName := typ.Elem().Name()
 				Expect(enumMarkers).To(ContainElement(templateName))
 			}
+			Expect(enumMarkers).To(ContainElement(v1alpha1.SubChainKind))
 		})
 	})
 })
@@ -28,7 +28,7 @@ import (
 var _ webhook.Validator = &ClusterSupplyChain{}
 
 func (c *ClusterSupplyChain) ValidateCreate() (admission.Warnings, error) {
-	err := c.validateNewState()
+	err := c.validateClusterScopedState()
 	if err != nil {
 		return nil, fmt.Errorf("error validating clustersupplychain [%s]: %w", c.Name, err)
 	}
@@ -36,7 +36,7 @@ func (c *ClusterSupplyChain) ValidateCreate() (admission.Warnings, error) {
 }
 
 func (c *ClusterSupplyChain) ValidateUpdate(_ runtime.Object) (admission.Warnings, error) {
-	err := c.validateNewState()
+	err := c.validateClusterScopedState()
 	if err != nil {
 		return nil, fmt.Errorf("error validating clustersupplychain [%s]: %w", c.Name, err)
 	}
@@ -47,10 +47,34 @@ func (c *ClusterSupplyChain) ValidateDelete() (admission.Warnings, error) {
 	return nil, nil
 }
 
+// validateClusterScopedState rejects references to namespaced templates, which a cluster
+// scoped supply chain has no namespace to look up in.
+func (c *ClusterSupplyChain) validateClusterScopedState() error {
+	if err := c.validateNewState(); err != nil {
+		return err
+	}
+
+	for _, resource := range c.Spec.Resources {
+		if IsNamespacedTemplateKind(resource.TemplateRef.Kind) {
+			return fmt.Errorf("error validating resource [%s]: TemplateRef.Kind [%s] may only be used by a SupplyChain", resource.Name, resource.TemplateRef.Kind)
+		}
+	}
+
+	return nil
+}
+
 func (c *ClusterSupplyChain) validateNewState() error {
 	names := make(map[string]bool)
 
-	if len(c.Spec.Selector) == 0 && len(c.Spec.SelectorMatchExpressions) == 0 && len(c.Spec.SelectorMatchFields) == 0 {
+	hasSelector := len(c.Spec.Selector) > 0 || len(c.Spec.SelectorMatchExpressions) > 0 || len(c.Spec.SelectorMatchFields) > 0
+	if c.Spec.SubChain != nil {
+		if hasSelector {
+			return fmt.Errorf("a sub-chain may not specify a selector, selectorMatchExpression or selectorMatchField")
+		}
+		if err := c.validateSubChain(); err != nil {
+			return err
+		}
+	} else if !hasSelector {
 		return fmt.Errorf("at least one selector, selectorMatchExpression, selectorMatchField must be specified")
 	}
 
@@ -83,6 +107,15 @@ func (c *ClusterSupplyChain) validateNewState() error {
 		}
 	}
 
+	for _, resource := range c.Spec.Resources {
+		if resource.ProgressDeadline != nil && resource.ProgressDeadline.Duration <= 0 {
+			return fmt.Errorf("error validating resource [%s]: progressDeadline must be greater than zero", resource.Name)
+		}
+		if resource.ResyncPeriod != nil && resource.ResyncPeriod.Duration <= 0 {
+			return fmt.Errorf("error validating resource [%s]: resyncPeriod must be greater than zero", resource.Name)
+		}
+	}
+
 	for _, resource := range c.Spec.Resources {
 		if err := validateSupplyChainTemplateRef(resource.TemplateRef); err != nil {
 			return fmt.Errorf("error validating resource [%s]: %w", resource.Name, err)
@@ -93,11 +126,12 @@ func (c *ClusterSupplyChain) validateNewState() error {
 		for _, option := range resource.TemplateRef.Options {
 			if option.PassThrough != "" {
 				var found bool
-				if resource.TemplateRef.Kind == "ClusterSourceTemplate" {
+				kind := ClusterScopedTemplateKind(resource.TemplateRef.Kind)
+				if kind == "ClusterSourceTemplate" {
 					found = isPassThroughInputFound(resource.Sources, option.PassThrough)
-				} else if resource.TemplateRef.Kind == "ClusterImageTemplate" {
+				} else if kind == "ClusterImageTemplate" {
 					found = isPassThroughInputFound(resource.Images, option.PassThrough)
-				} else if resource.TemplateRef.Kind == "ClusterConfigTemplate" {
+				} else if kind == "ClusterConfigTemplate" {
 					found = isPassThroughInputFound(resource.Configs, option.PassThrough)
 				} else {
 					return fmt.Errorf("error validating resource [%s]: TemplateRef.Kind [%s] is not a known type", resource.Name, resource.TemplateRef.Kind)
@@ -111,29 +145,92 @@ func (c *ClusterSupplyChain) validateNewState() error {
 	}
 
 	for _, resource := range c.Spec.Resources {
-		if err := c.validateResourceRefs(resource.Sources, "ClusterSourceTemplate"); err != nil {
-			return fmt.Errorf(
-				"invalid sources for resource [%s]: %w",
-				resource.Name,
-				err,
-			)
+		if err := c.validateResourceInputs(resource); err != nil {
+			return err
 		}
+	}
 
-		if err := c.validateResourceRefs(resource.Images, "ClusterImageTemplate"); err != nil {
-			return fmt.Errorf(
-				"invalid images for resource [%s]: %w",
-				resource.Name,
-				err,
-			)
+	return c.validateWhenConditions()
+}
+
+func (c *ClusterSupplyChain) validateSubChain() error {
+	if len(c.Spec.SubChain.Entries) == 0 {
+		return fmt.Errorf("subChain.entries must name at least one resource")
+	}
+
+	for _, entry := range c.Spec.SubChain.Entries {
+		if c.getResourceByName(entry) == nil {
+			return fmt.Errorf("subChain.entries references unknown resource [%s]", entry)
 		}
+	}
 
-		if err := c.validateResourceRefs(resource.Configs, "ClusterConfigTemplate"); err != nil {
-			return fmt.Errorf(
-				"invalid configs for resource [%s]: %w",
-				resource.Name,
-				err,
-			)
+	if c.getResourceByName(c.Spec.SubChain.Export) == nil {
+		return fmt.Errorf("subChain.export references unknown resource [%s]", c.Spec.SubChain.Export)
+	}
+
+	return nil
+}
+
+func (c *ClusterSupplyChain) validateWhenConditions() error {
+	var conditionalResources []conditionalResource
+	for _, resource := range c.Spec.Resources {
+		if resource.When != nil {
+			if resource.TemplateRef.Kind == SubChainKind {
+				return fmt.Errorf("error validating resource [%s]: when may not be used with a TemplateRef.Kind of [%s]", resource.Name, SubChainKind)
+			}
+
+			if err := resource.When.validate(ValidWorkloadPaths, ValidWorkloadPrefixes); err != nil {
+				return fmt.Errorf("error validating resource [%s] when: %w", resource.Name, err)
+			}
 		}
+
+		conditionalResources = append(conditionalResources, conditionalResource{
+			Name:        resource.Name,
+			Kind:        resource.TemplateRef.Kind,
+			Conditional: resource.When != nil,
+			Inputs: map[string][]string{
+				"sources": referencedResourceNames(resource.Sources),
+				"images":  referencedResourceNames(resource.Images),
+				"configs": referencedResourceNames(resource.Configs),
+			},
+		})
+	}
+
+	return validateSkippedResourcesAreNotOnlyProviders(conditionalResources)
+}
+
+func (c *ClusterSupplyChain) validateResourceInputs(resource SupplyChainResource) error {
+	if err := c.validateResourceRefs(resource.Sources, "ClusterSourceTemplate"); err != nil {
+		return fmt.Errorf(
+			"invalid sources for resource [%s]: %w",
+			resource.Name,
+			err,
+		)
+	}
+
+	if err := c.validateResourceRefs(resource.Images, "ClusterImageTemplate"); err != nil {
+		return fmt.Errorf(
+			"invalid images for resource [%s]: %w",
+			resource.Name,
+			err,
+		)
+	}
+
+	if err := c.validateResourceRefs(resource.Configs, "ClusterConfigTemplate"); err != nil {
+		return fmt.Errorf(
+			"invalid configs for resource [%s]: %w",
+			resource.Name,
+			err,
+		)
+	}
+
+	isResource := func(name string) bool { return c.getResourceByName(name) != nil }
+	if err := validateOutputReferences(resource.Name, resource.Outputs, isResource); err != nil {
+		return fmt.Errorf(
+			"invalid outputs for resource [%s]: %w",
+			resource.Name,
+			err,
+		)
 	}
 
 	return nil
@@ -178,7 +275,11 @@ func (c *ClusterSupplyChain) validateResourceRefs(references []ResourceReference
 				ref.Resource,
 			)
 		}
-		if referencedResource.TemplateRef.Kind != targetKind {
+		if referencedResource.TemplateRef.Kind == SubChainKind {
+			// the kind of the last resource of the sub-chain is checked once it is expanded
+			continue
+		}
+		if ClusterScopedTemplateKind(referencedResource.TemplateRef.Kind) != targetKind {
 			return fmt.Errorf(
 				"resource [%s] providing [%s] must reference a %s",
 				referencedResource.Name,
@@ -191,6 +292,10 @@ func (c *ClusterSupplyChain) validateResourceRefs(references []ResourceReference
 }
 
 func validateSupplyChainTemplateRef(ref SupplyChainTemplateReference) error {
+	if ref.Kind == SubChainKind && len(ref.Options) > 0 {
+		return fmt.Errorf("templateRef.Options may not be used with a TemplateRef.Kind of [%s]", SubChainKind)
+	}
+
 	if ref.Name != "" && len(ref.Options) > 0 {
 		return fmt.Errorf("exactly one of templateRef.Name or templateRef.Options must be specified, found both")
 	}
@@ -221,5 +326,6 @@ func (c *ClusterSupplyChain) getResourceByName(name string) *SupplyChainResource
 func (c *ClusterSupplyChain) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(c).
+		WithValidator(NewClusterSupplyChainValidator(mgr.GetClient())).
 		Complete()
 }
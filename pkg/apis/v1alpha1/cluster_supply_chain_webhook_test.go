@@ -17,6 +17,7 @@ package v1alpha1_test
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -86,6 +87,122 @@ var _ = Describe("Webhook Validation", func() {
 			})
 		})
 
+		Context("Supply chain referencing a namespaced template kind", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources[1].TemplateRef.Kind = "SourceTemplate"
+			})
+
+			It("on create, returns an error", func() {
+				_, err := supplyChain.ValidateCreate()
+				Expect(err).To(MatchError(
+					"error validating clustersupplychain [responsible-ops---default-params]: error validating resource [other-source-provider]: TemplateRef.Kind [SourceTemplate] may only be used by a SupplyChain",
+				))
+			})
+		})
+
+		Context("Supply chain referencing a sub-chain", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources[0].TemplateRef.Kind = "ClusterSupplyChain"
+				supplyChain.Spec.Resources[0].TemplateRef.Name = "some-sub-chain"
+				supplyChain.Spec.Resources[1].TemplateRef.Kind = "ClusterImageTemplate"
+				supplyChain.Spec.Resources[1].Sources = []v1alpha1.ResourceReference{
+					{
+						Name:     "source",
+						Resource: "source-provider",
+					},
+				}
+			})
+
+			It("leaves checking the kind of the sub-chain outputs to the expansion", func() {
+				_, err := supplyChain.ValidateCreate()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("with options", func() {
+				BeforeEach(func() {
+					supplyChain.Spec.Resources[0].TemplateRef.Name = ""
+					supplyChain.Spec.Resources[0].TemplateRef.Options = []v1alpha1.TemplateOption{
+						{Name: "some-sub-chain"},
+						{Name: "other-sub-chain"},
+					}
+				})
+
+				It("on create, returns an error", func() {
+					_, err := supplyChain.ValidateCreate()
+					Expect(err).To(MatchError(
+						"error validating clustersupplychain [responsible-ops---default-params]: error validating resource [source-provider]: templateRef.Options may not be used with a TemplateRef.Kind of [ClusterSupplyChain]",
+					))
+				})
+			})
+		})
+
+		Context("Supply chain declared a sub-chain", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.LegacySelector = v1alpha1.LegacySelector{}
+				supplyChain.Spec.SubChain = &v1alpha1.SubChain{
+					Entries: []string{"source-provider"},
+					Export:  "other-source-provider",
+				}
+			})
+
+			It("creates without a selector", func() {
+				_, err := supplyChain.ValidateCreate()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("with a selector", func() {
+				BeforeEach(func() {
+					supplyChain.Spec.Selector = map[string]string{"integration-test": "workload-no-supply-chain"}
+				})
+
+				It("on create, returns an error", func() {
+					_, err := supplyChain.ValidateCreate()
+					Expect(err).To(MatchError(
+						"error validating clustersupplychain [responsible-ops---default-params]: a sub-chain may not specify a selector, selectorMatchExpression or selectorMatchField",
+					))
+				})
+			})
+
+			Context("without entries", func() {
+				BeforeEach(func() {
+					supplyChain.Spec.SubChain.Entries = nil
+				})
+
+				It("on create, returns an error", func() {
+					_, err := supplyChain.ValidateCreate()
+					Expect(err).To(MatchError(
+						"error validating clustersupplychain [responsible-ops---default-params]: subChain.entries must name at least one resource",
+					))
+				})
+			})
+
+			Context("with an entry that is not a resource", func() {
+				BeforeEach(func() {
+					supplyChain.Spec.SubChain.Entries = []string{"source-provider", "some-nonexistent-resource"}
+				})
+
+				It("on create, returns an error", func() {
+					_, err := supplyChain.ValidateCreate()
+					Expect(err).To(MatchError(
+						"error validating clustersupplychain [responsible-ops---default-params]: subChain.entries references unknown resource [some-nonexistent-resource]",
+					))
+				})
+			})
+
+			Context("with an export that is not a resource", func() {
+				BeforeEach(func() {
+					supplyChain.Spec.SubChain.Export = "some-nonexistent-resource"
+				})
+
+				It("on create, returns an error", func() {
+					_, err := supplyChain.ValidateCreate()
+					Expect(err).To(MatchError(
+						"error validating clustersupplychain [responsible-ops---default-params]: subChain.export references unknown resource [some-nonexistent-resource]",
+					))
+				})
+			})
+		})
+
 		Context("Supply chain with a resource reference that does not exist", func() {
 			BeforeEach(func() {
 				supplyChain.Spec.Resources[1].Sources = []v1alpha1.ResourceReference{
@@ -143,6 +260,165 @@ var _ = Describe("Webhook Validation", func() {
 			})
 		})
 
+		Context("Resource with a progress deadline that is not positive", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources[1].ProgressDeadline = &metav1.Duration{}
+			})
+
+			It("on create, it rejects the Resource", func() {
+				_, err := supplyChain.ValidateCreate()
+				Expect(err).To(MatchError(
+					"error validating clustersupplychain [responsible-ops---default-params]: error validating resource [other-source-provider]: progressDeadline must be greater than zero",
+				))
+			})
+
+			It("creates without error when the progress deadline is positive", func() {
+				supplyChain.Spec.Resources[1].ProgressDeadline = &metav1.Duration{Duration: time.Minute}
+				_, err := supplyChain.ValidateCreate()
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("Resource with a resync period that is not positive", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources[1].ResyncPeriod = &metav1.Duration{}
+			})
+
+			It("on create, it rejects the Resource", func() {
+				_, err := supplyChain.ValidateCreate()
+				Expect(err).To(MatchError(
+					"error validating clustersupplychain [responsible-ops---default-params]: error validating resource [other-source-provider]: resyncPeriod must be greater than zero",
+				))
+			})
+
+			It("creates without error when the resync period is positive", func() {
+				supplyChain.Spec.Resources[1].ResyncPeriod = &metav1.Duration{Duration: time.Minute}
+				_, err := supplyChain.ValidateCreate()
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("Resource with a when condition", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources = append(supplyChain.Spec.Resources, v1alpha1.SupplyChainResource{
+					Name: "image-provider",
+					TemplateRef: v1alpha1.SupplyChainTemplateReference{
+						Kind: "ClusterImageTemplate",
+						Name: "kpack-template",
+					},
+					Sources: []v1alpha1.ResourceReference{
+						{
+							Name:     "source",
+							Resource: "other-source-provider",
+						},
+					},
+				})
+				supplyChain.Spec.Resources[1].When = &v1alpha1.ResourceCondition{
+					Expression: "params.scanning == 'enabled'",
+				}
+			})
+
+			It("creates without error when the skipped resource can pass through its input", func() {
+				supplyChain.Spec.Resources[1].Sources = []v1alpha1.ResourceReference{
+					{
+						Name:     "source",
+						Resource: "source-provider",
+					},
+				}
+				_, err := supplyChain.ValidateCreate()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("creates without error when the consumer also has a when condition", func() {
+				supplyChain.Spec.Resources[2].When = &v1alpha1.ResourceCondition{
+					Selector: v1alpha1.Selector{
+						LabelSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{"scanning": "enabled"},
+						},
+					},
+				}
+				_, err := supplyChain.ValidateCreate()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("creates without error when another input of the same type is always present", func() {
+				supplyChain.Spec.Resources[2].Sources = append(supplyChain.Spec.Resources[2].Sources, v1alpha1.ResourceReference{
+					Name:     "other-source",
+					Resource: "source-provider",
+				})
+				_, err := supplyChain.ValidateCreate()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("on create, rejects a skipped resource which is the only provider of an input", func() {
+				_, err := supplyChain.ValidateCreate()
+				Expect(err).To(MatchError(
+					"error validating clustersupplychain [responsible-ops---default-params]: resource [other-source-provider] may be skipped and is the only provider of sources for resource [image-provider], which has no when condition",
+				))
+			})
+
+			It("on create, rejects a skipped resource passing through an input which may itself be absent", func() {
+				supplyChain.Spec.Resources[0].When = &v1alpha1.ResourceCondition{
+					Expression: "has(workload.spec.source)",
+				}
+				supplyChain.Spec.Resources[1].Sources = []v1alpha1.ResourceReference{
+					{
+						Name:     "source",
+						Resource: "source-provider",
+					},
+				}
+				_, err := supplyChain.ValidateCreate()
+				Expect(err).To(MatchError(
+					"error validating clustersupplychain [responsible-ops---default-params]: resource [other-source-provider] may be skipped and is the only provider of sources for resource [image-provider], which has no when condition",
+				))
+			})
+
+			It("on create, rejects an expression which does not compile", func() {
+				supplyChain.Spec.Resources[1].When.Expression = "params.scanning =="
+				_, err := supplyChain.ValidateCreate()
+				Expect(err).To(MatchError(ContainSubstring(
+					"error validating clustersupplychain [responsible-ops---default-params]: error validating resource [other-source-provider] when: failed to compile expression [params.scanning ==]",
+				)))
+			})
+
+			It("on create, rejects an expression which does not evaluate to a bool", func() {
+				supplyChain.Spec.Resources[1].When.Expression = "'enabled'"
+				_, err := supplyChain.ValidateCreate()
+				Expect(err).To(MatchError(
+					"error validating clustersupplychain [responsible-ops---default-params]: error validating resource [other-source-provider] when: expression ['enabled'] must evaluate to bool, found string",
+				))
+			})
+
+			It("on create, rejects an empty condition", func() {
+				supplyChain.Spec.Resources[1].When.Expression = ""
+				_, err := supplyChain.ValidateCreate()
+				Expect(err).To(MatchError(
+					"error validating clustersupplychain [responsible-ops---default-params]: error validating resource [other-source-provider] when: at least one of matchLabels, matchExpressions, matchFields or expression must be specified",
+				))
+			})
+
+			It("on create, rejects a field selector on a path outside the workload spec", func() {
+				supplyChain.Spec.Resources[1].When.MatchFields = []v1alpha1.FieldSelectorRequirement{
+					{
+						Key:      "spec.nonexistent",
+						Operator: v1alpha1.FieldSelectorOpExists,
+					},
+				}
+				_, err := supplyChain.ValidateCreate()
+				Expect(err).To(MatchError(
+					"error validating clustersupplychain [responsible-ops---default-params]: error validating resource [other-source-provider] when: requirement key [spec.nonexistent] is not a valid path",
+				))
+			})
+
+			It("on create, rejects a when condition on a sub-chain resource", func() {
+				supplyChain.Spec.Resources[1].TemplateRef.Kind = "ClusterSupplyChain"
+				_, err := supplyChain.ValidateCreate()
+				Expect(err).To(MatchError(
+					"error validating clustersupplychain [responsible-ops---default-params]: error validating resource [other-source-provider]: when may not be used with a TemplateRef.Kind of [ClusterSupplyChain]",
+				))
+			})
+		})
+
 		Context("SupplyChain with malformed params", func() {
 			Context("Top level params are malformed", func() {
 				Context("param does not specify a value or default", func() {
@@ -59,17 +59,44 @@ type TemplateSpec struct {
 	// the owner namespace, the resource will fail to be created.
 	Ytt string `json:"ytt,omitempty"`
 
+	// YttTimeout limits how long the evaluation of a Ytt template may run.
+	// May only be set alongside Ytt. Defaults to 4s.
+	// +optional
+	YttTimeout *metav1.Duration `json:"yttTimeout,omitempty"`
+
 	// Additional parameters.
 	// See: https://cartographer.sh/docs/latest/architecture/#parameter-hierarchy
 	// +optional
 	Params TemplateParams `json:"params,omitempty"`
 
+	// Outputs are named values read from the stamped object, in addition to the
+	// output of the template kind. Each is a path into the stamped object
+	// specified in jsonpath format, eg: .status.latestImage
+	// Blueprint resources consume them through their outputs, as
+	// $(outputs.<resource>.<name>)$
+	// +optional
+	Outputs map[string]string `json:"outputs,omitempty"`
+
 	// HealthRule specifies rubric for determining the health of a resource
 	// stamped by this template.
 	// See: https://cartographer.sh/docs/latest/health-rules/
 	// +optional
 	HealthRule *HealthRule `json:"healthRule,omitempty"`
 
+	// ProgressDeadline is how long a resource stamped by this template may take
+	// to become healthy or produce an output before its Healthy condition is set
+	// to False with reason ProgressDeadlineExceeded.
+	// May be overridden by the blueprint resource.
+	// +optional
+	ProgressDeadline *metav1.Duration `json:"progressDeadline,omitempty"`
+
+	// ResyncPeriod is how often the owner of a resource stamped by this template
+	// is reconciled again, so that the object is stamped and its outputs are read
+	// even when nothing it watches has changed, e.g. to pick up external state.
+	// May be overridden by the blueprint resource.
+	// +optional
+	ResyncPeriod *metav1.Duration `json:"resyncPeriod,omitempty"`
+
 	// Lifecycle specifies whether template modifications should result in originally
 	// created objects being updated (`mutable`) or in new objects created alongside
 	// original objects (`immutable` or `tekton`).
@@ -78,6 +105,13 @@ type TemplateSpec struct {
 	// +kubebuilder:default="mutable"
 	Lifecycle string `json:"lifecycle,omitempty"`
 
+	// ForceApply makes Cartographer take ownership of fields of a `mutable` stamped
+	// object which are managed by another field manager, rather than reporting an
+	// ApplyConflict. Leave unset unless Cartographer should win over the other writers.
+	// See: https://kubernetes.io/docs/reference/using-api/server-side-apply/#conflicts
+	// +optional
+	ForceApply bool `json:"forceApply,omitempty"`
+
 	// RetentionPolicy specifies how many successful and failed runs should be retained
 	// if the template lifecycle is immutable/tekton.
 	// Runs older than this (ordered by creation time) will be deleted. Setting higher
@@ -87,7 +121,7 @@ type TemplateSpec struct {
 }
 
 // HealthRule specifies rubric for determining the health of a resource.
-// One of AlwaysHealthy, SingleConditionType or MultiMatch must be specified.
+// One of AlwaysHealthy, SingleConditionType, MultiMatch or CEL must be specified.
 type HealthRule struct {
 	// AlwaysHealthy being set indicates the resource should always be considered healthy
 	// once it exists.
@@ -103,6 +137,30 @@ type HealthRule struct {
 	// to determine healthiness.
 	// +optional
 	MultiMatch *MultiMatchHealthRule `json:"multiMatch,omitempty"`
+
+	// CEL specifies Common Expression Language expressions, evaluated against
+	// the stamped object, which determine healthiness.
+	// +optional
+	CEL *CELHealthRule `json:"cel,omitempty"`
+}
+
+// CELHealthRule is a set of CEL expressions evaluated against the stamped object,
+// which is available to each expression as `self`.
+// Unhealthy is evaluated first. When it is true the resource is unhealthy. Otherwise,
+// when Healthy is true the resource is healthy. Otherwise, healthiness is Unknown.
+type CELHealthRule struct {
+	// Healthy is a boolean expression which, when true, indicates the resource is healthy.
+	// e.g. `self.status.readyReplicas == self.spec.replicas`
+	Healthy string `json:"healthy"`
+
+	// Unhealthy is a boolean expression which, when true, indicates the resource is unhealthy.
+	// +optional
+	Unhealthy string `json:"unhealthy,omitempty"`
+
+	// Message is a string expression providing a message for the owner's resource
+	// condition.
+	// +optional
+	Message string `json:"message,omitempty"`
 }
 
 // MultiMatchHealthRule is a pair of HealthMatchRule defining when a resource should be considered healthy or unhealthy
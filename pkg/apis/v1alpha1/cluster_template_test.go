@@ -16,10 +16,12 @@ package v1alpha1_test
 
 import (
 	"encoding/json"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	crdmarkers "sigs.k8s.io/controller-tools/pkg/crd/markers"
@@ -89,7 +91,7 @@ var _ = Describe("ClusterTemplate", func() {
 				It("returns an error if no types are specified", func() {
 					_, err := template.ValidateCreate()
 					Expect(err).
-						To(MatchError("invalid health rule: must specify one of alwaysHealthy, singleConditionType or multiMatch, found neither"))
+						To(MatchError("invalid health rule: must specify one of alwaysHealthy, singleConditionType, multiMatch or cel, found neither"))
 				})
 
 				DescribeTable("returns an error if multiple types are specified",
@@ -131,7 +133,7 @@ var _ = Describe("ClusterTemplate", func() {
 						}
 						_, err := template.ValidateCreate()
 						Expect(err).
-							To(MatchError("invalid health rule: must specify one of alwaysHealthy, singleConditionType or multiMatch, found multiple"))
+							To(MatchError("invalid health rule: must specify one of alwaysHealthy, singleConditionType, multiMatch or cel, found multiple"))
 
 					},
 					Entry("All types", true, true, true),
@@ -231,6 +233,69 @@ var _ = Describe("ClusterTemplate", func() {
 							To(MatchError("invalid multi match health rule: healthy rule has no matchFields or matchConditions"))
 					})
 				})
+
+				It("returns an error if CEL is specified alongside another type", func() {
+					template.Spec.HealthRule = &v1alpha1.HealthRule{
+						SingleConditionType: "CantHaveThisToo",
+						CEL:                 &v1alpha1.CELHealthRule{Healthy: "true"},
+					}
+					_, err := template.ValidateCreate()
+					Expect(err).
+						To(MatchError("invalid health rule: must specify one of alwaysHealthy, singleConditionType, multiMatch or cel, found multiple"))
+				})
+
+				It("succeeds when CEL is set", func() {
+					template.Spec.HealthRule = &v1alpha1.HealthRule{
+						CEL: &v1alpha1.CELHealthRule{
+							Healthy:   "self.status.readyReplicas == self.spec.replicas",
+							Unhealthy: "has(self.status.failed) && self.status.failed",
+							Message:   `"ready replicas: " + string(self.status.readyReplicas)`,
+						},
+					}
+					_, err := template.ValidateCreate()
+					Expect(err).To(Succeed())
+				})
+
+				Context("Invalid CEL rules", func() {
+					BeforeEach(func() {
+						template.Spec.HealthRule = &v1alpha1.HealthRule{
+							CEL: &v1alpha1.CELHealthRule{
+								Healthy: "self.status.ready",
+							},
+						}
+					})
+
+					It("returns an error if Healthy is empty", func() {
+						template.Spec.HealthRule.CEL.Healthy = ""
+						_, err := template.ValidateCreate()
+						Expect(err).
+							To(MatchError("invalid cel health rule: healthy expression is required"))
+					})
+
+					It("returns an error if Healthy does not compile", func() {
+						template.Spec.HealthRule.CEL.Healthy = "self.status.ready ==="
+						_, err := template.ValidateCreate()
+						Expect(err).To(MatchError(ContainSubstring("invalid cel health rule: healthy: failed to compile expression [self.status.ready ===]")))
+					})
+
+					It("returns an error if Unhealthy does not evaluate to a bool", func() {
+						template.Spec.HealthRule.CEL.Unhealthy = `"not a bool"`
+						_, err := template.ValidateCreate()
+						Expect(err).To(MatchError(`invalid cel health rule: unhealthy: expression ["not a bool"] must evaluate to bool, found string`))
+					})
+
+					It("returns an error if Message does not evaluate to a string", func() {
+						template.Spec.HealthRule.CEL.Message = "1 + 1"
+						_, err := template.ValidateCreate()
+						Expect(err).To(MatchError("invalid cel health rule: message: expression [1 + 1] must evaluate to string, found int"))
+					})
+
+					It("returns an error if an unknown variable is referenced", func() {
+						template.Spec.HealthRule.CEL.Healthy = "other.status.ready"
+						_, err := template.ValidateCreate()
+						Expect(err).To(MatchError(ContainSubstring("undeclared reference to 'other'")))
+					})
+				})
 			})
 
 			Context("template sets object namespace", func() {
@@ -293,6 +358,179 @@ var _ = Describe("ClusterTemplate", func() {
 				})
 			})
 
+			Context("ytt timeout", func() {
+				BeforeEach(func() {
+					template.Spec.Ytt = `hello: #@ data.values.hello`
+				})
+
+				It("succeeds when the timeout is positive", func() {
+					template.Spec.YttTimeout = &metav1.Duration{Duration: 10 * time.Second}
+					_, err := template.ValidateCreate()
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("rejects a timeout that is not positive", func() {
+					template.Spec.YttTimeout = &metav1.Duration{}
+					_, err := template.ValidateCreate()
+					Expect(err).
+						To(MatchError("invalid template: yttTimeout must be greater than zero"))
+				})
+
+				It("rejects a timeout on a non-ytt template", func() {
+					template.Spec.Ytt = ""
+					template.Spec.Template = &runtime.RawExtension{Raw: []byte(`{"kind":"some-kind","apiVersion":"v1","metadata":{"name":"some-name"}}`)}
+					template.Spec.YttTimeout = &metav1.Duration{Duration: time.Second}
+					_, err := template.ValidateCreate()
+					Expect(err).
+						To(MatchError("invalid template: yttTimeout may only be set for ytt templates"))
+				})
+			})
+
+			Context("progress deadline", func() {
+				BeforeEach(func() {
+					template.Spec.Template = &runtime.RawExtension{Raw: []byte(`{"kind":"some-kind","apiVersion":"v1","metadata":{"name":"some-name"}}`)}
+				})
+
+				It("succeeds when the progress deadline is positive", func() {
+					template.Spec.ProgressDeadline = &metav1.Duration{Duration: 10 * time.Minute}
+					_, err := template.ValidateCreate()
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("rejects a progress deadline that is not positive", func() {
+					template.Spec.ProgressDeadline = &metav1.Duration{}
+					_, err := template.ValidateCreate()
+					Expect(err).
+						To(MatchError("invalid template: progressDeadline must be greater than zero"))
+				})
+			})
+
+			Context("resync period", func() {
+				BeforeEach(func() {
+					template.Spec.Template = &runtime.RawExtension{Raw: []byte(`{"kind":"some-kind","apiVersion":"v1","metadata":{"name":"some-name"}}`)}
+				})
+
+				It("succeeds when the resync period is positive", func() {
+					template.Spec.ResyncPeriod = &metav1.Duration{Duration: 5 * time.Minute}
+					_, err := template.ValidateCreate()
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("rejects a resync period that is not positive", func() {
+					template.Spec.ResyncPeriod = &metav1.Duration{}
+					_, err := template.ValidateCreate()
+					Expect(err).
+						To(MatchError("invalid template: resyncPeriod must be greater than zero"))
+				})
+			})
+
+			Context("outputs", func() {
+				BeforeEach(func() {
+					template.Spec.Template = &runtime.RawExtension{Raw: []byte(`{"kind":"some-kind","apiVersion":"v1","metadata":{"name":"some-name"}}`)}
+				})
+
+				It("succeeds when each output has a name and a path", func() {
+					template.Spec.Outputs = map[string]string{
+						"latestImage": ".status.latestImage",
+						"digest":      "{.status.artifact.digest}",
+					}
+					_, err := template.ValidateCreate()
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("rejects an output without a path", func() {
+					template.Spec.Outputs = map[string]string{"digest": ""}
+					_, err := template.ValidateCreate()
+					Expect(err).To(MatchError("invalid template: output [digest]: path must not be empty"))
+				})
+
+				It("rejects an output whose path is not valid jsonpath", func() {
+					template.Spec.Outputs = map[string]string{"digest": "{.status.digest"}
+					_, err := template.ValidateCreate()
+					Expect(err).To(MatchError(ContainSubstring("invalid template: output [digest]: invalid jsonpath [{.status.digest]")))
+				})
+
+				It("rejects an output named after the output of a template kind", func() {
+					template.Spec.Outputs = map[string]string{"image": ".status.latestImage"}
+					_, err := template.ValidateCreate()
+					Expect(err).To(MatchError("invalid template: output [image]: name is reserved for the output of the template kind"))
+				})
+
+				It("rejects an output name which cannot be referenced", func() {
+					template.Spec.Outputs = map[string]string{"latest.image": ".status.latestImage"}
+					_, err := template.ValidateCreate()
+					Expect(err).To(MatchError(ContainSubstring("invalid template: output [latest.image]: name must start with a letter")))
+				})
+			})
+
+			Context("params", func() {
+				BeforeEach(func() {
+					template.Spec.Template = &runtime.RawExtension{Raw: []byte(`{"kind":"some-kind","apiVersion":"v1","metadata":{"name":"some-name"}}`)}
+				})
+
+				It("succeeds when the default satisfies the schema", func() {
+					template.Spec.Params = v1alpha1.TemplateParams{
+						{
+							Name:         "replicas",
+							DefaultValue: apiextensionsv1.JSON{Raw: []byte(`3`)},
+							Schema:       &apiextensionsv1.JSON{Raw: []byte(`{"type":"integer","minimum":1}`)},
+						},
+					}
+					_, err := template.ValidateCreate()
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("succeeds for a required param without a default", func() {
+					template.Spec.Params = v1alpha1.TemplateParams{
+						{
+							Name:     "replicas",
+							Required: true,
+							Schema:   &apiextensionsv1.JSON{Raw: []byte(`{"type":"integer"}`)},
+						},
+					}
+					_, err := template.ValidateCreate()
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("rejects a default that does not satisfy the schema", func() {
+					template.Spec.Params = v1alpha1.TemplateParams{
+						{
+							Name:         "replicas",
+							DefaultValue: apiextensionsv1.JSON{Raw: []byte(`"three"`)},
+							Schema:       &apiextensionsv1.JSON{Raw: []byte(`{"type":"integer"}`)},
+						},
+					}
+					_, err := template.ValidateCreate()
+					Expect(err).To(MatchError(ContainSubstring("invalid template: default does not satisfy schema: param [replicas]")))
+					Expect(err).To(MatchError(ContainSubstring("must be of type integer")))
+				})
+
+				It("rejects a schema that cannot be parsed", func() {
+					template.Spec.Params = v1alpha1.TemplateParams{
+						{
+							Name:         "replicas",
+							DefaultValue: apiextensionsv1.JSON{Raw: []byte(`3`)},
+							Schema:       &apiextensionsv1.JSON{Raw: []byte(`{"typ":"integer"}`)},
+						},
+					}
+					_, err := template.ValidateCreate()
+					Expect(err).To(MatchError(ContainSubstring("invalid template: param [replicas]: invalid schema")))
+				})
+
+				It("rejects a required param with a default", func() {
+					template.Spec.Params = v1alpha1.TemplateParams{
+						{
+							Name:         "replicas",
+							Required:     true,
+							DefaultValue: apiextensionsv1.JSON{Raw: []byte(`3`)},
+						},
+					}
+					_, err := template.ValidateCreate()
+					Expect(err).
+						To(MatchError("invalid template: param [replicas]: required params may not specify a default"))
+				})
+			})
+
 			Context("lifecycle", func() {
 				BeforeEach(func() {
 					raw, err := json.Marshal(&ArbitraryObject{
@@ -432,7 +670,7 @@ var _ = Describe("ClusterTemplate", func() {
 				It("returns an error if no types are specified", func() {
 					_, err := template.ValidateUpdate(nil)
 					Expect(err).
-						To(MatchError("invalid health rule: must specify one of alwaysHealthy, singleConditionType or multiMatch, found neither"))
+						To(MatchError("invalid health rule: must specify one of alwaysHealthy, singleConditionType, multiMatch or cel, found neither"))
 				})
 
 				DescribeTable("returns an error if multiple types are specified",
@@ -474,7 +712,7 @@ var _ = Describe("ClusterTemplate", func() {
 						}
 						_, err := template.ValidateUpdate(nil)
 						Expect(err).
-							To(MatchError("invalid health rule: must specify one of alwaysHealthy, singleConditionType or multiMatch, found multiple"))
+							To(MatchError("invalid health rule: must specify one of alwaysHealthy, singleConditionType, multiMatch or cel, found multiple"))
 
 					},
 					Entry("All types", true, true, true),
@@ -51,7 +51,23 @@ type TemplateParam struct {
 	// DefaultValue of the parameter.
 	// Causes the parameter to be optional; If the Owner or Template
 	// does not specify this parameter, this value is used.
+	// +optional
 	DefaultValue apiextensionsv1.JSON `json:"default"`
+
+	// Description of the parameter.
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Required parameters have no default and must be given a value
+	// by the Blueprint or Owner.
+	// +optional
+	Required bool `json:"required,omitempty"`
+
+	// Schema is an OpenAPI v3 schema which values of the parameter
+	// must satisfy.
+	// +optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Schema *apiextensionsv1.JSON `json:"schema,omitempty"`
 }
 
 type OwnerParam struct {
@@ -98,6 +114,16 @@ type ResourceReference struct {
 	Resource string `json:"resource"`
 }
 
+// OutputReference is a named output, declared in the outputs of its template, of another
+// resource in the blueprint. It is available to the template as $(outputs.<resource>.<name>)$
+type OutputReference struct {
+	// Resource is the name of the resource in the blueprint which provides the output
+	Resource string `json:"resource"`
+
+	// Name is the name of the output in the outputs of the template of the resource
+	Name string `json:"name"`
+}
+
 type Source struct {
 	// Source code location in a git repository.
 	// +optional
@@ -154,12 +180,82 @@ func GetAPITemplate(templateKind string) (client.Object, error) {
 		template = &ClusterTemplate{}
 	case "ClusterDeploymentTemplate":
 		template = &ClusterDeploymentTemplate{}
+	case "SourceTemplate":
+		template = &SourceTemplate{}
+	case "ImageTemplate":
+		template = &ImageTemplate{}
+	case "ConfigTemplate":
+		template = &ConfigTemplate{}
+	case "Template":
+		template = &Template{}
+	case "DeploymentTemplate":
+		template = &DeploymentTemplate{}
 	default:
 		return nil, fmt.Errorf("resource does not have valid kind: %s", templateKind)
 	}
 	return template, nil
 }
 
+var namespacedTemplateKinds = map[string]string{
+	"SourceTemplate":     "ClusterSourceTemplate",
+	"ImageTemplate":      "ClusterImageTemplate",
+	"ConfigTemplate":     "ClusterConfigTemplate",
+	"Template":           "ClusterTemplate",
+	"DeploymentTemplate": "ClusterDeploymentTemplate",
+}
+
+// IsNamespacedTemplateKind is true for the kinds of the namespaced counterparts of the cluster templates
+func IsNamespacedTemplateKind(templateKind string) bool {
+	_, ok := namespacedTemplateKinds[templateKind]
+	return ok
+}
+
+// ClusterScopedTemplateKind returns the kind of the cluster template that a namespaced template kind
+// is the counterpart of. Any other kind is returned unchanged.
+func ClusterScopedTemplateKind(templateKind string) string {
+	if clusterKind, ok := namespacedTemplateKinds[templateKind]; ok {
+		return clusterKind
+	}
+	return templateKind
+}
+
+// ClusterScopedTemplate returns a namespaced template as its cluster scoped counterpart,
+// with the same metadata and spec, so that both are read and stamped alike.
+// Any other object is returned unchanged.
+func ClusterScopedTemplate(template client.Object) client.Object {
+	switch v := template.(type) {
+	case *SourceTemplate:
+		return &ClusterSourceTemplate{ObjectMeta: v.ObjectMeta, Spec: v.Spec}
+	case *ImageTemplate:
+		return &ClusterImageTemplate{ObjectMeta: v.ObjectMeta, Spec: v.Spec}
+	case *ConfigTemplate:
+		return &ClusterConfigTemplate{ObjectMeta: v.ObjectMeta, Spec: v.Spec}
+	case *DeploymentTemplate:
+		return &ClusterDeploymentTemplate{ObjectMeta: v.ObjectMeta, Spec: v.Spec}
+	case *Template:
+		return &ClusterTemplate{ObjectMeta: v.ObjectMeta, Spec: v.Spec}
+	}
+	return template
+}
+
+// GetTemplateSpec returns the TemplateSpec shared by every kind of template, or nil
+// when the object is not a template
+func GetTemplateSpec(template client.Object) *TemplateSpec {
+	switch v := ClusterScopedTemplate(template).(type) {
+	case *ClusterSourceTemplate:
+		return &v.Spec.TemplateSpec
+	case *ClusterImageTemplate:
+		return &v.Spec.TemplateSpec
+	case *ClusterConfigTemplate:
+		return &v.Spec.TemplateSpec
+	case *ClusterDeploymentTemplate:
+		return &v.Spec.TemplateSpec
+	case *ClusterTemplate:
+		return &v.Spec
+	}
+	return nil
+}
+
 type TemplateOption struct {
 	// Name of the template to apply
 	// Name or PassThrough must be specified
@@ -186,6 +282,22 @@ type Selector struct {
 	MatchFields []FieldSelectorRequirement `json:"matchFields,omitempty"`
 }
 
+// ResourceCondition decides whether a blueprint resource is realized. The resource is
+// realized when the owner matches the selector and the expression evaluates to true.
+// Either may be omitted.
+type ResourceCondition struct {
+	// Selector is matched against the owner, as the selector of a template option is.
+	// +optional
+	Selector `json:",inline"`
+
+	// Expression is a CEL expression which must evaluate to a bool. It may refer to
+	// workload (or deliverable), params, sources, images, configs and deployment,
+	// which hold the same values as they do in a template.
+	// e.g. "params.scanning == 'enabled'"
+	// +optional
+	Expression string `json:"expression,omitempty"`
+}
+
 type FieldSelectorRequirement struct {
 	// Key is the JSON path in the workload to match against.
 	// e.g. for workload: "workload.spec.source.git.url",
@@ -227,6 +339,22 @@ type RealizedResource struct {
 
 	// Outputs are values from the object in StampedRef that can be consumed by other resources
 	Outputs []Output `json:"outputs,omitempty"`
+
+	// ProgressDeadline is the time by which the resource must become healthy or produce
+	// an output. It is only set while the resource has done neither.
+	// +optional
+	ProgressDeadline *metav1.Time `json:"progressDeadline,omitempty"`
+
+	// ResyncPeriod is how often the resource is stamped again, from the blueprint
+	// resource or its template
+	// +optional
+	ResyncPeriod *metav1.Duration `json:"resyncPeriod,omitempty"`
+
+	// Skipped is true when the when condition of the resource was not met, so no
+	// object was stamped. The outputs of a skipped resource are passed through from
+	// its input of the same type, if it has exactly one.
+	// +optional
+	Skipped bool `json:"skipped,omitempty"`
 }
 
 type ResourceStatus struct {
@@ -244,7 +372,8 @@ type Input struct {
 }
 
 type Output struct {
-	// Name is the output type generated from the resource [url, revision, image or config]
+	// Name is the output type generated from the resource [url, revision, image or config],
+	// or the name of an output declared in the outputs of the template
 	Name string `json:"name"`
 
 	// Preview is a preview of the value of the output
@@ -255,6 +384,25 @@ type Output struct {
 
 	// LastTransitionTime is a timestamp of the last time the value changed
 	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+
+	// Inputs are the digests of the outputs of the input resources when the value
+	// last changed, i.e. the inputs which produced the value
+	// +optional
+	Inputs []OutputInput `json:"inputs,omitempty"`
+}
+
+// OutputInput is an output of another resource in the blueprint which was an input of the
+// resource when an output was produced. It refers to the output with the same digest in the
+// status of the other resource, whose own inputs continue the lineage.
+type OutputInput struct {
+	// Resource is the name of the resource in the blueprint which produced the output
+	Resource string `json:"resource"`
+
+	// Name is the name of the output [url, revision, image, config or a named output]
+	Name string `json:"name"`
+
+	// Digest is a sha256 of the full value of the output
+	Digest string `json:"digest"`
 }
 
 // LegacySelector is the collection of selection fields used congruously to specify
@@ -20,6 +20,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 )
@@ -37,6 +38,11 @@ var _ = Describe("common", func() {
 			Entry("ClusterImageTemplate", "ClusterImageTemplate", &v1alpha1.ClusterImageTemplate{}),
 			Entry("ClusterConfigTemplate", "ClusterConfigTemplate", &v1alpha1.ClusterConfigTemplate{}),
 			Entry("ClusterTemplate", "ClusterTemplate", &v1alpha1.ClusterTemplate{}),
+			Entry("SourceTemplate", "SourceTemplate", &v1alpha1.SourceTemplate{}),
+			Entry("ImageTemplate", "ImageTemplate", &v1alpha1.ImageTemplate{}),
+			Entry("ConfigTemplate", "ConfigTemplate", &v1alpha1.ConfigTemplate{}),
+			Entry("DeploymentTemplate", "DeploymentTemplate", &v1alpha1.DeploymentTemplate{}),
+			Entry("Template", "Template", &v1alpha1.Template{}),
 		)
 
 		Context("unknown template kind", func() {
@@ -49,4 +55,42 @@ var _ = Describe("common", func() {
 		})
 
 	})
+
+	Describe("ClusterScopedTemplateKind", func() {
+		DescribeTable("template kinds",
+			func(templateKind string, expectedKind string, namespaced bool) {
+				Expect(v1alpha1.ClusterScopedTemplateKind(templateKind)).To(Equal(expectedKind))
+				Expect(v1alpha1.IsNamespacedTemplateKind(templateKind)).To(Equal(namespaced))
+			},
+			Entry("SourceTemplate", "SourceTemplate", "ClusterSourceTemplate", true),
+			Entry("ImageTemplate", "ImageTemplate", "ClusterImageTemplate", true),
+			Entry("ConfigTemplate", "ConfigTemplate", "ClusterConfigTemplate", true),
+			Entry("DeploymentTemplate", "DeploymentTemplate", "ClusterDeploymentTemplate", true),
+			Entry("Template", "Template", "ClusterTemplate", true),
+			Entry("ClusterSourceTemplate", "ClusterSourceTemplate", "ClusterSourceTemplate", false),
+			Entry("Rubbish", "Rubbish", "Rubbish", false),
+		)
+	})
+
+	Describe("ClusterScopedTemplate", func() {
+		It("returns a namespaced template as its cluster scoped counterpart", func() {
+			template := &v1alpha1.ConfigTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-template", Namespace: "my-namespace"},
+				Spec: v1alpha1.ConfigTemplateSpec{
+					ConfigPath: ".data",
+				},
+			}
+
+			clusterTemplate, ok := v1alpha1.ClusterScopedTemplate(template).(*v1alpha1.ClusterConfigTemplate)
+			Expect(ok).To(BeTrue())
+			Expect(clusterTemplate.Name).To(Equal("my-template"))
+			Expect(clusterTemplate.Namespace).To(Equal("my-namespace"))
+			Expect(clusterTemplate.Spec.ConfigPath).To(Equal(".data"))
+		})
+
+		It("returns other objects unchanged", func() {
+			template := &v1alpha1.ClusterConfigTemplate{}
+			Expect(v1alpha1.ClusterScopedTemplate(template)).To(BeIdenticalTo(template))
+		})
+	})
 })
@@ -18,8 +18,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"regexp"
+	"sort"
 	"strings"
 
+	"github.com/google/cel-go/cel"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/util/jsonpath"
@@ -136,6 +140,24 @@ func validateFieldSelectorRequirements(reqs []FieldSelectorRequirement, validPat
 	return nil
 }
 
+// validateOutputReferences checks that every named output a resource consumes is provided by
+// another resource of the blueprint. Whether the template of that resource declares the output
+// is only known once the template is read.
+func validateOutputReferences(resourceName string, references []OutputReference, isResource func(name string) bool) error {
+	for _, reference := range references {
+		if reference.Name == "" {
+			return fmt.Errorf("output of resource [%s] must have a name", reference.Resource)
+		}
+		if reference.Resource == resourceName {
+			return fmt.Errorf("output [%s] cannot be provided by the resource consuming it", reference.Name)
+		}
+		if !isResource(reference.Resource) {
+			return fmt.Errorf("output [%s] is provided by unknown resource [%s]", reference.Name, reference.Resource)
+		}
+	}
+	return nil
+}
+
 func validJsonpath(path string) error {
 	parser := jsonpath.New("")
 
@@ -187,6 +209,15 @@ func validateLegacySelector(selectors LegacySelector, validPaths map[string]bool
 	return nil
 }
 
+// validateNamespacedServiceAccountRef prevents a namespaced blueprint from stamping with
+// a service account from another namespace.
+func validateNamespacedServiceAccountRef(ref ServiceAccountRef, namespace string) error {
+	if ref.Namespace != "" && ref.Namespace != namespace {
+		return fmt.Errorf("serviceAccountRef.namespace [%s] must be omitted or match the namespace [%s]", ref.Namespace, namespace)
+	}
+	return nil
+}
+
 func (t *TemplateSpec) validate() (admission.Warnings, error) {
 	if t.Template == nil && t.Ytt == "" {
 		return nil, fmt.Errorf("invalid template: must specify one of template or ytt, found neither")
@@ -194,6 +225,20 @@ func (t *TemplateSpec) validate() (admission.Warnings, error) {
 	if t.Template != nil && t.Ytt != "" {
 		return nil, fmt.Errorf("invalid template: must specify one of template or ytt, found both")
 	}
+	if t.ProgressDeadline != nil && t.ProgressDeadline.Duration <= 0 {
+		return nil, fmt.Errorf("invalid template: progressDeadline must be greater than zero")
+	}
+	if t.ResyncPeriod != nil && t.ResyncPeriod.Duration <= 0 {
+		return nil, fmt.Errorf("invalid template: resyncPeriod must be greater than zero")
+	}
+	if t.YttTimeout != nil {
+		if t.Ytt == "" {
+			return nil, fmt.Errorf("invalid template: yttTimeout may only be set for ytt templates")
+		}
+		if t.YttTimeout.Duration <= 0 {
+			return nil, fmt.Errorf("invalid template: yttTimeout must be greater than zero")
+		}
+	}
 	if t.Template != nil {
 		obj := unstructured.Unstructured{}
 		if err := json.Unmarshal(t.Template.Raw, &obj); err != nil {
@@ -203,6 +248,14 @@ func (t *TemplateSpec) validate() (admission.Warnings, error) {
 			return nil, fmt.Errorf("invalid template: template should not set metadata.namespace on the child object")
 		}
 	}
+	for i := range t.Params {
+		if err := t.Params[i].validate(); err != nil {
+			return nil, fmt.Errorf("invalid template: %w", err)
+		}
+	}
+	if err := validateTemplateOutputs(t.Outputs); err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
 	if t.HealthRule != nil {
 		return nil, t.HealthRule.validate()
 	}
@@ -214,6 +267,59 @@ func (t *TemplateSpec) validate() (admission.Warnings, error) {
 	return nil, nil
 }
 
+var outputNamePattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+
+// reservedOutputNames are the names of the outputs of the template kinds, which appear
+// alongside named outputs in the status of the owner
+var reservedOutputNames = map[string]bool{"url": true, "revision": true, "image": true, "config": true}
+
+func validateTemplateOutputs(outputs map[string]string) error {
+	var names []string
+	for name := range outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := outputs[name]
+		if !outputNamePattern.MatchString(name) {
+			return fmt.Errorf("output [%s]: name must start with a letter and contain only letters, digits, '-' and '_'", name)
+		}
+		if reservedOutputNames[name] {
+			return fmt.Errorf("output [%s]: name is reserved for the output of the template kind", name)
+		}
+		if path == "" {
+			return fmt.Errorf("output [%s]: path must not be empty", name)
+		}
+		if err := validJsonpath(path); err != nil {
+			return fmt.Errorf("output [%s]: invalid jsonpath [%s]: %w", name, path, err)
+		}
+	}
+	return nil
+}
+
+func (p *TemplateParam) validate() error {
+	if p.Required && p.HasDefault() {
+		return fmt.Errorf("param [%s]: required params may not specify a default", p.Name)
+	}
+
+	if p.Schema == nil {
+		return nil
+	}
+
+	if _, err := p.schemaValidator(); err != nil {
+		return err
+	}
+
+	if p.HasDefault() {
+		if err := p.ValidateValue(p.DefaultValue); err != nil {
+			return fmt.Errorf("default does not satisfy schema: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func (r *HealthRule) validate() error {
 	nRules := 0
 	if r.AlwaysHealthy != nil {
@@ -225,15 +331,41 @@ func (r *HealthRule) validate() error {
 	if r.MultiMatch != nil {
 		nRules++
 	}
+	if r.CEL != nil {
+		nRules++
+	}
 	if nRules == 0 {
-		return fmt.Errorf("invalid health rule: must specify one of alwaysHealthy, singleConditionType or multiMatch, found neither")
+		return fmt.Errorf("invalid health rule: must specify one of alwaysHealthy, singleConditionType, multiMatch or cel, found neither")
 	}
 	if nRules > 1 {
-		return fmt.Errorf("invalid health rule: must specify one of alwaysHealthy, singleConditionType or multiMatch, found multiple")
+		return fmt.Errorf("invalid health rule: must specify one of alwaysHealthy, singleConditionType, multiMatch or cel, found multiple")
 	}
 	if r.MultiMatch != nil {
 		return r.MultiMatch.validate()
 	}
+	if r.CEL != nil {
+		return r.CEL.validate()
+	}
+	return nil
+}
+
+func (c *CELHealthRule) validate() error {
+	if c.Healthy == "" {
+		return fmt.Errorf("invalid cel health rule: healthy expression is required")
+	}
+	if _, err := CompileCELHealthRuleExpression(c.Healthy, cel.BoolType); err != nil {
+		return fmt.Errorf("invalid cel health rule: healthy: %w", err)
+	}
+	if c.Unhealthy != "" {
+		if _, err := CompileCELHealthRuleExpression(c.Unhealthy, cel.BoolType); err != nil {
+			return fmt.Errorf("invalid cel health rule: unhealthy: %w", err)
+		}
+	}
+	if c.Message != "" {
+		if _, err := CompileCELHealthRuleExpression(c.Message, cel.StringType); err != nil {
+			return fmt.Errorf("invalid cel health rule: message: %w", err)
+		}
+	}
 	return nil
 }
 
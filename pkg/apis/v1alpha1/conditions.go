@@ -58,11 +58,15 @@ const (
 	TemplateObjectRetrievalFailureResourcesSubmittedReason = "TemplateObjectRetrievalFailure"
 	MissingValueAtPathResourcesSubmittedReason             = "MissingValueAtPath"
 	TemplateStampFailureResourcesSubmittedReason           = "TemplateStampFailure"
+	ParamValidationFailedResourcesSubmittedReason          = "ParamValidationFailed"
 	TemplateRejectedByAPIServerResourcesSubmittedReason    = "TemplateRejectedByAPIServer"
+	ApplyConflictResourcesSubmittedReason                  = "ApplyConflict"
 	UnknownErrorResourcesSubmittedReason                   = "UnknownError"
 	ResolveTemplateOptionsErrorResourcesSubmittedReason    = "ResolveTemplateOptionsError"
 	TemplateOptionsMatchErrorResourcesSubmittedReason      = "TemplateOptionsMatchError"
 	PassThroughReason                                      = "PassThrough"
+	SkippedReason                                          = "Skipped"
+	ResourceConditionErrorResourcesSubmittedReason         = "ResourceConditionError"
 )
 
 // -- RESOURCE (OWNER DELIVERABLE) ConditionType - ResourceSubmitted ConditionReasons &&
@@ -79,6 +83,7 @@ const (
 	ServiceAccountErrorResourcesSubmittedReason          = "ServiceAccountError"
 	ServiceAccountTokenErrorResourcesSubmittedReason     = "ServiceAccountTokenError"
 	ResourceRealizerBuilderErrorResourcesSubmittedReason = "ResourceRealizerBuilderError"
+	SubChainErrorResourcesSubmittedReason                = "SubChainError"
 )
 
 // -----------------------------------------
@@ -115,8 +120,10 @@ const (
 // -- BLUEPRINT ConditionType - TemplatesReady ConditionReasons
 
 const (
-	ReadyTemplatesReadyReason    = "Ready"
-	NotFoundTemplatesReadyReason = "TemplatesNotFound"
+	ReadyTemplatesReadyReason             = "Ready"
+	NotFoundTemplatesReadyReason          = "TemplatesNotFound"
+	InvalidSubChainTemplatesReadyReason   = "SubChainInvalid"
+	OutputPathInvalidTemplatesReadyReason = "TemplateOutputPathInvalid"
 )
 
 // -- BLUEPRINT ConditionType - ResourcesHealthy True ConditionReasons
@@ -126,6 +133,12 @@ const (
 	AlwaysHealthyResourcesHealthyReason   = "AlwaysHealthy"
 )
 
+// -- BLUEPRINT ConditionType - ResourcesHealthy False ConditionReasons
+
+const (
+	ProgressDeadlineExceededResourcesHealthyReason = "ProgressDeadlineExceeded"
+)
+
 // -- BLUEPRINT ConditionType - ResourcesHealthy Unknown ConditionReasons
 
 const (
@@ -142,6 +155,15 @@ const (
 	MultiMatchFieldHealthyReason     = "MatchedField"
 )
 
+// -- BLUEPRINT ConditionType - ResourcesHealthy CEL ConditionReasons
+
+const (
+	CELHealthyReason         = "MatchedCELHealthy"
+	CELUnhealthyReason       = "MatchedCELUnhealthy"
+	CELEvaluationErrorReason = "CELEvaluationError"
+	CELNoMatchReason         = "NoCELRuleMatched"
+)
+
 // -----------------------------------------
 // -- RUNNABLE.STATUS.CONDITIONS --
 // ConditionTypes
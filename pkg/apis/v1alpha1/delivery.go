@@ -0,0 +1,84 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +versionName=v1alpha1
+// +groupName=carto.run
+// +kubebuilder:object:generate=true
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DeliveryObject is implemented by both the cluster-scoped ClusterDelivery
+// and the namespaced Delivery.
+// +kubebuilder:object:generate=false
+type DeliveryObject interface {
+	client.Object
+	GetSelectors() LegacySelector
+	GetSpec() *DeliverySpec
+	GetStatus() *DeliveryStatus
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=deliveries,scope=Namespaced
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=='Ready')].status`
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=`.status.conditions[?(@.type=='Ready')].reason`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// Delivery is the namespaced counterpart of ClusterDelivery. It only selects
+// Deliverables in its own namespace, and may reference namespaced templates in that
+// namespace as well as cluster-scoped templates.
+type Delivery struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec describes the delivery.
+	// More info: https://cartographer.sh/docs/latest/reference/deliverable/#clusterdelivery
+	Spec DeliverySpec `json:"spec"`
+
+	// Status conforms to the Kubernetes conventions:
+	// https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#typical-status-properties
+	Status DeliveryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+type DeliveryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Delivery `json:"items"`
+}
+
+func (c *Delivery) GetSelectors() LegacySelector {
+	return c.Spec.LegacySelector
+}
+
+func (c *Delivery) GetSpec() *DeliverySpec {
+	return &c.Spec
+}
+
+func (c *Delivery) GetStatus() *DeliveryStatus {
+	return &c.Status
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&Delivery{},
+		&DeliveryList{},
+	)
+}
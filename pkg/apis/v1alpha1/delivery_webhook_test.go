@@ -0,0 +1,95 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+var _ = Describe("Delivery Webhook Validation", func() {
+	var delivery *v1alpha1.Delivery
+
+	BeforeEach(func() {
+		delivery = &v1alpha1.Delivery{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-delivery",
+				Namespace: "my-namespace",
+			},
+			Spec: v1alpha1.DeliverySpec{
+				Resources: []v1alpha1.DeliveryResource{
+					{
+						Name: "source-provider",
+						TemplateRef: v1alpha1.DeliveryTemplateReference{
+							Kind: "ClusterSourceTemplate",
+							Name: "git-template",
+						},
+					},
+					{
+						Name: "deployer",
+						TemplateRef: v1alpha1.DeliveryTemplateReference{
+							Kind: "DeploymentTemplate",
+							Name: "app-deploy",
+						},
+						Deployment: &v1alpha1.DeploymentReference{
+							Resource: "source-provider",
+						},
+					},
+				},
+				LegacySelector: v1alpha1.LegacySelector{
+					Selector: map[string]string{"app": "web"},
+				},
+			},
+		}
+	})
+
+	Context("Well formed delivery", func() {
+		It("accepts namespaced and cluster templates", func() {
+			_, err := delivery.ValidateCreate()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = delivery.ValidateUpdate(delivery)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = delivery.ValidateDelete()
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("Delivery whose DeploymentTemplate does not receive a deployment", func() {
+		BeforeEach(func() {
+			delivery.Spec.Resources[1].Deployment = nil
+		})
+
+		It("returns an error", func() {
+			_, err := delivery.ValidateCreate()
+			Expect(err).To(MatchError("error validating delivery [my-delivery]: spec.resources[deployer] is a ClusterDeploymentTemplate and must receive a deployment"))
+		})
+	})
+
+	Context("Delivery with a service account ref in another namespace", func() {
+		BeforeEach(func() {
+			delivery.Spec.ServiceAccountRef = v1alpha1.ServiceAccountRef{Name: "my-sa", Namespace: "other-namespace"}
+		})
+
+		It("returns an error", func() {
+			_, err := delivery.ValidateUpdate(delivery)
+			Expect(err).To(MatchError("error validating delivery [my-delivery]: serviceAccountRef.namespace [other-namespace] must be omitted or match the namespace [my-namespace]"))
+		})
+	})
+})
@@ -0,0 +1,52 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +versionName=v1alpha1
+// +groupName=carto.run
+// +kubebuilder:object:generate=true
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=deploymenttemplates,scope=Namespaced
+
+// DeploymentTemplate is the namespaced counterpart of ClusterDeploymentTemplate. It may only be
+// referenced by SupplyChains and Deliveries in its own namespace.
+type DeploymentTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec describes the deployment template.
+	// More info: https://cartographer.sh/docs/latest/reference/template/#clusterdeploymenttemplate
+	Spec DeploymentSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+type DeploymentTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DeploymentTemplate `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&DeploymentTemplate{},
+		&DeploymentTemplateList{},
+	)
+}
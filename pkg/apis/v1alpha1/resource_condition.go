@@ -0,0 +1,191 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+)
+
+// CELResourceConditionVariables are the names by which CEL resource condition expressions
+// refer to the templating context of the resource
+var CELResourceConditionVariables = []string{
+	"workload", "deliverable", "params",
+	"sources", "images", "configs", "deployment",
+	"source", "image", "config", "outputs",
+}
+
+var (
+	resourceConditionEnvOnce sync.Once
+	resourceConditionEnv     *cel.Env
+	resourceConditionEnvErr  error
+)
+
+func celResourceConditionEnv() (*cel.Env, error) {
+	resourceConditionEnvOnce.Do(func() {
+		var options []cel.EnvOption
+		for _, variable := range CELResourceConditionVariables {
+			options = append(options, cel.Variable(variable, cel.DynType))
+		}
+		resourceConditionEnv, resourceConditionEnvErr = cel.NewEnv(options...)
+	})
+	return resourceConditionEnv, resourceConditionEnvErr
+}
+
+// CompileCELResourceConditionExpression parses and type-checks a CEL resource condition
+// expression, which must evaluate to a bool (or dyn).
+func CompileCELResourceConditionExpression(expression string) (cel.Program, error) {
+	env, err := celResourceConditionEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cel environment: %w", err)
+	}
+
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("failed to compile expression [%s]: %w", expression, issues.Err())
+	}
+
+	if ast.OutputType() != cel.DynType && !cel.BoolType.IsAssignableType(ast.OutputType()) {
+		return nil, fmt.Errorf("expression [%s] must evaluate to %s, found %s", expression, cel.BoolType, ast.OutputType())
+	}
+
+	program, err := env.Program(ast, cel.CostLimit(CELHealthRuleCostLimit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build program for expression [%s]: %w", expression, err)
+	}
+
+	return program, nil
+}
+
+// IsSelectorEmpty is true when the condition has no matchLabels, matchExpressions or matchFields
+func (c *ResourceCondition) IsSelectorEmpty() bool {
+	return len(c.MatchLabels) == 0 && len(c.MatchExpressions) == 0 && len(c.MatchFields) == 0
+}
+
+func (c *ResourceCondition) validate(validPaths map[string]bool, validPrefixes []string) error {
+	if c.IsSelectorEmpty() && c.Expression == "" {
+		return fmt.Errorf("at least one of matchLabels, matchExpressions, matchFields or expression must be specified")
+	}
+
+	if !c.IsSelectorEmpty() {
+		if err := validateSelector(c.Selector, validPaths, validPrefixes); err != nil {
+			return err
+		}
+	}
+
+	if c.Expression != "" {
+		if _, err := CompileCELResourceConditionExpression(c.Expression); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// conditionalResource is a blueprint resource reduced to what is needed to find the inputs
+// which may be absent when resources are skipped. Inputs are keyed by sources, images,
+// configs or deployment and hold the names of the providing resources.
+type conditionalResource struct {
+	Name        string
+	Kind        string
+	Conditional bool
+	Inputs      map[string][]string
+}
+
+// passThroughInputType is the type of input a skipped resource of the kind passes through as its output
+func passThroughInputType(kind string) string {
+	switch ClusterScopedTemplateKind(kind) {
+	case "ClusterSourceTemplate":
+		return "sources"
+	case "ClusterImageTemplate":
+		return "images"
+	case "ClusterConfigTemplate":
+		return "configs"
+	case "ClusterDeploymentTemplate":
+		return "deployment"
+	}
+	return ""
+}
+
+// validateSkippedResourcesAreNotOnlyProviders rejects a resource without a when condition which
+// would receive none of a type of input when conditional resources are skipped. A skipped resource
+// only provides an output when it passes through exactly one input of the same type, and that
+// input is itself always present.
+func validateSkippedResourcesAreNotOnlyProviders(resources []conditionalResource) error {
+	resourcesByName := make(map[string]conditionalResource)
+	for _, resource := range resources {
+		resourcesByName[resource.Name] = resource
+	}
+
+	var mayBeAbsent func(name string, visited map[string]bool) bool
+	mayBeAbsent = func(name string, visited map[string]bool) bool {
+		provider, ok := resourcesByName[name]
+		if !ok || !provider.Conditional {
+			return false
+		}
+		if visited[name] {
+			return true
+		}
+		visited[name] = true
+
+		passThroughProviders := provider.Inputs[passThroughInputType(provider.Kind)]
+		if len(passThroughProviders) != 1 {
+			return true
+		}
+		return mayBeAbsent(passThroughProviders[0], visited)
+	}
+
+	for _, resource := range resources {
+		if resource.Conditional {
+			continue
+		}
+
+		for _, inputType := range []string{"sources", "images", "configs", "deployment"} {
+			providers := resource.Inputs[inputType]
+			if len(providers) == 0 {
+				continue
+			}
+
+			allMayBeAbsent := true
+			for _, provider := range providers {
+				if !mayBeAbsent(provider, map[string]bool{}) {
+					allMayBeAbsent = false
+					break
+				}
+			}
+
+			if allMayBeAbsent {
+				return fmt.Errorf(
+					"resource [%s] may be skipped and is the only provider of %s for resource [%s], which has no when condition",
+					providers[0],
+					inputType,
+					resource.Name,
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+func referencedResourceNames(references []ResourceReference) []string {
+	var names []string
+	for _, reference := range references {
+		names = append(names, reference.Resource)
+	}
+	return names
+}
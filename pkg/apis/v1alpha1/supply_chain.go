@@ -0,0 +1,84 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// +versionName=v1alpha1
+// +groupName=carto.run
+// +kubebuilder:object:generate=true
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SupplyChainObject is implemented by both the cluster-scoped ClusterSupplyChain
+// and the namespaced SupplyChain.
+// +kubebuilder:object:generate=false
+type SupplyChainObject interface {
+	client.Object
+	GetSelectors() LegacySelector
+	GetSpec() *SupplyChainSpec
+	GetStatus() *SupplyChainStatus
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=supplychains,scope=Namespaced
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=`.status.conditions[?(@.type=='Ready')].status`
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=`.status.conditions[?(@.type=='Ready')].reason`
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// SupplyChain is the namespaced counterpart of ClusterSupplyChain. It only selects
+// Workloads in its own namespace, and may reference namespaced templates in that
+// namespace as well as cluster-scoped templates.
+type SupplyChain struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+
+	// Spec describes the suppply chain.
+	// More info: https://cartographer.sh/docs/latest/reference/workload/#clustersupplychain
+	Spec SupplyChainSpec `json:"spec"`
+
+	// Status conforms to the Kubernetes conventions:
+	// https://github.com/kubernetes/community/blob/master/contributors/devel/sig-architecture/api-conventions.md#typical-status-properties
+	Status SupplyChainStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+type SupplyChainList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SupplyChain `json:"items"`
+}
+
+func (c *SupplyChain) GetSelectors() LegacySelector {
+	return c.Spec.LegacySelector
+}
+
+func (c *SupplyChain) GetSpec() *SupplyChainSpec {
+	return &c.Spec
+}
+
+func (c *SupplyChain) GetStatus() *SupplyChainStatus {
+	return &c.Status
+}
+
+func init() {
+	SchemeBuilder.Register(
+		&SupplyChain{},
+		&SupplyChainList{},
+	)
+}
@@ -0,0 +1,254 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// SubChainKind is the TemplateRef.Kind of a supply chain resource which inlines
+// the resources of another ClusterSupplyChain
+const SubChainKind = "ClusterSupplyChain"
+
+// SubChainResourceNameSeparator joins the name of a sub-chain resource to the names
+// of the resources it inlines, e.g. a resource [build] referencing a sub-chain with a
+// resource [image-builder] is realized as [build.image-builder]
+const SubChainResourceNameSeparator = "."
+
+// ClusterSupplyChainGetter returns the named ClusterSupplyChain, or nil if it does not exist
+// +kubebuilder:object:generate=false
+type ClusterSupplyChainGetter func(ctx context.Context, name string) (*ClusterSupplyChain, error)
+
+// SubChainNotFoundError is returned when a resource references a ClusterSupplyChain
+// which does not exist
+// +kubebuilder:object:generate=false
+type SubChainNotFoundError struct {
+	ResourceName string
+	SubChainName string
+}
+
+func (e SubChainNotFoundError) Error() string {
+	return fmt.Sprintf("sub-chain [%s] referenced by resource [%s] not found", e.SubChainName, e.ResourceName)
+}
+
+// SubChainNotDeclaredError is returned when a resource references a ClusterSupplyChain
+// which does not declare spec.subChain
+// +kubebuilder:object:generate=false
+type SubChainNotDeclaredError struct {
+	ResourceName string
+	SubChainName string
+}
+
+func (e SubChainNotDeclaredError) Error() string {
+	return fmt.Sprintf("supply chain [%s] referenced by resource [%s] is not a sub-chain, it must specify spec.subChain", e.SubChainName, e.ResourceName)
+}
+
+// ExpandSubChains returns the resources of the supply chain with every resource that
+// references a ClusterSupplyChain replaced by the resources of that sub-chain, recursively.
+//
+// Inlined resources are named <resource>.<sub-chain resource>. The entries of the sub-chain
+// receive the inputs of the sub-chain resource in addition to their own, and resources
+// consuming the sub-chain resource consume the export of the sub-chain instead.
+// Inlined resources are given the params of the sub-chain, then their own params, then the
+// params of the sub-chain resource, later params taking precedence.
+func ExpandSubChains(ctx context.Context, getSubChain ClusterSupplyChainGetter, supplyChain SupplyChainObject) ([]SupplyChainResource, error) {
+	var path []string
+	if _, ok := supplyChain.(*ClusterSupplyChain); ok {
+		path = []string{supplyChain.GetName()}
+	}
+
+	resources, _, err := expandSubChains(ctx, getSubChain, supplyChain.GetSpec().Resources, path)
+	return resources, err
+}
+
+// expandSubChains returns the expanded resources, and for each resource referencing a sub-chain
+// the name of the inlined resource providing its outputs
+func expandSubChains(ctx context.Context, getSubChain ClusterSupplyChainGetter, resources []SupplyChainResource, path []string) ([]SupplyChainResource, map[string]string, error) {
+	outputProviders := make(map[string]string)
+	blocks := make([][]SupplyChainResource, len(resources))
+
+	for i, resource := range resources {
+		if resource.TemplateRef.Kind != SubChainKind {
+			blocks[i] = []SupplyChainResource{resource}
+			continue
+		}
+
+		subChainName := resource.TemplateRef.Name
+		for _, visited := range path {
+			if visited == subChainName {
+				return nil, nil, fmt.Errorf("sub-chain cycle found: %s", strings.Join(append(path, subChainName), " -> "))
+			}
+		}
+
+		subChain, err := getSubChain(ctx, subChainName)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get sub-chain [%s] referenced by resource [%s]: %w", subChainName, resource.Name, err)
+		}
+		if subChain == nil {
+			return nil, nil, SubChainNotFoundError{ResourceName: resource.Name, SubChainName: subChainName}
+		}
+		if subChain.Spec.SubChain == nil {
+			return nil, nil, SubChainNotDeclaredError{ResourceName: resource.Name, SubChainName: subChainName}
+		}
+		if len(subChain.Spec.Resources) == 0 {
+			return nil, nil, fmt.Errorf("sub-chain [%s] referenced by resource [%s] has no resources", subChainName, resource.Name)
+		}
+
+		inlined, err := inlineSubChain(resource, subChain)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		subResources, subOutputProviders, err := expandSubChains(ctx, getSubChain, inlined, append(append([]string{}, path...), subChainName))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		blocks[i] = subResources
+		export := resource.Name + SubChainResourceNameSeparator + subChain.Spec.SubChain.Export
+		if provider, ok := subOutputProviders[export]; ok {
+			export = provider
+		}
+		outputProviders[resource.Name] = export
+	}
+
+	var expanded []SupplyChainResource
+	for _, block := range blocks {
+		for _, resource := range block {
+			resource.Sources = consumeOutputProviders(resource.Sources, outputProviders)
+			resource.Images = consumeOutputProviders(resource.Images, outputProviders)
+			resource.Configs = consumeOutputProviders(resource.Configs, outputProviders)
+			resource.Outputs = consumeNamedOutputProviders(resource.Outputs, outputProviders)
+			expanded = append(expanded, resource)
+		}
+	}
+
+	return expanded, outputProviders, nil
+}
+
+// inlineSubChain returns the resources of the sub-chain named and connected as resources of
+// the supply chain with the sub-chain resource. Resources of the sub-chain which reference
+// further sub-chains are expanded afterwards.
+func inlineSubChain(subChainResource SupplyChainResource, subChain *ClusterSupplyChain) ([]SupplyChainResource, error) {
+	prefix := subChainResource.Name + SubChainResourceNameSeparator
+
+	entries := make(map[string]bool)
+	for _, entry := range subChain.Spec.SubChain.Entries {
+		entries[entry] = true
+	}
+
+	var inlined []SupplyChainResource
+	var exportFound bool
+	for _, subResource := range subChain.Spec.Resources {
+		resource := subResource
+		resource.Name = prefix + subResource.Name
+		resource.Sources = prefixResourceReferences(subResource.Sources, prefix)
+		resource.Images = prefixResourceReferences(subResource.Images, prefix)
+		resource.Configs = prefixResourceReferences(subResource.Configs, prefix)
+		resource.Outputs = prefixOutputReferences(subResource.Outputs, prefix)
+
+		if entries[subResource.Name] {
+			delete(entries, subResource.Name)
+			resource.Sources = append(resource.Sources, subChainResource.Sources...)
+			resource.Images = append(resource.Images, subChainResource.Images...)
+			resource.Configs = append(resource.Configs, subChainResource.Configs...)
+			resource.Outputs = append(resource.Outputs, subChainResource.Outputs...)
+		}
+		if subResource.Name == subChain.Spec.SubChain.Export {
+			exportFound = true
+		}
+
+		var params []BlueprintParam
+		params = append(params, subChain.Spec.Params...)
+		params = append(params, subResource.Params...)
+		params = append(params, subChainResource.Params...)
+		resource.Params = params
+
+		if resource.ProgressDeadline == nil {
+			resource.ProgressDeadline = subChainResource.ProgressDeadline
+		}
+		if resource.ResyncPeriod == nil {
+			resource.ResyncPeriod = subChainResource.ResyncPeriod
+		}
+
+		inlined = append(inlined, resource)
+	}
+
+	for _, entry := range subChain.Spec.SubChain.Entries {
+		if !entries[entry] {
+			continue
+		}
+		return nil, fmt.Errorf("entry [%s] of sub-chain [%s] referenced by resource [%s] is not a resource of the sub-chain", entry, subChain.Name, subChainResource.Name)
+	}
+	if !exportFound {
+		return nil, fmt.Errorf("export [%s] of sub-chain [%s] referenced by resource [%s] is not a resource of the sub-chain", subChain.Spec.SubChain.Export, subChain.Name, subChainResource.Name)
+	}
+
+	return inlined, nil
+}
+
+func prefixResourceReferences(references []ResourceReference, prefix string) []ResourceReference {
+	var prefixed []ResourceReference
+	for _, reference := range references {
+		prefixed = append(prefixed, ResourceReference{
+			Name:     reference.Name,
+			Resource: prefix + reference.Resource,
+		})
+	}
+	return prefixed
+}
+
+func prefixOutputReferences(references []OutputReference, prefix string) []OutputReference {
+	var prefixed []OutputReference
+	for _, reference := range references {
+		prefixed = append(prefixed, OutputReference{
+			Resource: prefix + reference.Resource,
+			Name:     reference.Name,
+		})
+	}
+	return prefixed
+}
+
+func consumeOutputProviders(references []ResourceReference, outputProviders map[string]string) []ResourceReference {
+	if len(references) == 0 {
+		return references
+	}
+
+	var consumed []ResourceReference
+	for _, reference := range references {
+		if provider, ok := outputProviders[reference.Resource]; ok {
+			reference.Resource = provider
+		}
+		consumed = append(consumed, reference)
+	}
+	return consumed
+}
+
+func consumeNamedOutputProviders(references []OutputReference, outputProviders map[string]string) []OutputReference {
+	if len(references) == 0 {
+		return references
+	}
+
+	var consumed []OutputReference
+	for _, reference := range references {
+		if provider, ok := outputProviders[reference.Resource]; ok {
+			reference.Resource = provider
+		}
+		consumed = append(consumed, reference)
+	}
+	return consumed
+}
@@ -0,0 +1,326 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+var _ = Describe("Supply chain composition", func() {
+	var (
+		ctx         context.Context
+		buildChain  *v1alpha1.ClusterSupplyChain
+		supplyChain *v1alpha1.ClusterSupplyChain
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		buildChain = &v1alpha1.ClusterSupplyChain{
+			ObjectMeta: metav1.ObjectMeta{Name: "build-chain"},
+			Spec: v1alpha1.SupplyChainSpec{
+				SubChain: &v1alpha1.SubChain{
+					Entries: []string{"image-builder"},
+					Export:  "image-scanner",
+				},
+				Params: []v1alpha1.BlueprintParam{
+					{Name: "registry", DefaultValue: &apiextensionsv1.JSON{Raw: []byte(`"sub-chain"`)}},
+				},
+				Resources: []v1alpha1.SupplyChainResource{
+					{
+						Name:        "image-builder",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterImageTemplate", Name: "kpack"},
+						Params: []v1alpha1.BlueprintParam{
+							{Name: "registry", DefaultValue: &apiextensionsv1.JSON{Raw: []byte(`"sub-chain-resource"`)}},
+						},
+					},
+					{
+						Name:        "image-scanner",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterImageTemplate", Name: "scanner"},
+						Images:      []v1alpha1.ResourceReference{{Name: "image", Resource: "image-builder"}},
+					},
+				},
+			},
+		}
+
+		supplyChain = &v1alpha1.ClusterSupplyChain{
+			ObjectMeta: metav1.ObjectMeta{Name: "parent-chain"},
+			Spec: v1alpha1.SupplyChainSpec{
+				LegacySelector: v1alpha1.LegacySelector{
+					Selector: map[string]string{"app": "true"},
+				},
+				Resources: []v1alpha1.SupplyChainResource{
+					{
+						Name:        "source-provider",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterSourceTemplate", Name: "git"},
+					},
+					{
+						Name:        "build",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterSupplyChain", Name: "build-chain"},
+						Sources:     []v1alpha1.ResourceReference{{Name: "source", Resource: "source-provider"}},
+						Params: []v1alpha1.BlueprintParam{
+							{Name: "registry", Value: &apiextensionsv1.JSON{Raw: []byte(`"parent"`)}},
+						},
+					},
+					{
+						Name:        "config",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterConfigTemplate", Name: "app-config"},
+						Images:      []v1alpha1.ResourceReference{{Name: "image", Resource: "build"}},
+					},
+				},
+			},
+		}
+	})
+
+	Describe("ExpandSubChains", func() {
+		var (
+			subChains   map[string]*v1alpha1.ClusterSupplyChain
+			getSubChain v1alpha1.ClusterSupplyChainGetter
+		)
+
+		BeforeEach(func() {
+			subChains = map[string]*v1alpha1.ClusterSupplyChain{"build-chain": buildChain}
+			getSubChain = func(_ context.Context, name string) (*v1alpha1.ClusterSupplyChain, error) {
+				return subChains[name], nil
+			}
+		})
+
+		It("returns the resources unchanged when no sub-chain is referenced", func() {
+			supplyChain.Spec.Resources = supplyChain.Spec.Resources[:1]
+
+			resources, err := v1alpha1.ExpandSubChains(ctx, getSubChain, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resources).To(Equal(supplyChain.Spec.Resources))
+		})
+
+		It("inlines the sub-chain resources with names prefixed by the sub-chain resource name", func() {
+			resources, err := v1alpha1.ExpandSubChains(ctx, getSubChain, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+
+			var names []string
+			for _, resource := range resources {
+				names = append(names, resource.Name)
+			}
+			Expect(names).To(Equal([]string{"source-provider", "build.image-builder", "build.image-scanner", "config"}))
+		})
+
+		It("passes the inputs of the sub-chain resource to the entries of the sub-chain", func() {
+			resources, err := v1alpha1.ExpandSubChains(ctx, getSubChain, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resources[1].Sources).To(Equal([]v1alpha1.ResourceReference{{Name: "source", Resource: "source-provider"}}))
+			Expect(resources[2].Sources).To(BeEmpty())
+			Expect(resources[2].Images).To(Equal([]v1alpha1.ResourceReference{{Name: "image", Resource: "build.image-builder"}}))
+		})
+
+		It("passes the inputs of the sub-chain resource to entries in addition to their own inputs", func() {
+			buildChain.Spec.SubChain.Entries = []string{"image-scanner"}
+
+			resources, err := v1alpha1.ExpandSubChains(ctx, getSubChain, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resources[2].Images).To(Equal([]v1alpha1.ResourceReference{{Name: "image", Resource: "build.image-builder"}}))
+			Expect(resources[2].Sources).To(Equal([]v1alpha1.ResourceReference{{Name: "source", Resource: "source-provider"}}))
+		})
+
+		It("does not pass inputs to sub-chain resources which consume no other resource but are not entries", func() {
+			buildChain.Spec.Resources = append(buildChain.Spec.Resources, v1alpha1.SupplyChainResource{
+				Name:        "standalone",
+				TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterTemplate", Name: "policy"},
+			})
+
+			resources, err := v1alpha1.ExpandSubChains(ctx, getSubChain, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resources[3].Name).To(Equal("build.standalone"))
+			Expect(resources[3].Sources).To(BeEmpty())
+			Expect(resources[3].Images).To(BeEmpty())
+			Expect(resources[3].Configs).To(BeEmpty())
+		})
+
+		It("exposes the outputs of the export of the sub-chain as the outputs of the sub-chain resource", func() {
+			resources, err := v1alpha1.ExpandSubChains(ctx, getSubChain, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resources[3].Images).To(Equal([]v1alpha1.ResourceReference{{Name: "image", Resource: "build.image-scanner"}}))
+		})
+
+		It("exposes the export of the sub-chain even when it is not the last resource", func() {
+			buildChain.Spec.SubChain.Export = "image-builder"
+
+			resources, err := v1alpha1.ExpandSubChains(ctx, getSubChain, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resources[3].Images).To(Equal([]v1alpha1.ResourceReference{{Name: "image", Resource: "build.image-builder"}}))
+		})
+
+		It("resolves named outputs of the sub-chain resource and of sub-chain resources", func() {
+			buildChain.Spec.Resources[1].Outputs = []v1alpha1.OutputReference{{Resource: "image-builder", Name: "digest"}}
+			supplyChain.Spec.Resources[2].Outputs = []v1alpha1.OutputReference{{Resource: "build", Name: "report"}}
+
+			resources, err := v1alpha1.ExpandSubChains(ctx, getSubChain, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resources[2].Outputs).To(Equal([]v1alpha1.OutputReference{{Resource: "build.image-builder", Name: "digest"}}))
+			Expect(resources[3].Outputs).To(Equal([]v1alpha1.OutputReference{{Resource: "build.image-scanner", Name: "report"}}))
+		})
+
+		It("orders the params of the sub-chain, then the sub-chain resource's own, then the parent resource's", func() {
+			resources, err := v1alpha1.ExpandSubChains(ctx, getSubChain, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+
+			var values []string
+			for _, param := range resources[1].Params {
+				if param.Value != nil {
+					values = append(values, string(param.Value.Raw))
+				} else {
+					values = append(values, string(param.DefaultValue.Raw))
+				}
+			}
+			Expect(values).To(Equal([]string{`"sub-chain"`, `"sub-chain-resource"`, `"parent"`}))
+		})
+
+		It("expands nested sub-chains", func() {
+			subChains["outer-chain"] = &v1alpha1.ClusterSupplyChain{
+				ObjectMeta: metav1.ObjectMeta{Name: "outer-chain"},
+				Spec: v1alpha1.SupplyChainSpec{
+					SubChain: &v1alpha1.SubChain{
+						Entries: []string{"inner"},
+						Export:  "inner",
+					},
+					Resources: []v1alpha1.SupplyChainResource{
+						{
+							Name:        "inner",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterSupplyChain", Name: "build-chain"},
+						},
+					},
+				},
+			}
+			supplyChain.Spec.Resources[1].TemplateRef.Name = "outer-chain"
+
+			resources, err := v1alpha1.ExpandSubChains(ctx, getSubChain, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(resources[1].Name).To(Equal("build.inner.image-builder"))
+			Expect(resources[1].Sources).To(Equal([]v1alpha1.ResourceReference{{Name: "source", Resource: "source-provider"}}))
+			Expect(resources[2].Images).To(Equal([]v1alpha1.ResourceReference{{Name: "image", Resource: "build.inner.image-builder"}}))
+			Expect(resources[3].Images).To(Equal([]v1alpha1.ResourceReference{{Name: "image", Resource: "build.inner.image-scanner"}}))
+		})
+
+		It("returns an error when the composition has a cycle", func() {
+			buildChain.Spec.Resources[1] = v1alpha1.SupplyChainResource{
+				Name:        "again",
+				TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterSupplyChain", Name: "parent-chain"},
+			}
+			buildChain.Spec.SubChain.Export = "again"
+
+			_, err := v1alpha1.ExpandSubChains(ctx, getSubChain, supplyChain)
+			Expect(err).To(MatchError("sub-chain cycle found: parent-chain -> build-chain -> parent-chain"))
+		})
+
+		It("returns a SubChainNotFoundError when a sub-chain does not exist", func() {
+			delete(subChains, "build-chain")
+
+			_, err := v1alpha1.ExpandSubChains(ctx, getSubChain, supplyChain)
+			Expect(err).To(Equal(v1alpha1.SubChainNotFoundError{ResourceName: "build", SubChainName: "build-chain"}))
+		})
+
+		It("returns a SubChainNotDeclaredError when the referenced supply chain is not a sub-chain", func() {
+			buildChain.Spec.SubChain = nil
+
+			_, err := v1alpha1.ExpandSubChains(ctx, getSubChain, supplyChain)
+			Expect(err).To(Equal(v1alpha1.SubChainNotDeclaredError{ResourceName: "build", SubChainName: "build-chain"}))
+		})
+
+		It("returns an error when an entry of the sub-chain is not one of its resources", func() {
+			buildChain.Spec.SubChain.Entries = []string{"image-builder", "missing"}
+
+			_, err := v1alpha1.ExpandSubChains(ctx, getSubChain, supplyChain)
+			Expect(err).To(MatchError("entry [missing] of sub-chain [build-chain] referenced by resource [build] is not a resource of the sub-chain"))
+		})
+
+		It("returns an error when the export of the sub-chain is not one of its resources", func() {
+			buildChain.Spec.SubChain.Export = "missing"
+
+			_, err := v1alpha1.ExpandSubChains(ctx, getSubChain, supplyChain)
+			Expect(err).To(MatchError("export [missing] of sub-chain [build-chain] referenced by resource [build] is not a resource of the sub-chain"))
+		})
+
+		It("returns an error when a sub-chain cannot be retrieved", func() {
+			getSubChain = func(_ context.Context, _ string) (*v1alpha1.ClusterSupplyChain, error) {
+				return nil, fmt.Errorf("some error")
+			}
+
+			_, err := v1alpha1.ExpandSubChains(ctx, getSubChain, supplyChain)
+			Expect(err).To(MatchError("failed to get sub-chain [build-chain] referenced by resource [build]: some error"))
+		})
+	})
+
+	Describe("ClusterSupplyChainValidator", func() {
+		var validator *v1alpha1.ClusterSupplyChainValidator
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(v1alpha1.AddToScheme(scheme)).To(Succeed())
+			reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(buildChain).Build()
+			validator = v1alpha1.NewClusterSupplyChainValidator(reader)
+		})
+
+		It("accepts a supply chain consuming the outputs of a sub-chain of the right kind", func() {
+			_, err := validator.ValidateCreate(ctx, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects a supply chain consuming the outputs of a sub-chain of the wrong kind", func() {
+			supplyChain.Spec.Resources[2].Images = nil
+			supplyChain.Spec.Resources[2].Configs = []v1alpha1.ResourceReference{{Name: "config", Resource: "build"}}
+
+			_, err := validator.ValidateCreate(ctx, supplyChain)
+			Expect(err).To(MatchError("error validating clustersupplychain [parent-chain]: invalid configs for resource [config]: resource [build.image-scanner] providing [config] must reference a ClusterConfigTemplate"))
+		})
+
+		It("rejects a supply chain which references itself", func() {
+			supplyChain.Spec.Resources[1].TemplateRef.Name = "parent-chain"
+
+			_, err := validator.ValidateCreate(ctx, supplyChain)
+			Expect(err).To(MatchError("error validating clustersupplychain [parent-chain]: sub-chain cycle found: parent-chain -> parent-chain"))
+		})
+
+		It("rejects a supply chain whose inlined resource names collide", func() {
+			supplyChain.Spec.Resources[0].Name = "build.image-builder"
+			supplyChain.Spec.Resources[1].Sources = []v1alpha1.ResourceReference{{Name: "source", Resource: "build.image-builder"}}
+
+			_, err := validator.ValidateCreate(ctx, supplyChain)
+			Expect(err).To(MatchError("error validating clustersupplychain [parent-chain]: duplicate resource name [build.image-builder] found after expanding sub-chains"))
+		})
+
+		It("accepts a supply chain whose sub-chain does not exist yet", func() {
+			supplyChain.Spec.Resources[1].TemplateRef.Name = "not-yet-applied"
+
+			_, err := validator.ValidateCreate(ctx, supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,68 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// +kubebuilder:webhook:path=/validate-carto-run-v1alpha1-supplychain,mutating=false,failurePolicy=fail,sideEffects=none,admissionReviewVersions=v1beta1;v1,groups=carto.run,resources=supplychains,verbs=create;update,versions=v1alpha1,name=namespaced-supply-chain-validator.cartographer.com
+
+var _ webhook.Validator = &SupplyChain{}
+
+func (c *SupplyChain) ValidateCreate() (admission.Warnings, error) {
+	err := c.validateNewState()
+	if err != nil {
+		return nil, fmt.Errorf("error validating supplychain [%s]: %w", c.Name, err)
+	}
+	return nil, nil
+}
+
+func (c *SupplyChain) ValidateUpdate(_ runtime.Object) (admission.Warnings, error) {
+	err := c.validateNewState()
+	if err != nil {
+		return nil, fmt.Errorf("error validating supplychain [%s]: %w", c.Name, err)
+	}
+	return nil, nil
+}
+
+func (c *SupplyChain) ValidateDelete() (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (c *SupplyChain) validateNewState() error {
+	if c.Spec.SubChain != nil {
+		return fmt.Errorf("subChain may only be specified on a ClusterSupplyChain")
+	}
+
+	clusterSupplyChain := &ClusterSupplyChain{ObjectMeta: c.ObjectMeta, Spec: c.Spec}
+	if err := clusterSupplyChain.validateNewState(); err != nil {
+		return err
+	}
+
+	return validateNamespacedServiceAccountRef(c.Spec.ServiceAccountRef, c.Namespace)
+}
+
+func (c *SupplyChain) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(c).
+		WithValidator(NewSupplyChainValidator(mgr.GetClient())).
+		Complete()
+}
@@ -0,0 +1,119 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+var _ = Describe("SupplyChain Webhook Validation", func() {
+	var supplyChain *v1alpha1.SupplyChain
+
+	BeforeEach(func() {
+		supplyChain = &v1alpha1.SupplyChain{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-supply-chain",
+				Namespace: "my-namespace",
+			},
+			Spec: v1alpha1.SupplyChainSpec{
+				Resources: []v1alpha1.SupplyChainResource{
+					{
+						Name: "source-provider",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{
+							Kind: "SourceTemplate",
+							Name: "git-template",
+						},
+					},
+					{
+						Name: "config-provider",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{
+							Kind: "ClusterConfigTemplate",
+							Name: "config-template",
+						},
+						Sources: []v1alpha1.ResourceReference{
+							{
+								Name:     "source",
+								Resource: "source-provider",
+							},
+						},
+					},
+				},
+				LegacySelector: v1alpha1.LegacySelector{
+					Selector: map[string]string{"app": "web"},
+				},
+			},
+		}
+	})
+
+	Context("Well formed supply chain", func() {
+		It("accepts namespaced and cluster templates", func() {
+			_, err := supplyChain.ValidateCreate()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = supplyChain.ValidateUpdate(supplyChain)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = supplyChain.ValidateDelete()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("accepts a service account ref in its own namespace", func() {
+			supplyChain.Spec.ServiceAccountRef = v1alpha1.ServiceAccountRef{Name: "my-sa", Namespace: "my-namespace"}
+
+			_, err := supplyChain.ValidateCreate()
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("Supply chain which fails the validations shared with ClusterSupplyChain", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.Resources[0].TemplateRef.Kind = "ImageTemplate"
+		})
+
+		It("returns an error", func() {
+			_, err := supplyChain.ValidateCreate()
+			Expect(err).To(MatchError("error validating supplychain [my-supply-chain]: invalid sources for resource [config-provider]: resource [source-provider] providing [source] must reference a ClusterSourceTemplate"))
+		})
+	})
+
+	Context("Supply chain declared a sub-chain", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.SubChain = &v1alpha1.SubChain{
+				Entries: []string{"source-provider"},
+				Export:  "source-provider",
+			}
+		})
+
+		It("returns an error", func() {
+			_, err := supplyChain.ValidateCreate()
+			Expect(err).To(MatchError("error validating supplychain [my-supply-chain]: subChain may only be specified on a ClusterSupplyChain"))
+		})
+	})
+
+	Context("Supply chain with a service account ref in another namespace", func() {
+		BeforeEach(func() {
+			supplyChain.Spec.ServiceAccountRef = v1alpha1.ServiceAccountRef{Name: "my-sa", Namespace: "other-namespace"}
+		})
+
+		It("returns an error", func() {
+			_, err := supplyChain.ValidateUpdate(supplyChain)
+			Expect(err).To(MatchError("error validating supplychain [my-supply-chain]: serviceAccountRef.namespace [other-namespace] must be omitted or match the namespace [my-namespace]"))
+		})
+	})
+})
@@ -0,0 +1,59 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TemplateOutputPaths returns the apiVersion and kind of the object the template stamps and the
+// paths of its outputs, by output name. The object is not known for ytt templates, in which case
+// apiVersion and kind are empty.
+func TemplateOutputPaths(template client.Object) (apiVersion string, kind string, paths map[string]string) {
+	templateSpec := GetTemplateSpec(template)
+	if templateSpec == nil {
+		return "", "", nil
+	}
+
+	paths = make(map[string]string)
+	switch v := ClusterScopedTemplate(template).(type) {
+	case *ClusterSourceTemplate:
+		paths["url"] = v.Spec.URLPath
+		if v.Spec.RevisionPath != "" {
+			paths["revision"] = v.Spec.RevisionPath
+		}
+	case *ClusterImageTemplate:
+		paths["image"] = v.Spec.ImagePath
+	case *ClusterConfigTemplate:
+		paths["config"] = v.Spec.ConfigPath
+	}
+	for name, path := range templateSpec.Outputs {
+		paths[name] = path
+	}
+
+	if templateSpec.Template == nil {
+		return "", "", paths
+	}
+
+	var typeMeta metav1.TypeMeta
+	if err := json.Unmarshal(templateSpec.Template.Raw, &typeMeta); err != nil {
+		return "", "", paths
+	}
+
+	return typeMeta.APIVersion, typeMeta.Kind, paths
+}
@@ -0,0 +1,72 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+var _ = Describe("TemplateOutputPaths", func() {
+	It("returns the stamped kind and the paths of an image template", func() {
+		apiVersion, kind, paths := v1alpha1.TemplateOutputPaths(&v1alpha1.ImageTemplate{
+			Spec: v1alpha1.ImageTemplateSpec{
+				TemplateSpec: v1alpha1.TemplateSpec{
+					Template: &runtime.RawExtension{Raw: []byte(`{"apiVersion": "kpack.io/v1alpha2", "kind": "Image"}`)},
+					Outputs:  map[string]string{"tag": ".status.latestImage"},
+				},
+				ImagePath: ".status.latestImage",
+			},
+		})
+
+		Expect(apiVersion).To(Equal("kpack.io/v1alpha2"))
+		Expect(kind).To(Equal("Image"))
+		Expect(paths).To(Equal(map[string]string{
+			"image": ".status.latestImage",
+			"tag":   ".status.latestImage",
+		}))
+	})
+
+	It("returns the revision path of a source template only when it is set", func() {
+		_, _, paths := v1alpha1.TemplateOutputPaths(&v1alpha1.ClusterSourceTemplate{
+			Spec: v1alpha1.SourceTemplateSpec{
+				TemplateSpec: v1alpha1.TemplateSpec{
+					Template: &runtime.RawExtension{Raw: []byte(`{"apiVersion": "v1", "kind": "ConfigMap"}`)},
+				},
+				URLPath: ".data.url",
+			},
+		})
+
+		Expect(paths).To(Equal(map[string]string{"url": ".data.url"}))
+	})
+
+	It("does not know the stamped kind of a ytt template", func() {
+		apiVersion, kind, paths := v1alpha1.TemplateOutputPaths(&v1alpha1.ClusterConfigTemplate{
+			Spec: v1alpha1.ConfigTemplateSpec{
+				TemplateSpec: v1alpha1.TemplateSpec{
+					Ytt: "#@ load(\"@ytt:data\", \"data\")",
+				},
+				ConfigPath: ".data",
+			},
+		})
+
+		Expect(apiVersion).To(BeEmpty())
+		Expect(kind).To(BeEmpty())
+		Expect(paths).To(Equal(map[string]string{"config": ".data"}))
+	})
+})
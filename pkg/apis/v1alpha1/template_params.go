@@ -0,0 +1,107 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// HasDefault is true when the param declares a default value
+func (p *TemplateParam) HasDefault() bool {
+	return len(p.DefaultValue.Raw) > 0 && string(p.DefaultValue.Raw) != "null"
+}
+
+// ValidateValue checks that a value given for the param satisfies the param's schema.
+// Params without a schema accept any value.
+func (p *TemplateParam) ValidateValue(value apiextensionsv1.JSON) error {
+	if p.Schema == nil {
+		return nil
+	}
+
+	validator, err := p.schemaValidator()
+	if err != nil {
+		return err
+	}
+
+	var unmarshalledValue interface{}
+	if err := json.Unmarshal(value.Raw, &unmarshalledValue); err != nil {
+		return fmt.Errorf("param [%s]: value is not valid json: %w", p.Name, err)
+	}
+
+	if errs := validation.ValidateCustomResource(field.NewPath(p.Name), unmarshalledValue, validator); len(errs) > 0 {
+		return fmt.Errorf("param [%s]: %w", p.Name, errs.ToAggregate())
+	}
+
+	return nil
+}
+
+func (p *TemplateParam) schemaValidator() (validation.SchemaValidator, error) {
+	decoder := json.NewDecoder(bytes.NewReader(p.Schema.Raw))
+	decoder.DisallowUnknownFields()
+
+	v1Schema := apiextensionsv1.JSONSchemaProps{}
+	if err := decoder.Decode(&v1Schema); err != nil {
+		return nil, fmt.Errorf("param [%s]: invalid schema: %w", p.Name, err)
+	}
+
+	schema := apiextensions.JSONSchemaProps{}
+	if err := apiextensionsv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(&v1Schema, &schema, nil); err != nil {
+		return nil, fmt.Errorf("param [%s]: invalid schema: %w", p.Name, err)
+	}
+
+	validator, _, err := validation.NewSchemaValidator(&schema)
+	if err != nil {
+		return nil, fmt.Errorf("param [%s]: invalid schema: %w", p.Name, err)
+	}
+
+	return validator, nil
+}
+
+// Validate checks that every required param has a value and that every value
+// satisfies the schema of the param it is given for.
+// Values for params the template does not declare are ignored.
+func (t TemplateParams) Validate(values map[string]apiextensionsv1.JSON) error {
+	for i := range t {
+		value, ok := values[t[i].Name]
+		if !ok || len(value.Raw) == 0 {
+			if t[i].Required {
+				return fmt.Errorf("param [%s] is required", t[i].Name)
+			}
+			continue
+		}
+
+		if err := t[i].ValidateValue(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t TemplateParams) find(name string) *TemplateParam {
+	for i := range t {
+		if t[i].Name == name {
+			return &t[i]
+		}
+	}
+	return nil
+}
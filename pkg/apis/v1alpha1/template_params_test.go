@@ -0,0 +1,89 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+var _ = Describe("TemplateParams", func() {
+	var params v1alpha1.TemplateParams
+
+	BeforeEach(func() {
+		params = v1alpha1.TemplateParams{
+			{
+				Name:     "replicas",
+				Required: true,
+				Schema:   &apiextensionsv1.JSON{Raw: []byte(`{"type":"integer","minimum":1}`)},
+			},
+			{
+				Name:         "registry",
+				DefaultValue: apiextensionsv1.JSON{Raw: []byte(`"example.com"`)},
+			},
+		}
+	})
+
+	Describe("Validate", func() {
+		It("accepts values which satisfy the schemas", func() {
+			err := params.Validate(map[string]apiextensionsv1.JSON{
+				"replicas": {Raw: []byte(`2`)},
+				"registry": {Raw: []byte(`{"server":"example.com"}`)},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("ignores values for params the template does not declare", func() {
+			err := params.Validate(map[string]apiextensionsv1.JSON{
+				"replicas": {Raw: []byte(`2`)},
+				"unknown":  {Raw: []byte(`"anything"`)},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects a value of the wrong type", func() {
+			err := params.Validate(map[string]apiextensionsv1.JSON{
+				"replicas": {Raw: []byte(`"2"`)},
+			})
+			Expect(err).To(MatchError(ContainSubstring("param [replicas]: replicas: Invalid value")))
+			Expect(err).To(MatchError(ContainSubstring("must be of type integer")))
+		})
+
+		It("rejects a value which breaks a constraint", func() {
+			err := params.Validate(map[string]apiextensionsv1.JSON{
+				"replicas": {Raw: []byte(`0`)},
+			})
+			Expect(err).To(MatchError(ContainSubstring("should be greater than or equal to 1")))
+		})
+
+		It("rejects a missing required param", func() {
+			err := params.Validate(map[string]apiextensionsv1.JSON{})
+			Expect(err).To(MatchError("param [replicas] is required"))
+		})
+	})
+
+	Describe("HasDefault", func() {
+		It("is true when a default is given", func() {
+			Expect(params[1].HasDefault()).To(BeTrue())
+		})
+
+		It("is false when no default is given", func() {
+			Expect(params[0].HasDefault()).To(BeFalse())
+		})
+	})
+})
@@ -21,8 +21,14 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DryRunAnnotation when set to "true" on a workload causes the objects its Supply Chain
+// would stamp to be reported in `status.dryRun` rather than submitted to the cluster. The rest of
+// the status is left as the last reconcile without the annotation set it.
+const DryRunAnnotation = "carto.run/dry-run"
+
 // ValidWorkloadPaths Note: this needs to be updated anytime the spec changes
 var ValidWorkloadPaths = map[string]bool{
 	"spec.source":                true,
@@ -145,6 +151,21 @@ type WorkloadStatus struct {
 	// Resources contain references to the objects created by the Supply Chain and the templates used to create them.
 	// It also contains Inputs and Outputs that were passed between the templates as the Supply Chain was processed.
 	Resources []ResourceStatus `json:"resources,omitempty"`
+
+	// DryRun contains the objects the Supply Chain would stamp for this workload.
+	// It is only populated while the workload has the `carto.run/dry-run: "true"` annotation,
+	// in which case no objects are created, updated or deleted on the cluster.
+	// +optional
+	DryRun []DryRunObject `json:"dryRun,omitempty"`
+}
+
+type DryRunObject struct {
+	// ResourceName is the name of the Supply Chain resource that stamped the object.
+	ResourceName string `json:"resourceName"`
+
+	// Object is the stamped object, as it would have been submitted to the cluster.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Object runtime.RawExtension `json:"object"`
 }
 
 // +kubebuilder:object:root=true
@@ -19,8 +19,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/pprof"
+	"os"
+	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
@@ -31,6 +34,10 @@ import (
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/controllers"
+	"github.com/vmware-tanzu/cartographer/pkg/events"
+	"github.com/vmware-tanzu/cartographer/pkg/outputpath"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+	"github.com/vmware-tanzu/cartographer/pkg/tracing"
 	"github.com/vmware-tanzu/cartographer/pkg/utils"
 )
 
@@ -43,6 +50,15 @@ type Command struct {
 	MaxConcurrentDeliveries int
 	MaxConcurrentWorkloads  int
 	MaxConcurrentRunnables  int
+	MaxConcurrentResources  int
+	MinResyncPeriod         time.Duration
+	CloudEventsEndpoint     string
+	CloudEventsQueueSize    int
+	CloudEventsProvenance   bool
+	TracingExporter         string
+	TracingOTLPEndpoint     string
+	TracingOTLPInsecure     bool
+	YttBinary               string
 }
 
 func (cmd *Command) Execute(ctx context.Context) error {
@@ -50,6 +66,8 @@ func (cmd *Command) Execute(ctx context.Context) error {
 	klog.SetLogger(cmd.Logger)
 	l := log.Log.WithName("cartographer")
 
+	templates.YttBinary = cmd.YttBinary
+
 	cfg, err := config.GetConfig()
 	if err != nil {
 		return fmt.Errorf("get config: %w", err)
@@ -84,12 +102,43 @@ func (cmd *Command) Execute(ctx context.Context) error {
 		return fmt.Errorf("failed to create new manager: %w", err)
 	}
 
-	if err := cmd.registerControllers(mgr); err != nil {
+	var cloudEventSink events.CloudEventSink
+	if cmd.CloudEventsEndpoint != "" {
+		httpSink := events.NewHTTPCloudEventSink(cmd.CloudEventsEndpoint, cmd.CloudEventsQueueSize, 5, time.Second, l.WithName("cloudevents"))
+		if err := mgr.Add(httpSink); err != nil {
+			return fmt.Errorf("failed to add cloudevent sink: %w", err)
+		}
+		cloudEventSink = httpSink
+	}
+
+	tracerProvider, err := tracing.NewTracerProvider(ctx, cmd.TracingExporter, cmd.TracingOTLPEndpoint, cmd.TracingOTLPInsecure, os.Stdout)
+	if err != nil {
+		return fmt.Errorf("failed to create tracer provider: %w", err)
+	}
+	if tracerProvider != nil {
+		otel.SetTracerProvider(tracerProvider)
+		err = mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			return tracerProvider.Shutdown(shutdownCtx)
+		}))
+		if err != nil {
+			return fmt.Errorf("failed to add tracer provider: %w", err)
+		}
+	}
+
+	outputPaths, err := outputpath.NewValidatorForConfig(cfg, mgr.GetRESTMapper())
+	if err != nil {
+		return fmt.Errorf("failed to create output path validator: %w", err)
+	}
+
+	if err := cmd.registerControllers(mgr, cloudEventSink, outputPaths); err != nil {
 		return fmt.Errorf("failed to register controllers: %w", err)
 	}
 
 	if cmd.CertDir != "" {
-		if err := registerWebhooks(mgr); err != nil {
+		if err := registerWebhooks(mgr, outputPaths); err != nil {
 			return fmt.Errorf("failed to register webhooks: %w", err)
 		}
 	} else {
@@ -103,16 +152,16 @@ func (cmd *Command) Execute(ctx context.Context) error {
 	return nil
 }
 
-func (cmd *Command) registerControllers(mgr manager.Manager) error {
-	if err := (&controllers.WorkloadReconciler{}).SetupWithManager(mgr, cmd.MaxConcurrentWorkloads); err != nil {
+func (cmd *Command) registerControllers(mgr manager.Manager, cloudEventSink events.CloudEventSink, outputPaths outputpath.Validator) error {
+	if err := (&controllers.WorkloadReconciler{CloudEventSink: cloudEventSink, ExportProvenance: cmd.CloudEventsProvenance, MinResyncPeriod: cmd.MinResyncPeriod}).SetupWithManager(mgr, cmd.MaxConcurrentWorkloads, cmd.MaxConcurrentResources); err != nil {
 		return fmt.Errorf("failed to register workload controller: %w", err)
 	}
 
-	if err := (&controllers.SupplyChainReconciler{}).SetupWithManager(mgr); err != nil {
+	if err := (&controllers.SupplyChainReconciler{OutputPaths: outputPaths}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("failed to register supply chain controller: %w", err)
 	}
 
-	if err := (&controllers.DeliverableReconciler{}).SetupWithManager(mgr, cmd.MaxConcurrentDeliveries); err != nil {
+	if err := (&controllers.DeliverableReconciler{CloudEventSink: cloudEventSink, ExportProvenance: cmd.CloudEventsProvenance, MinResyncPeriod: cmd.MinResyncPeriod}).SetupWithManager(mgr, cmd.MaxConcurrentDeliveries, cmd.MaxConcurrentResources); err != nil {
 		return fmt.Errorf("failed to register deliverable controller: %w", err)
 	}
 
@@ -120,14 +169,14 @@ func (cmd *Command) registerControllers(mgr manager.Manager) error {
 		return fmt.Errorf("failed to register delivery controller: %w", err)
 	}
 
-	if err := (&controllers.RunnableReconciler{}).SetupWithManager(mgr, cmd.MaxConcurrentRunnables); err != nil {
+	if err := (&controllers.RunnableReconciler{CloudEventSink: cloudEventSink}).SetupWithManager(mgr, cmd.MaxConcurrentRunnables); err != nil {
 		return fmt.Errorf("failed to register runnable controller: %w", err)
 	}
 
 	return nil
 }
 
-func registerWebhooks(mgr manager.Manager) error {
+func registerWebhooks(mgr manager.Manager, outputPaths outputpath.Validator) error {
 	if err := (&v1alpha1.ClusterSupplyChain{}).SetupWebhookWithManager(mgr); err != nil {
 		return fmt.Errorf("failed to setup cluster supply chain webhook: %w", err)
 	}
@@ -136,15 +185,15 @@ func registerWebhooks(mgr manager.Manager) error {
 		return fmt.Errorf("failed to setup cluster delivery webhook: %w", err)
 	}
 
-	if err := (&v1alpha1.ClusterConfigTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := outputpath.SetupTemplateWebhookWithManager(mgr, &v1alpha1.ClusterConfigTemplate{}, outputPaths); err != nil {
 		return fmt.Errorf("failed to setup cluster config template webhook: %w", err)
 	}
 
-	if err := (&v1alpha1.ClusterDeploymentTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := outputpath.SetupTemplateWebhookWithManager(mgr, &v1alpha1.ClusterDeploymentTemplate{}, outputPaths); err != nil {
 		return fmt.Errorf("failed to setup cluster deployment template webhook: %w", err)
 	}
 
-	if err := (&v1alpha1.ClusterImageTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := outputpath.SetupTemplateWebhookWithManager(mgr, &v1alpha1.ClusterImageTemplate{}, outputPaths); err != nil {
 		return fmt.Errorf("failed to setup cluster image template webhook: %w", err)
 	}
 
@@ -152,14 +201,42 @@ func registerWebhooks(mgr manager.Manager) error {
 		return fmt.Errorf("failed to setup cluster run template webhook: %w", err)
 	}
 
-	if err := (&v1alpha1.ClusterSourceTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := outputpath.SetupTemplateWebhookWithManager(mgr, &v1alpha1.ClusterSourceTemplate{}, outputPaths); err != nil {
 		return fmt.Errorf("failed to setup cluster source template webhook: %w", err)
 	}
 
-	if err := (&v1alpha1.ClusterTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := outputpath.SetupTemplateWebhookWithManager(mgr, &v1alpha1.ClusterTemplate{}, outputPaths); err != nil {
 		return fmt.Errorf("failed to setup cluster template webhook: %w", err)
 	}
 
+	if err := (&v1alpha1.SupplyChain{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("failed to setup supply chain webhook: %w", err)
+	}
+
+	if err := (&v1alpha1.Delivery{}).SetupWebhookWithManager(mgr); err != nil {
+		return fmt.Errorf("failed to setup delivery webhook: %w", err)
+	}
+
+	if err := outputpath.SetupTemplateWebhookWithManager(mgr, &v1alpha1.ConfigTemplate{}, outputPaths); err != nil {
+		return fmt.Errorf("failed to setup config template webhook: %w", err)
+	}
+
+	if err := outputpath.SetupTemplateWebhookWithManager(mgr, &v1alpha1.DeploymentTemplate{}, outputPaths); err != nil {
+		return fmt.Errorf("failed to setup deployment template webhook: %w", err)
+	}
+
+	if err := outputpath.SetupTemplateWebhookWithManager(mgr, &v1alpha1.ImageTemplate{}, outputPaths); err != nil {
+		return fmt.Errorf("failed to setup image template webhook: %w", err)
+	}
+
+	if err := outputpath.SetupTemplateWebhookWithManager(mgr, &v1alpha1.SourceTemplate{}, outputPaths); err != nil {
+		return fmt.Errorf("failed to setup source template webhook: %w", err)
+	}
+
+	if err := outputpath.SetupTemplateWebhookWithManager(mgr, &v1alpha1.Template{}, outputPaths); err != nil {
+		return fmt.Errorf("failed to setup template webhook: %w", err)
+	}
+
 	return nil
 }
 
@@ -46,3 +46,26 @@ func TemplatesFoundCondition() metav1.Condition {
 		Reason: v1alpha1.ReadyTemplatesReadyReason,
 	}
 }
+
+func TemplateOutputPathInvalidCondition(invalidPaths []string) metav1.Condition {
+	message := fmt.Sprintf(
+		"found output paths which can never exist on the stamped object: %s",
+		strings.Join(invalidPaths, "; "),
+	)
+
+	return metav1.Condition{
+		Type:    v1alpha1.BlueprintTemplatesReady,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.OutputPathInvalidTemplatesReadyReason,
+		Message: message,
+	}
+}
+
+func SubChainInvalidCondition(err error) metav1.Condition {
+	return metav1.Condition{
+		Type:    v1alpha1.BlueprintTemplatesReady,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.InvalidSubChainTemplatesReadyReason,
+		Message: err.Error(),
+	}
+}
@@ -106,8 +106,14 @@ func AddConditionForResourceSubmittedDeliverable(conditionManager *ConditionMana
 		(*conditionManager).AddPositive(TemplateObjectRetrievalFailureCondition(isOwner, typedErr))
 	case cerrors.StampError:
 		(*conditionManager).AddPositive(TemplateStampFailureCondition(isOwner, typedErr))
+	case cerrors.ParamValidationError:
+		(*conditionManager).AddPositive(ParamValidationFailedCondition(isOwner, typedErr))
 	case cerrors.ApplyStampedObjectError:
-		(*conditionManager).AddPositive(TemplateRejectedByAPIServerCondition(isOwner, typedErr))
+		if typedErr.IsConflict() {
+			(*conditionManager).AddPositive(ApplyConflictCondition(isOwner, typedErr))
+		} else {
+			(*conditionManager).AddPositive(TemplateRejectedByAPIServerCondition(isOwner, typedErr))
+		}
 	case cerrors.RetrieveOutputError:
 		switch typedErr.Err.(type) {
 		case stamp.ObservedGenerationError:
@@ -129,6 +135,8 @@ func AddConditionForResourceSubmittedDeliverable(conditionManager *ConditionMana
 		(*conditionManager).AddPositive(ResolveTemplateOptionsErrorCondition(isOwner, typedErr))
 	case cerrors.TemplateOptionsMatchError:
 		(*conditionManager).AddPositive(TemplateOptionsMatchErrorCondition(isOwner, typedErr))
+	case cerrors.ResourceConditionError:
+		(*conditionManager).AddPositive(ResourceConditionErrorCondition(isOwner, typedErr))
 	default:
 		(*conditionManager).AddPositive(UnknownResourceErrorCondition(isOwner, typedErr))
 	}
@@ -39,6 +39,17 @@ func ResourceSubmittedCondition(isPassThrough bool) metav1.Condition {
 	}
 }
 
+// -- Owner.Status.Resource[x].Conditions - ResourceSubmitted - True - Skipped
+
+func ResourceSkippedCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:    v1alpha1.ResourceSubmitted,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1alpha1.SkippedReason,
+		Message: "when condition was not met",
+	}
+}
+
 // -- Owner.Status.Conditions - ResourcesSubmitted - True
 
 func ResourcesSubmittedCondition(isOwner bool) metav1.Condition {
@@ -98,6 +109,15 @@ func TemplateStampFailureCondition(isOwner bool, err error) metav1.Condition {
 	}
 }
 
+func ParamValidationFailedCondition(isOwner bool, err error) metav1.Condition {
+	return metav1.Condition{
+		Type:    getConditionType(isOwner),
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.ParamValidationFailedResourcesSubmittedReason,
+		Message: err.Error(),
+	}
+}
+
 func TemplateRejectedByAPIServerCondition(isOwner bool, err error) metav1.Condition {
 	return metav1.Condition{
 		Type:    getConditionType(isOwner),
@@ -107,6 +127,15 @@ func TemplateRejectedByAPIServerCondition(isOwner bool, err error) metav1.Condit
 	}
 }
 
+func ApplyConflictCondition(isOwner bool, err error) metav1.Condition {
+	return metav1.Condition{
+		Type:    getConditionType(isOwner),
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.ApplyConflictResourcesSubmittedReason,
+		Message: err.Error(),
+	}
+}
+
 func BlueprintsFailedToListCreatedObjectsCondition(isOwner bool, err error) metav1.Condition {
 	return metav1.Condition{
 		Type:    getConditionType(isOwner),
@@ -143,6 +172,15 @@ func TemplateOptionsMatchErrorCondition(isOwner bool, err error) metav1.Conditio
 	}
 }
 
+func ResourceConditionErrorCondition(isOwner bool, err error) metav1.Condition {
+	return metav1.Condition{
+		Type:    getConditionType(isOwner),
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.ResourceConditionErrorResourcesSubmittedReason,
+		Message: err.Error(),
+	}
+}
+
 func getConditionType(isOwner bool) string {
 	if isOwner {
 		return v1alpha1.OwnerResourcesSubmitted
@@ -179,3 +217,12 @@ func ResourceRealizerBuilderErrorCondition(err error) metav1.Condition {
 		Message: err.Error(),
 	}
 }
+
+func SubChainErrorCondition(err error) metav1.Condition {
+	return metav1.Condition{
+		Type:    v1alpha1.OwnerResourcesSubmitted,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.SubChainErrorResourcesSubmittedReason,
+		Message: err.Error(),
+	}
+}
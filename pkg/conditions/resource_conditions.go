@@ -16,6 +16,7 @@ package conditions
 
 import (
 	"fmt"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -49,6 +50,17 @@ func SingleConditionMatchCondition(status metav1.ConditionStatus, conditionName,
 	}
 }
 
+// -- Resource.Conditions - ResourcesHealthy - False
+
+func ProgressDeadlineExceededResourcesHealthyCondition(progressDeadline time.Duration) metav1.Condition {
+	return metav1.Condition{
+		Type:    v1alpha1.ResourceHealthy,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1alpha1.ProgressDeadlineExceededResourcesHealthyReason,
+		Message: fmt.Sprintf("resource did not become healthy or produce an output within the progress deadline [%s]", progressDeadline),
+	}
+}
+
 // -- Resource.Conditions - ResourcesHealthy - Unknown
 
 func NoStampedObjectResourcesHealthyCondition() metav1.Condition {
@@ -101,3 +113,23 @@ func MultiMatchResourcesHealthyCondition(status metav1.ConditionStatus, reason,
 		Message: message,
 	}
 }
+
+// -- Resource.Conditions - ResourcesHealthy - CEL
+
+func CELResourcesHealthyCondition(status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    v1alpha1.ResourceHealthy,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+func CELNoMatchCondition() metav1.Condition {
+	return metav1.Condition{
+		Type:    v1alpha1.ResourceHealthy,
+		Status:  metav1.ConditionUnknown,
+		Reason:  v1alpha1.CELNoMatchReason,
+		Message: "neither the healthy nor the unhealthy expression matched",
+	}
+}
@@ -84,8 +84,14 @@ func AddConditionForResourceSubmittedWorkload(conditionManager *ConditionManager
 		(*conditionManager).AddPositive(TemplateObjectRetrievalFailureCondition(isOwner, typedErr))
 	case cerrors.StampError:
 		(*conditionManager).AddPositive(TemplateStampFailureCondition(isOwner, typedErr))
+	case cerrors.ParamValidationError:
+		(*conditionManager).AddPositive(ParamValidationFailedCondition(isOwner, typedErr))
 	case cerrors.ApplyStampedObjectError:
-		(*conditionManager).AddPositive(TemplateRejectedByAPIServerCondition(isOwner, typedErr))
+		if typedErr.IsConflict() {
+			(*conditionManager).AddPositive(ApplyConflictCondition(isOwner, typedErr))
+		} else {
+			(*conditionManager).AddPositive(TemplateRejectedByAPIServerCondition(isOwner, typedErr))
+		}
 	case cerrors.ListCreatedObjectsError:
 		(*conditionManager).AddPositive(BlueprintsFailedToListCreatedObjectsCondition(isOwner, typedErr))
 	case cerrors.RetrieveOutputError:
@@ -98,6 +104,8 @@ func AddConditionForResourceSubmittedWorkload(conditionManager *ConditionManager
 		(*conditionManager).AddPositive(ResolveTemplateOptionsErrorCondition(isOwner, typedErr))
 	case cerrors.TemplateOptionsMatchError:
 		(*conditionManager).AddPositive(TemplateOptionsMatchErrorCondition(isOwner, typedErr))
+	case cerrors.ResourceConditionError:
+		(*conditionManager).AddPositive(ResourceConditionErrorCondition(isOwner, typedErr))
 	default:
 		(*conditionManager).AddPositive(UnknownResourceErrorCondition(isOwner, typedErr))
 	}
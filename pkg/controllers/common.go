@@ -17,15 +17,22 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/utils/strings/slices"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/metrics"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/statuses"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
+	"github.com/vmware-tanzu/cartographer/pkg/utils"
 )
 
 //go:generate go run -modfile ../../hack/tools/go.mod github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -68,13 +75,89 @@ func immutableEquivalenceTest(realizedResource v1alpha1.ResourceStatus, prevReso
 		realizedResource.Name == prevResource.Name, nil
 }
 
+// observeTimeToHealthy records how long each resource which became healthy took to do so, since its
+// Healthy condition last changed. A resource which is healthy when first realized took no time.
+func observeTimeToHealthy(owner client.Object, blueprintName string, resourceStatuses statuses.ResourceStatuses) {
+	now := time.Now()
+	for _, resourceStatus := range resourceStatuses.GetCurrent() {
+		if !slices.Contains(resourceStatuses.ChangedConditionTypes(resourceStatus.Name), v1alpha1.ResourceHealthy) {
+			continue
+		}
+
+		healthyCondition := utils.ConditionList(resourceStatus.Conditions).ConditionWithType(v1alpha1.ResourceHealthy)
+		if healthyCondition == nil || healthyCondition.Status != metav1.ConditionTrue {
+			continue
+		}
+
+		var timeToHealthy time.Duration
+		if previousResourceStatus := resourceStatuses.GetPreviousResourceStatus(resourceStatus.Name); previousResourceStatus != nil {
+			if previousHealthyCondition := utils.ConditionList(previousResourceStatus.Conditions).ConditionWithType(v1alpha1.ResourceHealthy); previousHealthyCondition != nil {
+				timeToHealthy = now.Sub(previousHealthyCondition.LastTransitionTime.Time)
+			}
+		}
+
+		var templateKind string
+		if resourceStatus.TemplateRef != nil {
+			templateKind = resourceStatus.TemplateRef.Kind
+		}
+
+		metrics.ResourceTimeToHealthy.
+			With(metrics.ResourceLabels(owner, blueprintName, resourceStatus.Name, templateKind)).
+			Observe(timeToHealthy.Seconds())
+	}
+}
+
+// resyncJitter is the largest fraction by which a resync period is lengthened, so that the
+// owners of resources with the same resync period do not all requeue at once
+const resyncJitter = 0.1
+
+// nextRequeue returns how long remains until the earliest progress deadline which has not yet
+// passed or, if sooner, until the shortest resync period of the resources has elapsed. Resync
+// periods are raised to minResyncPeriod and jittered.
+func nextRequeue(resourceStatuses statuses.ResourceStatusList, now time.Time, minResyncPeriod time.Duration) (time.Duration, bool) {
+	requeueAfter, found := resourceStatuses.UntilNextProgressDeadline(now)
+
+	if resyncPeriod, ok := resourceStatuses.ShortestResyncPeriod(); ok {
+		if resyncPeriod < minResyncPeriod {
+			resyncPeriod = minResyncPeriod
+		}
+		resyncPeriod = wait.Jitter(resyncPeriod, resyncJitter)
+		if !found || resyncPeriod < requeueAfter {
+			requeueAfter = resyncPeriod
+			found = true
+		}
+	}
+
+	return requeueAfter, found
+}
+
+// templateNamespace is the namespace a template of the kind is found in when it is
+// referenced by a blueprint in the blueprintNamespace
+func templateNamespace(templateKind, blueprintNamespace string) string {
+	if v1alpha1.IsNamespacedTemplateKind(templateKind) {
+		return blueprintNamespace
+	}
+	return ""
+}
+
+// getSubChainFunc looks up the ClusterSupplyChains which supply chains reference as sub-chains
+func getSubChainFunc(repo repository.Repository) v1alpha1.ClusterSupplyChainGetter {
+	return func(ctx context.Context, name string) (*v1alpha1.ClusterSupplyChain, error) {
+		supplyChain, err := repo.GetSupplyChain(ctx, name, "")
+		if err != nil || supplyChain == nil {
+			return nil, err
+		}
+		return supplyChain.(*v1alpha1.ClusterSupplyChain), nil
+	}
+}
+
 func getEquivalenceTest(ctx context.Context, repo repository.Repository, prevResource v1alpha1.ResourceStatus) (
 	func(v1alpha1.ResourceStatus, v1alpha1.ResourceStatus, context.Context, repository.Repository) (bool, error),
 	error,
 ) {
 	log := logr.FromContextOrDiscard(ctx)
 
-	apiTemplate, err := repo.GetTemplate(ctx, prevResource.TemplateRef.Name, prevResource.TemplateRef.Kind)
+	apiTemplate, err := repo.GetTemplate(ctx, prevResource.TemplateRef.Name, prevResource.TemplateRef.Kind, prevResource.TemplateRef.Namespace)
 	if err != nil {
 		log.Error(err, "unable to get api template")
 		return nil, fmt.Errorf("unable to get api template [%s/%s]: %w", prevResource.TemplateRef.Kind, prevResource.TemplateRef.Name, err)
@@ -17,6 +17,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
@@ -64,6 +65,9 @@ type DeliverableReconciler struct {
 	StampedTracker          stamped.StampedTracker
 	DependencyTracker       dependency.DependencyTracker
 	EventRecorder           record.EventRecorder
+	CloudEventSink          events.CloudEventSink
+	ExportProvenance        bool
+	MinResyncPeriod         time.Duration
 	RESTMapper              meta.RESTMapper
 	Scheme                  *runtime.Scheme
 }
@@ -91,7 +95,7 @@ func (r *DeliverableReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 		return ctrl.Result{}, nil
 	}
-	ctx = events.NewContext(ctx, events.FromEventRecorder(r.EventRecorder, deliverable, r.RESTMapper, log))
+	ctx = events.NewContext(ctx, events.FromEventRecorder(r.EventRecorder, r.CloudEventSink, deliverable, r.RESTMapper, log))
 
 	conditionManager := r.ConditionManagerBuilder(v1alpha1.OwnerReady, deliverable.Status.Conditions)
 
@@ -100,23 +104,24 @@ func (r *DeliverableReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return r.completeReconciliation(ctx, deliverable, nil, conditionManager, err)
 	}
 
-	log = log.WithValues("delivery", delivery.Name)
+	log = log.WithValues("delivery", delivery.GetName())
 	ctx = logr.NewContext(ctx, log)
 
 	deliveryGVK, err := utils.GetObjectGVK(delivery, r.Repo.GetScheme())
 	if err != nil {
 		log.Error(err, "failed to get object gvk for delivery")
 		return r.completeReconciliation(ctx, deliverable, nil, conditionManager, cerrors.NewUnhandledError(
-			fmt.Errorf("failed to get object gvk for delivery [%s]: %w", delivery.Name, err)))
+			fmt.Errorf("failed to get object gvk for delivery [%s]: %w", delivery.GetName(), err)))
 	}
 
 	deliverable.Status.DeliveryRef.Kind = deliveryGVK.Kind
-	deliverable.Status.DeliveryRef.Name = delivery.Name
+	deliverable.Status.DeliveryRef.Name = delivery.GetName()
+	deliverable.Status.DeliveryRef.Namespace = delivery.GetNamespace()
 
 	if !r.isDeliveryReady(delivery) {
 		conditionManager.AddPositive(conditions.MissingReadyInDeliveryCondition(getDeliveryReadyCondition(delivery)))
 		log.Info("delivery is not in ready state")
-		return r.completeReconciliation(ctx, deliverable, nil, conditionManager, fmt.Errorf("delivery [%s] is not in ready state", delivery.Name))
+		return r.completeReconciliation(ctx, deliverable, nil, conditionManager, fmt.Errorf("delivery [%s] is not in ready state", delivery.GetName()))
 	}
 	conditionManager.AddPositive(conditions.DeliveryReadyCondition())
 
@@ -134,8 +139,8 @@ func (r *DeliverableReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return r.completeReconciliation(ctx, deliverable, nil, conditionManager, fmt.Errorf("failed to get token for service account [%s]: %w", fmt.Sprintf("%s/%s", serviceAccountNS, serviceAccountName), err))
 	}
 
-	contextGenerator := realizer.NewContextGenerator(deliverable, deliverable.Spec.Params, delivery.Spec.Params)
-	resourceRealizer, err := r.ResourceRealizerBuilder(saToken, deliverable, contextGenerator, r.Repo, buildDeliverableResourceLabeler(deliverable, delivery))
+	contextGenerator := realizer.NewContextGenerator(deliverable, deliverable.Spec.Params, delivery.GetSpec().Params)
+	resourceRealizer, err := r.ResourceRealizerBuilder(saToken, deliverable, contextGenerator, r.Repo, BuildDeliverableResourceLabeler(deliverable, delivery))
 
 	if err != nil {
 		conditionManager.AddPositive(conditions.ResourceRealizerBuilderErrorCondition(err))
@@ -145,7 +150,7 @@ func (r *DeliverableReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	var reconcileErr error
 	resourceStatuses := statuses.NewResourceStatuses(deliverable.Status.Resources, conditions.AddConditionForResourceSubmittedDeliverable)
 
-	err = r.Realizer.Realize(ctx, resourceRealizer, delivery.Name, realizer.MakeDeliveryOwnerResources(delivery), resourceStatuses)
+	err = r.Realizer.Realize(ctx, resourceRealizer, delivery.GetName(), realizer.MakeDeliveryOwnerResources(delivery), resourceStatuses)
 	if err != nil {
 		conditions.AddConditionForResourceSubmittedDeliverable(&conditionManager, true, err)
 		log.V(logger.DEBUG).Info("failed to realize")
@@ -161,6 +166,7 @@ func (r *DeliverableReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	}
 
 	conditionManager.AddPositive(healthcheck.OwnerHealthCondition(resourceStatuses.GetCurrent(), deliverable.Status.Conditions))
+	observeTimeToHealthy(deliverable, delivery.GetName(), resourceStatuses)
 
 	r.trackDependencies(deliverable, resourceStatuses.GetCurrent(), serviceAccountName, serviceAccountNS)
 
@@ -218,15 +224,22 @@ func (r *DeliverableReconciler) completeReconciliation(ctx context.Context, deli
 		log.Info("handled error reconciling deliverable", "handled error", err)
 	}
 
+	if resourceStatuses != nil {
+		if requeueAfter, ok := nextRequeue(resourceStatuses.GetCurrent(), time.Now(), r.MinResyncPeriod); ok {
+			log.V(logger.DEBUG).Info("requeueing for progress deadline or resync period", "requeue after", requeueAfter)
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+	}
+
 	return ctrl.Result{}, nil
 }
 
-func (r *DeliverableReconciler) isDeliveryReady(delivery *v1alpha1.ClusterDelivery) bool {
+func (r *DeliverableReconciler) isDeliveryReady(delivery v1alpha1.DeliveryObject) bool {
 	readyCondition := getDeliveryReadyCondition(delivery)
 	return readyCondition.Status == "True"
 }
 
-func buildDeliverableResourceLabeler(owner, blueprint client.Object) realizer.ResourceLabeler {
+func BuildDeliverableResourceLabeler(owner, blueprint client.Object) realizer.ResourceLabeler {
 	return func(resource realizer.OwnerResource, reader templates.Reader) templates.Labels {
 		return templates.Labels{
 			"carto.run/deliverable-name":      owner.GetName(),
@@ -270,7 +283,7 @@ func (r *DeliverableReconciler) trackDependencies(deliverable *v1alpha1.Delivera
 				Kind:  resource.TemplateRef.Kind,
 			},
 			NamespacedName: types.NamespacedName{
-				Namespace: "",
+				Namespace: resource.TemplateRef.Namespace,
 				Name:      resource.TemplateRef.Name,
 			},
 		}, types.NamespacedName{
@@ -339,8 +352,8 @@ func (r *DeliverableReconciler) cleanupOrphanedObjects(ctx context.Context, prev
 	return nil
 }
 
-func getDeliveryReadyCondition(delivery *v1alpha1.ClusterDelivery) metav1.Condition {
-	for _, condition := range delivery.Status.Conditions {
+func getDeliveryReadyCondition(delivery v1alpha1.DeliveryObject) metav1.Condition {
+	for _, condition := range delivery.GetStatus().Conditions {
 		if condition.Type == "Ready" {
 			return condition
 		}
@@ -348,7 +361,7 @@ func getDeliveryReadyCondition(delivery *v1alpha1.ClusterDelivery) metav1.Condit
 	return metav1.Condition{}
 }
 
-func (r *DeliverableReconciler) getDeliveriesForDeliverable(ctx context.Context, deliverable *v1alpha1.Deliverable, conditionManager conditions.ConditionManager) (*v1alpha1.ClusterDelivery, error) {
+func (r *DeliverableReconciler) getDeliveriesForDeliverable(ctx context.Context, deliverable *v1alpha1.Deliverable, conditionManager conditions.ConditionManager) (v1alpha1.DeliveryObject, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	if len(deliverable.Labels) == 0 {
 		conditionManager.AddPositive(conditions.DeliverableMissingLabelsCondition())
@@ -381,11 +394,11 @@ func (r *DeliverableReconciler) getDeliveriesForDeliverable(ctx context.Context,
 	}
 
 	delivery := deliveries[0]
-	log.V(logger.DEBUG).Info("delivery matched for deliverable", "delivery", delivery.Name)
+	log.V(logger.DEBUG).Info("delivery matched for deliverable", "delivery", delivery.GetName())
 	return delivery, nil
 }
 
-func getDeliveryNames(objs []*v1alpha1.ClusterDelivery) []string {
+func getDeliveryNames(objs []v1alpha1.DeliveryObject) []string {
 	var names []string
 	for _, obj := range objs {
 		names = append(names, obj.GetName())
@@ -394,23 +407,23 @@ func getDeliveryNames(objs []*v1alpha1.ClusterDelivery) []string {
 	return names
 }
 
-func getServiceAccountNameAndNamespaceForDeliverable(deliverable *v1alpha1.Deliverable, delivery *v1alpha1.ClusterDelivery) (string, string) {
+func getServiceAccountNameAndNamespaceForDeliverable(deliverable *v1alpha1.Deliverable, delivery v1alpha1.DeliveryObject) (string, string) {
 	serviceAccountName := "default"
 	serviceAccountNS := deliverable.Namespace
 
 	if deliverable.Spec.ServiceAccountName != "" {
 		serviceAccountName = deliverable.Spec.ServiceAccountName
-	} else if delivery.Spec.ServiceAccountRef.Name != "" {
-		serviceAccountName = delivery.Spec.ServiceAccountRef.Name
-		if delivery.Spec.ServiceAccountRef.Namespace != "" {
-			serviceAccountNS = delivery.Spec.ServiceAccountRef.Namespace
+	} else if delivery.GetSpec().ServiceAccountRef.Name != "" {
+		serviceAccountName = delivery.GetSpec().ServiceAccountRef.Name
+		if delivery.GetSpec().ServiceAccountRef.Namespace != "" {
+			serviceAccountNS = delivery.GetSpec().ServiceAccountRef.Namespace
 		}
 	}
 
 	return serviceAccountName, serviceAccountNS
 }
 
-func (r *DeliverableReconciler) SetupWithManager(mgr ctrl.Manager, concurrency int) error {
+func (r *DeliverableReconciler) SetupWithManager(mgr ctrl.Manager, concurrency int, resourceConcurrency int) error {
 	clientSet, err := kubernetes.NewForConfig(mgr.GetConfig())
 	if err != nil {
 		return err
@@ -431,7 +444,7 @@ func (r *DeliverableReconciler) SetupWithManager(mgr ctrl.Manager, concurrency i
 		realizerclient.NewClientBuilder(mgr.GetConfig()),
 		repository.NewCache(mgr.GetLogger().WithName("deliverable-stamping-repo-cache")),
 	)
-	r.Realizer = realizer.NewRealizer(nil, r.RESTMapper)
+	r.Realizer = realizer.NewRealizer(nil, r.RESTMapper, resourceConcurrency, r.ExportProvenance)
 	r.DependencyTracker = dependency.NewDependencyTracker(
 		2*utils.DefaultResyncTime,
 		mgr.GetLogger().WithName("tracker-deliverable"),
@@ -449,6 +462,7 @@ func (r *DeliverableReconciler) SetupWithManager(mgr ctrl.Manager, concurrency i
 
 	watches := map[client.Object]handler.MapFunc{
 		&v1alpha1.ClusterDelivery{}:  m.ClusterDeliveryToDeliverableRequests,
+		&v1alpha1.Delivery{}:         m.DeliveryToDeliverableRequests,
 		&corev1.ServiceAccount{}:     m.ServiceAccountToDeliverableRequests,
 		&rbacv1.Role{}:               m.RoleToDeliverableRequests,
 		&rbacv1.RoleBinding{}:        m.RoleBindingToDeliverableRequests,
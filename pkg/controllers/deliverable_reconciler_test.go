@@ -253,7 +253,7 @@ var _ = Describe("DeliverableReconciler", func() {
 					},
 				},
 			}
-			repo.GetDeliveriesForDeliverableReturns([]*v1alpha1.ClusterDelivery{&delivery}, nil)
+			repo.GetDeliveriesForDeliverableReturns([]v1alpha1.DeliveryObject{&delivery}, nil)
 
 			resourceStatuses = statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedDeliverable)
 			resourceStatuses.Add(
@@ -550,7 +550,7 @@ var _ = Describe("DeliverableReconciler", func() {
 						Message: "some informative message",
 					},
 				}
-				repo.GetDeliveriesForDeliverableReturns([]*v1alpha1.ClusterDelivery{&delivery}, nil)
+				repo.GetDeliveriesForDeliverableReturns([]v1alpha1.DeliveryObject{&delivery}, nil)
 			})
 
 			It("does not return an error", func() {
@@ -697,6 +697,32 @@ var _ = Describe("DeliverableReconciler", func() {
 				})
 			})
 
+			Context("of type ParamValidationError", func() {
+				var paramValidationError cerrors.ParamValidationError
+				BeforeEach(func() {
+					paramValidationError = cerrors.ParamValidationError{
+						Err:           errors.New("param [replicas] is required"),
+						ResourceName:  "some-name",
+						BlueprintName: "some-delivery",
+						BlueprintType: cerrors.Delivery,
+						TemplateName:  "some-template",
+						TemplateKind:  "ClusterDeploymentTemplate",
+					}
+
+					rlzr.RealizeReturns(paramValidationError)
+				})
+
+				It("calls the condition manager to report", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(conditions.ParamValidationFailedCondition(true, paramValidationError)))
+				})
+
+				It("does not return an error", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+				})
+			})
+
 			Context("of type ApplyStampedObjectError", func() {
 				var stampedObjectError cerrors.ApplyStampedObjectError
 				BeforeEach(func() {
@@ -740,6 +766,44 @@ var _ = Describe("DeliverableReconciler", func() {
 				})
 			})
 
+			Context("of type ApplyStampedObjectError where the apply conflicted with another field manager", func() {
+				var stampedObjectError cerrors.ApplyStampedObjectError
+				BeforeEach(func() {
+					stampedObject1 := &unstructured.Unstructured{}
+					stampedObject1.SetNamespace("a-namespace")
+					stampedObject1.SetName("a-name")
+
+					stampedObjectError = cerrors.ApplyStampedObjectError{
+						Err:           kerrors.NewConflict(schema.GroupResource{Resource: "jobs"}, "a-name", errors.New("conflict with \"hpa\"")),
+						StampedObject: stampedObject1,
+						ResourceName:  "some-name",
+						BlueprintName: deliveryName,
+						BlueprintType: cerrors.Delivery,
+					}
+
+					rlzr.RealizeStub = func(ctx context.Context, resourceRealizer realizer.ResourceRealizer, deliveryName string, resources []realizer.OwnerResource, statuses statuses.ResourceStatuses) error {
+						statusesVal := reflect.ValueOf(statuses)
+						existingVal := reflect.ValueOf(resourceStatuses)
+
+						reflect.Indirect(statusesVal).Set(reflect.Indirect(existingVal))
+						return stampedObjectError
+					}
+				})
+
+				It("calls the condition manager to report an ApplyConflict", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(conditions.ApplyConflictCondition(true, stampedObjectError)))
+				})
+
+				It("handles the error and logs it", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(out).To(Say(`"level":"info"`))
+					Expect(out).To(Say(`"handled error":"unable to apply object \[a-namespace/a-name\] for resource \[some-name\] in delivery \[some-delivery\]: `))
+				})
+			})
+
 			Context("of type ApplyStampedObjectError where the user did not have proper permissions", func() {
 				var stampedObjectError cerrors.ApplyStampedObjectError
 				BeforeEach(func() {
@@ -1487,7 +1551,7 @@ var _ = Describe("DeliverableReconciler", func() {
 				Version: "alphabeta1",
 				Kind:    "MyThing",
 			})
-			repo.GetDeliveriesForDeliverableReturns([]*v1alpha1.ClusterDelivery{&delivery, &delivery}, nil)
+			repo.GetDeliveriesForDeliverableReturns([]v1alpha1.DeliveryObject{&delivery, &delivery}, nil)
 		})
 
 		It("does not return an error", func() {
@@ -1572,7 +1636,7 @@ var _ = Describe("DeliverableReconciler", func() {
 					},
 				},
 			}
-			repo.GetDeliveriesForDeliverableReturns([]*v1alpha1.ClusterDelivery{&delivery}, nil)
+			repo.GetDeliveriesForDeliverableReturns([]v1alpha1.DeliveryObject{&delivery}, nil)
 
 			rlzr.RealizeReturns(nil)
 
@@ -22,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
@@ -46,7 +47,7 @@ func (r *DeliveryReconiler) Reconcile(ctx context.Context, req reconcile.Request
 	log = log.WithValues("delivery", req.NamespacedName)
 	ctx = logr.NewContext(ctx, log)
 
-	delivery, err := r.Repo.GetDelivery(ctx, req.Name)
+	delivery, err := r.Repo.GetDelivery(ctx, req.Name, req.Namespace)
 	if err != nil {
 		log.Error(err, "failed to get delivery")
 		return ctrl.Result{}, fmt.Errorf("failed to get delivery [%s]: %w", req.NamespacedName, err)
@@ -57,18 +58,18 @@ func (r *DeliveryReconiler) Reconcile(ctx context.Context, req reconcile.Request
 		return ctrl.Result{}, nil
 	}
 
-	conditionManager := conditions.NewConditionManager(v1alpha1.BlueprintReady, delivery.Status.Conditions)
+	conditionManager := conditions.NewConditionManager(v1alpha1.BlueprintReady, delivery.GetStatus().Conditions)
 
 	err = r.reconcileDelivery(ctx, delivery, conditionManager)
 
 	return r.completeReconciliation(ctx, delivery, conditionManager, err)
 }
 
-func (r *DeliveryReconiler) reconcileDelivery(ctx context.Context, delivery *v1alpha1.ClusterDelivery, conditionManager conditions.ConditionManager) error {
+func (r *DeliveryReconiler) reconcileDelivery(ctx context.Context, delivery v1alpha1.DeliveryObject, conditionManager conditions.ConditionManager) error {
 	log := logr.FromContextOrDiscard(ctx)
 	var resourcesNotFound []string
 
-	for _, resource := range delivery.Spec.Resources {
+	for _, resource := range delivery.GetSpec().Resources {
 		if resource.TemplateRef.Name != "" {
 			found, err := r.validateResource(ctx, delivery, resource.TemplateRef.Name, resource.TemplateRef.Kind)
 			if err != nil {
@@ -107,8 +108,8 @@ func (r *DeliveryReconiler) reconcileDelivery(ctx context.Context, delivery *v1a
 	return nil
 }
 
-func (r *DeliveryReconiler) validateResource(ctx context.Context, delivery *v1alpha1.ClusterDelivery, templateName, templateKind string) (bool, error) {
-	template, err := r.Repo.GetTemplate(ctx, templateName, templateKind)
+func (r *DeliveryReconiler) validateResource(ctx context.Context, delivery v1alpha1.DeliveryObject, templateName, templateKind string) (bool, error) {
+	template, err := r.Repo.GetTemplate(ctx, templateName, templateKind, delivery.GetNamespace())
 	if err != nil {
 		return false, err
 	}
@@ -119,24 +120,27 @@ func (r *DeliveryReconiler) validateResource(ctx context.Context, delivery *v1al
 			Kind:  templateKind,
 		},
 		NamespacedName: types.NamespacedName{
-			Name: templateName,
+			Namespace: templateNamespace(templateKind, delivery.GetNamespace()),
+			Name:      templateName,
 		},
 	}, types.NamespacedName{
-		Namespace: delivery.Namespace,
-		Name:      delivery.Name,
+		Namespace: delivery.GetNamespace(),
+		Name:      delivery.GetName(),
 	})
 	return template != nil, nil
 }
 
-func (r *DeliveryReconiler) completeReconciliation(ctx context.Context, delivery *v1alpha1.ClusterDelivery, conditionManager conditions.ConditionManager, err error) (ctrl.Result, error) {
+func (r *DeliveryReconiler) completeReconciliation(ctx context.Context, delivery v1alpha1.DeliveryObject, conditionManager conditions.ConditionManager, err error) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
+	status := delivery.GetStatus()
+
 	var changed bool
-	delivery.Status.Conditions, changed = conditionManager.Finalize()
+	status.Conditions, changed = conditionManager.Finalize()
 
 	var updateErr error
-	if changed || (delivery.Status.ObservedGeneration != delivery.Generation) {
-		delivery.Status.ObservedGeneration = delivery.Generation
+	if changed || (status.ObservedGeneration != delivery.GetGeneration()) {
+		status.ObservedGeneration = delivery.GetGeneration()
 		updateErr = r.Repo.StatusUpdate(ctx, delivery)
 		if updateErr != nil {
 			log.Error(err, "failed to update status for delivery")
@@ -167,7 +171,8 @@ func (r *DeliveryReconiler) SetupWithManager(mgr ctrl.Manager) error {
 	)
 
 	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.ClusterDelivery{})
+		For(&v1alpha1.ClusterDelivery{}).
+		Watches(&v1alpha1.Delivery{}, &handler.EnqueueRequestForObject{})
 
 	for _, template := range v1alpha1.ValidDeliveryTemplates {
 		builder = builder.Watches(
@@ -151,7 +151,7 @@ var _ = Describe("delivery reconciler", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(repo.GetDeliveryCallCount()).To(Equal(1))
-				_, name := repo.GetDeliveryArgsForCall(0)
+				_, name, _ := repo.GetDeliveryArgsForCall(0)
 
 				Expect(name).To(Equal("my-new-delivery"))
 
@@ -63,6 +63,7 @@ type RunnableReconciler struct {
 	StampedTracker          stamped.StampedTracker
 	DependencyTracker       dependency.DependencyTracker
 	EventRecorder           record.EventRecorder
+	CloudEventSink          events.CloudEventSink
 	RESTMapper              meta.RESTMapper
 	Scheme                  *runtime.Scheme
 }
@@ -90,7 +91,7 @@ func (r *RunnableReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 		return ctrl.Result{}, nil
 	}
-	ctx = events.NewContext(ctx, events.FromEventRecorder(r.EventRecorder, runnable, r.RESTMapper, log))
+	ctx = events.NewContext(ctx, events.FromEventRecorder(r.EventRecorder, r.CloudEventSink, runnable, r.RESTMapper, log))
 
 	conditionManager := r.ConditionManagerBuilder(v1alpha1.RunnableReady, runnable.Status.Conditions)
 
@@ -16,6 +16,7 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/go-logr/logr"
@@ -23,11 +24,14 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/conditions"
 	"github.com/vmware-tanzu/cartographer/pkg/enqueuer"
 	cerrors "github.com/vmware-tanzu/cartographer/pkg/errors"
+	"github.com/vmware-tanzu/cartographer/pkg/outputpath"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/tracker/dependency"
 	"github.com/vmware-tanzu/cartographer/pkg/utils"
@@ -41,6 +45,7 @@ type SupplyChainReconciler struct {
 	Repo                    repository.Repository
 	ConditionManagerBuilder conditions.ConditionManagerBuilder
 	DependencyTracker       dependency.DependencyTracker
+	OutputPaths             outputpath.Validator
 }
 
 func (r *SupplyChainReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -51,7 +56,7 @@ func (r *SupplyChainReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	log = log.WithValues("supply chain", req.NamespacedName)
 	ctx = logr.NewContext(ctx, log)
 
-	supplyChain, err := r.Repo.GetSupplyChain(ctx, req.Name)
+	supplyChain, err := r.Repo.GetSupplyChain(ctx, req.Name, req.Namespace)
 	if err != nil {
 		log.Error(err, "failed to get supply chain")
 		return ctrl.Result{}, fmt.Errorf("failed to get supply chain [%s]: %w", req.NamespacedName, err)
@@ -62,22 +67,24 @@ func (r *SupplyChainReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, nil
 	}
 
-	conditionManager := r.ConditionManagerBuilder(v1alpha1.BlueprintReady, supplyChain.Status.Conditions)
+	conditionManager := r.ConditionManagerBuilder(v1alpha1.BlueprintReady, supplyChain.GetStatus().Conditions)
 
 	err = r.reconcileSupplyChain(ctx, supplyChain, conditionManager)
 
 	return r.completeReconciliation(ctx, supplyChain, conditionManager, err)
 }
 
-func (r *SupplyChainReconciler) completeReconciliation(ctx context.Context, supplyChain *v1alpha1.ClusterSupplyChain, conditionManager conditions.ConditionManager, err error) (ctrl.Result, error) {
+func (r *SupplyChainReconciler) completeReconciliation(ctx context.Context, supplyChain v1alpha1.SupplyChainObject, conditionManager conditions.ConditionManager, err error) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
+	status := supplyChain.GetStatus()
+
 	var changed bool
-	supplyChain.Status.Conditions, changed = conditionManager.Finalize()
+	status.Conditions, changed = conditionManager.Finalize()
 
 	var updateErr error
-	if changed || (supplyChain.Status.ObservedGeneration != supplyChain.Generation) {
-		supplyChain.Status.ObservedGeneration = supplyChain.Generation
+	if changed || (status.ObservedGeneration != supplyChain.GetGeneration()) {
+		status.ObservedGeneration = supplyChain.GetGeneration()
 		updateErr = r.Repo.StatusUpdate(ctx, supplyChain)
 		if updateErr != nil {
 			log.Error(err, "failed to update status for supply chain")
@@ -96,42 +103,79 @@ func (r *SupplyChainReconciler) completeReconciliation(ctx context.Context, supp
 	return ctrl.Result{}, nil
 }
 
-func (r *SupplyChainReconciler) reconcileSupplyChain(ctx context.Context, chain *v1alpha1.ClusterSupplyChain, conditionManager conditions.ConditionManager) error {
+func (r *SupplyChainReconciler) reconcileSupplyChain(ctx context.Context, chain v1alpha1.SupplyChainObject, conditionManager conditions.ConditionManager) error {
 	log := logr.FromContextOrDiscard(ctx)
 	var resourcesNotFound []string
 
-	for _, resource := range chain.Spec.Resources {
-		if resource.TemplateRef.Name != "" {
-			found, err := r.validateResource(ctx, chain, resource.TemplateRef.Name, resource.TemplateRef.Kind)
+	var subChainRetrievalErr error
+	getSubChain := func(ctx context.Context, name string) (*v1alpha1.ClusterSupplyChain, error) {
+		r.trackSubChain(chain, name)
+		subChain, err := getSubChainFunc(r.Repo)(ctx, name)
+		if err != nil {
+			subChainRetrievalErr = err
+		}
+		return subChain, err
+	}
+
+	resources, err := v1alpha1.ExpandSubChains(ctx, getSubChain, chain)
+	if subChainRetrievalErr != nil {
+		log.Error(err, "failed to get sub-chain")
+		return cerrors.NewUnhandledError(err)
+	}
+	if err != nil {
+		var notFoundErr v1alpha1.SubChainNotFoundError
+		if errors.As(err, &notFoundErr) {
+			conditionManager.AddPositive(conditions.TemplatesNotFoundCondition([]string{notFoundErr.ResourceName}))
+		} else {
+			conditionManager.AddPositive(conditions.SubChainInvalidCondition(err))
+		}
+		return nil
+	}
+
+	var invalidOutputPaths []string
+	for _, resource := range resources {
+		templateNames := []string{resource.TemplateRef.Name}
+		if resource.TemplateRef.Name == "" {
+			templateNames = nil
+			for _, option := range resource.TemplateRef.Options {
+				if option.Name != "" {
+					templateNames = append(templateNames, option.Name)
+				}
+			}
+		}
+
+		for _, templateName := range templateNames {
+			template, err := r.validateResource(ctx, chain, templateName, resource.TemplateRef.Kind)
 			if err != nil {
 				log.Error(err, "failed to get cluster template", "template",
-					fmt.Sprintf("%s/%s", resource.TemplateRef.Kind, resource.TemplateRef.Name))
+					fmt.Sprintf("%s/%s", resource.TemplateRef.Kind, templateName))
 				return cerrors.NewUnhandledError(fmt.Errorf("failed to get cluster template: %w", err))
 			}
 
-			if !found {
+			if template == nil {
 				resourcesNotFound = append(resourcesNotFound, resource.Name)
+				continue
 			}
-		} else {
-			for _, option := range resource.TemplateRef.Options {
-				if option.Name != "" {
-					found, err := r.validateResource(ctx, chain, option.Name, resource.TemplateRef.Kind)
-					if err != nil {
-						log.Error(err, "failed to get cluster template", "template",
-							fmt.Sprintf("%s/%s", resource.TemplateRef.Kind, resource.TemplateRef.Name))
-						return cerrors.NewUnhandledError(fmt.Errorf("failed to get cluster template: %w", err))
-					}
-
-					if !found {
-						resourcesNotFound = append(resourcesNotFound, resource.Name)
-					}
-				}
+
+			invalidPaths, err := r.validateOutputPaths(template)
+			if err != nil {
+				log.Error(err, "unable to validate output paths, leaving them unvalidated", "template",
+					fmt.Sprintf("%s/%s", resource.TemplateRef.Kind, templateName))
+			}
+
+			for _, invalidPath := range invalidPaths {
+				invalidOutputPaths = append(invalidOutputPaths, fmt.Sprintf(
+					"resource [%s] template [%s/%s] %s",
+					resource.Name, resource.TemplateRef.Kind, templateName, invalidPath,
+				))
 			}
 		}
 	}
 
 	if len(resourcesNotFound) > 0 {
 		conditionManager.AddPositive(conditions.TemplatesNotFoundCondition(resourcesNotFound))
+	} else if len(invalidOutputPaths) > 0 {
+		conditionManager.AddPositive(conditions.TemplateOutputPathInvalidCondition(invalidOutputPaths))
 	} else {
 		conditionManager.AddPositive(conditions.TemplatesFoundCondition())
 	}
@@ -139,10 +183,11 @@ func (r *SupplyChainReconciler) reconcileSupplyChain(ctx context.Context, chain
 	return nil
 }
 
-func (r *SupplyChainReconciler) validateResource(ctx context.Context, supplyChain *v1alpha1.ClusterSupplyChain, templateName, templateKind string) (bool, error) {
-	template, err := r.Repo.GetTemplate(ctx, templateName, templateKind)
+// validateResource gets and tracks the template, which is nil when it is not found
+func (r *SupplyChainReconciler) validateResource(ctx context.Context, supplyChain v1alpha1.SupplyChainObject, templateName, templateKind string) (client.Object, error) {
+	template, err := r.Repo.GetTemplate(ctx, templateName, templateKind, supplyChain.GetNamespace())
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
 	r.DependencyTracker.Track(dependency.Key{
@@ -151,14 +196,44 @@ func (r *SupplyChainReconciler) validateResource(ctx context.Context, supplyChai
 			Kind:  templateKind,
 		},
 		NamespacedName: types.NamespacedName{
-			Name: templateName,
+			Namespace: templateNamespace(templateKind, supplyChain.GetNamespace()),
+			Name:      templateName,
 		},
 	}, types.NamespacedName{
-		Namespace: supplyChain.Namespace,
-		Name:      supplyChain.Name,
+		Namespace: supplyChain.GetNamespace(),
+		Name:      supplyChain.GetName(),
 	})
 
-	return template != nil, nil
+	return template, nil
+}
+
+// validateOutputPaths finds the output paths of the template which can never exist on the object it stamps
+func (r *SupplyChainReconciler) validateOutputPaths(template client.Object) ([]outputpath.InvalidPath, error) {
+	if r.OutputPaths == nil {
+		return nil, nil
+	}
+
+	apiVersion, kind, paths := v1alpha1.TemplateOutputPaths(template)
+	if apiVersion == "" || kind == "" {
+		return nil, nil
+	}
+
+	return r.OutputPaths.Validate(apiVersion, kind, paths)
+}
+
+func (r *SupplyChainReconciler) trackSubChain(supplyChain v1alpha1.SupplyChainObject, subChainName string) {
+	r.DependencyTracker.Track(dependency.Key{
+		GroupKind: schema.GroupKind{
+			Group: v1alpha1.SchemeGroupVersion.Group,
+			Kind:  v1alpha1.SubChainKind,
+		},
+		NamespacedName: types.NamespacedName{
+			Name: subChainName,
+		},
+	}, types.NamespacedName{
+		Namespace: supplyChain.GetNamespace(),
+		Name:      supplyChain.GetName(),
+	})
 }
 
 func (r *SupplyChainReconciler) SetupWithManager(mgr ctrl.Manager) error {
@@ -174,7 +249,12 @@ func (r *SupplyChainReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	)
 
 	builder := ctrl.NewControllerManagedBy(mgr).
-		For(&v1alpha1.ClusterSupplyChain{})
+		For(&v1alpha1.ClusterSupplyChain{}).
+		Watches(&v1alpha1.SupplyChain{}, &handler.EnqueueRequestForObject{}).
+		Watches(
+			&v1alpha1.ClusterSupplyChain{},
+			enqueuer.EnqueueTracked(&v1alpha1.ClusterSupplyChain{}, r.DependencyTracker, mgr.GetScheme()),
+		)
 
 	for _, template := range v1alpha1.ValidSupplyChainTemplates {
 		builder = builder.Watches(
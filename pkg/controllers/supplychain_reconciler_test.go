@@ -33,6 +33,8 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/conditions"
 	"github.com/vmware-tanzu/cartographer/pkg/conditions/conditionsfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/controllers"
+	"github.com/vmware-tanzu/cartographer/pkg/outputpath"
+	"github.com/vmware-tanzu/cartographer/pkg/outputpath/outputpathfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/tracker/dependency/dependencyfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/utils"
@@ -47,6 +49,7 @@ var _ = Describe("SupplyChainReconciler", func() {
 		conditionManager   *conditionsfakes.FakeConditionManager
 		repo               *repositoryfakes.FakeRepository
 		dependencyTracker  *dependencyfakes.FakeDependencyTracker
+		outputPaths        *outputpathfakes.FakeValidator
 		sc                 *v1alpha1.ClusterSupplyChain
 		expectedConditions []metav1.Condition
 	)
@@ -78,6 +81,8 @@ var _ = Describe("SupplyChainReconciler", func() {
 
 		dependencyTracker = &dependencyfakes.FakeDependencyTracker{}
 
+		outputPaths = &outputpathfakes.FakeValidator{}
+
 		sc = &v1alpha1.ClusterSupplyChain{
 			ObjectMeta: metav1.ObjectMeta{
 				Generation: 1,
@@ -100,6 +105,7 @@ var _ = Describe("SupplyChainReconciler", func() {
 			Repo:                    repo,
 			ConditionManagerBuilder: fakeConditionManagerBuilder,
 			DependencyTracker:       dependencyTracker,
+			OutputPaths:             outputPaths,
 		}
 
 		req = reconcile.Request{
@@ -243,6 +249,195 @@ var _ = Describe("SupplyChainReconciler", func() {
 		})
 	})
 
+	Context("a namespaced supply chain", func() {
+		var namespacedSupplyChain *v1alpha1.SupplyChain
+
+		BeforeEach(func() {
+			namespacedSupplyChain = &v1alpha1.SupplyChain{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "my-supply-chain",
+					Namespace:  "my-namespace",
+					Generation: 1,
+				},
+				Spec: v1alpha1.SupplyChainSpec{
+					Resources: []v1alpha1.SupplyChainResource{
+						{
+							Name: "first-resource",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{
+								Kind: "SourceTemplate",
+								Name: "my-source-template",
+							},
+						},
+						{
+							Name: "second-resource",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{
+								Kind: "ClusterTemplate",
+								Name: "my-final-template",
+							},
+						},
+					},
+				},
+			}
+			repo.GetSupplyChainReturns(namespacedSupplyChain, nil)
+		})
+
+		It("gets the supply chain from the namespace of the request", func() {
+			_, _ = reconciler.Reconcile(ctx, req)
+
+			Expect(repo.GetSupplyChainCallCount()).To(Equal(1))
+			_, name, namespace := repo.GetSupplyChainArgsForCall(0)
+			Expect(name).To(Equal("my-supply-chain"))
+			Expect(namespace).To(Equal("my-namespace"))
+		})
+
+		It("gets the templates with the namespace of the supply chain", func() {
+			_, _ = reconciler.Reconcile(ctx, req)
+
+			Expect(repo.GetTemplateCallCount()).To(Equal(2))
+			_, name, kind, namespace := repo.GetTemplateArgsForCall(0)
+			Expect(name).To(Equal("my-source-template"))
+			Expect(kind).To(Equal("SourceTemplate"))
+			Expect(namespace).To(Equal("my-namespace"))
+		})
+
+		It("watches namespaced templates in the namespace of the supply chain", func() {
+			_, _ = reconciler.Reconcile(ctx, req)
+
+			Expect(dependencyTracker.TrackCallCount()).To(Equal(2))
+			firstTemplateKey, supplyChainName := dependencyTracker.TrackArgsForCall(0)
+			Expect(firstTemplateKey.String()).To(Equal("SourceTemplate.carto.run/my-namespace/my-source-template"))
+			Expect(supplyChainName).To(Equal(types.NamespacedName{Namespace: "my-namespace", Name: "my-supply-chain"}))
+
+			secondTemplateKey, _ := dependencyTracker.TrackArgsForCall(1)
+			Expect(secondTemplateKey.String()).To(Equal("ClusterTemplate.carto.run//my-final-template"))
+		})
+
+		It("updates the status of the namespaced supply chain", func() {
+			_, _ = reconciler.Reconcile(ctx, req)
+
+			Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+			_, updatedSupplyChain := repo.StatusUpdateArgsForCall(0)
+			Expect(updatedSupplyChain).To(BeAssignableToTypeOf(&v1alpha1.SupplyChain{}))
+			Expect(*updatedSupplyChain.(*v1alpha1.SupplyChain).GetStatus()).To(MatchFields(IgnoreExtras, Fields{
+				"ObservedGeneration": BeEquivalentTo(1),
+				"Conditions":         Equal(expectedConditions),
+			}))
+		})
+	})
+
+	Context("a supply chain referencing a sub-chain", func() {
+		var subChain *v1alpha1.ClusterSupplyChain
+
+		BeforeEach(func() {
+			sc.Name = "my-supply-chain"
+			sc.Spec.Resources = []v1alpha1.SupplyChainResource{
+				{
+					Name: "build",
+					TemplateRef: v1alpha1.SupplyChainTemplateReference{
+						Kind: "ClusterSupplyChain",
+						Name: "build-chain",
+					},
+				},
+			}
+			subChain = &v1alpha1.ClusterSupplyChain{
+				ObjectMeta: metav1.ObjectMeta{Name: "build-chain"},
+				Spec: v1alpha1.SupplyChainSpec{
+					SubChain: &v1alpha1.SubChain{
+						Entries: []string{"image-builder"},
+						Export:  "image-builder",
+					},
+					Resources: []v1alpha1.SupplyChainResource{
+						{
+							Name: "image-builder",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{
+								Kind: "ClusterImageTemplate",
+								Name: "kpack-template",
+							},
+						},
+					},
+				},
+			}
+
+			repo.GetSupplyChainStub = func(_ context.Context, name, _ string) (v1alpha1.SupplyChainObject, error) {
+				switch name {
+				case "build-chain":
+					return subChain, nil
+				case "my-supply-chain":
+					return sc, nil
+				}
+				return nil, nil
+			}
+		})
+
+		It("gets the templates of the sub-chain resources", func() {
+			_, _ = reconciler.Reconcile(ctx, req)
+
+			Expect(repo.GetTemplateCallCount()).To(Equal(1))
+			_, name, kind, _ := repo.GetTemplateArgsForCall(0)
+			Expect(name).To(Equal("kpack-template"))
+			Expect(kind).To(Equal("ClusterImageTemplate"))
+		})
+
+		It("watches the sub-chain", func() {
+			_, _ = reconciler.Reconcile(ctx, req)
+
+			subChainKey, _ := dependencyTracker.TrackArgsForCall(0)
+			Expect(subChainKey.String()).To(Equal("ClusterSupplyChain.carto.run//build-chain"))
+		})
+
+		It("adds a positive templates found condition", func() {
+			_, _ = reconciler.Reconcile(ctx, req)
+
+			Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(conditions.TemplatesFoundCondition()))
+		})
+
+		Context("the sub-chain does not exist", func() {
+			BeforeEach(func() {
+				subChain = nil
+			})
+
+			It("adds a positive templates NOT found condition", func() {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(conditions.TemplatesNotFoundCondition([]string{"build"})))
+			})
+		})
+
+		Context("the referenced supply chain is not declared a sub-chain", func() {
+			BeforeEach(func() {
+				subChain.Spec.SubChain = nil
+			})
+
+			It("adds a sub-chain invalid condition", func() {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(conditions.SubChainInvalidCondition(
+					v1alpha1.SubChainNotDeclaredError{ResourceName: "build", SubChainName: "build-chain"},
+				)))
+			})
+		})
+
+		Context("the sub-chain references the supply chain", func() {
+			BeforeEach(func() {
+				subChain.Spec.Resources[0].TemplateRef = v1alpha1.SupplyChainTemplateReference{
+					Kind: "ClusterSupplyChain",
+					Name: "my-supply-chain",
+				}
+			})
+
+			It("adds a sub-chain invalid condition", func() {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(conditions.SubChainInvalidCondition(
+					errors.New("sub-chain cycle found: my-supply-chain -> build-chain -> my-supply-chain"),
+				)))
+			})
+		})
+	})
+
 	Context("get cluster template fails", func() {
 		BeforeEach(func() {
 			sc.Spec.Resources = []v1alpha1.SupplyChainResource{
@@ -289,6 +484,86 @@ var _ = Describe("SupplyChainReconciler", func() {
 		})
 	})
 
+	Context("a template has output paths", func() {
+		BeforeEach(func() {
+			sc.Spec.Resources = []v1alpha1.SupplyChainResource{
+				{
+					Name: "source-provider",
+					TemplateRef: v1alpha1.SupplyChainTemplateReference{
+						Kind: "ClusterSourceTemplate",
+						Name: "my-source-template",
+					},
+				},
+			}
+
+			repo.GetTemplateReturns(&v1alpha1.ClusterSourceTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-source-template",
+				},
+				Spec: v1alpha1.SourceTemplateSpec{
+					TemplateSpec: v1alpha1.TemplateSpec{
+						Template: &runtime.RawExtension{Raw: []byte(`{"apiVersion": "v1", "kind": "ConfigMap"}`)},
+						Outputs:  map[string]string{"commit": ".data.commit"},
+					},
+					URLPath: ".data.url",
+				},
+			}, nil)
+		})
+
+		It("validates the output paths against the stamped object", func() {
+			_, _ = reconciler.Reconcile(ctx, req)
+
+			Expect(outputPaths.ValidateCallCount()).To(Equal(1))
+			apiVersion, kind, paths := outputPaths.ValidateArgsForCall(0)
+			Expect(apiVersion).To(Equal("v1"))
+			Expect(kind).To(Equal("ConfigMap"))
+			Expect(paths).To(Equal(map[string]string{
+				"url":    ".data.url",
+				"commit": ".data.commit",
+			}))
+		})
+
+		It("adds a positive templates found condition", func() {
+			_, _ = reconciler.Reconcile(ctx, req)
+			Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(conditions.TemplatesFoundCondition()))
+		})
+
+		Context("an output path can never exist on the stamped object", func() {
+			BeforeEach(func() {
+				outputPaths.ValidateReturns([]outputpath.InvalidPath{{
+					Output: "url",
+					Path:   ".spec.url",
+					Reason: "the object: has no field [spec]",
+				}}, nil)
+			})
+
+			It("adds a template output path invalid condition", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(conditions.TemplateOutputPathInvalidCondition([]string{
+					"resource [source-provider] template [ClusterSourceTemplate/my-source-template] output [url] path [.spec.url] the object: has no field [spec]",
+				})))
+			})
+
+			It("does not return an error", func() {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("the output paths cannot be validated", func() {
+			BeforeEach(func() {
+				outputPaths.ValidateReturns(nil, errors.New("openapi is unavailable"))
+			})
+
+			It("leaves the output paths unvalidated and logs why", func() {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conditionManager.AddPositiveArgsForCall(0)).To(Equal(conditions.TemplatesFoundCondition()))
+				Expect(out).To(Say(`"msg":"unable to validate output paths, leaving them unvalidated".*"error":"openapi is unavailable"`))
+			})
+		})
+	})
+
 	Context("when the update fails", func() {
 		BeforeEach(func() {
 			repo.StatusUpdateReturns(errors.New("updating is hard"))
@@ -16,9 +16,13 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -43,6 +47,7 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/events"
 	"github.com/vmware-tanzu/cartographer/pkg/logger"
 	"github.com/vmware-tanzu/cartographer/pkg/mapper"
+	"github.com/vmware-tanzu/cartographer/pkg/metrics"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer"
 	realizerclient "github.com/vmware-tanzu/cartographer/pkg/realizer/client"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/healthcheck"
@@ -50,6 +55,7 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/satoken"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
+	"github.com/vmware-tanzu/cartographer/pkg/tracing"
 	"github.com/vmware-tanzu/cartographer/pkg/tracker/dependency"
 	"github.com/vmware-tanzu/cartographer/pkg/tracker/stamped"
 	"github.com/vmware-tanzu/cartographer/pkg/utils"
@@ -60,17 +66,31 @@ type WorkloadReconciler struct {
 	Repo                    repository.Repository
 	ConditionManagerBuilder conditions.ConditionManagerBuilder
 	ResourceRealizerBuilder realizer.ResourceRealizerBuilder
+	DryRunRealizerBuilder   realizer.DryRunResourceRealizerBuilder
 	Realizer                Realizer
 	StampedTracker          stamped.StampedTracker
 	DependencyTracker       dependency.DependencyTracker
 	EventRecorder           record.EventRecorder
+	CloudEventSink          events.CloudEventSink
+	ExportProvenance        bool
+	MinResyncPeriod         time.Duration
 	RESTMapper              meta.RESTMapper
 	Scheme                  *runtime.Scheme
 }
 
 //counterfeiter:generate k8s.io/apimachinery/pkg/api/meta.RESTMapper
 
-func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
+	ctx, span := tracing.Start(ctx, "WorkloadReconciler.Reconcile",
+		tracing.WorkloadNameKey.String(req.Name),
+		tracing.WorkloadNamespaceKey.String(req.Namespace),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	return r.reconcile(ctx, req)
+}
+
+func (r *WorkloadReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.Info("started")
 	defer log.Info("finished")
@@ -90,36 +110,43 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			Namespace: req.Namespace,
 			Name:      req.Name,
 		})
+		metrics.SetWorkloadSupplyChain(req.NamespacedName, "")
 
 		return ctrl.Result{}, nil
 	}
-	ctx = events.NewContext(ctx, events.FromEventRecorder(r.EventRecorder, workload, r.RESTMapper, log))
+	ctx = events.NewContext(ctx, events.FromEventRecorder(r.EventRecorder, r.CloudEventSink, workload, r.RESTMapper, log))
 
 	conditionManager := r.ConditionManagerBuilder(v1alpha1.OwnerReady, workload.Status.Conditions)
 
-	supplyChain, err := r.getSupplyChainsForWorkload(ctx, workload, conditionManager)
+	selectCtx, selectSpan := tracing.Start(ctx, "WorkloadReconciler.getSupplyChainsForWorkload")
+	supplyChain, err := r.getSupplyChainsForWorkload(selectCtx, workload, conditionManager)
+	tracing.End(selectSpan, err)
 	if err != nil {
+		metrics.SetWorkloadSupplyChain(req.NamespacedName, "")
 		return r.completeReconciliation(ctx, workload, nil, conditionManager, err)
 	}
+	metrics.SetWorkloadSupplyChain(req.NamespacedName, supplyChain.GetName())
+	trace.SpanFromContext(ctx).SetAttributes(tracing.SupplyChainNameKey.String(supplyChain.GetName()))
 
-	log = log.WithValues("supply chain", supplyChain.Name)
+	log = log.WithValues("supply chain", supplyChain.GetName())
 	ctx = logr.NewContext(ctx, log)
 
 	supplyChainGVK, err := utils.GetObjectGVK(supplyChain, r.Repo.GetScheme())
 	if err != nil {
 		log.Error(err, "failed to get object gvk for supply chain")
 		return r.completeReconciliation(ctx, workload, nil, conditionManager, cerrors.NewUnhandledError(
-			fmt.Errorf("failed to get object gvk for supply chain [%s]: %w", supplyChain.Name, err)),
+			fmt.Errorf("failed to get object gvk for supply chain [%s]: %w", supplyChain.GetName(), err)),
 		)
 	}
 
 	workload.Status.SupplyChainRef.Kind = supplyChainGVK.Kind
-	workload.Status.SupplyChainRef.Name = supplyChain.Name
+	workload.Status.SupplyChainRef.Name = supplyChain.GetName()
+	workload.Status.SupplyChainRef.Namespace = supplyChain.GetNamespace()
 
 	if !r.isSupplyChainReady(supplyChain) {
 		conditionManager.AddPositive(conditions.MissingReadyInSupplyChainCondition(getSupplyChainReadyCondition(supplyChain)))
 		log.Info("supply chain is not in ready state")
-		return r.completeReconciliation(ctx, workload, nil, conditionManager, fmt.Errorf("supply chain [%s] is not in ready state", supplyChain.Name))
+		return r.completeReconciliation(ctx, workload, nil, conditionManager, fmt.Errorf("supply chain [%s] is not in ready state", supplyChain.GetName()))
 	}
 	conditionManager.AddPositive(conditions.SupplyChainReadyCondition())
 
@@ -131,15 +158,27 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		return r.completeReconciliation(ctx, workload, nil, conditionManager, fmt.Errorf("failed to get service account [%s]: %w", fmt.Sprintf("%s/%s", req.Namespace, serviceAccountName), err))
 	}
 
+	_, tokenSpan := tracing.Start(ctx, "TokenManager.GetServiceAccountToken",
+		tracing.ObjectAttributes("ServiceAccount", serviceAccountNS, serviceAccountName)...,
+	)
 	saToken, err := r.TokenManager.GetServiceAccountToken(serviceAccount)
+	tracing.End(tokenSpan, err)
 	if err != nil {
 		conditionManager.AddPositive(conditions.ServiceAccountTokenErrorCondition(err))
 		log.Info("failed to get token for service account", "service account", fmt.Sprintf("%s/%s", serviceAccountNS, serviceAccountName))
 		return r.completeReconciliation(ctx, workload, nil, conditionManager, fmt.Errorf("failed to get token for service account [%s]: %w", fmt.Sprintf("%s/%s", serviceAccountNS, serviceAccountName), err))
 	}
 
-	contextGenerator := realizer.NewContextGenerator(workload, workload.Spec.Params, supplyChain.Spec.Params)
-	resourceRealizer, err := r.ResourceRealizerBuilder(saToken, workload, contextGenerator, r.Repo, BuildWorkloadResourceLabeler(workload, supplyChain))
+	contextGenerator := realizer.NewContextGenerator(workload, workload.Spec.Params, supplyChain.GetSpec().Params)
+	var resourceRealizer realizer.ResourceRealizer
+	var dryRunRecorder *repository.RecordingRepository
+	if isDryRun(workload) {
+		log.V(logger.DEBUG).Info("dry run: recording stamped objects instead of submitting them")
+		ctx = events.NewContext(ctx, events.Discard())
+		resourceRealizer, dryRunRecorder, err = r.DryRunRealizerBuilder(saToken, workload, contextGenerator, r.Repo, BuildWorkloadResourceLabeler(workload, supplyChain))
+	} else {
+		resourceRealizer, err = r.ResourceRealizerBuilder(saToken, workload, contextGenerator, r.Repo, BuildWorkloadResourceLabeler(workload, supplyChain))
+	}
 	if err != nil {
 		conditionManager.AddPositive(conditions.ResourceRealizerBuilderErrorCondition(err))
 		log.Error(err, "failed to build resource realizer")
@@ -147,10 +186,32 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 			fmt.Errorf("failed to build resource realizer: %w", err)))
 	}
 
+	ownerResources, err := realizer.MakeSupplychainOwnerResources(ctx, getSubChainFunc(r.Repo), supplyChain)
+	if err != nil {
+		conditionManager.AddPositive(conditions.SubChainErrorCondition(err))
+		log.Info("failed to expand sub-chains", "error", err.Error())
+		return r.completeReconciliation(ctx, workload, nil, conditionManager, fmt.Errorf("failed to expand sub-chains of supply chain [%s]: %w", supplyChain.GetName(), err))
+	}
+
 	var reconcileErr error
 	resourceStatuses := statuses.NewResourceStatuses(workload.Status.Resources, conditions.AddConditionForResourceSubmittedWorkload)
 
-	err = r.Realizer.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)
+	err = r.Realizer.Realize(ctx, resourceRealizer, supplyChain.GetName(), ownerResources, resourceStatuses)
+
+	if dryRunRecorder != nil {
+		// the recorded objects were never submitted, so the conditions, status.resources and the
+		// tracked dependencies keep describing the last real reconcile
+		if err != nil {
+			log.Info("dry run did not realize every resource", "error", err.Error())
+		}
+		dryRunObjects, buildErr := buildDryRunObjects(ownerResources, dryRunRecorder.Recorded())
+		if buildErr != nil {
+			log.Error(buildErr, "failed to build dry run objects")
+			return ctrl.Result{}, cerrors.NewUnhandledError(buildErr)
+		}
+		return r.completeDryRun(ctx, workload, dryRunObjects, cerrors.WrapUnhandledError(err))
+	}
+
 	if err != nil {
 		conditions.AddConditionForResourceSubmittedWorkload(&conditionManager, true, err)
 		log.V(logger.DEBUG).Info("failed to realize")
@@ -169,6 +230,8 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 	r.trackDependencies(workload, resourceStatuses.GetCurrent(), serviceAccountName, serviceAccountNS)
 
+	observeTimeToHealthy(workload, supplyChain.GetName(), resourceStatuses)
+
 	cleanupErr := r.cleanupOrphanedObjects(ctx, workload.Status.Resources, resourceStatuses.GetCurrent())
 	if cleanupErr != nil {
 		log.Error(cleanupErr, "failed to cleanup orphaned objects")
@@ -202,10 +265,11 @@ func (r *WorkloadReconciler) completeReconciliation(ctx context.Context, workloa
 	workload.Status.Conditions, changed = conditionManager.Finalize()
 	var updateErr error
 
-	if changed || (workload.Status.ObservedGeneration != workload.Generation) || (resourceStatuses != nil && resourceStatuses.IsChanged()) {
+	if changed || (workload.Status.ObservedGeneration != workload.Generation) || (resourceStatuses != nil && resourceStatuses.IsChanged()) || len(workload.Status.DryRun) > 0 {
 		if resourceStatuses != nil {
 			workload.Status.Resources = resourceStatuses.GetCurrent()
 		}
+		workload.Status.DryRun = nil
 
 		workload.Status.ObservedGeneration = workload.Generation
 		updateErr = r.Repo.StatusUpdate(ctx, workload)
@@ -223,10 +287,37 @@ func (r *WorkloadReconciler) completeReconciliation(ctx context.Context, workloa
 		log.Info("handled error reconciling workload", "handled error", err)
 	}
 
+	if resourceStatuses != nil {
+		if requeueAfter, ok := nextRequeue(resourceStatuses.GetCurrent(), time.Now(), r.MinResyncPeriod); ok {
+			log.V(logger.DEBUG).Info("requeueing for progress deadline or resync period", "requeue after", requeueAfter)
+			return ctrl.Result{RequeueAfter: requeueAfter}, nil
+		}
+	}
+
 	return ctrl.Result{}, nil
 }
 
-func (r *WorkloadReconciler) isSupplyChainReady(supplyChain *v1alpha1.ClusterSupplyChain) bool {
+// completeDryRun publishes the objects a dry run would stamp. Nothing else in the status changes.
+func (r *WorkloadReconciler) completeDryRun(ctx context.Context, workload *v1alpha1.Workload, dryRunObjects []v1alpha1.DryRunObject, err error) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if dryRunObjectsChanged(workload.Status.DryRun, dryRunObjects) {
+		workload.Status.DryRun = dryRunObjects
+		if updateErr := r.Repo.StatusUpdate(ctx, workload); updateErr != nil {
+			log.Error(updateErr, "failed to update status for workload")
+			return ctrl.Result{}, fmt.Errorf("failed to update status for workload: %w", updateErr)
+		}
+	}
+
+	if err != nil && cerrors.IsUnhandledError(err) {
+		log.Error(err, "unhandled error in dry run of workload")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *WorkloadReconciler) isSupplyChainReady(supplyChain v1alpha1.SupplyChainObject) bool {
 	supplyChainReadyCondition := getSupplyChainReadyCondition(supplyChain)
 	return supplyChainReadyCondition.Status == "True"
 }
@@ -245,8 +336,61 @@ func BuildWorkloadResourceLabeler(owner, blueprint client.Object) realizer.Resou
 	}
 }
 
-func getSupplyChainReadyCondition(supplyChain *v1alpha1.ClusterSupplyChain) metav1.Condition {
-	for _, condition := range supplyChain.Status.Conditions {
+func isDryRun(workload *v1alpha1.Workload) bool {
+	return workload.Annotations[v1alpha1.DryRunAnnotation] == "true"
+}
+
+// buildDryRunObjects orders the recorded objects by the supply chain resource that stamped them
+func buildDryRunObjects(ownerResources []realizer.OwnerResource, recorded []*unstructured.Unstructured) ([]v1alpha1.DryRunObject, error) {
+	var dryRunObjects []v1alpha1.DryRunObject
+	for _, resource := range ownerResources {
+		for _, obj := range recorded {
+			if obj.GetLabels()["carto.run/resource-name"] != resource.Name {
+				continue
+			}
+
+			raw, err := json.Marshal(obj)
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal dry run object for resource [%s]: %w", resource.Name, err)
+			}
+
+			dryRunObjects = append(dryRunObjects, v1alpha1.DryRunObject{
+				ResourceName: resource.Name,
+				Object:       runtime.RawExtension{Raw: raw},
+			})
+		}
+	}
+
+	return dryRunObjects, nil
+}
+
+func dryRunObjectsChanged(previous, current []v1alpha1.DryRunObject) bool {
+	if len(previous) != len(current) {
+		return true
+	}
+
+	for i := range previous {
+		if previous[i].ResourceName != current[i].ResourceName {
+			return true
+		}
+
+		var previousObject, currentObject interface{}
+		if err := json.Unmarshal(previous[i].Object.Raw, &previousObject); err != nil {
+			return true
+		}
+		if err := json.Unmarshal(current[i].Object.Raw, &currentObject); err != nil {
+			return true
+		}
+		if !reflect.DeepEqual(previousObject, currentObject) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getSupplyChainReadyCondition(supplyChain v1alpha1.SupplyChainObject) metav1.Condition {
+	for _, condition := range supplyChain.GetStatus().Conditions {
 		if condition.Type == "Ready" {
 			return condition
 		}
@@ -254,7 +398,7 @@ func getSupplyChainReadyCondition(supplyChain *v1alpha1.ClusterSupplyChain) meta
 	return metav1.Condition{}
 }
 
-func (r *WorkloadReconciler) getSupplyChainsForWorkload(ctx context.Context, workload *v1alpha1.Workload, conditionManager conditions.ConditionManager) (*v1alpha1.ClusterSupplyChain, error) {
+func (r *WorkloadReconciler) getSupplyChainsForWorkload(ctx context.Context, workload *v1alpha1.Workload, conditionManager conditions.ConditionManager) (v1alpha1.SupplyChainObject, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	if len(workload.Labels) == 0 {
 		conditionManager.AddPositive(conditions.WorkloadMissingLabelsCondition())
@@ -286,7 +430,7 @@ func (r *WorkloadReconciler) getSupplyChainsForWorkload(ctx context.Context, wor
 			workload.Namespace, workload.Name, GetSupplyChainNames(supplyChains))
 	}
 
-	log.V(logger.DEBUG).Info("supply chain matched for workload", "supply chain", supplyChains[0].Name)
+	log.V(logger.DEBUG).Info("supply chain matched for workload", "supply chain", supplyChains[0].GetName())
 	return supplyChains[0], nil
 }
 
@@ -321,7 +465,8 @@ func (r *WorkloadReconciler) trackDependencies(workload *v1alpha1.Workload, real
 					Kind:  resource.TemplateRef.Kind,
 				},
 				NamespacedName: types.NamespacedName{
-					Name: resource.TemplateRef.Name,
+					Namespace: resource.TemplateRef.Namespace,
+					Name:      resource.TemplateRef.Name,
 				},
 			},
 			types.NamespacedName{
@@ -391,7 +536,7 @@ func (r *WorkloadReconciler) cleanupOrphanedObjects(ctx context.Context, previou
 	return nil
 }
 
-func GetSupplyChainNames(objs []*v1alpha1.ClusterSupplyChain) []string {
+func GetSupplyChainNames(objs []v1alpha1.SupplyChainObject) []string {
 	var names []string
 	for _, obj := range objs {
 		names = append(names, obj.GetName())
@@ -400,16 +545,16 @@ func GetSupplyChainNames(objs []*v1alpha1.ClusterSupplyChain) []string {
 	return names
 }
 
-func getServiceAccountNameAndNamespaceForWorkload(workload *v1alpha1.Workload, supplyChain *v1alpha1.ClusterSupplyChain) (string, string) {
+func getServiceAccountNameAndNamespaceForWorkload(workload *v1alpha1.Workload, supplyChain v1alpha1.SupplyChainObject) (string, string) {
 	serviceAccountName := "default"
 	serviceAccountNS := workload.Namespace
 
 	if workload.Spec.ServiceAccountName != "" {
 		serviceAccountName = workload.Spec.ServiceAccountName
-	} else if supplyChain.Spec.ServiceAccountRef.Name != "" {
-		serviceAccountName = supplyChain.Spec.ServiceAccountRef.Name
-		if supplyChain.Spec.ServiceAccountRef.Namespace != "" {
-			serviceAccountNS = supplyChain.Spec.ServiceAccountRef.Namespace
+	} else if supplyChain.GetSpec().ServiceAccountRef.Name != "" {
+		serviceAccountName = supplyChain.GetSpec().ServiceAccountRef.Name
+		if supplyChain.GetSpec().ServiceAccountRef.Namespace != "" {
+			serviceAccountNS = supplyChain.GetSpec().ServiceAccountRef.Namespace
 		}
 	}
 
@@ -417,7 +562,7 @@ func getServiceAccountNameAndNamespaceForWorkload(workload *v1alpha1.Workload, s
 }
 
 // TODO: kubebuilder:rbac
-func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager, concurrency int) error {
+func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager, concurrency int, resourceConcurrency int) error {
 	clientSet, err := kubernetes.NewForConfig(mgr.GetConfig())
 	if err != nil {
 		return err
@@ -437,8 +582,13 @@ func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager, concurrency int)
 		realizerclient.NewClientBuilder(mgr.GetConfig()),
 		repository.NewCache(mgr.GetLogger().WithName("workload-stamping-repo-cache")),
 	)
+	r.DryRunRealizerBuilder = realizer.NewDryRunResourceRealizerBuilder(
+		repository.NewRepository,
+		realizerclient.NewClientBuilder(mgr.GetConfig()),
+		repository.NewCache(mgr.GetLogger().WithName("workload-dry-run-repo-cache")),
+	)
 
-	r.Realizer = realizer.NewRealizer(nil, r.RESTMapper)
+	r.Realizer = realizer.NewRealizer(nil, r.RESTMapper, resourceConcurrency, r.ExportProvenance)
 	r.DependencyTracker = dependency.NewDependencyTracker(
 		2*utils.DefaultResyncTime,
 		mgr.GetLogger().WithName("tracker-workload"),
@@ -456,6 +606,7 @@ func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager, concurrency int)
 
 	watches := map[client.Object]handler.MapFunc{
 		&v1alpha1.ClusterSupplyChain{}: m.ClusterSupplyChainToWorkloadRequests,
+		&v1alpha1.SupplyChain{}:        m.SupplyChainToWorkloadRequests,
 		&corev1.ServiceAccount{}:       m.ServiceAccountToWorkloadRequests,
 		&rbacv1.Role{}:                 m.RoleToWorkloadRequests,
 		&rbacv1.RoleBinding{}:          m.RoleBindingToWorkloadRequests,
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"time"
 
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo"
@@ -43,6 +44,8 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/controllers"
 	"github.com/vmware-tanzu/cartographer/pkg/controllers/controllersfakes"
 	cerrors "github.com/vmware-tanzu/cartographer/pkg/errors"
+	"github.com/vmware-tanzu/cartographer/pkg/events"
+	"github.com/vmware-tanzu/cartographer/pkg/events/eventsfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/realizerfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/statuses"
@@ -253,7 +256,7 @@ var _ = Describe("WorkloadReconciler", func() {
 					},
 				},
 			}
-			repo.GetSupplyChainsForWorkloadReturns([]*v1alpha1.ClusterSupplyChain{&supplyChain}, nil)
+			repo.GetSupplyChainsForWorkloadReturns([]v1alpha1.SupplyChainObject{&supplyChain}, nil)
 
 			resourceStatuses = statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
 			resourceStatuses.Add(
@@ -529,6 +532,319 @@ var _ = Describe("WorkloadReconciler", func() {
 			Expect(secondTemplateKey.String()).To(Equal("my-config-kind.carto.run//my-config-template"))
 		})
 
+		Context("and the supply chain references a sub-chain which does not exist", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources = []v1alpha1.SupplyChainResource{
+					{
+						Name: "build",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{
+							Kind: "ClusterSupplyChain",
+							Name: "build-chain",
+						},
+					},
+				}
+			})
+
+			It("reports the sub-chain error and does not realize the supply chain", func() {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(conditions.SubChainErrorCondition(
+					v1alpha1.SubChainNotFoundError{ResourceName: "build", SubChainName: "build-chain"},
+				)))
+				Expect(rlzr.RealizeCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("and the supply chain is namespaced", func() {
+			var namespacedSupplyChain *v1alpha1.SupplyChain
+
+			BeforeEach(func() {
+				namespacedSupplyChain = &v1alpha1.SupplyChain{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      supplyChainName,
+						Namespace: "my-namespace",
+					},
+					Status: supplyChain.Status,
+				}
+				repo.GetSupplyChainsForWorkloadReturns([]v1alpha1.SupplyChainObject{namespacedSupplyChain}, nil)
+
+				resourceStatuses.Add(
+					&v1alpha1.RealizedResource{
+						Name: "resource1",
+						StampedRef: &v1alpha1.StampedRef{
+							ObjectReference: &corev1.ObjectReference{
+								Kind:       "MyThing",
+								APIVersion: "thing.io/alphabeta1",
+							},
+							Resource: "mything",
+						},
+						TemplateRef: &corev1.ObjectReference{
+							Kind:       "ImageTemplate",
+							Name:       "my-image-template",
+							Namespace:  "my-namespace",
+							APIVersion: "carto.run/v1alpha1",
+						},
+					}, nil, false,
+				)
+			})
+
+			It("sets the SupplyChainRef with the namespace", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(wl.Status.SupplyChainRef.Kind).To(Equal("SupplyChain"))
+				Expect(wl.Status.SupplyChainRef.Name).To(Equal(supplyChainName))
+				Expect(wl.Status.SupplyChainRef.Namespace).To(Equal("my-namespace"))
+			})
+
+			It("watches the namespaced templates in their namespace", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				firstTemplateKey, _ := dependencyTracker.TrackArgsForCall(1)
+				Expect(firstTemplateKey.String()).To(Equal("ImageTemplate.carto.run/my-namespace/my-image-template"))
+			})
+		})
+
+		It("clears status.dryRun when the workload is not annotated for a dry run", func() {
+			wl.Status.DryRun = []v1alpha1.DryRunObject{{ResourceName: "resource1", Object: runtime.RawExtension{Raw: []byte(`{"kind":"Thing"}`)}}}
+
+			_, _ = reconciler.Reconcile(ctx, req)
+
+			Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+			_, wk := repo.StatusUpdateArgsForCall(0)
+			Expect(wk.(*v1alpha1.Workload).Status.DryRun).To(BeNil())
+		})
+
+		It("does not requeue when no resource is waiting on a progress deadline", func() {
+			result, err := reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(ctrl.Result{}))
+		})
+
+		It("requeues at the earliest pending progress deadline", func() {
+			deadline := metav1.NewTime(time.Now().Add(5 * time.Minute))
+			resourceStatuses.Add(&v1alpha1.RealizedResource{
+				Name:             "slow-resource",
+				ProgressDeadline: &deadline,
+			}, nil, false)
+
+			result, err := reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically("~", 5*time.Minute, time.Second))
+		})
+
+		Context("when a resource has a resync period", func() {
+			BeforeEach(func() {
+				reconciler.MinResyncPeriod = time.Minute
+			})
+
+			It("requeues after the resync period, with jitter", func() {
+				resourceStatuses.Add(&v1alpha1.RealizedResource{
+					Name:         "polled-resource",
+					ResyncPeriod: &metav1.Duration{Duration: 10 * time.Minute},
+				}, nil, false)
+
+				result, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically(">=", 10*time.Minute))
+				Expect(result.RequeueAfter).To(BeNumerically("<=", 11*time.Minute))
+			})
+
+			It("raises the resync period to the minimum resync period", func() {
+				resourceStatuses.Add(&v1alpha1.RealizedResource{
+					Name:         "polled-resource",
+					ResyncPeriod: &metav1.Duration{Duration: time.Second},
+				}, nil, false)
+
+				result, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically(">=", time.Minute))
+				Expect(result.RequeueAfter).To(BeNumerically("<=", 66*time.Second))
+			})
+
+			It("requeues at an earlier pending progress deadline instead", func() {
+				deadline := metav1.NewTime(time.Now().Add(5 * time.Minute))
+				resourceStatuses.Add(&v1alpha1.RealizedResource{
+					Name:             "slow-resource",
+					ProgressDeadline: &deadline,
+				}, nil, false)
+				resourceStatuses.Add(&v1alpha1.RealizedResource{
+					Name:         "polled-resource",
+					ResyncPeriod: &metav1.Duration{Duration: 10 * time.Minute},
+				}, nil, false)
+
+				result, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeNumerically("~", 5*time.Minute, time.Second))
+			})
+		})
+
+		Context("and the workload is annotated for a dry run", func() {
+			var (
+				dryRunResourceRealizer *realizerfakes.FakeResourceRealizer
+				recorder               *repository.RecordingRepository
+			)
+
+			BeforeEach(func() {
+				wl.Annotations = map[string]string{"carto.run/dry-run": "true"}
+				wl.Status.Resources = []v1alpha1.ResourceStatus{
+					{
+						RealizedResource: v1alpha1.RealizedResource{
+							Name: "removed-resource",
+							StampedRef: &v1alpha1.StampedRef{
+								ObjectReference: &corev1.ObjectReference{
+									Kind:       "Gone",
+									Name:       "removed-thing",
+									APIVersion: "thing.io/alphabeta1",
+								},
+							},
+						},
+					},
+				}
+				supplyChain.Spec.Resources = []v1alpha1.SupplyChainResource{
+					{Name: "resource1"},
+					{Name: "resource2"},
+				}
+
+				dryRunResourceRealizer = &realizerfakes.FakeResourceRealizer{}
+				recorder = repository.NewRecordingRepository(&repositoryfakes.FakeRepository{})
+				reconciler.DryRunRealizerBuilder = func(authToken string, owner client.Object, templatingContext realizer.ContextGenerator, systemRepo repository.Repository, resourceLabeler realizer.ResourceLabeler) (realizer.ResourceRealizer, *repository.RecordingRepository, error) {
+					return dryRunResourceRealizer, recorder, nil
+				}
+
+				realizeStub := rlzr.RealizeStub
+				rlzr.RealizeStub = func(ctx context.Context, resourceRealizer realizer.ResourceRealizer, deliveryName string, resources []realizer.OwnerResource, statuses statuses.ResourceStatuses) error {
+					for _, resourceName := range []string{"resource2", "resource1"} {
+						obj := &unstructured.Unstructured{}
+						obj.SetKind("Thing")
+						obj.SetName(resourceName + "-thing")
+						obj.SetLabels(map[string]string{"carto.run/resource-name": resourceName})
+						Expect(recorder.EnsureMutableObjectExistsOnCluster(ctx, obj, false)).To(Succeed())
+					}
+					return realizeStub(ctx, resourceRealizer, deliveryName, resources, statuses)
+				}
+			})
+
+			It("realizes the supply chain with a dry run resource realizer", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(rlzr.RealizeCallCount()).To(Equal(1))
+				_, resourceRealizer, _, _, _ := rlzr.RealizeArgsForCall(0)
+				Expect(resourceRealizer).To(Equal(dryRunResourceRealizer))
+			})
+
+			It("reports the recorded objects in supply chain order", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+				_, wk := repo.StatusUpdateArgsForCall(0)
+				dryRun := wk.(*v1alpha1.Workload).Status.DryRun
+				Expect(dryRun).To(HaveLen(2))
+				Expect(dryRun[0].ResourceName).To(Equal("resource1"))
+				Expect(dryRun[0].Object.Raw).To(MatchJSON(`{"kind":"Thing","metadata":{"name":"resource1-thing","labels":{"carto.run/resource-name":"resource1"}}}`))
+				Expect(dryRun[1].ResourceName).To(Equal("resource2"))
+				Expect(dryRun[1].Object.Raw).To(MatchJSON(`{"kind":"Thing","metadata":{"name":"resource2-thing","labels":{"carto.run/resource-name":"resource2"}}}`))
+			})
+
+			It("leaves the resources of the last real reconcile in the status", func() {
+				previousResources := wl.Status.DeepCopy().Resources
+				realizeStub := rlzr.RealizeStub
+				rlzr.RealizeStub = func(ctx context.Context, resourceRealizer realizer.ResourceRealizer, deliveryName string, resources []realizer.OwnerResource, statuses statuses.ResourceStatuses) error {
+					statuses.Add(&v1alpha1.RealizedResource{
+						Name: "resource1",
+						StampedRef: &v1alpha1.StampedRef{
+							ObjectReference: &corev1.ObjectReference{
+								Kind: "Thing",
+								Name: "resource1-thing",
+							},
+						},
+					}, nil, false)
+					return realizeStub(ctx, resourceRealizer, deliveryName, resources, statuses)
+				}
+
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+				_, wk := repo.StatusUpdateArgsForCall(0)
+				Expect(wk.(*v1alpha1.Workload).Status.Resources).To(Equal(previousResources))
+				Expect(wk.(*v1alpha1.Workload).Status.DryRun).To(HaveLen(2))
+			})
+
+			It("does not emit events or cloudevents", func() {
+				eventRecorder := &eventsfakes.FakeEventRecorder{}
+				cloudEventSink := &eventsfakes.FakeCloudEventSink{}
+				reconciler.EventRecorder = eventRecorder
+				reconciler.CloudEventSink = cloudEventSink
+
+				realizeStub := rlzr.RealizeStub
+				rlzr.RealizeStub = func(ctx context.Context, resourceRealizer realizer.ResourceRealizer, deliveryName string, resources []realizer.OwnerResource, statuses statuses.ResourceStatuses) error {
+					rec := events.FromContextOrDie(ctx)
+					rec.Eventf(events.NormalType, events.StampedObjectAppliedReason, "Created or updated object [%s]", "resource1-thing")
+					rec.ResourceCloudEvent(events.ResourceOutputChangedReason, events.ResourceEventData{Resource: "resource1"})
+					return realizeStub(ctx, resourceRealizer, deliveryName, resources, statuses)
+				}
+
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(eventRecorder.EventfCallCount()).To(Equal(0))
+				Expect(cloudEventSink.SendCallCount()).To(Equal(0))
+			})
+
+			It("does not watch the stamped objects", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(stampedTracker.WatchCallCount()).To(Equal(0))
+			})
+
+			It("does not clean up objects stamped before the dry run", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(repo.DeleteCallCount()).To(Equal(0))
+			})
+
+			It("does not change what the workload is tracked against", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(dependencyTracker.TrackCallCount()).To(Equal(0))
+			})
+
+			It("keeps the conditions and observed generation of the last real reconcile", func() {
+				wl.Generation = 2
+				wl.Status.ObservedGeneration = 1
+				wl.Status.Conditions = []metav1.Condition{{
+					Type:   v1alpha1.OwnerReady,
+					Status: metav1.ConditionTrue,
+					Reason: "Ready",
+				}}
+				previousConditions := wl.Status.DeepCopy().Conditions
+				realizeStub := rlzr.RealizeStub
+				rlzr.RealizeStub = func(ctx context.Context, resourceRealizer realizer.ResourceRealizer, deliveryName string, resources []realizer.OwnerResource, statuses statuses.ResourceStatuses) error {
+					_ = realizeStub(ctx, resourceRealizer, deliveryName, resources, statuses)
+					return cerrors.GetTemplateError{Err: errors.New("some error"), ResourceName: "resource2"}
+				}
+
+				_, _ = reconciler.Reconcile(ctx, req)
+
+				Expect(conditionManager.FinalizeCallCount()).To(Equal(0))
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+				_, wk := repo.StatusUpdateArgsForCall(0)
+				Expect(wk.(*v1alpha1.Workload).Status.Conditions).To(Equal(previousConditions))
+				Expect(wk.(*v1alpha1.Workload).Status.ObservedGeneration).To(Equal(int64(1)))
+				Expect(wk.(*v1alpha1.Workload).Status.DryRun).To(HaveLen(2))
+			})
+
+			It("does not update the status when the preview is unchanged", func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+				_, wk := repo.StatusUpdateArgsForCall(0)
+				wl.Status.DryRun = wk.(*v1alpha1.Workload).Status.DryRun
+				recorder = repository.NewRecordingRepository(&repositoryfakes.FakeRepository{})
+
+				_, _ = reconciler.Reconcile(ctx, req)
+				Expect(repo.StatusUpdateCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("but getting the object GVK fails", func() {
 			BeforeEach(func() {
 				repo.GetSchemeReturns(runtime.NewScheme())
@@ -550,7 +866,7 @@ var _ = Describe("WorkloadReconciler", func() {
 						Message: "some informative message",
 					},
 				}
-				repo.GetSupplyChainsForWorkloadReturns([]*v1alpha1.ClusterSupplyChain{&supplyChain}, nil)
+				repo.GetSupplyChainsForWorkloadReturns([]v1alpha1.SupplyChainObject{&supplyChain}, nil)
 			})
 
 			It("does not return an error", func() {
@@ -704,6 +1020,40 @@ var _ = Describe("WorkloadReconciler", func() {
 				})
 			})
 
+			Context("of type ParamValidationError", func() {
+				var paramValidationError cerrors.ParamValidationError
+				BeforeEach(func() {
+					paramValidationError = cerrors.ParamValidationError{
+						Err:           errors.New("param [replicas] is required"),
+						ResourceName:  "some-name",
+						BlueprintName: supplyChainName,
+						BlueprintType: cerrors.SupplyChain,
+						TemplateName:  "cool-template",
+						TemplateKind:  "ClusterTemplate",
+					}
+
+					rlzr.RealizeReturns(paramValidationError)
+				})
+
+				It("calls the condition manager to report", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(conditions.ParamValidationFailedCondition(true, paramValidationError)))
+				})
+
+				It("does not return an error", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("logs the handled error message", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+
+					Expect(out).To(Say(`"level":"info"`))
+					Expect(out).To(Say(`"msg":"handled error reconciling workload"`))
+					Expect(out).To(Say(`"handled error":"invalid params for resource \[some-name\] for template \[ClusterTemplate/cool-template\] in supply chain \[some-supply-chain\]: param \[replicas\] is required"`))
+				})
+			})
+
 			Context("of type ApplyStampedObjectError", func() {
 				var stampedObjectError cerrors.ApplyStampedObjectError
 				BeforeEach(func() {
@@ -756,6 +1106,44 @@ var _ = Describe("WorkloadReconciler", func() {
 				})
 			})
 
+			Context("of type ApplyStampedObjectError where the apply conflicted with another field manager", func() {
+				var stampedObjectError cerrors.ApplyStampedObjectError
+				BeforeEach(func() {
+					stampedObject1 := &unstructured.Unstructured{}
+					stampedObject1.SetNamespace("a-namespace")
+					stampedObject1.SetName("a-name")
+
+					stampedObjectError = cerrors.ApplyStampedObjectError{
+						Err:           kerrors.NewConflict(schema.GroupResource{Resource: "jobs"}, "a-name", errors.New("conflict with \"hpa\"")),
+						StampedObject: stampedObject1,
+						ResourceName:  "some-name",
+						BlueprintName: supplyChainName,
+						BlueprintType: cerrors.SupplyChain,
+					}
+
+					rlzr.RealizeStub = func(ctx context.Context, resourceRealizer realizer.ResourceRealizer, deliveryName string, resources []realizer.OwnerResource, statuses statuses.ResourceStatuses) error {
+						statusesVal := reflect.ValueOf(statuses)
+						existingVal := reflect.ValueOf(resourceStatuses)
+
+						reflect.Indirect(statusesVal).Set(reflect.Indirect(existingVal))
+						return stampedObjectError
+					}
+				})
+
+				It("calls the condition manager to report an ApplyConflict", func() {
+					_, _ = reconciler.Reconcile(ctx, req)
+					Expect(conditionManager.AddPositiveArgsForCall(1)).To(Equal(conditions.ApplyConflictCondition(true, stampedObjectError)))
+				})
+
+				It("handles the error and logs it", func() {
+					_, err := reconciler.Reconcile(ctx, req)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(out).To(Say(`"level":"info"`))
+					Expect(out).To(Say(`"handled error":"unable to apply object \[a-namespace/a-name\] for resource \[some-name\] in supply chain \[some-supply-chain\]: `))
+				})
+			})
+
 			Context("of type ApplyStampedObjectError where the user did not have proper permissions", func() {
 				var stampedObjectError cerrors.ApplyStampedObjectError
 				BeforeEach(func() {
@@ -1279,7 +1667,7 @@ var _ = Describe("WorkloadReconciler", func() {
 			supplyChain := v1alpha1.ClusterSupplyChain{
 				ObjectMeta: metav1.ObjectMeta{Name: "my-supply-chain"},
 			}
-			repo.GetSupplyChainsForWorkloadReturns([]*v1alpha1.ClusterSupplyChain{&supplyChain, &supplyChain}, nil)
+			repo.GetSupplyChainsForWorkloadReturns([]v1alpha1.SupplyChainObject{&supplyChain, &supplyChain}, nil)
 		})
 
 		It("calls the condition manager to report too mane supply chains matched", func() {
@@ -1361,7 +1749,7 @@ var _ = Describe("WorkloadReconciler", func() {
 					},
 				},
 			}
-			repo.GetSupplyChainsForWorkloadReturns([]*v1alpha1.ClusterSupplyChain{&supplyChain}, nil)
+			repo.GetSupplyChainsForWorkloadReturns([]v1alpha1.SupplyChainObject{&supplyChain}, nil)
 
 			rlzr.RealizeReturns(nil)
 
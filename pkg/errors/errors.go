@@ -64,6 +64,22 @@ func (e ResolveTemplateOptionError) Error() string {
 	).Error()
 }
 
+type ResourceConditionError struct {
+	Err           error
+	ResourceName  string
+	BlueprintName string
+	BlueprintType string
+}
+
+func (e ResourceConditionError) Error() string {
+	return fmt.Errorf("error evaluating when condition for resource [%s] in %s [%s]: %w",
+		e.ResourceName,
+		e.BlueprintType,
+		e.BlueprintName,
+		e.Err,
+	).Error()
+}
+
 type TemplateOptionsMatchError struct {
 	ResourceName  string
 	OptionNames   []string
@@ -104,6 +120,12 @@ func (e ApplyStampedObjectError) Error() string {
 	).Error()
 }
 
+// IsConflict is true when the server-side apply of the object conflicted with a field
+// owned by another field manager
+func (e ApplyStampedObjectError) IsConflict() bool {
+	return kerrors.IsConflict(e.Err)
+}
+
 type StampError struct {
 	Err           error
 	ResourceName  string
@@ -124,6 +146,26 @@ func (e StampError) Error() string {
 	).Error()
 }
 
+type ParamValidationError struct {
+	Err           error
+	ResourceName  string
+	TemplateName  string
+	TemplateKind  string
+	BlueprintName string
+	BlueprintType string
+}
+
+func (e ParamValidationError) Error() string {
+	return fmt.Errorf("invalid params for resource [%s] for template [%s/%s] in %s [%s]: %w",
+		e.ResourceName,
+		e.TemplateKind,
+		e.TemplateName,
+		e.BlueprintType,
+		e.BlueprintName,
+		e.Err,
+	).Error()
+}
+
 type RetrieveOutputError struct {
 	Err               error
 	ResourceName      string
@@ -207,12 +249,12 @@ func IsUnhandledErrorType(err error) bool {
 	case GetTemplateError:
 		return true
 	case ApplyStampedObjectError:
-		if !kerrors.IsForbidden(typedErr.Err) {
+		if !kerrors.IsForbidden(typedErr.Err) && !typedErr.IsConflict() {
 			return true
 		} else {
 			return false
 		}
-	case StampError, RetrieveOutputError, ResolveTemplateOptionError, TemplateOptionsMatchError:
+	case StampError, ParamValidationError, RetrieveOutputError, ResolveTemplateOptionError, TemplateOptionsMatchError, ResourceConditionError:
 		return false
 	default:
 		return true
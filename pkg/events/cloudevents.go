@@ -0,0 +1,199 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/uuid"
+
+	"github.com/vmware-tanzu/cartographer/pkg/logger"
+	"github.com/vmware-tanzu/cartographer/pkg/provenance"
+)
+
+const CloudEventSpecVersion = "1.0"
+const CloudEventSource = "/cartographer"
+const CloudEventTypePrefix = "run.carto."
+const CloudEventContentType = "application/cloudevents+json; charset=UTF-8"
+
+// CloudEvent is a CloudEvent in the structured content mode
+type CloudEvent struct {
+	SpecVersion     string            `json:"specversion"`
+	ID              string            `json:"id"`
+	Source          string            `json:"source"`
+	Type            string            `json:"type"`
+	Subject         string            `json:"subject,omitempty"`
+	Time            time.Time         `json:"time"`
+	DataContentType string            `json:"datacontenttype"`
+	Data            ResourceEventData `json:"data"`
+}
+
+// ResourceEventData describes a change to a resource of an owner (workload, deliverable or runnable)
+type ResourceEventData struct {
+	Owner         corev1.ObjectReference  `json:"owner"`
+	Resource      string                  `json:"resource,omitempty"`
+	StampedObject *corev1.ObjectReference `json:"stampedObject,omitempty"`
+	Outputs       []ResourceOutput        `json:"outputs,omitempty"`
+	Healthy       string                  `json:"healthy,omitempty"`
+	Provenance    *provenance.Statement   `json:"provenance,omitempty"`
+}
+
+type ResourceOutput struct {
+	Name    string `json:"name"`
+	Digest  string `json:"digest,omitempty"`
+	Preview string `json:"preview,omitempty"`
+}
+
+// NewCloudEvent builds the CloudEvent of type run.carto.<reason> for the data
+func NewCloudEvent(reason string, data ResourceEventData) CloudEvent {
+	subject := data.Owner.Name
+	if data.Owner.Namespace != "" {
+		subject = data.Owner.Namespace + "/" + data.Owner.Name
+	}
+
+	return CloudEvent{
+		SpecVersion:     CloudEventSpecVersion,
+		ID:              string(uuid.NewUUID()),
+		Source:          CloudEventSource,
+		Type:            CloudEventTypePrefix + reason,
+		Subject:         subject,
+		Time:            time.Now().UTC(),
+		DataContentType: "application/json",
+		Data:            data,
+	}
+}
+
+// StampedObjectReference returns a reference to the stamped object, or nil if there is none
+func StampedObjectReference(obj *unstructured.Unstructured) *corev1.ObjectReference {
+	if obj == nil {
+		return nil
+	}
+	return &corev1.ObjectReference{
+		APIVersion: obj.GetAPIVersion(),
+		Kind:       obj.GetKind(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+	}
+}
+
+//counterfeiter:generate . CloudEventSink
+type CloudEventSink interface {
+	// Send queues the event for delivery. It must not block the caller.
+	Send(event CloudEvent)
+}
+
+type httpCloudEventSink struct {
+	endpoint      string
+	client        *http.Client
+	queue         chan CloudEvent
+	maxRetries    int
+	retryInterval time.Duration
+	log           logr.Logger
+}
+
+// NewHTTPCloudEventSink returns a sink which POSTs events to the endpoint once it is started. At most queueSize
+// events wait for delivery; events sent while the queue is full are dropped. A failed delivery is retried up to
+// maxRetries times, waiting retryInterval before the first retry and twice as long before each following one.
+func NewHTTPCloudEventSink(endpoint string, queueSize int, maxRetries int, retryInterval time.Duration, log logr.Logger) *httpCloudEventSink {
+	if queueSize < 1 {
+		queueSize = 1
+	}
+	return &httpCloudEventSink{
+		endpoint:      endpoint,
+		client:        &http.Client{Timeout: 10 * time.Second},
+		queue:         make(chan CloudEvent, queueSize),
+		maxRetries:    maxRetries,
+		retryInterval: retryInterval,
+		log:           log,
+	}
+}
+
+func (s *httpCloudEventSink) Send(event CloudEvent) {
+	select {
+	case s.queue <- event:
+	default:
+		s.log.Info("cloudevent queue is full, dropping event", "type", event.Type, "subject", event.Subject)
+	}
+}
+
+// Start delivers queued events, one at a time, until the context is done
+func (s *httpCloudEventSink) Start(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event := <-s.queue:
+			if err := s.deliver(ctx, event); err != nil {
+				s.log.Error(err, "failed to deliver cloudevent", "type", event.Type, "subject", event.Subject)
+			}
+		}
+	}
+}
+
+func (s *httpCloudEventSink) deliver(ctx context.Context, event CloudEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cloudevent: %w", err)
+	}
+
+	backoff := s.retryInterval
+	for attempt := 0; ; attempt++ {
+		err = s.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+
+		if attempt >= s.maxRetries {
+			return fmt.Errorf("giving up after %d attempts: %w", attempt+1, err)
+		}
+
+		s.log.V(logger.DEBUG).Info("retrying cloudevent delivery", "type", event.Type, "error", err.Error())
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (s *httpCloudEventSink) post(ctx context.Context, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", CloudEventContentType)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to [%s]: %w", s.endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("endpoint [%s] responded with status [%d]", s.endpoint, resp.StatusCode)
+	}
+
+	return nil
+}
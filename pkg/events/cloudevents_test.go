@@ -0,0 +1,172 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/events"
+)
+
+var _ = Describe("HTTPCloudEventSink", func() {
+	var (
+		server       *httptest.Server
+		mu           sync.Mutex
+		received     []map[string]interface{}
+		contentTypes []string
+		failures     int
+		ctx          context.Context
+		cancel       context.CancelFunc
+		event        events.CloudEvent
+	)
+
+	receivedEvents := func() []map[string]interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]map[string]interface{}{}, received...)
+	}
+
+	BeforeEach(func() {
+		received = nil
+		contentTypes = nil
+		failures = 0
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			if failures > 0 {
+				failures--
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			Expect(err).NotTo(HaveOccurred())
+			var payload map[string]interface{}
+			Expect(json.Unmarshal(body, &payload)).To(Succeed())
+			received = append(received, payload)
+			contentTypes = append(contentTypes, r.Header.Get("Content-Type"))
+			w.WriteHeader(http.StatusAccepted)
+		}))
+
+		ctx, cancel = context.WithCancel(context.Background())
+
+		event = events.NewCloudEvent(events.ResourceOutputChangedReason, events.ResourceEventData{
+			Owner: corev1.ObjectReference{
+				APIVersion: "carto.run/v1alpha1",
+				Kind:       "Workload",
+				Namespace:  "my-namespace",
+				Name:       "my-workload",
+			},
+			Resource: "image-builder",
+			Outputs:  []events.ResourceOutput{{Name: "image", Digest: "sha256:abc", Preview: "my-image\n"}},
+		})
+	})
+
+	AfterEach(func() {
+		cancel()
+		server.Close()
+	})
+
+	It("posts structured cloudevents to the endpoint", func() {
+		sink := events.NewHTTPCloudEventSink(server.URL, 10, 0, time.Millisecond, logr.Discard())
+		go func() {
+			defer GinkgoRecover()
+			Expect(sink.Start(ctx)).To(Succeed())
+		}()
+
+		sink.Send(event)
+
+		Eventually(receivedEvents).Should(HaveLen(1))
+		payload := receivedEvents()[0]
+		Expect(payload["specversion"]).To(Equal("1.0"))
+		Expect(payload["type"]).To(Equal("run.carto.ResourceOutputChanged"))
+		Expect(payload["source"]).To(Equal("/cartographer"))
+		Expect(payload["subject"]).To(Equal("my-namespace/my-workload"))
+		Expect(payload["id"]).To(Equal(event.ID))
+		Expect(payload["data"]).To(Equal(map[string]interface{}{
+			"owner": map[string]interface{}{
+				"apiVersion": "carto.run/v1alpha1",
+				"kind":       "Workload",
+				"namespace":  "my-namespace",
+				"name":       "my-workload",
+			},
+			"resource": "image-builder",
+			"outputs": []interface{}{
+				map[string]interface{}{"name": "image", "digest": "sha256:abc", "preview": "my-image\n"},
+			},
+		}))
+
+		mu.Lock()
+		defer mu.Unlock()
+		Expect(contentTypes).To(ConsistOf("application/cloudevents+json; charset=UTF-8"))
+	})
+
+	It("retries failed deliveries", func() {
+		failures = 2
+		sink := events.NewHTTPCloudEventSink(server.URL, 10, 2, time.Millisecond, logr.Discard())
+		go func() {
+			defer GinkgoRecover()
+			Expect(sink.Start(ctx)).To(Succeed())
+		}()
+
+		sink.Send(event)
+
+		Eventually(receivedEvents).Should(HaveLen(1))
+	})
+
+	It("gives up once the retries are exhausted", func() {
+		failures = 2
+		sink := events.NewHTTPCloudEventSink(server.URL, 10, 1, time.Millisecond, logr.Discard())
+		go func() {
+			defer GinkgoRecover()
+			Expect(sink.Start(ctx)).To(Succeed())
+		}()
+
+		sink.Send(event)
+		sink.Send(event)
+
+		Eventually(receivedEvents).Should(HaveLen(1))
+		Consistently(receivedEvents, 50*time.Millisecond).Should(HaveLen(1))
+	})
+
+	It("drops events sent while the queue is full", func() {
+		sink := events.NewHTTPCloudEventSink(server.URL, 2, 0, time.Millisecond, logr.Discard())
+
+		sink.Send(event)
+		sink.Send(event)
+		sink.Send(event)
+
+		go func() {
+			defer GinkgoRecover()
+			Expect(sink.Start(ctx)).To(Succeed())
+		}()
+
+		Eventually(receivedEvents).Should(HaveLen(2))
+		Consistently(receivedEvents, 50*time.Millisecond).Should(HaveLen(2))
+	})
+})
@@ -15,8 +15,11 @@
 package events
 
 const NormalType = "Normal"
+const WarningType = "Warning"
 
 const StampedObjectAppliedReason = "StampedObjectApplied"
 const StampedObjectRemovedReason = "StampedObjectRemoved"
 const ResourceOutputChangedReason = "ResourceOutputChanged"
 const ResourceHealthyStatusChangedReason = "ResourceHealthyStatusChanged"
+const ProgressDeadlineExceededReason = "ProgressDeadlineExceeded"
+const ImageProvenanceReason = "ImageProvenance"
@@ -0,0 +1,76 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package eventsfakes
+
+import (
+	"sync"
+
+	"github.com/vmware-tanzu/cartographer/pkg/events"
+)
+
+type FakeCloudEventSink struct {
+	SendStub        func(events.CloudEvent)
+	sendMutex       sync.RWMutex
+	sendArgsForCall []struct {
+		arg1 events.CloudEvent
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeCloudEventSink) Send(arg1 events.CloudEvent) {
+	fake.sendMutex.Lock()
+	fake.sendArgsForCall = append(fake.sendArgsForCall, struct {
+		arg1 events.CloudEvent
+	}{arg1})
+	stub := fake.SendStub
+	fake.recordInvocation("Send", []interface{}{arg1})
+	fake.sendMutex.Unlock()
+	if stub != nil {
+		fake.SendStub(arg1)
+	}
+}
+
+func (fake *FakeCloudEventSink) SendCallCount() int {
+	fake.sendMutex.RLock()
+	defer fake.sendMutex.RUnlock()
+	return len(fake.sendArgsForCall)
+}
+
+func (fake *FakeCloudEventSink) SendCalls(stub func(events.CloudEvent)) {
+	fake.sendMutex.Lock()
+	defer fake.sendMutex.Unlock()
+	fake.SendStub = stub
+}
+
+func (fake *FakeCloudEventSink) SendArgsForCall(i int) events.CloudEvent {
+	fake.sendMutex.RLock()
+	defer fake.sendMutex.RUnlock()
+	argsForCall := fake.sendArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCloudEventSink) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.sendMutex.RLock()
+	defer fake.sendMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeCloudEventSink) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ events.CloudEventSink = new(FakeCloudEventSink)
@@ -33,6 +33,12 @@ type FakeOwnerEventRecorder struct {
 		arg3 string
 		arg4 []interface{}
 	}
+	ResourceCloudEventStub        func(string, events.ResourceEventData)
+	resourceCloudEventMutex       sync.RWMutex
+	resourceCloudEventArgsForCall []struct {
+		arg1 string
+		arg2 events.ResourceEventData
+	}
 	ResourceEventfStub        func(string, string, string, *unstructured.Unstructured, ...interface{})
 	resourceEventfMutex       sync.RWMutex
 	resourceEventfArgsForCall []struct {
@@ -151,6 +157,39 @@ func (fake *FakeOwnerEventRecorder) EventfArgsForCall(i int) (string, string, st
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
+func (fake *FakeOwnerEventRecorder) ResourceCloudEvent(arg1 string, arg2 events.ResourceEventData) {
+	fake.resourceCloudEventMutex.Lock()
+	fake.resourceCloudEventArgsForCall = append(fake.resourceCloudEventArgsForCall, struct {
+		arg1 string
+		arg2 events.ResourceEventData
+	}{arg1, arg2})
+	stub := fake.ResourceCloudEventStub
+	fake.recordInvocation("ResourceCloudEvent", []interface{}{arg1, arg2})
+	fake.resourceCloudEventMutex.Unlock()
+	if stub != nil {
+		fake.ResourceCloudEventStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeOwnerEventRecorder) ResourceCloudEventCallCount() int {
+	fake.resourceCloudEventMutex.RLock()
+	defer fake.resourceCloudEventMutex.RUnlock()
+	return len(fake.resourceCloudEventArgsForCall)
+}
+
+func (fake *FakeOwnerEventRecorder) ResourceCloudEventCalls(stub func(string, events.ResourceEventData)) {
+	fake.resourceCloudEventMutex.Lock()
+	defer fake.resourceCloudEventMutex.Unlock()
+	fake.ResourceCloudEventStub = stub
+}
+
+func (fake *FakeOwnerEventRecorder) ResourceCloudEventArgsForCall(i int) (string, events.ResourceEventData) {
+	fake.resourceCloudEventMutex.RLock()
+	defer fake.resourceCloudEventMutex.RUnlock()
+	argsForCall := fake.resourceCloudEventArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeOwnerEventRecorder) ResourceEventf(arg1 string, arg2 string, arg3 string, arg4 *unstructured.Unstructured, arg5 ...interface{}) {
 	fake.resourceEventfMutex.Lock()
 	fake.resourceEventfArgsForCall = append(fake.resourceEventfArgsForCall, struct {
@@ -196,6 +235,8 @@ func (fake *FakeOwnerEventRecorder) Invocations() map[string][][]interface{} {
 	defer fake.eventMutex.RUnlock()
 	fake.eventfMutex.RLock()
 	defer fake.eventfMutex.RUnlock()
+	fake.resourceCloudEventMutex.RLock()
+	defer fake.resourceCloudEventMutex.RUnlock()
 	fake.resourceEventfMutex.RLock()
 	defer fake.resourceEventfMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
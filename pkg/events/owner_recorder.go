@@ -16,9 +16,11 @@ package events
 
 import (
 	"context"
+	"reflect"
 	"strings"
 
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -46,23 +48,50 @@ type OwnerEventRecorder interface {
 
 	// ResourceEventf is just like Eventf, but the token %Q will be substituted with the qualified name of the provided unstructured resource
 	ResourceEventf(eventtype, reason, messageFmt string, resource *unstructured.Unstructured, args ...interface{})
+
+	// ResourceCloudEvent sends a CloudEvent of type run.carto.<reason> about a resource of the owner to the
+	// CloudEventSink, if there is one. The owner of the data is set to this OwnerEventRecorder's owner object
+	ResourceCloudEvent(reason string, data ResourceEventData)
 }
 
-func FromEventRecorder(rec record.EventRecorder, ownerObj runtime.Object, mapper meta.RESTMapper, log logr.Logger) OwnerEventRecorder {
+// FromEventRecorder returns an OwnerEventRecorder for the owner object. sink may be nil, in which case
+// ResourceCloudEvent does nothing
+func FromEventRecorder(rec record.EventRecorder, sink CloudEventSink, ownerObj runtime.Object, mapper meta.RESTMapper, log logr.Logger) OwnerEventRecorder {
 	return ownerEventRecorder{
 		obj:    ownerObj,
 		rec:    rec,
+		sink:   sink,
 		mapper: mapper,
 		log:    log,
 	}
 }
 
+// Discard returns an OwnerEventRecorder that records no events and sends no CloudEvents
+func Discard() OwnerEventRecorder {
+	return discardEventRecorder{}
+}
+
+type discardEventRecorder struct{}
+
+func (discardEventRecorder) Event(string, string, string) {}
+
+func (discardEventRecorder) Eventf(string, string, string, ...interface{}) {}
+
+func (discardEventRecorder) AnnotatedEventf(map[string]string, string, string, string, ...interface{}) {
+}
+
+func (discardEventRecorder) ResourceEventf(string, string, string, *unstructured.Unstructured, ...interface{}) {
+}
+
+func (discardEventRecorder) ResourceCloudEvent(string, ResourceEventData) {}
+
 // contextKey is how we find OwnerEventRecorder in a context.Context.
 type contextKey struct{}
 
 type ownerEventRecorder struct {
 	obj    runtime.Object
 	rec    record.EventRecorder
+	sink   CloudEventSink
 	mapper meta.RESTMapper
 	log    logr.Logger
 }
@@ -93,6 +122,27 @@ func (o ownerEventRecorder) AnnotatedEventf(annotations map[string]string, event
 	o.rec.AnnotatedEventf(o.obj, annotations, eventtype, reason, messageFmt, args...)
 }
 
+func (o ownerEventRecorder) ResourceCloudEvent(reason string, data ResourceEventData) {
+	if o.sink == nil {
+		return
+	}
+
+	data.Owner = corev1.ObjectReference{
+		APIVersion: o.obj.GetObjectKind().GroupVersionKind().GroupVersion().String(),
+		Kind:       o.obj.GetObjectKind().GroupVersionKind().Kind,
+	}
+	if data.Owner.Kind == "" {
+		data.Owner.APIVersion = ""
+		data.Owner.Kind = reflect.Indirect(reflect.ValueOf(o.obj)).Type().Name()
+	}
+	if accessor, err := meta.Accessor(o.obj); err == nil {
+		data.Owner.Namespace = accessor.GetNamespace()
+		data.Owner.Name = accessor.GetName()
+	}
+
+	o.sink.Send(NewCloudEvent(reason, data))
+}
+
 // FromContextOrDie returns a OwnerEventRecorder from ctx.  If no OwnerEventRecorder is found, this
 // panics
 func FromContextOrDie(ctx context.Context) OwnerEventRecorder {
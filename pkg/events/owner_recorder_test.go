@@ -17,6 +17,7 @@ package events_test
 import (
 	"errors"
 
+	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gbytes"
@@ -33,6 +34,7 @@ var _ = Describe("OwnerRecorder", func() {
 	var (
 		rec            events.OwnerEventRecorder
 		fakeRecorder   *eventsfakes.FakeEventRecorder
+		fakeSink       *eventsfakes.FakeCloudEventSink
 		fakeMapper     *eventsfakes.FakeRESTMapper
 		out            *Buffer
 		ownerObject    *unstructured.Unstructured
@@ -41,9 +43,13 @@ var _ = Describe("OwnerRecorder", func() {
 
 	BeforeEach(func() {
 		fakeRecorder = &eventsfakes.FakeEventRecorder{}
+		fakeSink = &eventsfakes.FakeCloudEventSink{}
 
 		ownerObject = &unstructured.Unstructured{}
 		ownerObject.SetName("the-owner")
+		ownerObject.SetNamespace("the-namespace")
+		ownerObject.SetAPIVersion("carto.run/v1alpha1")
+		ownerObject.SetKind("Workload")
 
 		resourceObject = &unstructured.Unstructured{}
 		resourceObject.SetName("the-resource")
@@ -58,7 +64,7 @@ var _ = Describe("OwnerRecorder", func() {
 		out = NewBuffer()
 		log := zap.New(zap.WriteTo(out))
 
-		rec = events.FromEventRecorder(fakeRecorder, ownerObject, fakeMapper, log)
+		rec = events.FromEventRecorder(fakeRecorder, fakeSink, ownerObject, fakeMapper, log)
 	})
 
 	Describe("ResourceEventf", func() {
@@ -114,4 +120,40 @@ var _ = Describe("OwnerRecorder", func() {
 			Expect(eventMessageArgs).To(BeEmpty())
 		})
 	})
+
+	Describe("ResourceCloudEvent", func() {
+		It("sends a cloudevent about the owner's resource to the sink", func() {
+			rec.ResourceCloudEvent("EggsHatched", events.ResourceEventData{
+				Resource:      "the-coop",
+				StampedObject: events.StampedObjectReference(resourceObject),
+				Outputs:       []events.ResourceOutput{{Name: "image", Digest: "sha256:abc", Preview: "hen\n"}},
+			})
+
+			Expect(fakeRecorder.Invocations()).To(BeEmpty())
+			Expect(fakeSink.SendCallCount()).To(Equal(1))
+			event := fakeSink.SendArgsForCall(0)
+			Expect(event.SpecVersion).To(Equal("1.0"))
+			Expect(event.ID).NotTo(BeEmpty())
+			Expect(event.Source).To(Equal("/cartographer"))
+			Expect(event.Type).To(Equal("run.carto.EggsHatched"))
+			Expect(event.Subject).To(Equal("the-namespace/the-owner"))
+			Expect(event.Data.Owner.APIVersion).To(Equal("carto.run/v1alpha1"))
+			Expect(event.Data.Owner.Kind).To(Equal("Workload"))
+			Expect(event.Data.Owner.Namespace).To(Equal("the-namespace"))
+			Expect(event.Data.Owner.Name).To(Equal("the-owner"))
+			Expect(event.Data.Resource).To(Equal("the-coop"))
+			Expect(event.Data.StampedObject.APIVersion).To(Equal("example.com/v1"))
+			Expect(event.Data.StampedObject.Kind).To(Equal("foo"))
+			Expect(event.Data.StampedObject.Name).To(Equal("the-resource"))
+			Expect(event.Data.Outputs).To(Equal([]events.ResourceOutput{{Name: "image", Digest: "sha256:abc", Preview: "hen\n"}}))
+		})
+
+		It("does nothing without a sink", func() {
+			rec = events.FromEventRecorder(fakeRecorder, nil, ownerObject, fakeMapper, logr.Discard())
+			Expect(func() {
+				rec.ResourceCloudEvent("EggsHatched", events.ResourceEventData{Resource: "the-coop"})
+			}).NotTo(Panic())
+			Expect(fakeRecorder.Invocations()).To(BeEmpty())
+		})
+	})
 })
@@ -0,0 +1,204 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	cerrors "github.com/vmware-tanzu/cartographer/pkg/errors"
+	"github.com/vmware-tanzu/cartographer/pkg/eval"
+	"github.com/vmware-tanzu/cartographer/pkg/stamp"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+	"github.com/vmware-tanzu/cartographer/pkg/utils"
+)
+
+// Explanation is why a workload or deliverable is not ready: the first of its resources, in graph
+// order, which is not ready, and what its stamped object says of itself
+type Explanation struct {
+	OwnerKind       string
+	Owner           client.Object
+	OwnerConditions []metav1.Condition
+
+	// Resource is nil when every resource is ready
+	Resource *v1alpha1.ResourceStatus
+
+	// StampedObject is nil when the resource has stamped no object, or it no longer exists
+	StampedObject *unstructured.Unstructured
+	Conditions    []metav1.Condition
+	Messages      []Message
+	OutputError   *cerrors.RetrieveOutputError
+
+	Events []corev1.Event
+}
+
+// Message is the value at the messagePath of a health rule
+type Message struct {
+	Path  string
+	Value string
+}
+
+// FirstUnreadyResource returns the status of the first resource, in order, whose Ready condition
+// is not True. Skipped resources and resources without a status are passed over.
+func FirstUnreadyResource(order []string, statuses []v1alpha1.ResourceStatus) *v1alpha1.ResourceStatus {
+	statusesByName := make(map[string]*v1alpha1.ResourceStatus)
+	for i := range statuses {
+		statusesByName[statuses[i].Name] = &statuses[i]
+	}
+
+	for _, name := range order {
+		status, ok := statusesByName[name]
+		if !ok || status.Skipped {
+			continue
+		}
+
+		ready := conditionWithType(status.Conditions, v1alpha1.ResourceReady)
+		if ready == nil || ready.Status != metav1.ConditionTrue {
+			return status
+		}
+	}
+
+	return nil
+}
+
+// RelevantConditions are the conditions of the stamped object which its health rule reads
+// or, when the rule reads none, all of its conditions
+func RelevantConditions(rule *v1alpha1.HealthRule, stampedObject *unstructured.Unstructured) []metav1.Condition {
+	conditions := utils.ExtractConditions(stampedObject)
+
+	var types []string
+	if rule != nil && rule.SingleConditionType != "" {
+		types = append(types, rule.SingleConditionType)
+	}
+	if rule != nil && rule.MultiMatch != nil {
+		for _, requirement := range append(rule.MultiMatch.Unhealthy.MatchConditions, rule.MultiMatch.Healthy.MatchConditions...) {
+			types = append(types, requirement.Type)
+		}
+	}
+
+	if len(types) == 0 {
+		return conditions
+	}
+
+	var relevant []metav1.Condition
+	seen := make(map[string]bool)
+	for _, conditionType := range types {
+		condition := conditions.ConditionWithType(conditionType)
+		if condition != nil && !seen[conditionType] {
+			relevant = append(relevant, *condition)
+		}
+		seen[conditionType] = true
+	}
+	return relevant
+}
+
+// HealthMessages are the values at the messagePaths of the match fields of the health rule
+func HealthMessages(rule *v1alpha1.HealthRule, stampedObject *unstructured.Unstructured) []Message {
+	if rule == nil || rule.MultiMatch == nil {
+		return nil
+	}
+
+	var messages []Message
+	evaluator := eval.EvaluatorBuilder()
+	for _, requirement := range append(rule.MultiMatch.Unhealthy.MatchFields, rule.MultiMatch.Healthy.MatchFields...) {
+		if requirement.MessagePath == "" {
+			continue
+		}
+
+		value, err := evaluator.EvaluateJsonPath(requirement.MessagePath, stampedObject.UnstructuredContent())
+		message := Message{Path: requirement.MessagePath, Value: fmt.Sprintf("%v", value)}
+		if err != nil {
+			message.Value = fmt.Sprintf("<error retrieving message path: %s>", err.Error())
+		}
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+// OutputError reads the outputs of the template from the stamped object, as the realizer does,
+// and returns the error the realizer would have met, if any. The deployment a deployment template
+// passes through is not known, only whether the stamped object is ready to pass it on.
+func OutputError(template client.Object, resource *v1alpha1.ResourceStatus, stampedObject *unstructured.Unstructured, blueprintName, blueprintType string) *cerrors.RetrieveOutputError {
+	reader, err := stamp.NewReader(template, unknownDeployment{})
+	if err != nil {
+		return nil
+	}
+
+	if _, err = reader.Output(stampedObject); err == nil {
+		return nil
+	}
+
+	var qualifiedResource string
+	if resource.StampedRef != nil {
+		qualifiedResource = resource.StampedRef.Resource
+	}
+
+	return &cerrors.RetrieveOutputError{
+		Err:               err,
+		ResourceName:      resource.Name,
+		StampedObject:     stampedObject,
+		BlueprintName:     blueprintName,
+		BlueprintType:     blueprintType,
+		QualifiedResource: qualifiedResource,
+		Healthy:           healthStatus(resource.Conditions),
+	}
+}
+
+// SortEvents sorts events by the time they were last seen, the most recent last
+func SortEvents(events []corev1.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return lastSeen(events[i]).Before(lastSeen(events[j]))
+	})
+}
+
+func lastSeen(event corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+func healthStatus(conditions []metav1.Condition) metav1.ConditionStatus {
+	healthy := conditionWithType(conditions, v1alpha1.ResourceHealthy)
+	if healthy == nil {
+		return metav1.ConditionUnknown
+	}
+	return healthy.Status
+}
+
+func conditionWithType(conditions []metav1.Condition, conditionType string) *metav1.Condition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+type unknownDeployment struct{}
+
+func (unknownDeployment) GetDeployment() *templates.SourceInput {
+	return &templates.SourceInput{}
+}
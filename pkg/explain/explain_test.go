@@ -0,0 +1,238 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explain_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	cerrors "github.com/vmware-tanzu/cartographer/pkg/errors"
+	"github.com/vmware-tanzu/cartographer/pkg/explain"
+)
+
+var _ = Describe("Explain", func() {
+	var stampedObject *unstructured.Unstructured
+
+	BeforeEach(func() {
+		stampedObject = &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "kpack.io/v1alpha2",
+			"kind":       "Image",
+			"metadata":   map[string]interface{}{"name": "app", "namespace": "dev"},
+			"status": map[string]interface{}{
+				"message": "out of disk",
+				"phase":   "Failed",
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Ready", "status": "False", "reason": "BuildFailed", "message": "build failed"},
+					map[string]interface{}{"type": "Builder", "status": "True", "reason": "", "message": ""},
+				},
+			},
+		}}
+	})
+
+	Describe("FirstUnreadyResource", func() {
+		It("returns the first resource in order which is not ready", func() {
+			statuses := []v1alpha1.ResourceStatus{
+				resourceStatus("deployer", metav1.ConditionFalse),
+				resourceStatus("image-builder", metav1.ConditionFalse),
+				resourceStatus("source-provider", metav1.ConditionTrue),
+			}
+
+			resource := explain.FirstUnreadyResource([]string{"source-provider", "image-builder", "deployer"}, statuses)
+
+			Expect(resource).NotTo(BeNil())
+			Expect(resource.Name).To(Equal("image-builder"))
+		})
+
+		It("passes over skipped resources", func() {
+			skipped := resourceStatus("tester", metav1.ConditionUnknown)
+			skipped.Skipped = true
+
+			resource := explain.FirstUnreadyResource([]string{"tester"}, []v1alpha1.ResourceStatus{skipped})
+
+			Expect(resource).To(BeNil())
+		})
+
+		It("treats a resource without a Ready condition as not ready", func() {
+			statuses := []v1alpha1.ResourceStatus{{RealizedResource: v1alpha1.RealizedResource{Name: "source-provider"}}}
+
+			resource := explain.FirstUnreadyResource([]string{"source-provider"}, statuses)
+
+			Expect(resource).NotTo(BeNil())
+		})
+	})
+
+	Describe("RelevantConditions", func() {
+		It("returns the condition of a single condition type health rule", func() {
+			conditions := explain.RelevantConditions(&v1alpha1.HealthRule{SingleConditionType: "Ready"}, stampedObject)
+
+			Expect(conditions).To(HaveLen(1))
+			Expect(conditions[0].Reason).To(Equal("BuildFailed"))
+		})
+
+		It("returns the conditions of a multi match health rule", func() {
+			rule := &v1alpha1.HealthRule{MultiMatch: &v1alpha1.MultiMatchHealthRule{
+				Healthy:   v1alpha1.HealthMatchRule{MatchConditions: []v1alpha1.ConditionRequirement{{Type: "Builder", Status: "True"}}},
+				Unhealthy: v1alpha1.HealthMatchRule{MatchConditions: []v1alpha1.ConditionRequirement{{Type: "Builder", Status: "False"}}},
+			}}
+
+			conditions := explain.RelevantConditions(rule, stampedObject)
+
+			Expect(conditions).To(HaveLen(1))
+			Expect(conditions[0].Type).To(Equal("Builder"))
+		})
+
+		It("returns every condition when the health rule reads none", func() {
+			Expect(explain.RelevantConditions(nil, stampedObject)).To(HaveLen(2))
+		})
+	})
+
+	Describe("HealthMessages", func() {
+		It("reads the message paths of the match fields", func() {
+			rule := &v1alpha1.HealthRule{MultiMatch: &v1alpha1.MultiMatchHealthRule{
+				Unhealthy: v1alpha1.HealthMatchRule{MatchFields: []v1alpha1.HealthMatchFieldSelectorRequirement{
+					{
+						FieldSelectorRequirement: v1alpha1.FieldSelectorRequirement{Key: "status.phase", Operator: "In", Values: []string{"Failed"}},
+						MessagePath:              ".status.message",
+					},
+					{
+						FieldSelectorRequirement: v1alpha1.FieldSelectorRequirement{Key: "status.phase", Operator: "In", Values: []string{"Error"}},
+						MessagePath:              ".status.reason",
+					},
+				}},
+			}}
+
+			messages := explain.HealthMessages(rule, stampedObject)
+
+			Expect(messages).To(HaveLen(2))
+			Expect(messages[0]).To(Equal(explain.Message{Path: ".status.message", Value: "out of disk"}))
+			Expect(messages[1].Path).To(Equal(".status.reason"))
+			Expect(messages[1].Value).To(HavePrefix("<error retrieving message path"))
+		})
+	})
+
+	Describe("OutputError", func() {
+		var (
+			template *v1alpha1.ClusterImageTemplate
+			resource *v1alpha1.ResourceStatus
+		)
+
+		BeforeEach(func() {
+			template = &v1alpha1.ClusterImageTemplate{
+				Spec: v1alpha1.ImageTemplateSpec{ImagePath: ".status.latestImage"},
+			}
+			status := resourceStatus("image-builder", metav1.ConditionFalse)
+			status.StampedRef = &v1alpha1.StampedRef{Resource: "images.kpack.io"}
+			resource = &status
+		})
+
+		It("returns the error of reading an output path which is missing", func() {
+			err := explain.OutputError(template, resource, stampedObject, "source-to-url", cerrors.SupplyChain)
+
+			Expect(err).NotTo(BeNil())
+			Expect(err.JsonPathExpression()).To(Equal(".status.latestImage"))
+			Expect(err.Error()).To(ContainSubstring("unable to retrieve outputs [.status.latestImage] from stamped object [dev/app] of type [images.kpack.io] for resource [image-builder] in supply chain [source-to-url]"))
+		})
+
+		It("returns nil when the outputs can be read", func() {
+			Expect(unstructured.SetNestedField(stampedObject.Object, "registry.example/app@sha256:abc", "status", "latestImage")).To(Succeed())
+
+			Expect(explain.OutputError(template, resource, stampedObject, "source-to-url", cerrors.SupplyChain)).To(BeNil())
+		})
+	})
+
+	Describe("Write", func() {
+		It("writes the explanation", func() {
+			resource := resourceStatus("image-builder", metav1.ConditionFalse)
+			resource.Conditions[0].Message = "stamped object is unhealthy"
+			resource.TemplateRef = &corev1.ObjectReference{Kind: "ClusterImageTemplate", Name: "kpack"}
+			resource.StampedRef = &v1alpha1.StampedRef{
+				ObjectReference: &corev1.ObjectReference{Kind: "Image", Namespace: "dev", Name: "app"},
+				Resource:        "images.kpack.io",
+			}
+
+			seen := metav1.NewTime(time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC))
+			e := &explain.Explanation{
+				OwnerKind: "Workload",
+				Owner:     &v1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Namespace: "dev", Name: "app"}},
+				OwnerConditions: []metav1.Condition{
+					{Type: "Ready", Status: metav1.ConditionFalse, Reason: "HealthyConditionRule", Message: "build failed"},
+				},
+				Resource:      &resource,
+				StampedObject: stampedObject,
+				Conditions:    explain.RelevantConditions(&v1alpha1.HealthRule{SingleConditionType: "Ready"}, stampedObject),
+				Messages:      []explain.Message{{Path: ".status.message", Value: "out of disk"}},
+				Events: []corev1.Event{{
+					InvolvedObject: corev1.ObjectReference{Kind: "Image", Name: "app"},
+					Type:           "Warning",
+					Reason:         "BuildFailed",
+					Message:        "build failed\n",
+					LastTimestamp:  seen,
+				}},
+			}
+
+			out := &bytes.Buffer{}
+			Expect(explain.Write(out, e)).To(Succeed())
+
+			Expect(out.String()).To(Equal(`Workload dev/app is not ready
+  Ready  False  HealthyConditionRule  build failed
+
+Resource [image-builder] of template [ClusterImageTemplate/kpack] is the first which is not ready
+  Ready  False  Reason  stamped object is unhealthy
+
+Stamped object [images.kpack.io/app] in namespace [dev]
+  Conditions:
+    Ready  False  BuildFailed  build failed
+  Health rule messages:
+    .status.message  out of disk
+
+Events:
+  LAST SEEN             TYPE     REASON       OBJECT     MESSAGE
+  2021-01-02T03:04:05Z  Warning  BuildFailed  image/app  build failed
+`))
+		})
+
+		It("says when every resource is ready", func() {
+			e := &explain.Explanation{
+				OwnerKind: "Deliverable",
+				Owner:     &v1alpha1.Deliverable{ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "app"}},
+				OwnerConditions: []metav1.Condition{
+					{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Ready"},
+				},
+			}
+
+			out := &bytes.Buffer{}
+			Expect(explain.Write(out, e)).To(Succeed())
+
+			Expect(out.String()).To(ContainSubstring("Deliverable prod/app is ready"))
+			Expect(out.String()).To(ContainSubstring("No resource of Deliverable prod/app is unready"))
+		})
+	})
+})
+
+func resourceStatus(name string, ready metav1.ConditionStatus) v1alpha1.ResourceStatus {
+	return v1alpha1.ResourceStatus{
+		RealizedResource: v1alpha1.RealizedResource{Name: name},
+		Conditions: []metav1.Condition{
+			{Type: v1alpha1.ResourceReady, Status: ready, Reason: "Reason"},
+		},
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package explain
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	cerrors "github.com/vmware-tanzu/cartographer/pkg/errors"
+)
+
+// Write writes the explanation for a person to read
+func Write(w io.Writer, e *Explanation) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	owner := fmt.Sprintf("%s %s/%s", e.OwnerKind, e.Owner.GetNamespace(), e.Owner.GetName())
+	ready := conditionWithType(e.OwnerConditions, v1alpha1.OwnerReady)
+	if ready != nil && ready.Status == metav1.ConditionTrue {
+		fmt.Fprintf(tw, "%s is ready\n", owner)
+	} else {
+		fmt.Fprintf(tw, "%s is not ready\n", owner)
+	}
+	writeConditions(tw, "", e.OwnerConditions)
+
+	if e.Resource == nil {
+		fmt.Fprintf(tw, "\nNo resource of %s is unready\n", owner)
+		return tw.Flush()
+	}
+
+	fmt.Fprintf(tw, "\nResource [%s]", e.Resource.Name)
+	if e.Resource.TemplateRef != nil {
+		fmt.Fprintf(tw, " of template [%s/%s]", e.Resource.TemplateRef.Kind, e.Resource.TemplateRef.Name)
+	}
+	fmt.Fprintf(tw, " is the first which is not ready\n")
+	writeConditions(tw, "", e.Resource.Conditions)
+
+	if e.StampedObject == nil {
+		if e.Resource.StampedRef == nil {
+			fmt.Fprintf(tw, "\nThe resource has not stamped an object\n")
+		} else {
+			fmt.Fprintf(tw, "\nStamped object [%s/%s] was not found\n", e.Resource.StampedRef.Resource, e.Resource.StampedRef.Name)
+		}
+	} else {
+		fmt.Fprintf(tw, "\nStamped object [%s/%s]", stampedResource(e), e.StampedObject.GetName())
+		if e.StampedObject.GetNamespace() != "" {
+			fmt.Fprintf(tw, " in namespace [%s]", e.StampedObject.GetNamespace())
+		}
+		fmt.Fprintf(tw, "\n")
+
+		if len(e.Conditions) == 0 {
+			fmt.Fprintf(tw, "  has no conditions\n")
+		} else {
+			fmt.Fprintf(tw, "  Conditions:\n")
+			writeConditions(tw, "  ", e.Conditions)
+		}
+
+		if len(e.Messages) > 0 {
+			fmt.Fprintf(tw, "  Health rule messages:\n")
+			for _, message := range e.Messages {
+				fmt.Fprintf(tw, "    %s\t%s\n", message.Path, message.Value)
+			}
+		}
+
+		if e.OutputError != nil {
+			if path := e.OutputError.JsonPathExpression(); path != cerrors.NoJsonpathContext {
+				fmt.Fprintf(tw, "  Output path [%s] cannot be read\n", path)
+			}
+			fmt.Fprintf(tw, "    %s\n", e.OutputError.Error())
+		}
+	}
+
+	if len(e.Events) > 0 {
+		fmt.Fprintf(tw, "\nEvents:\n")
+		fmt.Fprintf(tw, "  LAST SEEN\tTYPE\tREASON\tOBJECT\tMESSAGE\n")
+		for _, event := range e.Events {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\t%s/%s\t%s\n",
+				lastSeen(event).UTC().Format("2006-01-02T15:04:05Z"),
+				event.Type,
+				event.Reason,
+				strings.ToLower(event.InvolvedObject.Kind),
+				event.InvolvedObject.Name,
+				strings.TrimSpace(event.Message),
+			)
+		}
+	}
+
+	return tw.Flush()
+}
+
+func writeConditions(w io.Writer, indent string, conditions []metav1.Condition) {
+	for _, condition := range conditions {
+		fmt.Fprintf(w, "%s  %s\t%s\t%s\t%s\n", indent, condition.Type, condition.Status, condition.Reason, condition.Message)
+	}
+}
+
+func stampedResource(e *Explanation) string {
+	if e.Resource.StampedRef != nil && e.Resource.StampedRef.Resource != "" {
+		return e.Resource.StampedRef.Resource
+	}
+	return strings.ToLower(e.StampedObject.GetKind())
+}
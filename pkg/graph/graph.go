@@ -0,0 +1,261 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+// Health of a resource, read from the Healthy condition of its status on a workload or deliverable
+const (
+	Healthy   = "Healthy"
+	Unhealthy = "Unhealthy"
+	Unknown   = "Unknown"
+	Skipped   = "Skipped"
+)
+
+// Graph is a supply chain or delivery, its resources being the nodes and the inputs
+// they consume from one another the edges
+type Graph struct {
+	Kind      string
+	Name      string
+	Resources []Resource
+	Edges     []Edge
+}
+
+// Resource is a node of the graph. TemplateName is empty when the template is chosen
+// among Options. Health is empty unless the graph was overlaid with the status of an owner.
+type Resource struct {
+	Name         string
+	TemplateKind string
+	TemplateName string
+	Options      []string
+	Conditional  bool
+	Health       string
+}
+
+// Edge is an input of type source, image, config, deployment or output, which the To resource
+// consumes by Name from the From resource
+type Edge struct {
+	From string
+	To   string
+	Type string
+	Name string
+}
+
+// Label is the label of the edge, e.g. "image: built-image"
+func (e Edge) Label() string {
+	if e.Name == "" {
+		return e.Type
+	}
+	return fmt.Sprintf("%s: %s", e.Type, e.Name)
+}
+
+// ForSupplyChain builds the graph of the supply chain from its resources, which are
+// expected to have had their sub-chains expanded
+func ForSupplyChain(supplyChain v1alpha1.SupplyChainObject, resources []v1alpha1.SupplyChainResource) *Graph {
+	g := &Graph{
+		Kind: "ClusterSupplyChain",
+		Name: supplyChain.GetName(),
+	}
+
+	if _, ok := supplyChain.(*v1alpha1.SupplyChain); ok {
+		g.Kind = "SupplyChain"
+	}
+
+	for _, resource := range resources {
+		g.Resources = append(g.Resources, Resource{
+			Name:         resource.Name,
+			TemplateKind: resource.TemplateRef.Kind,
+			TemplateName: resource.TemplateRef.Name,
+			Options:      optionNames(resource.TemplateRef.Options),
+			Conditional:  resource.When != nil,
+		})
+	}
+
+	for _, resource := range resources {
+		g.addEdges(resource.Name, "source", resource.Sources)
+		g.addEdges(resource.Name, "image", resource.Images)
+		g.addEdges(resource.Name, "config", resource.Configs)
+		g.addOutputEdges(resource.Name, resource.Outputs)
+	}
+
+	return g
+}
+
+// ForDelivery builds the graph of the delivery
+func ForDelivery(delivery v1alpha1.DeliveryObject) *Graph {
+	g := &Graph{
+		Kind: "ClusterDelivery",
+		Name: delivery.GetName(),
+	}
+
+	if _, ok := delivery.(*v1alpha1.Delivery); ok {
+		g.Kind = "Delivery"
+	}
+
+	resources := delivery.GetSpec().Resources
+	for _, resource := range resources {
+		g.Resources = append(g.Resources, Resource{
+			Name:         resource.Name,
+			TemplateKind: resource.TemplateRef.Kind,
+			TemplateName: resource.TemplateRef.Name,
+			Options:      optionNames(resource.TemplateRef.Options),
+			Conditional:  resource.When != nil,
+		})
+	}
+
+	for _, resource := range resources {
+		g.addEdges(resource.Name, "source", resource.Sources)
+		if resource.Deployment != nil {
+			g.addEdge(Edge{From: resource.Deployment.Resource, To: resource.Name, Type: "deployment"})
+		}
+		g.addEdges(resource.Name, "config", resource.Configs)
+		g.addOutputEdges(resource.Name, resource.Outputs)
+	}
+
+	return g
+}
+
+// Overlay sets the health of each resource from its status on a workload or deliverable.
+// Resources without a status are left without a health.
+func (g *Graph) Overlay(statuses []v1alpha1.ResourceStatus) {
+	healthByName := make(map[string]string)
+	for _, status := range statuses {
+		healthByName[status.Name] = health(status)
+	}
+
+	for i := range g.Resources {
+		g.Resources[i].Health = healthByName[g.Resources[i].Name]
+	}
+}
+
+// Order is the names of the resources, each after the resources it consumes inputs from.
+// Resources otherwise keep their order in the blueprint, as do the resources of a cycle.
+func (g *Graph) Order() []string {
+	providers := make(map[string][]string)
+	for _, edge := range g.Edges {
+		providers[edge.To] = append(providers[edge.To], edge.From)
+	}
+
+	var order []string
+	ordered := make(map[string]bool)
+	for len(order) < len(g.Resources) {
+		progressed := false
+		for _, resource := range g.Resources {
+			if ordered[resource.Name] || !allOrdered(providers[resource.Name], ordered) {
+				continue
+			}
+			order = append(order, resource.Name)
+			ordered[resource.Name] = true
+			progressed = true
+			break
+		}
+
+		if !progressed {
+			for _, resource := range g.Resources {
+				if !ordered[resource.Name] {
+					order = append(order, resource.Name)
+					ordered[resource.Name] = true
+				}
+			}
+		}
+	}
+
+	return order
+}
+
+func allOrdered(names []string, ordered map[string]bool) bool {
+	for _, name := range names {
+		if !ordered[name] {
+			return false
+		}
+	}
+	return true
+}
+
+func health(status v1alpha1.ResourceStatus) string {
+	if status.Skipped {
+		return Skipped
+	}
+
+	for _, condition := range status.Conditions {
+		if condition.Type != v1alpha1.ResourceHealthy {
+			continue
+		}
+		switch condition.Status {
+		case metav1.ConditionTrue:
+			return Healthy
+		case metav1.ConditionFalse:
+			return Unhealthy
+		}
+	}
+	return Unknown
+}
+
+func (g *Graph) addEdges(to, inputType string, references []v1alpha1.ResourceReference) {
+	for _, reference := range references {
+		g.addEdge(Edge{From: reference.Resource, To: to, Type: inputType, Name: reference.Name})
+	}
+}
+
+func (g *Graph) addOutputEdges(to string, references []v1alpha1.OutputReference) {
+	for _, reference := range references {
+		g.addEdge(Edge{From: reference.Resource, To: to, Type: "output", Name: reference.Name})
+	}
+}
+
+// addEdge adds the edge when both of its resources are in the graph
+func (g *Graph) addEdge(edge Edge) {
+	if g.resourceIndex(edge.From) < 0 || g.resourceIndex(edge.To) < 0 {
+		return
+	}
+	g.Edges = append(g.Edges, edge)
+}
+
+func (g *Graph) resourceIndex(name string) int {
+	for i, resource := range g.Resources {
+		if resource.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// templateLabel describes the template of the resource, e.g. ClusterImageTemplate/kpack
+// or ClusterImageTemplate [kpack | kaniko]
+func (r Resource) templateLabel() string {
+	if r.TemplateName != "" {
+		return fmt.Sprintf("%s/%s", r.TemplateKind, r.TemplateName)
+	}
+	return fmt.Sprintf("%s [%s]", r.TemplateKind, strings.Join(r.Options, " | "))
+}
+
+func optionNames(options []v1alpha1.TemplateOption) []string {
+	var names []string
+	for _, option := range options {
+		if option.Name != "" {
+			names = append(names, option.Name)
+		} else {
+			names = append(names, fmt.Sprintf("passThrough: %s", option.PassThrough))
+		}
+	}
+	return names
+}
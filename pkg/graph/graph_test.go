@@ -0,0 +1,186 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/graph"
+)
+
+var _ = Describe("Graph", func() {
+	Describe("ForSupplyChain", func() {
+		var supplyChain *v1alpha1.ClusterSupplyChain
+
+		BeforeEach(func() {
+			supplyChain = &v1alpha1.ClusterSupplyChain{
+				ObjectMeta: metav1.ObjectMeta{Name: "source-to-url"},
+				Spec: v1alpha1.SupplyChainSpec{
+					Resources: []v1alpha1.SupplyChainResource{
+						{
+							Name:        "source-provider",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterSourceTemplate", Name: "source"},
+						},
+						{
+							Name: "image-builder",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{
+								Kind: "ClusterImageTemplate",
+								Options: []v1alpha1.TemplateOption{
+									{Name: "kpack"},
+									{PassThrough: "source"},
+								},
+							},
+							Sources: []v1alpha1.ResourceReference{{Resource: "source-provider", Name: "source"}},
+						},
+						{
+							Name:        "deployer",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterTemplate", Name: "app-deploy"},
+							Images:      []v1alpha1.ResourceReference{{Resource: "image-builder", Name: "image"}},
+							Outputs:     []v1alpha1.OutputReference{{Resource: "source-provider", Name: "commit"}},
+							When:        &v1alpha1.ResourceCondition{Expression: "true"},
+						},
+					},
+				},
+			}
+		})
+
+		It("has a node for each resource", func() {
+			g := graph.ForSupplyChain(supplyChain, supplyChain.Spec.Resources)
+
+			Expect(g.Kind).To(Equal("ClusterSupplyChain"))
+			Expect(g.Name).To(Equal("source-to-url"))
+			Expect(g.Resources).To(Equal([]graph.Resource{
+				{Name: "source-provider", TemplateKind: "ClusterSourceTemplate", TemplateName: "source"},
+				{Name: "image-builder", TemplateKind: "ClusterImageTemplate", Options: []string{"kpack", "passThrough: source"}},
+				{Name: "deployer", TemplateKind: "ClusterTemplate", TemplateName: "app-deploy", Conditional: true},
+			}))
+		})
+
+		It("has an edge for each input", func() {
+			g := graph.ForSupplyChain(supplyChain, supplyChain.Spec.Resources)
+
+			Expect(g.Edges).To(Equal([]graph.Edge{
+				{From: "source-provider", To: "image-builder", Type: "source", Name: "source"},
+				{From: "image-builder", To: "deployer", Type: "image", Name: "image"},
+				{From: "source-provider", To: "deployer", Type: "output", Name: "commit"},
+			}))
+		})
+
+		It("does not draw inputs from resources which are not in the graph", func() {
+			supplyChain.Spec.Resources[1].Sources[0].Resource = "missing"
+
+			g := graph.ForSupplyChain(supplyChain, supplyChain.Spec.Resources)
+
+			Expect(g.Edges).NotTo(ContainElement(HaveField("From", "missing")))
+		})
+
+		It("is of kind SupplyChain for a namespaced supply chain", func() {
+			g := graph.ForSupplyChain(&v1alpha1.SupplyChain{}, nil)
+
+			Expect(g.Kind).To(Equal("SupplyChain"))
+		})
+	})
+
+	Describe("ForDelivery", func() {
+		It("has an edge for the deployment input", func() {
+			delivery := &v1alpha1.ClusterDelivery{
+				ObjectMeta: metav1.ObjectMeta{Name: "delivery"},
+				Spec: v1alpha1.DeliverySpec{
+					Resources: []v1alpha1.DeliveryResource{
+						{
+							Name:        "deployer",
+							TemplateRef: v1alpha1.DeliveryTemplateReference{Kind: "ClusterDeploymentTemplate", Name: "app-deploy"},
+						},
+						{
+							Name:        "tester",
+							TemplateRef: v1alpha1.DeliveryTemplateReference{Kind: "ClusterTemplate", Name: "smoke-test"},
+							Deployment:  &v1alpha1.DeploymentReference{Resource: "deployer"},
+						},
+					},
+				},
+			}
+
+			g := graph.ForDelivery(delivery)
+
+			Expect(g.Kind).To(Equal("ClusterDelivery"))
+			Expect(g.Edges).To(Equal([]graph.Edge{
+				{From: "deployer", To: "tester", Type: "deployment"},
+			}))
+		})
+	})
+
+	Describe("Order", func() {
+		It("orders each resource after the resources it consumes", func() {
+			g := &graph.Graph{
+				Resources: []graph.Resource{{Name: "deployer"}, {Name: "image-builder"}, {Name: "source-provider"}, {Name: "tester"}},
+				Edges: []graph.Edge{
+					{From: "source-provider", To: "image-builder"},
+					{From: "image-builder", To: "deployer"},
+					{From: "source-provider", To: "tester"},
+				},
+			}
+
+			Expect(g.Order()).To(Equal([]string{"source-provider", "image-builder", "deployer", "tester"}))
+		})
+
+		It("keeps the resources of a cycle in their blueprint order", func() {
+			g := &graph.Graph{
+				Resources: []graph.Resource{{Name: "a"}, {Name: "b"}, {Name: "c"}},
+				Edges: []graph.Edge{
+					{From: "a", To: "b"},
+					{From: "b", To: "a"},
+				},
+			}
+
+			Expect(g.Order()).To(Equal([]string{"c", "a", "b"}))
+		})
+	})
+
+	Describe("Overlay", func() {
+		It("sets the health of each resource from its status", func() {
+			g := &graph.Graph{
+				Resources: []graph.Resource{
+					{Name: "healthy"}, {Name: "unhealthy"}, {Name: "unknown"}, {Name: "skipped"}, {Name: "not-realized"},
+				},
+			}
+
+			g.Overlay([]v1alpha1.ResourceStatus{
+				resourceStatus("healthy", metav1.ConditionTrue),
+				resourceStatus("unhealthy", metav1.ConditionFalse),
+				resourceStatus("unknown", metav1.ConditionUnknown),
+				{RealizedResource: v1alpha1.RealizedResource{Name: "skipped", Skipped: true}},
+			})
+
+			var health []string
+			for _, resource := range g.Resources {
+				health = append(health, resource.Health)
+			}
+			Expect(health).To(Equal([]string{graph.Healthy, graph.Unhealthy, graph.Unknown, graph.Skipped, ""}))
+		})
+	})
+})
+
+func resourceStatus(name string, healthy metav1.ConditionStatus) v1alpha1.ResourceStatus {
+	return v1alpha1.ResourceStatus{
+		RealizedResource: v1alpha1.RealizedResource{Name: name},
+		Conditions: []metav1.Condition{
+			{Type: v1alpha1.ResourceReady, Status: metav1.ConditionTrue},
+			{Type: v1alpha1.ResourceHealthy, Status: healthy},
+		},
+	}
+}
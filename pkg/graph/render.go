@@ -0,0 +1,159 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Formats which Write accepts
+const (
+	DOTFormat     = "dot"
+	MermaidFormat = "mermaid"
+)
+
+// fill and stroke colors of resources by health
+var healthColors = map[string][2]string{
+	Healthy:   {"#c8e6c9", "#2e7d32"},
+	Unhealthy: {"#ffcdd2", "#c62828"},
+	Unknown:   {"#fff9c4", "#f9a825"},
+	Skipped:   {"#eeeeee", "#9e9e9e"},
+}
+
+// Write writes the graph as a Graphviz DOT digraph or as a Mermaid flowchart
+func Write(w io.Writer, g *Graph, format string) error {
+	switch format {
+	case DOTFormat:
+		return WriteDOT(w, g)
+	case MermaidFormat:
+		return WriteMermaid(w, g)
+	default:
+		return fmt.Errorf("unknown graph format [%s], expected one of %s or %s", format, DOTFormat, MermaidFormat)
+	}
+}
+
+// WriteDOT writes the graph as a Graphviz DOT digraph. Conditional resources are dashed
+// and resources with a health are filled with its color.
+func WriteDOT(w io.Writer, g *Graph) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "digraph %s {\n", dotQuote(g.Kind+"/"+g.Name))
+	b.WriteString("\trankdir=LR;\n")
+	b.WriteString("\tnode [shape=box, style=\"rounded,filled\", fillcolor=\"#ffffff\"];\n")
+
+	for _, resource := range g.Resources {
+		attributes := []string{"label=" + dotQuote(strings.Join(resource.labelLines(), "\n"))}
+
+		style := "rounded,filled"
+		if resource.Conditional {
+			style += ",dashed"
+		}
+		attributes = append(attributes, "style="+dotQuote(style))
+
+		if colors, ok := healthColors[resource.Health]; ok {
+			attributes = append(attributes, "fillcolor="+dotQuote(colors[0]), "color="+dotQuote(colors[1]))
+		}
+
+		fmt.Fprintf(&b, "\t%s [%s];\n", dotQuote(resource.Name), strings.Join(attributes, ", "))
+	}
+
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "\t%s -> %s [label=%s];\n", dotQuote(edge.From), dotQuote(edge.To), dotQuote(edge.Label()))
+	}
+
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteMermaid writes the graph as a Mermaid flowchart. Resources are given the ids r0, r1...
+// as their names may contain characters which Mermaid does not allow in ids.
+func WriteMermaid(w io.Writer, g *Graph) error {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "---\ntitle: %s/%s\n---\n", g.Kind, g.Name)
+	b.WriteString("flowchart LR\n")
+
+	ids := make(map[string]string)
+	for i, resource := range g.Resources {
+		ids[resource.Name] = fmt.Sprintf("r%d", i)
+		fmt.Fprintf(&b, "    %s[\"%s\"]\n", ids[resource.Name], mermaidEscape(strings.Join(resource.labelLines(), "<br/>")))
+	}
+
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&b, "    %s -->|\"%s\"| %s\n", ids[edge.From], mermaidEscape(edge.Label()), ids[edge.To])
+	}
+
+	classes := make(map[string][]string)
+	var classNames []string
+	addClass := func(class, id string) {
+		if _, ok := classes[class]; !ok {
+			classNames = append(classNames, class)
+		}
+		classes[class] = append(classes[class], id)
+	}
+
+	for _, resource := range g.Resources {
+		if resource.Health != "" {
+			addClass(strings.ToLower(resource.Health), ids[resource.Name])
+		}
+	}
+	for _, resource := range g.Resources {
+		if resource.Conditional {
+			addClass("conditional", ids[resource.Name])
+		}
+	}
+
+	for _, class := range classNames {
+		if class == "conditional" {
+			b.WriteString("    classDef conditional stroke-dasharray: 5 5\n")
+			continue
+		}
+		for health, colors := range healthColors {
+			if strings.ToLower(health) == class {
+				fmt.Fprintf(&b, "    classDef %s fill:%s,stroke:%s\n", class, colors[0], colors[1])
+			}
+		}
+	}
+	for _, class := range classNames {
+		fmt.Fprintf(&b, "    class %s %s\n", strings.Join(classes[class], ","), class)
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// labelLines are the name of the resource, its template and its health, if any
+func (r Resource) labelLines() []string {
+	lines := []string{r.Name, r.templateLabel()}
+	if r.Health != "" {
+		lines = append(lines, r.Health)
+	}
+	return lines
+}
+
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
+
+func mermaidEscape(s string) string {
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
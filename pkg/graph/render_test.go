@@ -0,0 +1,90 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/cartographer/pkg/graph"
+)
+
+var _ = Describe("Render", func() {
+	var g *graph.Graph
+
+	BeforeEach(func() {
+		g = &graph.Graph{
+			Kind: "ClusterSupplyChain",
+			Name: "source-to-url",
+			Resources: []graph.Resource{
+				{Name: "source-provider", TemplateKind: "ClusterSourceTemplate", TemplateName: "source", Health: graph.Healthy},
+				{Name: "image-builder", TemplateKind: "ClusterImageTemplate", Options: []string{"kpack", "kaniko"}, Conditional: true},
+			},
+			Edges: []graph.Edge{
+				{From: "source-provider", To: "image-builder", Type: "source", Name: "source"},
+			},
+		}
+	})
+
+	It("writes a DOT digraph", func() {
+		out := &bytes.Buffer{}
+		Expect(graph.Write(out, g, graph.DOTFormat)).To(Succeed())
+
+		Expect(out.String()).To(Equal(`digraph "ClusterSupplyChain/source-to-url" {
+	rankdir=LR;
+	node [shape=box, style="rounded,filled", fillcolor="#ffffff"];
+	"source-provider" [label="source-provider\nClusterSourceTemplate/source\nHealthy", style="rounded,filled", fillcolor="#c8e6c9", color="#2e7d32"];
+	"image-builder" [label="image-builder\nClusterImageTemplate [kpack | kaniko]", style="rounded,filled,dashed"];
+	"source-provider" -> "image-builder" [label="source: source"];
+}
+`))
+	})
+
+	It("writes a Mermaid flowchart", func() {
+		out := &bytes.Buffer{}
+		Expect(graph.Write(out, g, graph.MermaidFormat)).To(Succeed())
+
+		Expect(out.String()).To(Equal(`---
+title: ClusterSupplyChain/source-to-url
+---
+flowchart LR
+    r0["source-provider<br/>ClusterSourceTemplate/source<br/>Healthy"]
+    r1["image-builder<br/>ClusterImageTemplate [kpack | kaniko]"]
+    r0 -->|"source: source"| r1
+    classDef healthy fill:#c8e6c9,stroke:#2e7d32
+    classDef conditional stroke-dasharray: 5 5
+    class r0 healthy
+    class r1 conditional
+`))
+	})
+
+	It("escapes quotes", func() {
+		g.Resources[0].TemplateName = `my"template`
+
+		dot := &bytes.Buffer{}
+		Expect(graph.WriteDOT(dot, g)).To(Succeed())
+		Expect(dot.String()).To(ContainSubstring(`ClusterSourceTemplate/my\"template`))
+
+		mermaid := &bytes.Buffer{}
+		Expect(graph.WriteMermaid(mermaid, g)).To(Succeed())
+		Expect(mermaid.String()).To(ContainSubstring(`ClusterSourceTemplate/my#quot;template`))
+	})
+
+	It("rejects an unknown format", func() {
+		Expect(graph.Write(&bytes.Buffer{}, g, "svg")).To(MatchError("unknown graph format [svg], expected one of dot or mermaid"))
+	})
+})
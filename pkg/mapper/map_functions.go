@@ -67,6 +67,29 @@ func (mapper *Mapper) ClusterSupplyChainToWorkloadRequests(_ context.Context, _
 	return requests
 }
 
+// SupplyChainToWorkloadRequests only enqueues the workloads in the namespace of the supply chain,
+// as those are the only workloads it may select
+func (mapper *Mapper) SupplyChainToWorkloadRequests(_ context.Context, supplyChainObject client.Object) []reconcile.Request {
+	workloadList := &v1alpha1.WorkloadList{}
+	err := mapper.Client.List(context.TODO(), workloadList, client.InNamespace(supplyChainObject.GetNamespace()))
+	if err != nil {
+		mapper.Logger.Error(err, "supply chain to workload requests: client list workloads")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, workload := range workloadList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      workload.Name,
+				Namespace: workload.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
+
 func (mapper *Mapper) ServiceAccountToWorkloadRequests(_ context.Context, serviceAccountObject client.Object) []reconcile.Request {
 	err := mapper.addGVK(serviceAccountObject)
 	if err != nil {
@@ -212,6 +235,29 @@ func (mapper *Mapper) ClusterDeliveryToDeliverableRequests(_ context.Context, _
 	return requests
 }
 
+// DeliveryToDeliverableRequests only enqueues the deliverables in the namespace of the delivery,
+// as those are the only deliverables it may select
+func (mapper *Mapper) DeliveryToDeliverableRequests(_ context.Context, deliveryObject client.Object) []reconcile.Request {
+	deliverableList := &v1alpha1.DeliverableList{}
+	err := mapper.Client.List(context.TODO(), deliverableList, client.InNamespace(deliveryObject.GetNamespace()))
+	if err != nil {
+		mapper.Logger.Error(err, "delivery to deliverable requests: client list deliverables")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, deliverable := range deliverableList.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{
+				Name:      deliverable.Name,
+				Namespace: deliverable.Namespace,
+			},
+		})
+	}
+
+	return requests
+}
+
 func (mapper *Mapper) ServiceAccountToDeliverableRequests(_ context.Context, serviceAccountObject client.Object) []reconcile.Request {
 	err := mapper.addGVK(serviceAccountObject)
 	if err != nil {
@@ -135,6 +135,46 @@ var _ = Describe("MapFunctions", func() {
 		})
 	})
 
+	Describe("SupplyChainToWorkloadRequests", func() {
+		var supplyChain *v1alpha1.SupplyChain
+		BeforeEach(func() {
+			supplyChain = &v1alpha1.SupplyChain{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-supply-chain",
+					Namespace: "first-namespace",
+				},
+			}
+
+			fakeClient.ListStub = func(ctx context.Context, list client.ObjectList, options ...client.ListOption) error {
+				listVal := reflect.Indirect(reflect.ValueOf(list))
+
+				existingVal := reflect.Indirect(reflect.ValueOf(v1alpha1.WorkloadList{Items: []v1alpha1.Workload{{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "first-workload",
+						Namespace: "first-namespace",
+					},
+				}}}))
+				listVal.Set(existingVal)
+				return nil
+			}
+		})
+
+		It("lists the workloads in the namespace of the supply chain", func() {
+			result := m.SupplyChainToWorkloadRequests(context.Background(), supplyChain)
+			Expect(result).To(Equal([]reconcile.Request{
+				{
+					NamespacedName: types.NamespacedName{
+						Namespace: "first-namespace",
+						Name:      "first-workload",
+					},
+				},
+			}))
+
+			_, _, opts := fakeClient.ListArgsForCall(0)
+			Expect(opts).To(ConsistOf(client.InNamespace("first-namespace")))
+		})
+	})
+
 	Describe("ServiceAccountToWorkloadRequests", func() {
 		var serviceAccount *corev1.ServiceAccount
 		BeforeEach(func() {
@@ -0,0 +1,204 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	cerrors "github.com/vmware-tanzu/cartographer/pkg/errors"
+)
+
+const Namespace = "cartographer"
+
+// Label names are those of the carto.run/* labels on stamped objects, with underscores,
+// e.g. carto.run/supply-chain-name is supply_chain_name
+const (
+	NamespaceLabel       = "namespace"
+	SupplyChainNameLabel = "supply_chain_name"
+	DeliveryNameLabel    = "delivery_name"
+	ResourceNameLabel    = "resource_name"
+	TemplateKindLabel    = "template_kind"
+	ErrorTypeLabel       = "error_type"
+	ResultLabel          = "result"
+)
+
+const (
+	SuccessResult = "success"
+	FailureResult = "failure"
+	HitResult     = "hit"
+	MissResult    = "miss"
+)
+
+var resourceLabelNames = []string{SupplyChainNameLabel, DeliveryNameLabel, ResourceNameLabel, TemplateKindLabel}
+
+var (
+	ResourceRealizeDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "resource_realize_duration_seconds",
+		Help:      "How long it took to get, stamp and apply the template of a resource and read its outputs",
+		Buckets:   prometheus.DefBuckets,
+	}, resourceLabelNames)
+
+	StampFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "stamp_failures_total",
+		Help:      "Number of times a resource failed to be realized, by the type of error",
+	}, append(append([]string{}, resourceLabelNames...), ErrorTypeLabel))
+
+	YttDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "ytt_duration_seconds",
+		Help:      "How long ytt took to evaluate a template",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{ResultLabel})
+
+	RepoCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "repo_cache_requests_total",
+		Help:      "Number of times the cache was consulted before creating an immutable object, by hit or miss",
+	}, []string{ResultLabel})
+
+	ResourceTimeToHealthy = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "resource_time_to_healthy_seconds",
+		Help:      "How long a resource took to become healthy, since its Healthy condition last changed",
+		Buckets:   prometheus.ExponentialBuckets(1, 2, 14),
+	}, resourceLabelNames)
+
+	WorkloadsPerSupplyChain = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Name:      "workloads",
+		Help:      "Number of workloads in each namespace selected by each supply chain",
+	}, []string{NamespaceLabel, SupplyChainNameLabel})
+
+	ServiceAccountTokenRefreshes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "service_account_token_refreshes_total",
+		Help:      "Number of service account tokens requested from the TokenRequest API, by success or failure",
+	}, []string{ResultLabel})
+)
+
+func init() {
+	crmetrics.Registry.MustRegister(
+		ResourceRealizeDuration,
+		StampFailures,
+		YttDuration,
+		RepoCacheRequests,
+		ResourceTimeToHealthy,
+		WorkloadsPerSupplyChain,
+		ServiceAccountTokenRefreshes,
+	)
+}
+
+// ResourceLabels labels a metric about a resource of a blueprint. The blueprint is a
+// delivery when the owner is a deliverable, and a supply chain otherwise.
+func ResourceLabels(owner client.Object, blueprintName, resourceName, templateKind string) prometheus.Labels {
+	labels := prometheus.Labels{
+		SupplyChainNameLabel: "",
+		DeliveryNameLabel:    "",
+		ResourceNameLabel:    resourceName,
+		TemplateKindLabel:    templateKind,
+	}
+	if _, ok := owner.(*v1alpha1.Deliverable); ok {
+		labels[DeliveryNameLabel] = blueprintName
+	} else {
+		labels[SupplyChainNameLabel] = blueprintName
+	}
+	return labels
+}
+
+// ObserveRealize records how long realizing a resource took and, when it failed, the type of the error
+func ObserveRealize(labels prometheus.Labels, started time.Time, err error) {
+	ResourceRealizeDuration.With(labels).Observe(time.Since(started).Seconds())
+	if err == nil {
+		return
+	}
+
+	failureLabels := prometheus.Labels{ErrorTypeLabel: ErrorType(err)}
+	for name, value := range labels {
+		failureLabels[name] = value
+	}
+	StampFailures.With(failureLabels).Inc()
+}
+
+// realizeErrorTypes are the errors of pkg/errors with which realizing a resource fails
+var realizeErrorTypes = []reflect.Type{
+	reflect.TypeOf(cerrors.GetTemplateError{}),
+	reflect.TypeOf(cerrors.ResolveTemplateOptionError{}),
+	reflect.TypeOf(cerrors.TemplateOptionsMatchError{}),
+	reflect.TypeOf(cerrors.ResourceConditionError{}),
+	reflect.TypeOf(cerrors.ParamValidationError{}),
+	reflect.TypeOf(cerrors.StampError{}),
+	reflect.TypeOf(cerrors.ApplyStampedObjectError{}),
+	reflect.TypeOf(cerrors.RetrieveOutputError{}),
+}
+
+// ErrorType is the name of the type of the error when it is, or wraps, one of the realize errors of
+// pkg/errors, otherwise "Unknown"
+func ErrorType(err error) string {
+	for _, errType := range realizeErrorTypes {
+		if errors.As(err, reflect.New(errType).Interface()) {
+			return errType.Name()
+		}
+	}
+	return "Unknown"
+}
+
+// Result is SuccessResult when err is nil, otherwise FailureResult
+func Result(err error) string {
+	if err != nil {
+		return FailureResult
+	}
+	return SuccessResult
+}
+
+var workloadSupplyChains = &supplyChainSelections{
+	supplyChains: map[types.NamespacedName]string{},
+}
+
+type supplyChainSelections struct {
+	mtx          sync.Mutex
+	supplyChains map[types.NamespacedName]string
+}
+
+// SetWorkloadSupplyChain counts the workload against the supply chain which selects it, and no longer
+// against the one which selected it before. An empty supplyChainName counts it against none.
+func SetWorkloadSupplyChain(workload types.NamespacedName, supplyChainName string) {
+	workloadSupplyChains.mtx.Lock()
+	defer workloadSupplyChains.mtx.Unlock()
+
+	previous, ok := workloadSupplyChains.supplyChains[workload]
+	if ok && previous == supplyChainName {
+		return
+	}
+	if ok {
+		WorkloadsPerSupplyChain.WithLabelValues(workload.Namespace, previous).Dec()
+		delete(workloadSupplyChains.supplyChains, workload)
+	}
+
+	if supplyChainName != "" {
+		WorkloadsPerSupplyChain.WithLabelValues(workload.Namespace, supplyChainName).Inc()
+		workloadSupplyChains.supplyChains[workload] = supplyChainName
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics_test
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	cerrors "github.com/vmware-tanzu/cartographer/pkg/errors"
+	"github.com/vmware-tanzu/cartographer/pkg/metrics"
+)
+
+var _ = Describe("Metrics", func() {
+	Describe("ResourceLabels", func() {
+		It("labels resources of a workload with the supply chain name", func() {
+			Expect(metrics.ResourceLabels(&v1alpha1.Workload{}, "my-supply-chain", "my-resource", "ClusterImageTemplate")).To(Equal(prometheus.Labels{
+				"supply_chain_name": "my-supply-chain",
+				"delivery_name":     "",
+				"resource_name":     "my-resource",
+				"template_kind":     "ClusterImageTemplate",
+			}))
+		})
+
+		It("labels resources of a deliverable with the delivery name", func() {
+			Expect(metrics.ResourceLabels(&v1alpha1.Deliverable{}, "my-delivery", "my-resource", "ClusterDeploymentTemplate")).To(Equal(prometheus.Labels{
+				"supply_chain_name": "",
+				"delivery_name":     "my-delivery",
+				"resource_name":     "my-resource",
+				"template_kind":     "ClusterDeploymentTemplate",
+			}))
+		})
+	})
+
+	Describe("ErrorType", func() {
+		It("is the name of errors from pkg/errors", func() {
+			Expect(metrics.ErrorType(cerrors.StampError{Err: errors.New("bad")})).To(Equal("StampError"))
+			Expect(metrics.ErrorType(cerrors.ApplyStampedObjectError{Err: errors.New("bad")})).To(Equal("ApplyStampedObjectError"))
+		})
+
+		It("is the name of wrapped errors from pkg/errors", func() {
+			Expect(metrics.ErrorType(cerrors.NewUnhandledError(cerrors.GetTemplateError{Err: errors.New("bad")}))).To(Equal("GetTemplateError"))
+			Expect(metrics.ErrorType(fmt.Errorf("wrapped: %w", cerrors.RetrieveOutputError{Err: errors.New("bad")}))).To(Equal("RetrieveOutputError"))
+		})
+
+		It("is Unknown for other errors", func() {
+			Expect(metrics.ErrorType(fmt.Errorf("wrapped: %w", errors.New("bad")))).To(Equal("Unknown"))
+		})
+	})
+
+	Describe("ObserveRealize", func() {
+		var labels prometheus.Labels
+
+		BeforeEach(func() {
+			labels = metrics.ResourceLabels(&v1alpha1.Workload{}, "observed-supply-chain", "observed-resource", "ClusterTemplate")
+		})
+
+		It("records the duration", func() {
+			metrics.ObserveRealize(labels, time.Now().Add(-time.Second), nil)
+			Expect(testutil.CollectAndCount(metrics.ResourceRealizeDuration)).To(BeNumerically(">=", 1))
+		})
+
+		It("counts failures by error type", func() {
+			failureLabels := prometheus.Labels{"error_type": "ParamValidationError"}
+			for name, value := range labels {
+				failureLabels[name] = value
+			}
+			before := testutil.ToFloat64(metrics.StampFailures.With(failureLabels))
+
+			metrics.ObserveRealize(labels, time.Now(), nil)
+			metrics.ObserveRealize(labels, time.Now(), cerrors.ParamValidationError{Err: errors.New("bad")})
+
+			Expect(testutil.ToFloat64(metrics.StampFailures.With(failureLabels))).To(Equal(before + 1))
+		})
+	})
+
+	Describe("SetWorkloadSupplyChain", func() {
+		var workload, otherWorkload types.NamespacedName
+
+		BeforeEach(func() {
+			workload = types.NamespacedName{Namespace: "my-namespace", Name: "my-workload"}
+			otherWorkload = types.NamespacedName{Namespace: "other-namespace", Name: "my-workload"}
+		})
+
+		AfterEach(func() {
+			metrics.SetWorkloadSupplyChain(workload, "")
+			metrics.SetWorkloadSupplyChain(otherWorkload, "")
+		})
+
+		It("counts the workload against the supply chain which selects it", func() {
+			metrics.SetWorkloadSupplyChain(workload, "first-supply-chain")
+			metrics.SetWorkloadSupplyChain(workload, "first-supply-chain")
+			Expect(testutil.ToFloat64(metrics.WorkloadsPerSupplyChain.WithLabelValues("my-namespace", "first-supply-chain"))).To(Equal(1.0))
+
+			metrics.SetWorkloadSupplyChain(workload, "second-supply-chain")
+			Expect(testutil.ToFloat64(metrics.WorkloadsPerSupplyChain.WithLabelValues("my-namespace", "first-supply-chain"))).To(Equal(0.0))
+			Expect(testutil.ToFloat64(metrics.WorkloadsPerSupplyChain.WithLabelValues("my-namespace", "second-supply-chain"))).To(Equal(1.0))
+
+			metrics.SetWorkloadSupplyChain(workload, "")
+			Expect(testutil.ToFloat64(metrics.WorkloadsPerSupplyChain.WithLabelValues("my-namespace", "second-supply-chain"))).To(Equal(0.0))
+		})
+
+		It("counts workloads of the same name in each namespace", func() {
+			metrics.SetWorkloadSupplyChain(workload, "first-supply-chain")
+			metrics.SetWorkloadSupplyChain(otherWorkload, "first-supply-chain")
+			Expect(testutil.ToFloat64(metrics.WorkloadsPerSupplyChain.WithLabelValues("my-namespace", "first-supply-chain"))).To(Equal(1.0))
+			Expect(testutil.ToFloat64(metrics.WorkloadsPerSupplyChain.WithLabelValues("other-namespace", "first-supply-chain"))).To(Equal(1.0))
+
+			metrics.SetWorkloadSupplyChain(otherWorkload, "")
+			Expect(testutil.ToFloat64(metrics.WorkloadsPerSupplyChain.WithLabelValues("my-namespace", "first-supply-chain"))).To(Equal(1.0))
+			Expect(testutil.ToFloat64(metrics.WorkloadsPerSupplyChain.WithLabelValues("other-namespace", "first-supply-chain"))).To(Equal(0.0))
+		})
+	})
+})
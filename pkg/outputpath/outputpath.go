@@ -0,0 +1,381 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outputpath
+
+//go:generate go run -modfile ../../hack/tools/go.mod github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/cache"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/openapi"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/jsonpath"
+	"k8s.io/kube-openapi/pkg/spec3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+)
+
+const schemaRefPrefix = "#/components/schemas/"
+
+const (
+	// schemaCacheSize is the number of group versions whose schemas are kept
+	schemaCacheSize = 64
+	// schemaCacheTTL is how long the schemas of a group version are kept, after which changes
+	// to the definitions of the group version, such as a new version of a CRD, are read
+	schemaCacheTTL = 5 * time.Minute
+)
+
+// InvalidPath is an output path of a template which can never exist on the object the template stamps
+type InvalidPath struct {
+	Output string
+	Path   string
+	Reason string
+}
+
+func (p InvalidPath) String() string {
+	return fmt.Sprintf("output [%s] path [%s] %s", p.Output, p.Path, p.Reason)
+}
+
+//counterfeiter:generate . Validator
+type Validator interface {
+	// Validate returns the paths, by output name, which can never exist on an object of the apiVersion
+	// and kind. Kinds the cluster does not serve, and fields which their schema leaves open, are not reported.
+	Validate(apiVersion, kind string, paths map[string]string) ([]InvalidPath, error)
+}
+
+type schemaValidator struct {
+	mapper  meta.RESTMapper
+	openAPI openapi.Client
+	schemas *cache.LRUExpireCache
+}
+
+// NewValidator returns a Validator reading the schemas from the OpenAPI v3 client. The schemas of a
+// group version are read once and kept for schemaCacheTTL, rather than read for every validation.
+func NewValidator(mapper meta.RESTMapper, openAPI openapi.Client) Validator {
+	return &schemaValidator{
+		mapper:  mapper,
+		openAPI: openAPI,
+		schemas: cache.NewLRUExpireCache(schemaCacheSize),
+	}
+}
+
+// NewValidatorForConfig returns a Validator reading the OpenAPI v3 schemas served by the cluster
+func NewValidatorForConfig(config *rest.Config, mapper meta.RESTMapper) (Validator, error) {
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery client: %w", err)
+	}
+	return NewValidator(mapper, discoveryClient.OpenAPIV3()), nil
+}
+
+func (v *schemaValidator) Validate(apiVersion, kind string, paths map[string]string) ([]InvalidPath, error) {
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse apiVersion [%s]: %w", apiVersion, err)
+	}
+
+	mapping, err := v.mapper.RESTMapping(schema.GroupKind{Group: gv.Group, Kind: kind}, gv.Version)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get rest mapping for [%s/%s]: %w", apiVersion, kind, err)
+	}
+
+	schemas, root, err := v.getSchema(mapping.GroupVersionKind)
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, nil
+	}
+
+	var names []string
+	for name := range paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var invalidPaths []InvalidPath
+	for _, name := range names {
+		path := paths[name]
+		reason, err := walk(schemas, root, path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse path [%s] of output [%s]: %w", path, name, err)
+		}
+		if reason != "" {
+			invalidPaths = append(invalidPaths, InvalidPath{Output: name, Path: path, Reason: reason})
+		}
+	}
+
+	return invalidPaths, nil
+}
+
+// getSchema returns the schemas of the group version of the kind and the schema of the kind, which is
+// nil when the group version is not served with an OpenAPI v3 schema or does not describe the kind
+func (v *schemaValidator) getSchema(gvk schema.GroupVersionKind) (map[string]*spec.Schema, *spec.Schema, error) {
+	schemas, err := v.getGroupVersionSchemas(gvk.GroupVersion())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, kindSchema := range schemas {
+		if describesKind(kindSchema, gvk) {
+			return schemas, kindSchema, nil
+		}
+	}
+	return schemas, nil, nil
+}
+
+// getGroupVersionSchemas returns the schemas of the group version from the cache, reading them when
+// they are not cached. They are nil when the group version is not served with an OpenAPI v3 schema.
+func (v *schemaValidator) getGroupVersionSchemas(gv schema.GroupVersion) (map[string]*spec.Schema, error) {
+	if cached, ok := v.schemas.Get(gv); ok {
+		return cached.(map[string]*spec.Schema), nil
+	}
+
+	schemas, err := v.readGroupVersionSchemas(gv)
+	if err != nil {
+		return nil, err
+	}
+
+	v.schemas.Add(gv, schemas, schemaCacheTTL)
+	return schemas, nil
+}
+
+func (v *schemaValidator) readGroupVersionSchemas(gv schema.GroupVersion) (map[string]*spec.Schema, error) {
+	openAPIPaths, err := v.openAPI.Paths()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get openapi paths: %w", err)
+	}
+
+	openAPIPath := "apis/" + gv.Group + "/" + gv.Version
+	if gv.Group == "" {
+		openAPIPath = "api/" + gv.Version
+	}
+
+	groupVersion, ok := openAPIPaths[openAPIPath]
+	if !ok {
+		return nil, nil
+	}
+
+	raw, err := groupVersion.Schema(runtime.ContentTypeJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get openapi schema of [%s]: %w", openAPIPath, err)
+	}
+
+	document := &spec3.OpenAPI{}
+	if err := json.Unmarshal(raw, document); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal openapi schema of [%s]: %w", openAPIPath, err)
+	}
+	if document.Components == nil {
+		return nil, nil
+	}
+
+	return document.Components.Schemas, nil
+}
+
+func describesKind(kindSchema *spec.Schema, gvk schema.GroupVersionKind) bool {
+	gvks, ok := kindSchema.Extensions["x-kubernetes-group-version-kind"].([]interface{})
+	if !ok {
+		return false
+	}
+
+	for _, item := range gvks {
+		candidate, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if candidate["group"] == gvk.Group && candidate["version"] == gvk.Version && candidate["kind"] == gvk.Kind {
+			return true
+		}
+	}
+	return false
+}
+
+// walk follows the path through the schema. It returns why the path can never exist, or an empty
+// string when it may, including when the path leaves the part of the object the schema describes.
+func walk(schemas map[string]*spec.Schema, root *spec.Schema, path string) (string, error) {
+	parser, err := jsonpath.Parse("output", wrap(path))
+	if err != nil {
+		return "", err
+	}
+
+	current := resolve(schemas, root)
+	location := ""
+
+	for _, list := range parser.Root.Nodes {
+		listNode, ok := list.(*jsonpath.ListNode)
+		if !ok {
+			continue
+		}
+
+		for _, node := range listNode.Nodes {
+			if current == nil || isOpen(current) {
+				return "", nil
+			}
+
+			switch n := node.(type) {
+			case *jsonpath.FieldNode:
+				if n.Value == "" {
+					continue
+				}
+				next, reason := field(current, n.Value)
+				if reason != "" {
+					return fmt.Sprintf("%s: %s", describeLocation(location), reason), nil
+				}
+				current = resolve(schemas, next)
+				location += "." + n.Value
+			case *jsonpath.ArrayNode, *jsonpath.FilterNode, *jsonpath.WildcardNode:
+				next, reason := element(current)
+				if reason != "" {
+					return fmt.Sprintf("%s: %s", describeLocation(location), reason), nil
+				}
+				current = resolve(schemas, next)
+				location += "[]"
+			default:
+				// recursive descent and unions may match anything
+				return "", nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// wrap wraps a path such as data.url or .data.url as {.data.url}, as the output readers do
+func wrap(path string) string {
+	if !strings.HasPrefix(path, "{.") {
+		if !strings.HasPrefix(path, ".") {
+			path = "{." + path
+		} else {
+			path = "{" + path
+		}
+	}
+	if !strings.HasSuffix(path, "}") {
+		path += "}"
+	}
+	return path
+}
+
+func describeLocation(location string) string {
+	if location == "" {
+		return "the object"
+	}
+	return fmt.Sprintf("field [%s]", strings.TrimPrefix(location, "."))
+}
+
+// resolve follows references, including a reference wrapped in allOf to carry a description or default
+func resolve(schemas map[string]*spec.Schema, s *spec.Schema) *spec.Schema {
+	for s != nil {
+		if ref := s.Ref.String(); ref != "" {
+			s = schemas[strings.TrimPrefix(ref, schemaRefPrefix)]
+			continue
+		}
+		if len(s.AllOf) == 1 && len(s.Properties) == 0 && len(s.Type) == 0 {
+			s = &s.AllOf[0]
+			continue
+		}
+		return s
+	}
+	return nil
+}
+
+// isOpen is true when the schema does not describe the value well enough to rule out any path into it
+func isOpen(s *spec.Schema) bool {
+	if preserve, ok := s.Extensions["x-kubernetes-preserve-unknown-fields"].(bool); ok && preserve {
+		return true
+	}
+	if intOrString, ok := s.Extensions["x-kubernetes-int-or-string"].(bool); ok && intOrString {
+		return false
+	}
+	if len(s.Type) == 0 {
+		return len(s.Properties) == 0 && s.Items == nil && s.AdditionalProperties == nil
+	}
+	return len(s.AnyOf) > 0 || len(s.OneOf) > 0
+}
+
+func field(s *spec.Schema, name string) (*spec.Schema, string) {
+	if isScalar(s) {
+		return nil, fmt.Sprintf("is of type %s, which has no field [%s]", typeName(s), name)
+	}
+	if s.Type.Contains("array") {
+		return nil, fmt.Sprintf("is of type array, which has no field [%s]", name)
+	}
+
+	if property, ok := s.Properties[name]; ok {
+		return &property, ""
+	}
+	if s.AdditionalProperties != nil {
+		if s.AdditionalProperties.Schema != nil {
+			return s.AdditionalProperties.Schema, ""
+		}
+		if s.AdditionalProperties.Allows {
+			return nil, ""
+		}
+	}
+	if len(s.Properties) == 0 && s.AdditionalProperties == nil {
+		return nil, ""
+	}
+
+	return nil, fmt.Sprintf("has no field [%s]", name)
+}
+
+func element(s *spec.Schema) (*spec.Schema, string) {
+	if isScalar(s) {
+		return nil, fmt.Sprintf("is of type %s, which has no elements", typeName(s))
+	}
+	if s.Type.Contains("array") {
+		if s.Items != nil && s.Items.Schema != nil {
+			return s.Items.Schema, ""
+		}
+		return nil, ""
+	}
+	if s.AdditionalProperties != nil && s.AdditionalProperties.Schema != nil {
+		return s.AdditionalProperties.Schema, ""
+	}
+	return nil, ""
+}
+
+func isScalar(s *spec.Schema) bool {
+	if intOrString, ok := s.Extensions["x-kubernetes-int-or-string"].(bool); ok && intOrString {
+		return true
+	}
+	for _, scalarType := range []string{"string", "integer", "number", "boolean"} {
+		if s.Type.Contains(scalarType) {
+			return true
+		}
+	}
+	return false
+}
+
+func typeName(s *spec.Schema) string {
+	if len(s.Type) == 0 {
+		return "int-or-string"
+	}
+	return s.Type[0]
+}
@@ -0,0 +1,132 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outputpath_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/openapi"
+	"k8s.io/client-go/openapi/openapitest"
+
+	"github.com/vmware-tanzu/cartographer/pkg/outputpath"
+)
+
+type failingOpenAPIClient struct{}
+
+func (failingOpenAPIClient) Paths() (map[string]openapi.GroupVersion, error) {
+	return nil, fmt.Errorf("some error")
+}
+
+type countingOpenAPIClient struct {
+	openapi.Client
+	pathsCalls int
+}
+
+func (c *countingOpenAPIClient) Paths() (map[string]openapi.GroupVersion, error) {
+	c.pathsCalls++
+	return c.Client.Paths()
+}
+
+var _ = Describe("Validator", func() {
+	var (
+		mapper    *meta.DefaultRESTMapper
+		validator outputpath.Validator
+	)
+
+	BeforeEach(func() {
+		mapper = meta.NewDefaultRESTMapper(nil)
+		mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}, meta.RESTScopeNamespace)
+		mapper.Add(schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, meta.RESTScopeNamespace)
+		mapper.Add(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, meta.RESTScopeNamespace)
+		mapper.Add(schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}, meta.RESTScopeNamespace)
+
+		validator = outputpath.NewValidator(mapper, openapitest.NewEmbeddedFileClient())
+	})
+
+	It("accepts paths which may exist on the object", func() {
+		invalidPaths, err := validator.Validate("v1", "ConfigMap", map[string]string{
+			"url":      ".data.url",
+			"revision": "{.metadata.labels.revision}",
+			"name":     "metadata.name",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(invalidPaths).To(BeEmpty())
+	})
+
+	It("follows references, arrays and filters", func() {
+		invalidPaths, err := validator.Validate("v1", "Pod", map[string]string{
+			"image":  ".spec.containers[0].image",
+			"ready":  `.status.conditions[?(@.type=="Ready")].status`,
+			"digest": ".status.containerStatuses[*].imageID",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(invalidPaths).To(BeEmpty())
+	})
+
+	It("returns the paths which can never exist, by output name", func() {
+		invalidPaths, err := validator.Validate("apps/v1", "Deployment", map[string]string{
+			"image":    ".spec.template.spec.containers[0].imagee",
+			"replicas": ".spec.replicas.count",
+			"name":     ".metadata.name",
+			"config":   ".data.config",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(invalidPaths).To(Equal([]outputpath.InvalidPath{
+			{Output: "config", Path: ".data.config", Reason: "the object: has no field [data]"},
+			{Output: "image", Path: ".spec.template.spec.containers[0].imagee", Reason: "field [spec.template.spec.containers[]]: has no field [imagee]"},
+			{Output: "replicas", Path: ".spec.replicas.count", Reason: "field [spec.replicas]: is of type integer, which has no field [count]"},
+		}))
+		Expect(invalidPaths[0].String()).To(Equal("output [config] path [.data.config] the object: has no field [data]"))
+	})
+
+	It("does not report paths into a kind the cluster does not serve", func() {
+		invalidPaths, err := validator.Validate("example.com/v2", "Gadget", map[string]string{"url": ".spec.nope"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(invalidPaths).To(BeEmpty())
+	})
+
+	It("does not report paths into a kind without an openapi schema", func() {
+		invalidPaths, err := validator.Validate("example.com/v1", "Widget", map[string]string{"url": ".spec.nope"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(invalidPaths).To(BeEmpty())
+	})
+
+	It("reads the schemas of a group version once", func() {
+		openAPI := &countingOpenAPIClient{Client: openapitest.NewEmbeddedFileClient()}
+		validator = outputpath.NewValidator(mapper, openAPI)
+
+		_, err := validator.Validate("v1", "ConfigMap", map[string]string{"url": ".data.url"})
+		Expect(err).NotTo(HaveOccurred())
+		invalidPaths, err := validator.Validate("v1", "Pod", map[string]string{"url": ".spec.url"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(invalidPaths).To(HaveLen(1))
+		Expect(openAPI.pathsCalls).To(Equal(1))
+
+		_, err = validator.Validate("apps/v1", "Deployment", map[string]string{"url": ".spec.url"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(openAPI.pathsCalls).To(Equal(2))
+	})
+
+	It("returns an error when the schemas cannot be read", func() {
+		validator = outputpath.NewValidator(mapper, failingOpenAPIClient{})
+
+		_, err := validator.Validate("v1", "ConfigMap", map[string]string{"url": ".data.url"})
+		Expect(err).To(MatchError("failed to get openapi paths: some error"))
+	})
+})
@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package outputpathfakes
+
+import (
+	"sync"
+
+	"github.com/vmware-tanzu/cartographer/pkg/outputpath"
+)
+
+type FakeValidator struct {
+	ValidateStub        func(string, string, map[string]string) ([]outputpath.InvalidPath, error)
+	validateMutex       sync.RWMutex
+	validateArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 map[string]string
+	}
+	validateReturns struct {
+		result1 []outputpath.InvalidPath
+		result2 error
+	}
+	validateReturnsOnCall map[int]struct {
+		result1 []outputpath.InvalidPath
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeValidator) Validate(arg1 string, arg2 string, arg3 map[string]string) ([]outputpath.InvalidPath, error) {
+	fake.validateMutex.Lock()
+	ret, specificReturn := fake.validateReturnsOnCall[len(fake.validateArgsForCall)]
+	fake.validateArgsForCall = append(fake.validateArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 map[string]string
+	}{arg1, arg2, arg3})
+	stub := fake.ValidateStub
+	fakeReturns := fake.validateReturns
+	fake.recordInvocation("Validate", []interface{}{arg1, arg2, arg3})
+	fake.validateMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeValidator) ValidateCallCount() int {
+	fake.validateMutex.RLock()
+	defer fake.validateMutex.RUnlock()
+	return len(fake.validateArgsForCall)
+}
+
+func (fake *FakeValidator) ValidateCalls(stub func(string, string, map[string]string) ([]outputpath.InvalidPath, error)) {
+	fake.validateMutex.Lock()
+	defer fake.validateMutex.Unlock()
+	fake.ValidateStub = stub
+}
+
+func (fake *FakeValidator) ValidateArgsForCall(i int) (string, string, map[string]string) {
+	fake.validateMutex.RLock()
+	defer fake.validateMutex.RUnlock()
+	argsForCall := fake.validateArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeValidator) ValidateReturns(result1 []outputpath.InvalidPath, result2 error) {
+	fake.validateMutex.Lock()
+	defer fake.validateMutex.Unlock()
+	fake.ValidateStub = nil
+	fake.validateReturns = struct {
+		result1 []outputpath.InvalidPath
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeValidator) ValidateReturnsOnCall(i int, result1 []outputpath.InvalidPath, result2 error) {
+	fake.validateMutex.Lock()
+	defer fake.validateMutex.Unlock()
+	fake.ValidateStub = nil
+	if fake.validateReturnsOnCall == nil {
+		fake.validateReturnsOnCall = make(map[int]struct {
+			result1 []outputpath.InvalidPath
+			result2 error
+		})
+	}
+	fake.validateReturnsOnCall[i] = struct {
+		result1 []outputpath.InvalidPath
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeValidator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.validateMutex.RLock()
+	defer fake.validateMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeValidator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ outputpath.Validator = new(FakeValidator)
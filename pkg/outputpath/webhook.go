@@ -0,0 +1,103 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outputpath
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+// TemplateValidator validates a template with its own validations, then warns of output
+// paths which can never exist on the object the template stamps. Templates have no status,
+// so an invalid output path is a warning rather than a condition.
+type TemplateValidator struct {
+	OutputPaths Validator
+}
+
+var _ webhook.CustomValidator = &TemplateValidator{}
+
+// SetupTemplateWebhookWithManager registers the validating webhook of the kind of the template,
+// which warns of the output paths the validator finds invalid
+func SetupTemplateWebhookWithManager(mgr ctrl.Manager, template client.Object, validator Validator) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(template).
+		WithValidator(&TemplateValidator{OutputPaths: validator}).
+		Complete()
+}
+
+func (v *TemplateValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	template, ok := obj.(webhook.Validator)
+	if !ok {
+		return nil, fmt.Errorf("expected a template but got a %T", obj)
+	}
+
+	warnings, err := template.ValidateCreate()
+	if err != nil {
+		return warnings, err
+	}
+	return append(warnings, v.outputPathWarnings(obj)...), nil
+}
+
+func (v *TemplateValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	template, ok := newObj.(webhook.Validator)
+	if !ok {
+		return nil, fmt.Errorf("expected a template but got a %T", newObj)
+	}
+
+	warnings, err := template.ValidateUpdate(oldObj)
+	if err != nil {
+		return warnings, err
+	}
+	return append(warnings, v.outputPathWarnings(newObj)...), nil
+}
+
+func (v *TemplateValidator) ValidateDelete(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	template, ok := obj.(webhook.Validator)
+	if !ok {
+		return nil, fmt.Errorf("expected a template but got a %T", obj)
+	}
+	return template.ValidateDelete()
+}
+
+func (v *TemplateValidator) outputPathWarnings(obj runtime.Object) admission.Warnings {
+	template, ok := obj.(client.Object)
+	if !ok || v.OutputPaths == nil {
+		return nil
+	}
+
+	apiVersion, kind, paths := v1alpha1.TemplateOutputPaths(template)
+	if apiVersion == "" || kind == "" {
+		return nil
+	}
+
+	invalidPaths, err := v.OutputPaths.Validate(apiVersion, kind, paths)
+	if err != nil {
+		return admission.Warnings{fmt.Sprintf("unable to validate output paths: %s", err.Error())}
+	}
+
+	var warnings admission.Warnings
+	for _, invalidPath := range invalidPaths {
+		warnings = append(warnings, fmt.Sprintf("%s: %s", v1alpha1.OutputPathInvalidTemplatesReadyReason, invalidPath))
+	}
+	return warnings
+}
@@ -0,0 +1,95 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package outputpath_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/outputpath"
+	"github.com/vmware-tanzu/cartographer/pkg/outputpath/outputpathfakes"
+)
+
+var _ = Describe("TemplateValidator", func() {
+	var (
+		outputPaths *outputpathfakes.FakeValidator
+		validator   *outputpath.TemplateValidator
+		template    *v1alpha1.ClusterConfigTemplate
+	)
+
+	BeforeEach(func() {
+		outputPaths = &outputpathfakes.FakeValidator{}
+		validator = &outputpath.TemplateValidator{OutputPaths: outputPaths}
+
+		template = &v1alpha1.ClusterConfigTemplate{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "some-template",
+			},
+			Spec: v1alpha1.ConfigTemplateSpec{
+				TemplateSpec: v1alpha1.TemplateSpec{
+					Template: &runtime.RawExtension{Raw: []byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "some-name"}}`)},
+				},
+				ConfigPath: ".data.config",
+			},
+		}
+	})
+
+	It("validates the output paths against the stamped kind", func() {
+		_, err := validator.ValidateCreate(context.Background(), template)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(outputPaths.ValidateCallCount()).To(Equal(1))
+		apiVersion, kind, paths := outputPaths.ValidateArgsForCall(0)
+		Expect(apiVersion).To(Equal("v1"))
+		Expect(kind).To(Equal("ConfigMap"))
+		Expect(paths).To(Equal(map[string]string{"config": ".data.config"}))
+	})
+
+	It("warns of output paths which can never exist", func() {
+		outputPaths.ValidateReturns([]outputpath.InvalidPath{{
+			Output: "config",
+			Path:   ".spec.config",
+			Reason: "the object: has no field [spec]",
+		}}, nil)
+
+		warnings, err := validator.ValidateUpdate(context.Background(), template, template)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf(
+			"TemplateOutputPathInvalid: output [config] path [.spec.config] the object: has no field [spec]",
+		))
+	})
+
+	It("warns when the output paths cannot be validated", func() {
+		outputPaths.ValidateReturns(nil, errors.New("openapi is unavailable"))
+
+		warnings, err := validator.ValidateCreate(context.Background(), template)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warnings).To(ConsistOf("unable to validate output paths: openapi is unavailable"))
+	})
+
+	It("rejects a template which fails its own validations without validating its output paths", func() {
+		template.Spec.Ytt = "#@ load(\"@ytt:data\", \"data\")"
+
+		_, err := validator.ValidateCreate(context.Background(), template)
+		Expect(err).To(HaveOccurred())
+		Expect(outputPaths.ValidateCallCount()).To(Equal(0))
+	})
+})
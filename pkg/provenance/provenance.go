@@ -0,0 +1,178 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provenance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+const StatementType = "https://in-toto.io/Statement/v1"
+const PredicateType = "https://slsa.dev/provenance/v1"
+const BuilderID = "https://cartographer.sh/cartographer"
+const BuildType = "https://cartographer.sh/provenance/blueprint/v1"
+
+// Statement is an in-toto attestation statement whose predicate is SLSA provenance
+type Statement struct {
+	Type          string               `json:"_type"`
+	Subject       []ResourceDescriptor `json:"subject"`
+	PredicateType string               `json:"predicateType"`
+	Predicate     Predicate            `json:"predicate"`
+}
+
+type ResourceDescriptor struct {
+	Name   string            `json:"name,omitempty"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+type Predicate struct {
+	BuildDefinition BuildDefinition `json:"buildDefinition"`
+	RunDetails      RunDetails      `json:"runDetails"`
+}
+
+type BuildDefinition struct {
+	BuildType            string               `json:"buildType"`
+	ExternalParameters   ExternalParameters   `json:"externalParameters"`
+	ResolvedDependencies []ResourceDescriptor `json:"resolvedDependencies,omitempty"`
+}
+
+// ExternalParameters identify the owner and the resource of its blueprint which produced the subject
+type ExternalParameters struct {
+	Owner     Reference `json:"owner"`
+	Blueprint string    `json:"blueprint"`
+	Resource  string    `json:"resource"`
+}
+
+type Reference struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+type RunDetails struct {
+	Builder  Builder  `json:"builder"`
+	Metadata Metadata `json:"metadata"`
+}
+
+type Builder struct {
+	ID string `json:"id"`
+}
+
+type Metadata struct {
+	InvocationID string    `json:"invocationId,omitempty"`
+	FinishedOn   time.Time `json:"finishedOn"`
+}
+
+// Lineage follows the inputs of an output back through the resources which produced them. It
+// returns every output the inputs were produced from, directly or transitively, nearest first.
+// An input is only followed when the resource still has an output with the digest of the input.
+func Lineage(resources []v1alpha1.RealizedResource, inputs []v1alpha1.OutputInput) []v1alpha1.OutputInput {
+	resourcesByName := make(map[string]v1alpha1.RealizedResource)
+	for _, resource := range resources {
+		resourcesByName[resource.Name] = resource
+	}
+
+	var lineage []v1alpha1.OutputInput
+	visited := make(map[v1alpha1.OutputInput]bool)
+
+	queue := append([]v1alpha1.OutputInput{}, inputs...)
+	for len(queue) > 0 {
+		input := queue[0]
+		queue = queue[1:]
+
+		if visited[input] {
+			continue
+		}
+		visited[input] = true
+		lineage = append(lineage, input)
+
+		for _, output := range resourcesByName[input.Resource].Outputs {
+			if output.Name == input.Name && output.Digest == input.Digest {
+				queue = append(queue, output.Inputs...)
+			}
+		}
+	}
+
+	return lineage
+}
+
+// ImageSubject describes an image pinned by digest, e.g. registry.example/app@sha256:abc...
+// It returns false when the image is not pinned by a sha256 digest.
+func ImageSubject(image string) (ResourceDescriptor, bool) {
+	name, digest, found := strings.Cut(image, "@")
+	if !found {
+		return ResourceDescriptor{}, false
+	}
+
+	algorithm, value, found := strings.Cut(digest, ":")
+	if !found || algorithm != "sha256" || value == "" {
+		return ResourceDescriptor{}, false
+	}
+
+	return ResourceDescriptor{
+		Name:   name,
+		Digest: map[string]string{algorithm: value},
+	}, true
+}
+
+// NewImageStatement builds the provenance of an image stamped for the owner, which is the controller of the
+// stamped object, by a resource of its blueprint. lineage is the Lineage of the image output; each entry
+// becomes a resolved dependency named <resource>/<output>.
+func NewImageStatement(owner metav1.OwnerReference, namespace string, blueprintName string, resourceName string, image ResourceDescriptor, lineage []v1alpha1.OutputInput, finishedOn time.Time) Statement {
+	var dependencies []ResourceDescriptor
+	for _, input := range lineage {
+		algorithm, value, found := strings.Cut(input.Digest, ":")
+		if !found {
+			algorithm, value = "sha256", input.Digest
+		}
+		dependencies = append(dependencies, ResourceDescriptor{
+			Name:   fmt.Sprintf("%s/%s", input.Resource, input.Name),
+			Digest: map[string]string{algorithm: value},
+		})
+	}
+
+	return Statement{
+		Type:          StatementType,
+		Subject:       []ResourceDescriptor{image},
+		PredicateType: PredicateType,
+		Predicate: Predicate{
+			BuildDefinition: BuildDefinition{
+				BuildType: BuildType,
+				ExternalParameters: ExternalParameters{
+					Owner: Reference{
+						Kind:      owner.Kind,
+						Namespace: namespace,
+						Name:      owner.Name,
+					},
+					Blueprint: blueprintName,
+					Resource:  resourceName,
+				},
+				ResolvedDependencies: dependencies,
+			},
+			RunDetails: RunDetails{
+				Builder: Builder{ID: BuilderID},
+				Metadata: Metadata{
+					InvocationID: string(owner.UID),
+					FinishedOn:   finishedOn.UTC(),
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provenance_test
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/provenance"
+)
+
+var _ = Describe("Provenance", func() {
+	Describe("Lineage", func() {
+		var resources []v1alpha1.RealizedResource
+
+		BeforeEach(func() {
+			resources = []v1alpha1.RealizedResource{
+				{
+					Name: "source-provider",
+					Outputs: []v1alpha1.Output{
+						{Name: "url", Digest: "sha256:url"},
+						{Name: "revision", Digest: "sha256:revision"},
+					},
+				},
+				{
+					Name: "source-tester",
+					Outputs: []v1alpha1.Output{
+						{Name: "url", Digest: "sha256:url", Inputs: []v1alpha1.OutputInput{
+							{Resource: "source-provider", Name: "url", Digest: "sha256:url"},
+							{Resource: "source-provider", Name: "revision", Digest: "sha256:revision"},
+						}},
+						{Name: "revision", Digest: "sha256:revision", Inputs: []v1alpha1.OutputInput{
+							{Resource: "source-provider", Name: "url", Digest: "sha256:url"},
+							{Resource: "source-provider", Name: "revision", Digest: "sha256:revision"},
+						}},
+					},
+				},
+			}
+		})
+
+		It("follows inputs transitively, nearest first and without repeats", func() {
+			lineage := provenance.Lineage(resources, []v1alpha1.OutputInput{
+				{Resource: "source-tester", Name: "url", Digest: "sha256:url"},
+				{Resource: "source-tester", Name: "revision", Digest: "sha256:revision"},
+			})
+
+			Expect(lineage).To(Equal([]v1alpha1.OutputInput{
+				{Resource: "source-tester", Name: "url", Digest: "sha256:url"},
+				{Resource: "source-tester", Name: "revision", Digest: "sha256:revision"},
+				{Resource: "source-provider", Name: "url", Digest: "sha256:url"},
+				{Resource: "source-provider", Name: "revision", Digest: "sha256:revision"},
+			}))
+		})
+
+		It("does not follow an input whose resource has since produced a different output", func() {
+			lineage := provenance.Lineage(resources, []v1alpha1.OutputInput{
+				{Resource: "source-tester", Name: "revision", Digest: "sha256:older-revision"},
+			})
+
+			Expect(lineage).To(Equal([]v1alpha1.OutputInput{
+				{Resource: "source-tester", Name: "revision", Digest: "sha256:older-revision"},
+			}))
+		})
+	})
+
+	Describe("ImageSubject", func() {
+		It("describes an image pinned by digest", func() {
+			subject, ok := provenance.ImageSubject("registry.example/app@sha256:abc123")
+			Expect(ok).To(BeTrue())
+			Expect(subject).To(Equal(provenance.ResourceDescriptor{
+				Name:   "registry.example/app",
+				Digest: map[string]string{"sha256": "abc123"},
+			}))
+		})
+
+		It("rejects an image which is not pinned by a sha256 digest", func() {
+			_, ok := provenance.ImageSubject("registry.example/app:latest")
+			Expect(ok).To(BeFalse())
+
+			_, ok = provenance.ImageSubject("registry.example/app@md5:abc123")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("NewImageStatement", func() {
+		It("builds an in-toto statement with slsa provenance", func() {
+			finishedOn := time.Date(2022, 2, 16, 3, 23, 37, 0, time.UTC)
+			statement := provenance.NewImageStatement(
+				metav1.OwnerReference{Kind: "Workload", Name: "my-workload", UID: "my-uid"},
+				"my-ns",
+				"my-supply-chain",
+				"image-builder",
+				provenance.ResourceDescriptor{Name: "registry.example/app", Digest: map[string]string{"sha256": "abc123"}},
+				[]v1alpha1.OutputInput{{Resource: "source-provider", Name: "revision", Digest: "sha256:revision"}},
+				finishedOn,
+			)
+
+			statementJSON, err := json.Marshal(statement)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statementJSON).To(MatchJSON(`{
+				"_type": "https://in-toto.io/Statement/v1",
+				"subject": [{"name": "registry.example/app", "digest": {"sha256": "abc123"}}],
+				"predicateType": "https://slsa.dev/provenance/v1",
+				"predicate": {
+					"buildDefinition": {
+						"buildType": "https://cartographer.sh/provenance/blueprint/v1",
+						"externalParameters": {
+							"owner": {"kind": "Workload", "namespace": "my-ns", "name": "my-workload"},
+							"blueprint": "my-supply-chain",
+							"resource": "image-builder"
+						},
+						"resolvedDependencies": [{"name": "source-provider/revision", "digest": {"sha256": "revision"}}]
+					},
+					"runDetails": {
+						"builder": {"id": "https://cartographer.sh/cartographer"},
+						"metadata": {"invocationId": "my-uid", "finishedOn": "2022-02-16T03:23:37Z"}
+					}
+				}
+			}`))
+		})
+	})
+})
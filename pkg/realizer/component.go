@@ -16,7 +16,9 @@ package realizer
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -26,6 +28,7 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/errors"
 	"github.com/vmware-tanzu/cartographer/pkg/logger"
+	"github.com/vmware-tanzu/cartographer/pkg/metrics"
 	realizerclient "github.com/vmware-tanzu/cartographer/pkg/realizer/client"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/healthcheck"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/runnable/gc"
@@ -33,6 +36,7 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/selector"
 	"github.com/vmware-tanzu/cartographer/pkg/stamp"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
+	"github.com/vmware-tanzu/cartographer/pkg/tracing"
 	"github.com/vmware-tanzu/cartographer/pkg/utils"
 )
 
@@ -40,6 +44,7 @@ import (
 
 type ContextGenerator interface {
 	Generate(templateParams TemplateParams, resource OwnerResource, outputs OutputsGetter, labels templates.Labels) map[string]interface{}
+	ValidateParams(templateParams TemplateParams, resource OwnerResource) error
 }
 
 type resourceRealizer struct {
@@ -74,7 +79,46 @@ func NewResourceRealizerBuilder(repositoryBuilder repository.RepositoryBuilder,
 	}
 }
 
+type DryRunResourceRealizerBuilder func(authToken string, owner client.Object, templatingContext ContextGenerator, systemRepo repository.Repository, resourceLabeler ResourceLabeler) (ResourceRealizer, *repository.RecordingRepository, error)
+
+// NewDryRunResourceRealizerBuilder builds resource realizers that stamp objects exactly as NewResourceRealizerBuilder's
+// do, but record them in the returned RecordingRepository rather than submitting them to the cluster.
+func NewDryRunResourceRealizerBuilder(repositoryBuilder repository.RepositoryBuilder, clientBuilder realizerclient.ClientBuilder, cache repository.RepoCache) DryRunResourceRealizerBuilder {
+	return func(authToken string, owner client.Object, templatingContext ContextGenerator, systemRepo repository.Repository, resourceLabeler ResourceLabeler) (ResourceRealizer, *repository.RecordingRepository, error) {
+		ownerClient, _, err := clientBuilder(authToken, false)
+		if err != nil {
+			return nil, nil, fmt.Errorf("can't build client: %w", err)
+		}
+
+		ownerRepo := repository.NewRecordingRepository(repositoryBuilder(ownerClient, cache))
+
+		return &resourceRealizer{
+			owner:             owner,
+			systemRepo:        systemRepo,
+			ownerRepo:         ownerRepo,
+			templatingContext: templatingContext,
+			resourceLabeler:   resourceLabeler,
+		}, ownerRepo, nil
+	}
+}
+
 func (r *resourceRealizer) Do(ctx context.Context, resource OwnerResource, blueprintName string, outputs Outputs, mapper meta.RESTMapper) (templates.Reader, *unstructured.Unstructured, *templates.Output, bool, string, error) {
+	attributes := append(tracing.OwnerAttributes(r.owner, blueprintName),
+		tracing.ResourceNameKey.String(resource.Name),
+		tracing.TemplateKindKey.String(resource.TemplateRef.Kind),
+	)
+	ctx, span := tracing.Start(ctx, "ResourceRealizer.Do", attributes...)
+
+	started := time.Now()
+	template, stampedObject, output, passThrough, templateName, err := r.do(ctx, resource, blueprintName, outputs, mapper)
+	metrics.ObserveRealize(metrics.ResourceLabels(r.owner, blueprintName, resource.Name, resource.TemplateRef.Kind), started, err)
+
+	span.SetAttributes(tracing.TemplateNameKey.String(templateName))
+	tracing.End(span, err)
+	return template, stampedObject, output, passThrough, templateName, err
+}
+
+func (r *resourceRealizer) do(ctx context.Context, resource OwnerResource, blueprintName string, outputs Outputs, mapper meta.RESTMapper) (templates.Reader, *unstructured.Unstructured, *templates.Output, bool, string, error) {
 	log := logr.FromContextOrDiscard(ctx).WithValues("template", resource.TemplateRef)
 	ctx = logr.NewContext(ctx, log)
 
@@ -102,7 +146,7 @@ func (r *resourceRealizer) Do(ctx context.Context, resource OwnerResource, bluep
 
 	log.V(logger.DEBUG).Info("realizing template", "template", fmt.Sprintf("[%s/%s]", resource.TemplateRef.Kind, templateName))
 
-	apiTemplate, err = r.systemRepo.GetTemplate(ctx, templateName, resource.TemplateRef.Kind)
+	apiTemplate, err = r.systemRepo.GetTemplate(ctx, templateName, resource.TemplateRef.Kind, resource.TemplateNamespace)
 	if err != nil {
 		log.Error(err, "failed to get cluster template")
 		return nil, nil, nil, passThrough, templateName, errors.GetTemplateError{
@@ -120,6 +164,18 @@ func (r *resourceRealizer) Do(ctx context.Context, resource OwnerResource, bluep
 		return nil, nil, nil, passThrough, templateName, fmt.Errorf("failed to get cluster template [%+v]: %w", resource.TemplateRef, err)
 	}
 
+	if err = r.templatingContext.ValidateParams(template, resource); err != nil {
+		log.Error(err, "failed to validate params")
+		return template, nil, nil, passThrough, templateName, errors.ParamValidationError{
+			Err:           err,
+			ResourceName:  resource.Name,
+			TemplateName:  templateName,
+			TemplateKind:  resource.TemplateRef.Kind,
+			BlueprintName: blueprintName,
+			BlueprintType: errors.SupplyChain,
+		}
+	}
+
 	labels := r.resourceLabeler(resource, template)
 
 	stamper := templates.StamperBuilder(r.owner, r.templatingContext.Generate(template, resource, outputs, labels), labels)
@@ -150,6 +206,117 @@ func (r *resourceRealizer) Do(ctx context.Context, resource OwnerResource, bluep
 	}
 }
 
+// IsSkipped evaluates the when condition of the resource. A resource without a when condition is never skipped.
+func (r *resourceRealizer) IsSkipped(ctx context.Context, resource OwnerResource, blueprintName string, outputs Outputs) (bool, error) {
+	if resource.When == nil {
+		return false, nil
+	}
+
+	met, err := r.isConditionMet(resource.When, resource, outputs)
+	if err != nil {
+		logr.FromContextOrDiscard(ctx).Error(err, "failed to evaluate when condition")
+		return false, errors.ResourceConditionError{
+			Err:           err,
+			ResourceName:  resource.Name,
+			BlueprintName: blueprintName,
+			BlueprintType: errors.SupplyChain,
+		}
+	}
+
+	return !met, nil
+}
+
+func (r *resourceRealizer) isConditionMet(condition *v1alpha1.ResourceCondition, resource OwnerResource, outputs Outputs) (bool, error) {
+	if !condition.IsSelectorEmpty() {
+		matchingIndices, err := selector.BestSelectorMatchIndices(r.owner, []v1alpha1.Selector{condition.Selector})
+		if err != nil {
+			return false, err
+		}
+		if len(matchingIndices) == 0 {
+			return false, nil
+		}
+	}
+
+	if condition.Expression == "" {
+		return true, nil
+	}
+
+	program, err := healthcheck.CompileCELResourceCondition(condition.Expression)
+	if err != nil {
+		return false, err
+	}
+
+	// round trip the templating context through json so that cel sees plain maps and lists
+	contextBytes, err := json.Marshal(r.templatingContext.Generate(nil, resource, outputs, nil))
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal templating context: %w", err)
+	}
+	variables := map[string]interface{}{}
+	if err = json.Unmarshal(contextBytes, &variables); err != nil {
+		return false, fmt.Errorf("failed to unmarshal templating context: %w", err)
+	}
+
+	result, _, err := program.Eval(variables)
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate expression [%s]: %w", condition.Expression, err)
+	}
+
+	met, ok := result.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression [%s] must evaluate to a bool, found [%v]", condition.Expression, result.Value())
+	}
+
+	return met, nil
+}
+
+// skippedResourceOutput passes through the input of a skipped resource which has the same type as its output.
+// A skipped resource with no such input, or more than one, has no output.
+func skippedResourceOutput(resource OwnerResource, outputs Outputs) *templates.Output {
+	var inputName string
+	switch v1alpha1.ClusterScopedTemplateKind(resource.TemplateRef.Kind) {
+	case "ClusterSourceTemplate":
+		if len(resource.Sources) != 1 {
+			return nil
+		}
+		inputName = resource.Sources[0].Name
+	case "ClusterImageTemplate":
+		if len(resource.Images) != 1 {
+			return nil
+		}
+		inputName = resource.Images[0].Name
+	case "ClusterConfigTemplate":
+		if len(resource.Configs) != 1 {
+			return nil
+		}
+		inputName = resource.Configs[0].Name
+	case "ClusterDeploymentTemplate":
+		deployment := NewInputGenerator(resource, outputs).GetDeployment()
+		if deployment == nil {
+			return nil
+		}
+		return &templates.Output{
+			Source: &templates.Source{
+				URL:      deployment.URL,
+				Revision: deployment.Revision,
+			},
+		}
+	default:
+		return nil
+	}
+
+	stampReader, err := stamp.NewPassThroughReader(resource.TemplateRef.Kind, inputName, NewInputGenerator(resource, outputs))
+	if err != nil {
+		return nil
+	}
+
+	output, err := stampReader.Output(nil)
+	if err != nil {
+		return nil
+	}
+
+	return output
+}
+
 func GetTemplateNameFromResource(resource OwnerResource, blueprintName string, owner client.Object) (string, bool, v1alpha1.TemplateOption, error) {
 	var (
 		templateName   string
@@ -259,7 +426,7 @@ func (r *resourceRealizer) doMutable(ctx context.Context, resource OwnerResource
 	templateName string, stampReader stamp.Outputter, mapper meta.RESTMapper,
 	templateOption v1alpha1.TemplateOption) (templates.Reader, *unstructured.Unstructured, *templates.Output, bool, string, error) {
 
-	err := r.ownerRepo.EnsureMutableObjectExistsOnCluster(ctx, stampedObject)
+	err := r.ownerRepo.EnsureMutableObjectExistsOnCluster(ctx, stampedObject, template.GetResourceTemplate().ForceApply)
 	if err != nil {
 		log.Error(err, "failed to ensure object exists on cluster", "object", stampedObject)
 		return template, nil, nil, passThrough, templateName, errors.ApplyStampedObjectError{
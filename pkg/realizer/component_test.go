@@ -27,6 +27,7 @@ import (
 	. "github.com/onsi/gomega/gbytes"
 	"github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -223,7 +224,8 @@ var _ = Describe("Resource", func() {
 
 					Expect(fakeOwnerRepo.EnsureMutableObjectExistsOnClusterCallCount()).To(Equal(1))
 
-					_, stampedObject := fakeOwnerRepo.EnsureMutableObjectExistsOnClusterArgsForCall(0)
+					_, stampedObject, force := fakeOwnerRepo.EnsureMutableObjectExistsOnClusterArgsForCall(0)
+					Expect(force).To(BeFalse())
 
 					Expect(returnedStampedObject).To(Equal(stampedObject))
 
@@ -247,6 +249,21 @@ var _ = Describe("Resource", func() {
 					Expect(out.Source.Revision).To(Equal("some-revision"))
 					Expect(out.Source.URL).To(Equal("some-url"))
 				})
+
+				When("the template forces apply", func() {
+					BeforeEach(func() {
+						templateAPI.Spec.TemplateSpec.ForceApply = true
+					})
+
+					It("applies the stamped object with force", func() {
+						_, _, _, _, _, err := r.Do(ctx, resource, blueprintName, outputs, fakeMapper)
+						Expect(err).ToNot(HaveOccurred())
+
+						Expect(fakeOwnerRepo.EnsureMutableObjectExistsOnClusterCallCount()).To(Equal(1))
+						_, _, force := fakeOwnerRepo.EnsureMutableObjectExistsOnClusterArgsForCall(0)
+						Expect(force).To(BeTrue())
+					})
+				})
 			})
 
 			When("template is immutable", func() {
@@ -523,6 +540,47 @@ var _ = Describe("Resource", func() {
 			})
 		})
 
+		When("the params do not satisfy the template", func() {
+			BeforeEach(func() {
+				templateAPI := &v1alpha1.ClusterImageTemplate{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ClusterImageTemplate",
+						APIVersion: "carto.run/v1alpha1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "image-template-1",
+						Namespace: "some-namespace",
+					},
+					Spec: v1alpha1.ImageTemplateSpec{
+						TemplateSpec: v1alpha1.TemplateSpec{
+							Template: &runtime.RawExtension{},
+							Params: v1alpha1.TemplateParams{
+								{
+									Name:     "replicas",
+									Required: true,
+									Schema:   &apiextensionsv1.JSON{Raw: []byte(`{"type":"integer"}`)},
+								},
+							},
+						},
+					},
+				}
+
+				fakeSystemRepo.GetTemplateReturns(templateAPI, nil)
+			})
+
+			It("returns ParamValidationError without stamping", func() {
+				template, stampedObject, _, isPassThrough, templateRefName, err := r.Do(ctx, resource, blueprintName, outputs, fakeMapper)
+				Expect(template).ToNot(BeNil())
+				Expect(stampedObject).To(BeNil())
+				Expect(isPassThrough).To(BeFalse())
+				Expect(templateRefName).To(Equal("image-template-1"))
+
+				Expect(err).To(MatchError("invalid params for resource [resource-1] for template [ClusterImageTemplate/image-template-1] in supply chain [supply-chain-name]: param [replicas] is required"))
+				Expect(reflect.TypeOf(err).String()).To(Equal("errors.ParamValidationError"))
+				Expect(fakeOwnerRepo.EnsureMutableObjectExistsOnClusterCallCount()).To(Equal(0))
+			})
+		})
+
 		When("unable to retrieve the output from the stamped object", func() {
 			BeforeEach(func() {
 				configMap := &corev1.ConfigMap{
@@ -902,7 +960,7 @@ var _ = Describe("Resource", func() {
 						Expect(err).NotTo(HaveOccurred())
 						Expect(templateRefName).To(Equal("template-chosen"))
 
-						_, name, kind := fakeSystemRepo.GetTemplateArgsForCall(0)
+						_, name, kind, _ := fakeSystemRepo.GetTemplateArgsForCall(0)
 						Expect(name).To(Equal("template-chosen"))
 						Expect(kind).To(Equal("ClusterImageTemplate"))
 					})
@@ -947,7 +1005,7 @@ var _ = Describe("Resource", func() {
 						Expect(templateRefName).To(Equal("template-chosen"))
 
 						Expect(err).NotTo(HaveOccurred())
-						_, name, kind := fakeSystemRepo.GetTemplateArgsForCall(0)
+						_, name, kind, _ := fakeSystemRepo.GetTemplateArgsForCall(0)
 						Expect(name).To(Equal("template-chosen"))
 						Expect(kind).To(Equal("ClusterImageTemplate"))
 					})
@@ -983,7 +1041,7 @@ var _ = Describe("Resource", func() {
 						Expect(templateRefName).To(Equal("template-chosen"))
 
 						Expect(err).NotTo(HaveOccurred())
-						_, name, kind := fakeSystemRepo.GetTemplateArgsForCall(0)
+						_, name, kind, _ := fakeSystemRepo.GetTemplateArgsForCall(0)
 						Expect(name).To(Equal("template-chosen"))
 						Expect(kind).To(Equal("ClusterImageTemplate"))
 					})
@@ -991,4 +1049,285 @@ var _ = Describe("Resource", func() {
 			})
 		})
 	})
+
+	Describe("IsSkipped", func() {
+		BeforeEach(func() {
+			workload.Labels = map[string]string{"scanning": "enabled"}
+			workload.Spec.ServiceAccountName = "some-service-account"
+			resource.Images = []v1alpha1.ResourceReference{
+				{
+					Name:     "built-image",
+					Resource: "image-builder",
+				},
+			}
+			outputs.AddOutput("image-builder", &templates.Output{Image: "some-registry/some-image"})
+		})
+
+		It("does not skip a resource without a when condition", func() {
+			skipped, err := r.IsSkipped(ctx, resource, blueprintName, outputs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(skipped).To(BeFalse())
+		})
+
+		Context("the when condition has a selector", func() {
+			BeforeEach(func() {
+				resource.When = &v1alpha1.ResourceCondition{
+					Selector: v1alpha1.Selector{
+						LabelSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{"scanning": "enabled"},
+						},
+					},
+				}
+			})
+
+			It("does not skip the resource when the workload matches", func() {
+				skipped, err := r.IsSkipped(ctx, resource, blueprintName, outputs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(skipped).To(BeFalse())
+			})
+
+			It("skips the resource when the workload does not match", func() {
+				workload.Labels["scanning"] = "disabled"
+				skipped, err := r.IsSkipped(ctx, resource, blueprintName, outputs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(skipped).To(BeTrue())
+			})
+
+			It("skips the resource when the workload matches but the expression is false", func() {
+				resource.When.Expression = "workload.spec.serviceAccountName == 'another-service-account'"
+				skipped, err := r.IsSkipped(ctx, resource, blueprintName, outputs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(skipped).To(BeTrue())
+			})
+		})
+
+		Context("the when condition has an expression", func() {
+			It("evaluates the expression over the workload", func() {
+				resource.When = &v1alpha1.ResourceCondition{
+					Expression: "workload.spec.serviceAccountName == 'some-service-account'",
+				}
+				skipped, err := r.IsSkipped(ctx, resource, blueprintName, outputs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(skipped).To(BeFalse())
+			})
+
+			It("evaluates the expression over the inputs of the resource", func() {
+				resource.When = &v1alpha1.ResourceCondition{
+					Expression: "image.startsWith('other-registry/')",
+				}
+				skipped, err := r.IsSkipped(ctx, resource, blueprintName, outputs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(skipped).To(BeTrue())
+			})
+
+			It("evaluates the expression over the params of the resource", func() {
+				resource.Params = []v1alpha1.BlueprintParam{
+					{
+						Name:         "scanning",
+						DefaultValue: &apiextensionsv1.JSON{Raw: []byte(`"enabled"`)},
+					},
+				}
+				resource.When = &v1alpha1.ResourceCondition{
+					Expression: "params.scanning == 'enabled'",
+				}
+				skipped, err := r.IsSkipped(ctx, resource, blueprintName, outputs)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(skipped).To(BeFalse())
+			})
+
+			It("returns a ResourceConditionError when the expression cannot be evaluated", func() {
+				resource.When = &v1alpha1.ResourceCondition{
+					Expression: "params.scanning == 'enabled'",
+				}
+				_, err := r.IsSkipped(ctx, resource, blueprintName, outputs)
+				Expect(err).To(HaveOccurred())
+				Expect(err).To(BeAssignableToTypeOf(cerrors.ResourceConditionError{}))
+				Expect(err.Error()).To(HavePrefix("error evaluating when condition for resource [resource-1] in supply chain [supply-chain-name]: failed to evaluate expression [params.scanning == 'enabled']: no such key: scanning"))
+			})
+
+			It("returns a ResourceConditionError when evaluating the expression exceeds the cost limit", func() {
+				items := make([]int, 2000)
+				for i := range items {
+					items[i] = i
+				}
+				itemsJSON, err := json.Marshal(items)
+				Expect(err).NotTo(HaveOccurred())
+				resource.Params = []v1alpha1.BlueprintParam{
+					{
+						Name:         "items",
+						DefaultValue: &apiextensionsv1.JSON{Raw: itemsJSON},
+					},
+				}
+				resource.When = &v1alpha1.ResourceCondition{
+					Expression: "params.items.all(x, params.items.exists(y, y == x))",
+				}
+				_, err = r.IsSkipped(ctx, resource, blueprintName, outputs)
+				Expect(err).To(BeAssignableToTypeOf(cerrors.ResourceConditionError{}))
+				Expect(err).To(MatchError(ContainSubstring("cost limit exceeded")))
+			})
+
+			It("returns a ResourceConditionError when the expression does not evaluate to a bool", func() {
+				resource.When = &v1alpha1.ResourceCondition{
+					Expression: "workload.spec.serviceAccountName",
+				}
+				_, err := r.IsSkipped(ctx, resource, blueprintName, outputs)
+				Expect(err).To(MatchError("error evaluating when condition for resource [resource-1] in supply chain [supply-chain-name]: expression [workload.spec.serviceAccountName] must evaluate to a bool, found [some-service-account]"))
+			})
+		})
+	})
+
+	Describe("dry run", func() {
+		var (
+			dryRunRealizer realizer.ResourceRealizer
+			recorder       *repository.RecordingRepository
+		)
+
+		BeforeEach(func() {
+			var err error
+
+			repositoryBuilder := func(client client.Client, repoCache repository.RepoCache) repository.Repository {
+				return &fakeOwnerRepo
+			}
+			clientBuilder := func(authToken string, _ bool) (client.Client, discovery.DiscoveryInterface, error) {
+				return &repositoryfakes.FakeClient{}, nil, nil
+			}
+			placeholderLabeler := func(resource realizer.OwnerResource, reader templates.Reader) templates.Labels {
+				return templates.Labels{"carto.run/resource-name": resource.Name}
+			}
+
+			dryRunRealizerBuilder := realizer.NewDryRunResourceRealizerBuilder(repositoryBuilder, clientBuilder, repoCache)
+			dryRunRealizer, recorder, err = dryRunRealizerBuilder(theAuthToken, &workload, realizer.NewContextGenerator(&workload, []v1alpha1.OwnerParam{}, supplyChainParams), &fakeSystemRepo, placeholderLabeler)
+			Expect(err).NotTo(HaveOccurred())
+
+			configMap := &corev1.ConfigMap{
+				TypeMeta: metav1.TypeMeta{
+					Kind:       "ConfigMap",
+					APIVersion: "v1",
+				},
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "example-config-map",
+				},
+				Data: map[string]string{
+					"config": "some-config",
+				},
+			}
+			dbytes, err := json.Marshal(configMap)
+			Expect(err).ToNot(HaveOccurred())
+
+			fakeSystemRepo.GetTemplateReturns(&v1alpha1.ClusterConfigTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "config-template-1"},
+				Spec: v1alpha1.ConfigTemplateSpec{
+					TemplateSpec: v1alpha1.TemplateSpec{
+						Template: &runtime.RawExtension{Raw: dbytes},
+					},
+					ConfigPath: "data.config",
+				},
+			}, nil)
+		})
+
+		It("records the stamped object instead of submitting it", func() {
+			_, stampedObject, out, _, _, err := dryRunRealizer.Do(ctx, resource, blueprintName, outputs, fakeMapper)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeOwnerRepo.EnsureMutableObjectExistsOnClusterCallCount()).To(Equal(0))
+			Expect(recorder.Recorded()).To(Equal([]*unstructured.Unstructured{stampedObject}))
+			Expect(stampedObject.GetLabels()).To(Equal(map[string]string{"carto.run/resource-name": "resource-1"}))
+			Expect(out.Config).To(Equal("some-config"))
+		})
+
+		Context("a chain whose first resource outputs from the status of its object", func() {
+			var sourceResource, imageResource realizer.OwnerResource
+
+			BeforeEach(func() {
+				sourceResource = realizer.OwnerResource{
+					Name: "source-provider",
+					TemplateRef: v1alpha1.TemplateReference{
+						Kind: "ClusterSourceTemplate",
+						Name: "source-template",
+					},
+				}
+				imageResource = realizer.OwnerResource{
+					Name: "image-builder",
+					TemplateRef: v1alpha1.TemplateReference{
+						Kind: "ClusterImageTemplate",
+						Name: "image-template",
+					},
+					Sources: []v1alpha1.ResourceReference{{Name: "source", Resource: "source-provider"}},
+				}
+
+				fakeSystemRepo.GetTemplateStub = func(_ context.Context, name, _, _ string) (client.Object, error) {
+					if name == "source-template" {
+						return &v1alpha1.ClusterSourceTemplate{
+							ObjectMeta: metav1.ObjectMeta{Name: "source-template"},
+							Spec: v1alpha1.SourceTemplateSpec{
+								TemplateSpec: v1alpha1.TemplateSpec{
+									Template: &runtime.RawExtension{Raw: []byte(`{"apiVersion": "example.com/v1", "kind": "Source", "metadata": {"name": "my-source"}, "spec": {}}`)},
+								},
+								URLPath:      "status.artifact.url",
+								RevisionPath: "status.artifact.revision",
+							},
+						}, nil
+					}
+					return &v1alpha1.ClusterImageTemplate{
+						ObjectMeta: metav1.ObjectMeta{Name: "image-template"},
+						Spec: v1alpha1.ImageTemplateSpec{
+							TemplateSpec: v1alpha1.TemplateSpec{
+								Template: &runtime.RawExtension{Raw: []byte(`{"apiVersion": "example.com/v1", "kind": "Builder", "metadata": {"name": "my-builder"}, "spec": {"source": "$(source.url)$"}}`)},
+							},
+							ImagePath: "spec.source",
+						},
+					}, nil
+				}
+			})
+
+			Context("and the object of the first resource exists on the cluster", func() {
+				BeforeEach(func() {
+					existing := &unstructured.Unstructured{}
+					existing.SetAPIVersion("example.com/v1")
+					existing.SetKind("Source")
+					existing.SetName("my-source")
+					existing.Object["status"] = map[string]interface{}{
+						"artifact": map[string]interface{}{
+							"url":      "https://example.com/source.tar.gz",
+							"revision": "abc123",
+						},
+					}
+					fakeOwnerRepo.GetUnstructuredReturns(existing, nil)
+				})
+
+				It("previews the whole chain from the status on the cluster", func() {
+					_, _, sourceOutput, _, _, err := dryRunRealizer.Do(ctx, sourceResource, blueprintName, outputs, fakeMapper)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(sourceOutput.Source.URL).To(Equal("https://example.com/source.tar.gz"))
+					Expect(sourceOutput.Source.Revision).To(Equal("abc123"))
+
+					outputs.AddOutput("source-provider", sourceOutput)
+
+					_, builder, imageOutput, _, _, err := dryRunRealizer.Do(ctx, imageResource, blueprintName, outputs, fakeMapper)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(builder.Object["spec"]).To(Equal(map[string]interface{}{"source": "https://example.com/source.tar.gz"}))
+					Expect(imageOutput.Image).To(Equal("https://example.com/source.tar.gz"))
+
+					Expect(recorder.Recorded()).To(HaveLen(2))
+					Expect(recorder.Recorded()[0].Object).NotTo(HaveKey("status"))
+					Expect(fakeOwnerRepo.EnsureMutableObjectExistsOnClusterCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("and the object of the first resource is not on the cluster yet", func() {
+				BeforeEach(func() {
+					fakeMapper.RESTMappingReturns(&meta.RESTMapping{
+						Resource: schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "sources"},
+					}, nil)
+				})
+
+				It("records the object and reports that its output cannot be read yet", func() {
+					_, _, _, _, _, err := dryRunRealizer.Do(ctx, sourceResource, blueprintName, outputs, fakeMapper)
+					var outputErr cerrors.RetrieveOutputError
+					Expect(errors.As(err, &outputErr)).To(BeTrue())
+					Expect(recorder.Recorded()).To(HaveLen(1))
+				})
+			})
+		})
+	})
 })
@@ -52,6 +52,7 @@ func (c contextGenerator) Generate(templateParams TemplateParams, resource Owner
 		"images":      images,
 		"configs":     configs,
 		"deployment":  inputGenerator.GetDeployment(),
+		"outputs":     inputGenerator.GetOutputs(),
 		"labels":      labels,
 	}
 
@@ -75,3 +76,11 @@ func (c contextGenerator) Generate(templateParams TemplateParams, resource Owner
 
 	return result
 }
+
+// ValidateParams checks the params the template would be stamped with against the
+// schemas and required flags the template declares
+func (c contextGenerator) ValidateParams(templateParams TemplateParams, resource OwnerResource) error {
+	merger := NewParamMerger(resource.Params, c.blueprintParams, c.ownerParams)
+
+	return templateParams.GetDefaultParams().Validate(merger.Merge(templateParams))
+}
@@ -17,9 +17,11 @@ package healthcheck
 import (
 	"fmt"
 
+	"github.com/google/cel-go/cel"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/lru"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/conditions"
@@ -31,7 +33,7 @@ import (
 func IsClusterTemplate(reference *corev1.ObjectReference) bool {
 	apiVersion := v1alpha1.SchemeGroupVersion.Group + "/" + v1alpha1.SchemeGroupVersion.Version
 	if reference != nil &&
-		reference.Kind == "ClusterTemplate" &&
+		v1alpha1.ClusterScopedTemplateKind(reference.Kind) == "ClusterTemplate" &&
 		reference.APIVersion == apiVersion {
 		return true
 	}
@@ -84,6 +86,9 @@ func DetermineHealthCondition(rule *v1alpha1.HealthRule, realizedResource *v1alp
 			if rule.MultiMatch != nil {
 				return multiMatchCondition(rule.MultiMatch, stampedObject)
 			}
+			if rule.CEL != nil {
+				return celCondition(rule.CEL, stampedObject)
+			}
 		} else if rule.AlwaysHealthy != nil {
 			return conditions.NoStampedObjectResourcesHealthyCondition()
 		}
@@ -105,6 +110,11 @@ func DetermineStampedObjectHealth(rule *v1alpha1.HealthRule, stampedObject *unst
 		return condition.Status
 	}
 
+	if rule.CEL != nil {
+		condition := celCondition(rule.CEL, stampedObject)
+		return condition.Status
+	}
+
 	condition := multiMatchCondition(rule.MultiMatch, stampedObject)
 	return condition.Status
 }
@@ -195,3 +205,108 @@ func allHealthyMatchCondition(rule v1alpha1.HealthMatchRule, stampedObject *unst
 	condition := conditions.MultiMatchResourcesHealthyCondition(metav1.ConditionTrue, firstReason, message)
 	return &condition
 }
+
+func celCondition(celRule *v1alpha1.CELHealthRule, stampedObject *unstructured.Unstructured) metav1.Condition {
+	if celRule.Unhealthy != "" {
+		// an unhealthy expression which cannot be evaluated (e.g. a field is not yet present) does not match
+		unhealthy, err := evaluateCELBool(celRule.Unhealthy, stampedObject)
+		if err == nil && unhealthy {
+			return conditions.CELResourcesHealthyCondition(metav1.ConditionFalse,
+				v1alpha1.CELUnhealthyReason,
+				messageForCELRule(celRule, stampedObject))
+		}
+	}
+
+	healthy, err := evaluateCELBool(celRule.Healthy, stampedObject)
+	if err != nil {
+		return conditions.CELResourcesHealthyCondition(metav1.ConditionUnknown,
+			v1alpha1.CELEvaluationErrorReason,
+			err.Error())
+	}
+	if healthy {
+		return conditions.CELResourcesHealthyCondition(metav1.ConditionTrue,
+			v1alpha1.CELHealthyReason,
+			messageForCELRule(celRule, stampedObject))
+	}
+
+	return conditions.CELNoMatchCondition()
+}
+
+func evaluateCELBool(expression string, stampedObject *unstructured.Unstructured) (bool, error) {
+	result, err := evaluateCEL(expression, cel.BoolType, stampedObject)
+	if err != nil {
+		return false, err
+	}
+	value, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("expression [%s] did not evaluate to a bool, found: %v", expression, result)
+	}
+	return value, nil
+}
+
+func messageForCELRule(celRule *v1alpha1.CELHealthRule, stampedObject *unstructured.Unstructured) string {
+	if celRule.Message == "" {
+		return ""
+	}
+	message, err := evaluateCEL(celRule.Message, cel.StringType, stampedObject)
+	if err != nil {
+		return fmt.Sprintf("unknown, error evaluating message expression: %s", err)
+	}
+	return fmt.Sprintf("%v", message)
+}
+
+// maxCachedCELPrograms bounds the number of compiled CEL health rule and resource condition
+// expressions kept for reuse
+const maxCachedCELPrograms = 1000
+
+var celPrograms = lru.New(maxCachedCELPrograms)
+
+type celProgramKey struct {
+	resourceCondition bool
+	expression        string
+	outputType        string
+}
+
+func evaluateCEL(expression string, outputType *cel.Type, stampedObject *unstructured.Unstructured) (interface{}, error) {
+	program, err := compileCEL(expression, outputType)
+	if err != nil {
+		return nil, err
+	}
+	result, _, err := program.Eval(map[string]interface{}{
+		v1alpha1.CELSelfVariable: stampedObject.UnstructuredContent(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate expression [%s]: %w", expression, err)
+	}
+	return result.Value(), nil
+}
+
+// compileCEL returns the compiled program for the expression, compiling it only the first time it is seen
+func compileCEL(expression string, outputType *cel.Type) (cel.Program, error) {
+	key := celProgramKey{expression: expression, outputType: outputType.String()}
+	return cachedCELProgram(key, func() (cel.Program, error) {
+		return v1alpha1.CompileCELHealthRuleExpression(expression, outputType)
+	})
+}
+
+// CompileCELResourceCondition returns the compiled program for a resource condition expression,
+// compiling it only the first time it is seen
+func CompileCELResourceCondition(expression string) (cel.Program, error) {
+	key := celProgramKey{resourceCondition: true, expression: expression}
+	return cachedCELProgram(key, func() (cel.Program, error) {
+		return v1alpha1.CompileCELResourceConditionExpression(expression)
+	})
+}
+
+func cachedCELProgram(key celProgramKey, compile func() (cel.Program, error)) (cel.Program, error) {
+	if program, ok := celPrograms.Get(key); ok {
+		return program.(cel.Program), nil
+	}
+
+	program, err := compile()
+	if err != nil {
+		return nil, err
+	}
+	celPrograms.Add(key, program)
+	return program, nil
+}
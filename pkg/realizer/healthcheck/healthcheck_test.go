@@ -593,6 +593,140 @@ var _ = Describe("DetermineHealthCondition", func() {
 			))
 		})
 	})
+
+	Context("HealthRule is CEL", func() {
+		var (
+			healthRule    *v1alpha1.HealthRule
+			stampedObject *unstructured.Unstructured
+		)
+
+		BeforeEach(func() {
+			healthRule = &v1alpha1.HealthRule{
+				CEL: &v1alpha1.CELHealthRule{
+					Healthy:   "self.status.readyReplicas == self.spec.replicas",
+					Unhealthy: "has(self.status.failed) && self.status.failed",
+					Message:   `"ready replicas: " + string(self.status.readyReplicas)`,
+				},
+			}
+
+			stampedObject = &unstructured.Unstructured{}
+			stampedObjectYaml := utils.HereYamlF(`
+				apiVersion: thing/v1
+				kind: Thing
+				metadata:
+				  name: named-thing
+				  namespace: somens
+				spec:
+				  replicas: 3
+			`)
+
+			dec := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
+			_, _, err := dec.Decode([]byte(stampedObjectYaml), nil, stampedObject)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns unknown if there is no stamped object", func() {
+			Expect(healthcheck.DetermineHealthCondition(healthRule, nil, nil)).To(MatchFields(IgnoreExtras,
+				Fields{
+					"Type":   Equal("Healthy"),
+					"Status": Equal(metav1.ConditionUnknown),
+				},
+			))
+		})
+
+		It("returns True with the evaluated message when the healthy expression is true", func() {
+			Expect(unstructured.SetNestedField(stampedObject.Object, int64(3), "status", "readyReplicas")).To(Succeed())
+
+			Expect(healthcheck.DetermineHealthCondition(healthRule, nil, stampedObject)).To(MatchFields(IgnoreExtras,
+				Fields{
+					"Type":    Equal("Healthy"),
+					"Status":  Equal(metav1.ConditionTrue),
+					"Reason":  Equal("MatchedCELHealthy"),
+					"Message": Equal("ready replicas: 3"),
+				},
+			))
+		})
+
+		It("returns Unknown when the healthy expression is false", func() {
+			Expect(unstructured.SetNestedField(stampedObject.Object, int64(1), "status", "readyReplicas")).To(Succeed())
+
+			Expect(healthcheck.DetermineHealthCondition(healthRule, nil, stampedObject)).To(MatchFields(IgnoreExtras,
+				Fields{
+					"Type":   Equal("Healthy"),
+					"Status": Equal(metav1.ConditionUnknown),
+					"Reason": Equal("NoCELRuleMatched"),
+				},
+			))
+		})
+
+		It("returns False when the unhealthy expression is true", func() {
+			Expect(unstructured.SetNestedField(stampedObject.Object, int64(3), "status", "readyReplicas")).To(Succeed())
+			Expect(unstructured.SetNestedField(stampedObject.Object, true, "status", "failed")).To(Succeed())
+
+			Expect(healthcheck.DetermineHealthCondition(healthRule, nil, stampedObject)).To(MatchFields(IgnoreExtras,
+				Fields{
+					"Type":    Equal("Healthy"),
+					"Status":  Equal(metav1.ConditionFalse),
+					"Reason":  Equal("MatchedCELUnhealthy"),
+					"Message": Equal("ready replicas: 3"),
+				},
+			))
+		})
+
+		It("returns Unknown with the error when the healthy expression cannot be evaluated", func() {
+			Expect(healthcheck.DetermineHealthCondition(healthRule, nil, stampedObject)).To(MatchFields(IgnoreExtras,
+				Fields{
+					"Type":    Equal("Healthy"),
+					"Status":  Equal(metav1.ConditionUnknown),
+					"Reason":  Equal("CELEvaluationError"),
+					"Message": ContainSubstring("no such key: status"),
+				},
+			))
+		})
+
+		It("returns Unknown with the error when evaluating the healthy expression exceeds the cost limit", func() {
+			items := make([]interface{}, 2000)
+			for i := range items {
+				items[i] = int64(i)
+			}
+			Expect(unstructured.SetNestedSlice(stampedObject.Object, items, "spec", "items")).To(Succeed())
+			healthRule.CEL.Healthy = "self.spec.items.all(x, self.spec.items.exists(y, y == x))"
+
+			Expect(healthcheck.DetermineHealthCondition(healthRule, nil, stampedObject)).To(MatchFields(IgnoreExtras,
+				Fields{
+					"Type":    Equal("Healthy"),
+					"Status":  Equal(metav1.ConditionUnknown),
+					"Reason":  Equal("CELEvaluationError"),
+					"Message": ContainSubstring("cost limit exceeded"),
+				},
+			))
+		})
+
+		It("reuses the compiled expressions across evaluations", func() {
+			Expect(unstructured.SetNestedField(stampedObject.Object, int64(3), "status", "readyReplicas")).To(Succeed())
+			Expect(healthcheck.DetermineHealthCondition(healthRule, nil, stampedObject).Status).To(Equal(metav1.ConditionTrue))
+
+			Expect(unstructured.SetNestedField(stampedObject.Object, int64(2), "status", "readyReplicas")).To(Succeed())
+			Expect(healthcheck.DetermineHealthCondition(healthRule, nil, stampedObject)).To(MatchFields(IgnoreExtras,
+				Fields{
+					"Status": Equal(metav1.ConditionUnknown),
+					"Reason": Equal("NoCELRuleMatched"),
+				},
+			))
+		})
+
+		It("surfaces errors evaluating the message expression into the message", func() {
+			healthRule.CEL.Healthy = "self.spec.replicas == 3"
+
+			Expect(healthcheck.DetermineHealthCondition(healthRule, nil, stampedObject)).To(MatchFields(IgnoreExtras,
+				Fields{
+					"Type":    Equal("Healthy"),
+					"Status":  Equal(metav1.ConditionTrue),
+					"Message": HavePrefix("unknown, error evaluating message expression:"),
+				},
+			))
+		})
+	})
 })
 
 var _ = Describe("DetermineStampedObjectHealth", func() {
@@ -883,4 +1017,55 @@ var _ = Describe("DetermineStampedObjectHealth", func() {
 			})
 		})
 	})
+
+	Context("when healthRule is CEL", func() {
+		BeforeEach(func() {
+			rule = &v1alpha1.HealthRule{
+				CEL: &v1alpha1.CELHealthRule{
+					Healthy:   "self.status.ready",
+					Unhealthy: "has(self.status.failed) && self.status.failed",
+				},
+			}
+			stampedObject = &unstructured.Unstructured{Object: map[string]interface{}{}}
+		})
+
+		Context("and the healthy expression is true", func() {
+			BeforeEach(func() {
+				Expect(unstructured.SetNestedField(stampedObject.Object, true, "status", "ready")).To(Succeed())
+			})
+			It("returns true", func() {
+				Expect(returnedStatus).To(Equal(metav1.ConditionTrue))
+			})
+		})
+
+		Context("and the unhealthy expression is true", func() {
+			BeforeEach(func() {
+				Expect(unstructured.SetNestedField(stampedObject.Object, true, "status", "ready")).To(Succeed())
+				Expect(unstructured.SetNestedField(stampedObject.Object, true, "status", "failed")).To(Succeed())
+			})
+			It("returns false", func() {
+				Expect(returnedStatus).To(Equal(metav1.ConditionFalse))
+			})
+		})
+
+		Context("and the healthy expression cannot be evaluated", func() {
+			It("returns unknown", func() {
+				Expect(returnedStatus).To(Equal(metav1.ConditionUnknown))
+			})
+		})
+	})
+})
+
+var _ = Describe("CompileCELResourceCondition", func() {
+	It("reuses the compiled expression across evaluations", func() {
+		program, err := healthcheck.CompileCELResourceCondition("workload.metadata.name == 'some-workload'")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(healthcheck.CompileCELResourceCondition("workload.metadata.name == 'some-workload'")).To(BeIdenticalTo(program))
+	})
+
+	It("returns the error of an invalid expression", func() {
+		_, err := healthcheck.CompileCELResourceCondition("'not a bool'")
+		Expect(err).To(MatchError(ContainSubstring("must evaluate to bool")))
+	})
 })
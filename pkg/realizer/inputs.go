@@ -32,12 +32,14 @@ type Resource interface {
 	GetImages() []v1alpha1.ResourceReference
 	GetConfigs() []v1alpha1.ResourceReference
 	GetDeployment() *v1alpha1.DeploymentReference
+	GetOutputs() []v1alpha1.OutputReference
 }
 
 type OutputsGetter interface {
 	GetSource(resourceName string) *templates.Source
 	GetImage(resourceName string) templates.Image
 	GetConfig(resourceName string) templates.Config
+	GetNamed(resourceName string) map[string]interface{}
 }
 
 type InputGenerator struct {
@@ -107,3 +109,25 @@ func (i *InputGenerator) GetDeployment() *templates.SourceInput {
 
 	return nil
 }
+
+// GetOutputs returns the named outputs the resource consumes, by providing resource and then by name
+func (i *InputGenerator) GetOutputs() map[string]interface{} {
+	inputs := map[string]interface{}{}
+
+	for _, reference := range i.resource.GetOutputs() {
+		named := i.outputs.GetNamed(reference.Resource)
+		value, ok := named[reference.Name]
+		if !ok {
+			continue
+		}
+
+		resourceOutputs, ok := inputs[reference.Resource].(map[string]interface{})
+		if !ok {
+			resourceOutputs = map[string]interface{}{}
+			inputs[reference.Resource] = resourceOutputs
+		}
+		resourceOutputs[reference.Name] = value
+	}
+
+	return inputs
+}
@@ -164,4 +164,34 @@ var _ = Describe("Inputs", func() {
 			})
 		})
 	})
+
+	Context("When resource contains outputs", func() {
+		var outs realizer.Outputs
+		BeforeEach(func() {
+			outs = realizer.NewOutputs()
+			outs.AddOutput("image-provider", &templates.Output{
+				Image: "my-image",
+				Named: map[string]interface{}{
+					"digest": "sha256:abc",
+					"tag":    "v1",
+				},
+			})
+		})
+
+		It("Adds the referenced outputs to inputs by resource and name", func() {
+			resource := realizer.OwnerResource{
+				Outputs: []v1alpha1.OutputReference{
+					{Resource: "image-provider", Name: "digest"},
+					{Resource: "image-provider", Name: "does-not-exist"},
+					{Resource: "resource-does-not-exist", Name: "digest"},
+				},
+			}
+			inputGenerator := realizer.NewInputGenerator(resource, outs)
+			Expect(inputGenerator.GetOutputs()).To(Equal(map[string]interface{}{
+				"image-provider": map[string]interface{}{
+					"digest": "sha256:abc",
+				},
+			}))
+		})
+	})
 })
@@ -52,3 +52,12 @@ func (o Outputs) GetSource(resourceName string) *templates.Source {
 
 	return output.Source
 }
+
+func (o Outputs) GetNamed(resourceName string) map[string]interface{} {
+	output := o[resourceName]
+	if output == nil {
+		return nil
+	}
+
+	return output.Named
+}
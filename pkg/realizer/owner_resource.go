@@ -14,17 +14,27 @@
 
 package realizer
 
-import "github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
 
 type OwnerResource struct {
 	TemplateRef     v1alpha1.TemplateReference
 	TemplateOptions []v1alpha1.TemplateOption
-	Params          []v1alpha1.BlueprintParam
-	Name            string
-	Sources         []v1alpha1.ResourceReference
-	Images          []v1alpha1.ResourceReference
-	Configs         []v1alpha1.ResourceReference
-	Deployment      *v1alpha1.DeploymentReference
+	// TemplateNamespace is the namespace of the blueprint, in which namespaced templates are found
+	TemplateNamespace string
+	Params            []v1alpha1.BlueprintParam
+	Name              string
+	Sources           []v1alpha1.ResourceReference
+	Images            []v1alpha1.ResourceReference
+	Configs           []v1alpha1.ResourceReference
+	Deployment        *v1alpha1.DeploymentReference
+	Outputs           []v1alpha1.OutputReference
+	ProgressDeadline  *metav1.Duration
+	ResyncPeriod      *metav1.Duration
+	When              *v1alpha1.ResourceCondition
 }
 
 func (o OwnerResource) GetImages() []v1alpha1.ResourceReference {
@@ -46,3 +56,7 @@ func (o OwnerResource) GetName() string {
 func (o OwnerResource) GetSources() []v1alpha1.ResourceReference {
 	return o.Sources
 }
+
+func (o OwnerResource) GetOutputs() []v1alpha1.OutputReference {
+	return o.Outputs
+}
@@ -43,7 +43,9 @@ func (p ParamMerger) Merge(templateParams TemplateParams) map[string]apiextensio
 
 	if templateParams != nil {
 		for _, param := range templateParams.GetDefaultParams() {
-			newParams[param.Name] = param.DefaultValue
+			if param.HasDefault() {
+				newParams[param.Name] = param.DefaultValue
+			}
 		}
 	}
 
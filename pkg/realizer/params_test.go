@@ -42,6 +42,15 @@ var _ = Describe("Params", func() {
 		},
 	}
 
+	requiredTemplate := Template{
+		params: v1alpha1.TemplateParams{
+			v1alpha1.TemplateParam{
+				Name:     "target-name",
+				Required: true,
+			},
+		},
+	}
+
 	delegatingBlueprintParam := &v1alpha1.BlueprintParam{
 		Name:         "target-name",
 		DefaultValue: &apiextensionsv1.JSON{Raw: []byte("from the blueprint")},
@@ -183,5 +192,19 @@ var _ = Describe("Params", func() {
 			nil,
 			ownerParam,
 			"from the owner"),
+
+		Entry("required param with no value",
+			requiredTemplate,
+			nil,
+			nil,
+			nil,
+			""),
+
+		Entry("required param with value from the owner",
+			requiredTemplate,
+			nil,
+			nil,
+			ownerParam,
+			"from the owner"),
 	)
 })
@@ -22,6 +22,8 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -34,48 +36,68 @@ import (
 	"k8s.io/utils/strings/slices"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/conditions"
 	cerrors "github.com/vmware-tanzu/cartographer/pkg/errors"
 	"github.com/vmware-tanzu/cartographer/pkg/events"
 	"github.com/vmware-tanzu/cartographer/pkg/logger"
+	"github.com/vmware-tanzu/cartographer/pkg/provenance"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/healthcheck"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/statuses"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
+	"github.com/vmware-tanzu/cartographer/pkg/tracing"
 	"github.com/vmware-tanzu/cartographer/pkg/utils"
 )
 
-func MakeSupplychainOwnerResources(supplyChain *v1alpha1.ClusterSupplyChain) []OwnerResource {
+// MakeSupplychainOwnerResources returns the resources of the supply chain, with the resources of any
+// sub-chains it references inlined in their place.
+func MakeSupplychainOwnerResources(ctx context.Context, getSubChain v1alpha1.ClusterSupplyChainGetter, supplyChain v1alpha1.SupplyChainObject) ([]OwnerResource, error) {
+	supplyChainResources, err := v1alpha1.ExpandSubChains(ctx, getSubChain, supplyChain)
+	if err != nil {
+		return nil, err
+	}
+
 	var resources []OwnerResource
-	for _, resource := range supplyChain.Spec.Resources {
+	for _, resource := range supplyChainResources {
 		resources = append(resources, OwnerResource{
 			Name: resource.Name,
 			TemplateRef: v1alpha1.TemplateReference{
 				Kind: resource.TemplateRef.Kind,
 				Name: resource.TemplateRef.Name,
 			},
-			TemplateOptions: resource.TemplateRef.Options,
-			Params:          resource.Params,
-			Sources:         resource.Sources,
-			Images:          resource.Images,
-			Configs:         resource.Configs,
+			TemplateOptions:   resource.TemplateRef.Options,
+			TemplateNamespace: supplyChain.GetNamespace(),
+			Params:            resource.Params,
+			Sources:           resource.Sources,
+			Images:            resource.Images,
+			Configs:           resource.Configs,
+			Outputs:           resource.Outputs,
+			ProgressDeadline:  resource.ProgressDeadline,
+			ResyncPeriod:      resource.ResyncPeriod,
+			When:              resource.When,
 		})
 	}
-	return resources
+	return resources, nil
 }
 
-func MakeDeliveryOwnerResources(delivery *v1alpha1.ClusterDelivery) []OwnerResource {
+func MakeDeliveryOwnerResources(delivery v1alpha1.DeliveryObject) []OwnerResource {
 	var resources []OwnerResource
-	for _, resource := range delivery.Spec.Resources {
+	for _, resource := range delivery.GetSpec().Resources {
 		resources = append(resources, OwnerResource{
 			Name: resource.Name,
 			TemplateRef: v1alpha1.TemplateReference{
 				Kind: resource.TemplateRef.Kind,
 				Name: resource.TemplateRef.Name,
 			},
-			TemplateOptions: resource.TemplateRef.Options,
-			Params:          resource.Params,
-			Sources:         resource.Sources,
-			Configs:         resource.Configs,
-			Deployment:      resource.Deployment,
+			TemplateOptions:   resource.TemplateRef.Options,
+			TemplateNamespace: delivery.GetNamespace(),
+			Params:            resource.Params,
+			Sources:           resource.Sources,
+			Configs:           resource.Configs,
+			Deployment:        resource.Deployment,
+			Outputs:           resource.Outputs,
+			ProgressDeadline:  resource.ProgressDeadline,
+			ResyncPeriod:      resource.ResyncPeriod,
+			When:              resource.When,
 		})
 	}
 	return resources
@@ -83,52 +105,74 @@ func MakeDeliveryOwnerResources(delivery *v1alpha1.ClusterDelivery) []OwnerResou
 
 //counterfeiter:generate . ResourceRealizer
 type ResourceRealizer interface {
+	IsSkipped(ctx context.Context, resource OwnerResource, blueprintName string, outputs Outputs) (bool, error)
 	Do(ctx context.Context, resource OwnerResource, blueprintName string, outputs Outputs, mapper meta.RESTMapper) (templates.Reader, *unstructured.Unstructured, *templates.Output, bool, string, error)
 }
 
 type realizer struct {
 	healthyConditionEvaluator HealthyConditionEvaluator
 	mapper                    meta.RESTMapper
+	maxConcurrency            int
+	exportProvenance          bool
 }
 
 type HealthyConditionEvaluator func(rule *v1alpha1.HealthRule, realizedResource *v1alpha1.RealizedResource, stampedObject *unstructured.Unstructured) metav1.Condition
 
+// NewRealizer returns a realizer which realizes up to maxConcurrency resources at once. When exportProvenance
+// is set, a provenance statement is sent as a CloudEvent for every new image a resource produces.
+//
 //counterfeiter:generate k8s.io/apimachinery/pkg/api/meta.RESTMapper
-func NewRealizer(healthyConditionEvaluator HealthyConditionEvaluator, mapper meta.RESTMapper) *realizer {
+func NewRealizer(healthyConditionEvaluator HealthyConditionEvaluator, mapper meta.RESTMapper, maxConcurrency int, exportProvenance bool) *realizer {
 	if healthyConditionEvaluator == nil {
 		healthyConditionEvaluator = healthcheck.DetermineHealthCondition
 	}
+	if maxConcurrency < 1 {
+		maxConcurrency = 1
+	}
 	return &realizer{
 		healthyConditionEvaluator: healthyConditionEvaluator,
 		mapper:                    mapper,
+		maxConcurrency:            maxConcurrency,
+		exportProvenance:          exportProvenance,
 	}
 }
 
+type doResult struct {
+	template      templates.Reader
+	stampedObject *unstructured.Unstructured
+	output        *templates.Output
+	isPassThrough bool
+	templateName  string
+	isSkipped     bool
+	err           error
+}
+
 func (r *realizer) Realize(ctx context.Context, resourceRealizer ResourceRealizer, blueprintName string, ownerResources []OwnerResource, resourceStatuses statuses.ResourceStatuses) error {
+	ctx, span := tracing.Start(ctx, "Realizer.Realize", tracing.BlueprintNameKey.String(blueprintName))
+	err := r.realize(ctx, resourceRealizer, blueprintName, ownerResources, resourceStatuses)
+	tracing.End(span, err)
+	return err
+}
+
+func (r *realizer) realize(ctx context.Context, resourceRealizer ResourceRealizer, blueprintName string, ownerResources []OwnerResource, resourceStatuses statuses.ResourceStatuses) error {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("Realize")
 
-	outs := NewOutputs()
+	results := r.doAll(ctx, resourceRealizer, blueprintName, ownerResources)
 	var firstError error
 
-	for _, resource := range ownerResources {
-		log = log.WithValues("resource", resource.Name)
-		ctx = logr.NewContext(ctx, log)
-		template, stampedObject, out, isPassThrough, templateName, err := resourceRealizer.Do(ctx, resource, blueprintName, outs, r.mapper)
-
-		if stampedObject != nil {
-			log.V(logger.DEBUG).Info("realized resource as object",
-				"object", stampedObject)
-		}
-
-		outs.AddOutput(resource.Name, out)
+	for i, resource := range ownerResources {
+		log := log.WithValues("resource", resource.Name)
+		ctx := logr.NewContext(ctx, log)
+		result := results[i]
+		err := result.err
 
 		previousResourceStatus := resourceStatuses.GetPreviousResourceStatus(resource.Name)
 
 		var realizedResource *v1alpha1.RealizedResource
 
 		var additionalConditions []metav1.Condition
-		if (stampedObject == nil || template == nil) && previousResourceStatus != nil {
+		if !result.isSkipped && (result.stampedObject == nil || result.template == nil) && previousResourceStatus != nil {
 			realizedResource = &previousResourceStatus.RealizedResource
 			if previousResourceStatusHealthyCondition := utils.ConditionList(previousResourceStatus.Conditions).ConditionWithType(v1alpha1.ResourceHealthy); previousResourceStatusHealthyCondition != nil {
 				additionalConditions = []metav1.Condition{*previousResourceStatusHealthyCondition}
@@ -138,7 +182,7 @@ func (r *realizer) Realize(ctx context.Context, resourceRealizer ResourceRealize
 			if previousResourceStatus != nil {
 				previousRealizedResource = &previousResourceStatus.RealizedResource
 			}
-			realizedResource = r.generateRealizedResource(ctx, resource, template, stampedObject, out, previousRealizedResource, isPassThrough, templateName)
+			realizedResource = r.generateRealizedResource(ctx, resource, result.template, result.stampedObject, result.output, previousRealizedResource, resourceStatuses.GetCurrent(), result.isPassThrough, result.isSkipped, result.templateName)
 
 			var previousOutputs []v1alpha1.Output
 			if previousRealizedResource != nil {
@@ -147,15 +191,27 @@ func (r *realizer) Realize(ctx context.Context, resourceRealizer ResourceRealize
 
 			if !reflect.DeepEqual(previousOutputs, realizedResource.Outputs) {
 				rec := events.FromContextOrDie(ctx)
-				if isPassThrough {
+				if result.isPassThrough || result.isSkipped {
 					rec.Eventf(events.NormalType, events.ResourceOutputChangedReason, "[%s] passed through a new output", realizedResource.Name)
 				} else {
-					rec.ResourceEventf(events.NormalType, events.ResourceOutputChangedReason, "[%s] found a new output in [%Q]", stampedObject, realizedResource.Name)
+					rec.ResourceEventf(events.NormalType, events.ResourceOutputChangedReason, "[%s] found a new output in [%Q]", result.stampedObject, realizedResource.Name)
+				}
+				rec.ResourceCloudEvent(events.ResourceOutputChangedReason, events.ResourceEventData{
+					Resource:      realizedResource.Name,
+					StampedObject: events.StampedObjectReference(result.stampedObject),
+					Outputs:       cloudEventOutputs(realizedResource.Outputs),
+				})
+
+				if r.exportProvenance && !result.isPassThrough && !result.isSkipped {
+					r.recordImageProvenance(ctx, blueprintName, realizedResource, result.stampedObject, result.output, previousOutputs, resourceStatuses.GetCurrent())
 				}
 			}
 
-			if template != nil {
-				additionalConditions = []metav1.Condition{r.healthyConditionEvaluator(template.GetHealthRule(), realizedResource, stampedObject)}
+			if result.template != nil {
+				healthyCondition := r.healthyConditionEvaluator(result.template.GetHealthRule(), realizedResource, result.stampedObject)
+				healthyCondition = applyProgressDeadline(ctx, resource, result.template, result.stampedObject, realizedResource, previousResourceStatus, healthyCondition)
+				additionalConditions = []metav1.Condition{healthyCondition}
+				realizedResource.ResyncPeriod = resyncPeriod(resource, result.template)
 			}
 		}
 
@@ -168,7 +224,7 @@ func (r *realizer) Realize(ctx context.Context, resourceRealizer ResourceRealize
 			}
 		}
 
-		resourceStatuses.Add(realizedResource, err, isPassThrough, additionalConditions...)
+		resourceStatuses.Add(realizedResource, err, result.isPassThrough, additionalConditions...)
 
 		if slices.Contains(resourceStatuses.ChangedConditionTypes(realizedResource.Name), v1alpha1.ResourceHealthy) {
 			newStatus := metav1.ConditionUnknown
@@ -176,7 +232,14 @@ func (r *realizer) Realize(ctx context.Context, resourceRealizer ResourceRealize
 			if newHealthyCondition != nil {
 				newStatus = newHealthyCondition.Status
 			}
-			events.FromContextOrDie(ctx).ResourceEventf(events.NormalType, events.ResourceHealthyStatusChangedReason, "[%s] found healthy status in [%Q] changed to [%s]", stampedObject, realizedResource.Name, newStatus)
+			rec := events.FromContextOrDie(ctx)
+			rec.ResourceEventf(events.NormalType, events.ResourceHealthyStatusChangedReason, "[%s] found healthy status in [%Q] changed to [%s]", result.stampedObject, realizedResource.Name, newStatus)
+			rec.ResourceCloudEvent(events.ResourceHealthyStatusChangedReason, events.ResourceEventData{
+				Resource:      realizedResource.Name,
+				StampedObject: events.StampedObjectReference(result.stampedObject),
+				Outputs:       cloudEventOutputs(realizedResource.Outputs),
+				Healthy:       string(newStatus),
+			})
 		}
 
 		if err != nil {
@@ -190,9 +253,175 @@ func (r *realizer) Realize(ctx context.Context, resourceRealizer ResourceRealize
 	return firstError
 }
 
+// applyProgressDeadline fails the healthy condition of a resource which has neither become healthy nor produced an
+// output by its progress deadline. Until then, the deadline is recorded on the realized resource.
+func applyProgressDeadline(ctx context.Context, resource OwnerResource, template templates.Reader, stampedObject *unstructured.Unstructured,
+	realizedResource *v1alpha1.RealizedResource, previousResourceStatus *v1alpha1.ResourceStatus, healthyCondition metav1.Condition) metav1.Condition {
+	progressDeadline := resource.ProgressDeadline
+	if progressDeadline == nil {
+		progressDeadline = template.GetResourceTemplate().ProgressDeadline
+	}
+
+	if progressDeadline == nil || healthyCondition.Status != metav1.ConditionUnknown || len(realizedResource.Outputs) > 0 {
+		return healthyCondition
+	}
+
+	now := time.Now()
+	deadline := metav1.NewTime(now.Add(progressDeadline.Duration)).Rfc3339Copy()
+	if previousResourceStatus != nil && previousResourceStatus.ProgressDeadline != nil {
+		deadline = *previousResourceStatus.ProgressDeadline
+	}
+	realizedResource.ProgressDeadline = &deadline
+
+	if now.Before(deadline.Time) {
+		return healthyCondition
+	}
+
+	var previousHealthyCondition *metav1.Condition
+	if previousResourceStatus != nil {
+		previousHealthyCondition = utils.ConditionList(previousResourceStatus.Conditions).ConditionWithType(v1alpha1.ResourceHealthy)
+	}
+	if previousHealthyCondition == nil || previousHealthyCondition.Reason != v1alpha1.ProgressDeadlineExceededResourcesHealthyReason {
+		events.FromContextOrDie(ctx).ResourceEventf(events.WarningType, events.ProgressDeadlineExceededReason, "[%s] did not become healthy or produce an output in [%Q] within progress deadline [%s]", stampedObject, resource.Name, progressDeadline.Duration)
+	}
+
+	return conditions.ProgressDeadlineExceededResourcesHealthyCondition(progressDeadline.Duration)
+}
+
+// resyncPeriod is the resync period of the resource, or else of its template
+func resyncPeriod(resource OwnerResource, template templates.Reader) *metav1.Duration {
+	if resource.ResyncPeriod != nil {
+		return resource.ResyncPeriod
+	}
+	return template.GetResourceTemplate().ResyncPeriod
+}
+
+// doAll realizes every resource, starting each one as soon as the resources it consumes have been realized.
+// Independent resources are realized concurrently, bounded by maxConcurrency. Results are returned in the
+// order of ownerResources.
+func (r *realizer) doAll(ctx context.Context, resourceRealizer ResourceRealizer, blueprintName string, ownerResources []OwnerResource) []doResult {
+	log := logr.FromContextOrDiscard(ctx)
+
+	dependencies := resourceDependencies(ownerResources)
+	results := make([]doResult, len(ownerResources))
+	realized := make([]chan struct{}, len(ownerResources))
+	for i := range realized {
+		realized[i] = make(chan struct{})
+	}
+
+	outs := NewOutputs()
+	outsMtx := &sync.Mutex{}
+	workers := make(chan struct{}, r.maxConcurrency)
+	wg := &sync.WaitGroup{}
+
+	for i := range ownerResources {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer close(realized[i])
+
+			for _, dependency := range dependencies[i] {
+				<-realized[dependency]
+			}
+
+			workers <- struct{}{}
+			defer func() { <-workers }()
+
+			resource := ownerResources[i]
+			log := log.WithValues("resource", resource.Name)
+			ctx := logr.NewContext(ctx, log)
+
+			inputs := NewOutputs()
+			outsMtx.Lock()
+			for _, dependency := range dependencies[i] {
+				name := ownerResources[dependency].Name
+				inputs.AddOutput(name, outs[name])
+			}
+			outsMtx.Unlock()
+
+			skipped, err := resourceRealizer.IsSkipped(ctx, resource, blueprintName, inputs)
+			if err != nil || skipped {
+				var out *templates.Output
+				if skipped {
+					log.V(logger.DEBUG).Info("skipped resource, when condition not met")
+					out = skippedResourceOutput(resource, inputs)
+				}
+
+				outsMtx.Lock()
+				outs.AddOutput(resource.Name, out)
+				outsMtx.Unlock()
+
+				results[i] = doResult{
+					output:    out,
+					isSkipped: skipped,
+					err:       err,
+				}
+				return
+			}
+
+			template, stampedObject, out, isPassThrough, templateName, err := resourceRealizer.Do(ctx, resource, blueprintName, inputs, r.mapper)
+
+			if stampedObject != nil {
+				log.V(logger.DEBUG).Info("realized resource as object",
+					"object", stampedObject)
+			}
+
+			outsMtx.Lock()
+			outs.AddOutput(resource.Name, out)
+			outsMtx.Unlock()
+
+			results[i] = doResult{
+				template:      template,
+				stampedObject: stampedObject,
+				output:        out,
+				isPassThrough: isPassThrough,
+				templateName:  templateName,
+				err:           err,
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// resourceDependencies returns, for each resource, the indices of the resources whose outputs it consumes.
+// Only resources declared earlier in the blueprint are considered, matching the inputs that were available
+// when resources were realized one after the other. This also guarantees the graph is acyclic.
+func resourceDependencies(ownerResources []OwnerResource) [][]int {
+	dependencies := make([][]int, len(ownerResources))
+
+	for i, resource := range ownerResources {
+		var referencedNames []string
+		for _, reference := range resource.Sources {
+			referencedNames = append(referencedNames, reference.Resource)
+		}
+		for _, reference := range resource.Images {
+			referencedNames = append(referencedNames, reference.Resource)
+		}
+		for _, reference := range resource.Configs {
+			referencedNames = append(referencedNames, reference.Resource)
+		}
+		if resource.Deployment != nil {
+			referencedNames = append(referencedNames, resource.Deployment.Resource)
+		}
+		for _, reference := range resource.Outputs {
+			referencedNames = append(referencedNames, reference.Resource)
+		}
+
+		for j := 0; j < i; j++ {
+			if slices.Contains(referencedNames, ownerResources[j].Name) {
+				dependencies[i] = append(dependencies[i], j)
+			}
+		}
+	}
+
+	return dependencies
+}
+
 func (r *realizer) generateRealizedResource(ctx context.Context, resource OwnerResource, template templates.Reader,
 	stampedObject *unstructured.Unstructured, output *templates.Output, previousRealizedResource *v1alpha1.RealizedResource,
-	isPassThrough bool, templateName string) *v1alpha1.RealizedResource {
+	currentResourceStatuses statuses.ResourceStatusList, isPassThrough bool, isSkipped bool, templateName string) *v1alpha1.RealizedResource {
 	log := logr.FromContextOrDiscard(ctx)
 
 	if previousRealizedResource == nil {
@@ -216,6 +445,12 @@ func (r *realizer) generateRealizedResource(ctx context.Context, resource OwnerR
 		inputs = append(inputs, v1alpha1.Input{Name: config.Resource})
 	}
 
+	for _, output := range resource.Outputs {
+		inputs = append(inputs, v1alpha1.Input{Name: output.Resource})
+	}
+
+	outputInputs := currentOutputInputs(inputs, currentResourceStatuses)
+
 	var templateRef *corev1.ObjectReference
 	var outputs []v1alpha1.Output
 
@@ -225,11 +460,14 @@ func (r *realizer) generateRealizedResource(ctx context.Context, resource OwnerR
 			Name:       templateName,
 			APIVersion: v1alpha1.SchemeGroupVersion.String(),
 		}
-		outputs = getOutputs(previousRealizedResource, output)
+		if v1alpha1.IsNamespacedTemplateKind(resource.TemplateRef.Kind) {
+			templateRef.Namespace = resource.TemplateNamespace
+		}
+		outputs = getOutputs(previousRealizedResource, output, outputInputs)
 	}
 
-	if isPassThrough {
-		outputs = getOutputs(previousRealizedResource, output)
+	if isPassThrough || isSkipped {
+		outputs = getOutputs(previousRealizedResource, output, outputInputs)
 	}
 
 	var stampedRef *v1alpha1.StampedRef
@@ -257,10 +495,13 @@ func (r *realizer) generateRealizedResource(ctx context.Context, resource OwnerR
 		TemplateRef: templateRef,
 		Inputs:      inputs,
 		Outputs:     outputs,
+		Skipped:     isSkipped,
 	}
 }
 
-func getOutputs(previousRealizedResource *v1alpha1.RealizedResource, output *templates.Output) []v1alpha1.Output {
+// getOutputs returns the outputs of the resource. An output which changed is given the current time and
+// the outputs of the input resources which produced it, an output which did not keeps those it had.
+func getOutputs(previousRealizedResource *v1alpha1.RealizedResource, output *templates.Output, outputInputs []v1alpha1.OutputInput) []v1alpha1.Output {
 	outputs, err := generateResourceOutput(output)
 	if err != nil {
 		outputs = previousRealizedResource.Outputs
@@ -268,10 +509,12 @@ func getOutputs(previousRealizedResource *v1alpha1.RealizedResource, output *tem
 		currTime := metav1.NewTime(time.Now())
 		for j, out := range outputs {
 			outputs[j].LastTransitionTime = currTime
+			outputs[j].Inputs = append([]v1alpha1.OutputInput(nil), outputInputs...)
 			for _, previousOutput := range previousRealizedResource.Outputs {
 				if previousOutput.Name == out.Name {
 					if previousOutput.Digest == out.Digest {
 						outputs[j].LastTransitionTime = previousOutput.LastTransitionTime
+						outputs[j].Inputs = previousOutput.Inputs
 					}
 					break
 				}
@@ -282,8 +525,104 @@ func getOutputs(previousRealizedResource *v1alpha1.RealizedResource, output *tem
 	return outputs
 }
 
-// TODO: This should be polymorphic
+// currentOutputInputs are the current outputs of the input resources
+func currentOutputInputs(inputs []v1alpha1.Input, currentResourceStatuses statuses.ResourceStatusList) []v1alpha1.OutputInput {
+	var outputInputs []v1alpha1.OutputInput
+	seen := make(map[string]bool)
+	for _, input := range inputs {
+		if seen[input.Name] {
+			continue
+		}
+		seen[input.Name] = true
+
+		for _, resourceStatus := range currentResourceStatuses {
+			if resourceStatus.Name != input.Name {
+				continue
+			}
+			for _, output := range resourceStatus.Outputs {
+				outputInputs = append(outputInputs, v1alpha1.OutputInput{
+					Resource: resourceStatus.Name,
+					Name:     output.Name,
+					Digest:   output.Digest,
+				})
+			}
+		}
+	}
+	return outputInputs
+}
 
+// recordImageProvenance sends a provenance statement for the image output of the resource when the image
+// changed and is pinned by digest. The lineage of the image is traced through the current resource statuses.
+func (r *realizer) recordImageProvenance(ctx context.Context, blueprintName string, realizedResource *v1alpha1.RealizedResource,
+	stampedObject *unstructured.Unstructured, output *templates.Output, previousOutputs []v1alpha1.Output, currentResourceStatuses statuses.ResourceStatusList) {
+	if output == nil || stampedObject == nil {
+		return
+	}
+
+	image, ok := output.Image.(string)
+	if !ok {
+		return
+	}
+
+	var imageOutput *v1alpha1.Output
+	for i := range realizedResource.Outputs {
+		if realizedResource.Outputs[i].Name == "image" {
+			imageOutput = &realizedResource.Outputs[i]
+		}
+	}
+	if imageOutput == nil {
+		return
+	}
+	for _, previousOutput := range previousOutputs {
+		if previousOutput.Name == imageOutput.Name && previousOutput.Digest == imageOutput.Digest {
+			return
+		}
+	}
+
+	log := logr.FromContextOrDiscard(ctx)
+
+	subject, ok := provenance.ImageSubject(image)
+	if !ok {
+		log.V(logger.DEBUG).Info("not recording provenance of image which is not pinned by digest", "image", image)
+		return
+	}
+
+	owner := metav1.GetControllerOf(stampedObject)
+	if owner == nil {
+		log.V(logger.DEBUG).Info("not recording provenance of image of object without controller", "object", stampedObject)
+		return
+	}
+
+	var realizedResources []v1alpha1.RealizedResource
+	for _, resourceStatus := range currentResourceStatuses {
+		realizedResources = append(realizedResources, resourceStatus.RealizedResource)
+	}
+
+	statement := provenance.NewImageStatement(*owner, stampedObject.GetNamespace(), blueprintName, realizedResource.Name,
+		subject, provenance.Lineage(realizedResources, imageOutput.Inputs), imageOutput.LastTransitionTime.Time)
+
+	rec := events.FromContextOrDie(ctx)
+	rec.ResourceCloudEvent(events.ImageProvenanceReason, events.ResourceEventData{
+		Resource:      realizedResource.Name,
+		StampedObject: events.StampedObjectReference(stampedObject),
+		Outputs:       cloudEventOutputs([]v1alpha1.Output{*imageOutput}),
+		Provenance:    &statement,
+	})
+}
+
+func cloudEventOutputs(outputs []v1alpha1.Output) []events.ResourceOutput {
+	var cloudEventOutputs []events.ResourceOutput
+	for _, output := range outputs {
+		cloudEventOutputs = append(cloudEventOutputs, events.ResourceOutput{
+			Name:    output.Name,
+			Digest:  output.Digest,
+			Preview: output.Preview,
+		})
+	}
+	return cloudEventOutputs
+}
+
+// generateResourceOutput returns the output of the template kind followed by the named outputs, by name
 func generateResourceOutput(output *templates.Output) ([]v1alpha1.Output, error) {
 	if output == nil {
 		return nil, nil
@@ -315,10 +654,22 @@ func generateResourceOutput(output *templates.Output) ([]v1alpha1.Output, error)
 			return nil, err
 		}
 		result = append(result, out)
+	}
 
-	} else {
-		return nil, nil
+	var names []string
+	for name := range output.Named {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		out, err := buildOneOutput(name, output.Named[name])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, out)
+	}
+
 	return result, nil
 }
 
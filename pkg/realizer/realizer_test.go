@@ -19,6 +19,7 @@ import (
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -30,18 +31,29 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/ptr"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/conditions"
 	"github.com/vmware-tanzu/cartographer/pkg/controllers"
+	cerrors "github.com/vmware-tanzu/cartographer/pkg/errors"
 	"github.com/vmware-tanzu/cartographer/pkg/events"
 	"github.com/vmware-tanzu/cartographer/pkg/events/eventsfakes"
+	"github.com/vmware-tanzu/cartographer/pkg/provenance"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/realizerfakes"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/statuses"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
 )
 
+type doReturn struct {
+	reader        templates.Reader
+	stampedObject *unstructured.Unstructured
+	output        *templates.Output
+	templateName  string
+	err           error
+}
+
 type event struct {
 	EventType    string
 	Reason       string
@@ -50,6 +62,93 @@ type event struct {
 	FmtArgs      []interface{}
 }
 
+func makeSupplychainOwnerResources(supplyChain v1alpha1.SupplyChainObject) []realizer.OwnerResource {
+	ownerResources, err := realizer.MakeSupplychainOwnerResources(context.TODO(), nil, supplyChain)
+	ExpectWithOffset(1, err).NotTo(HaveOccurred())
+	return ownerResources
+}
+
+var _ = Describe("MakeSupplychainOwnerResources", func() {
+	var (
+		supplyChain *v1alpha1.ClusterSupplyChain
+		subChains   map[string]*v1alpha1.ClusterSupplyChain
+		getSubChain v1alpha1.ClusterSupplyChainGetter
+	)
+
+	BeforeEach(func() {
+		subChains = map[string]*v1alpha1.ClusterSupplyChain{
+			"build-chain": {
+				ObjectMeta: metav1.ObjectMeta{Name: "build-chain"},
+				Spec: v1alpha1.SupplyChainSpec{
+					SubChain: &v1alpha1.SubChain{
+						Entries: []string{"image-builder"},
+						Export:  "image-scanner",
+					},
+					Resources: []v1alpha1.SupplyChainResource{
+						{
+							Name:        "image-builder",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterImageTemplate", Name: "kpack"},
+						},
+						{
+							Name:        "image-scanner",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterImageTemplate", Name: "scanner"},
+							Images:      []v1alpha1.ResourceReference{{Name: "image", Resource: "image-builder"}},
+						},
+					},
+				},
+			},
+		}
+		getSubChain = func(_ context.Context, name string) (*v1alpha1.ClusterSupplyChain, error) {
+			return subChains[name], nil
+		}
+
+		supplyChain = &v1alpha1.ClusterSupplyChain{
+			ObjectMeta: metav1.ObjectMeta{Name: "parent-chain"},
+			Spec: v1alpha1.SupplyChainSpec{
+				Resources: []v1alpha1.SupplyChainResource{
+					{
+						Name:        "source-provider",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterSourceTemplate", Name: "git"},
+					},
+					{
+						Name:        "build",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterSupplyChain", Name: "build-chain"},
+						Sources:     []v1alpha1.ResourceReference{{Name: "source", Resource: "source-provider"}},
+					},
+					{
+						Name:        "config",
+						TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterConfigTemplate", Name: "app-config"},
+						Images:      []v1alpha1.ResourceReference{{Name: "image", Resource: "build"}},
+					},
+				},
+			},
+		}
+	})
+
+	It("inlines the resources of referenced sub-chains", func() {
+		ownerResources, err := realizer.MakeSupplychainOwnerResources(context.TODO(), getSubChain, supplyChain)
+		Expect(err).NotTo(HaveOccurred())
+
+		var names []string
+		for _, resource := range ownerResources {
+			names = append(names, resource.Name)
+		}
+		Expect(names).To(Equal([]string{"source-provider", "build.image-builder", "build.image-scanner", "config"}))
+
+		Expect(ownerResources[1].TemplateRef).To(Equal(v1alpha1.TemplateReference{Kind: "ClusterImageTemplate", Name: "kpack"}))
+		Expect(ownerResources[1].Sources).To(Equal([]v1alpha1.ResourceReference{{Name: "source", Resource: "source-provider"}}))
+		Expect(ownerResources[2].Images).To(Equal([]v1alpha1.ResourceReference{{Name: "image", Resource: "build.image-builder"}}))
+		Expect(ownerResources[3].Images).To(Equal([]v1alpha1.ResourceReference{{Name: "image", Resource: "build.image-scanner"}}))
+	})
+
+	It("returns an error when a sub-chain does not exist", func() {
+		delete(subChains, "build-chain")
+
+		_, err := realizer.MakeSupplychainOwnerResources(context.TODO(), getSubChain, supplyChain)
+		Expect(err).To(MatchError("sub-chain [build-chain] referenced by resource [build] not found"))
+	})
+})
+
 var _ = Describe("Realize", func() {
 	var (
 		resourceRealizer               *realizerfakes.FakeResourceRealizer
@@ -90,7 +189,7 @@ var _ = Describe("Realize", func() {
 			}
 		}
 		fakeMapper = &realizerfakes.FakeRESTMapper{}
-		rlzr = realizer.NewRealizer(healthyConditionEvaluator, fakeMapper)
+		rlzr = realizer.NewRealizer(healthyConditionEvaluator, fakeMapper, 1, false)
 		resourceRealizer = &realizerfakes.FakeResourceRealizer{}
 	})
 
@@ -199,7 +298,7 @@ var _ = Describe("Realize", func() {
 
 		It("realizes each resource in supply chain order, accumulating output for each subsequent resource", func() {
 			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
-			err := rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)
+			err := rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)
 			Expect(err).ToNot(HaveOccurred())
 
 			currentResourceStatuses := resourceStatuses.GetCurrent()
@@ -266,7 +365,7 @@ var _ = Describe("Realize", func() {
 
 		It("records an event for resource output changes and health status", func() {
 			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
-			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
 
 			Expect(recordedEvents).To(ConsistOf(
 				event{"Normal", events.ResourceOutputChangedReason, "[%s] found a new output in [%Q]", "obj1", []interface{}{"resource1"}},
@@ -293,7 +392,7 @@ var _ = Describe("Realize", func() {
 			}
 
 			resourceStatuses := statuses.NewResourceStatuses(previousResources, conditions.AddConditionForResourceSubmittedWorkload)
-			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
 
 			Expect(recordedEvents).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{"Reason": Equal(events.ResourceOutputChangedReason)})))
 		})
@@ -312,7 +411,7 @@ var _ = Describe("Realize", func() {
 
 			It("returns the first error encountered and continues to realize", func() {
 				resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
-				err = rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)
+				err = rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)
 
 				Expect(err).To(MatchError("realizing is hard"))
 				rs := *resourceStatuses
@@ -413,7 +512,7 @@ var _ = Describe("Realize", func() {
 
 		It("records an event for resource output changes", func() {
 			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
-			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
 
 			Expect(recordedEvents).To(ConsistOf(
 				event{"Normal", events.ResourceOutputChangedReason, "[%s] found a new output in [%Q]", "obj1", []interface{}{"resource1"}},
@@ -422,6 +521,33 @@ var _ = Describe("Realize", func() {
 			))
 		})
 
+		It("sends cloudevents carrying the new outputs", func() {
+			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+
+			Expect(rec.ResourceCloudEventCallCount()).To(Equal(3))
+
+			reason, data := rec.ResourceCloudEventArgsForCall(0)
+			Expect(reason).To(Equal(events.ResourceOutputChangedReason))
+			Expect(data.Resource).To(Equal("resource1"))
+			Expect(data.StampedObject.Name).To(Equal("obj1"))
+			Expect(data.Outputs).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Name":    Equal("image"),
+				"Preview": Equal("whatever\n"),
+				"Digest":  HavePrefix("sha256:"),
+			})))
+
+			reason, data = rec.ResourceCloudEventArgsForCall(1)
+			Expect(reason).To(Equal(events.ResourceHealthyStatusChangedReason))
+			Expect(data.Resource).To(Equal("resource1"))
+			Expect(data.Healthy).To(Equal("True"))
+
+			reason, data = rec.ResourceCloudEventArgsForCall(2)
+			Expect(reason).To(Equal(events.ResourceOutputChangedReason))
+			Expect(data.Resource).To(Equal("resource2"))
+			Expect(data.StampedObject).To(BeNil())
+		})
+
 		It("does not record an event if there was no resource output change", func() {
 			previousResources := []v1alpha1.ResourceStatus{
 				{
@@ -440,14 +566,14 @@ var _ = Describe("Realize", func() {
 			}
 
 			resourceStatuses := statuses.NewResourceStatuses(previousResources, conditions.AddConditionForResourceSubmittedWorkload)
-			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
 
 			Expect(recordedEvents).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{"Message": ContainSubstring("passed through")})))
 		})
 
 		It("generates the correct realized resource", func() {
 			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
-			err := rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)
+			err := rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)
 			Expect(err).ToNot(HaveOccurred())
 
 			currentResourceStatuses := resourceStatuses.GetCurrent()
@@ -509,6 +635,798 @@ var _ = Describe("Realize", func() {
 		})
 	})
 
+	Context("a resource consumes the outputs of another", func() {
+		var (
+			sourceTemplate *v1alpha1.ClusterSourceTemplate
+			imageTemplate  *v1alpha1.ClusterImageTemplate
+			supplyChain    *v1alpha1.ClusterSupplyChain
+			sourceOutput   *templates.Output
+			imageOutput    *templates.Output
+		)
+
+		BeforeEach(func() {
+			sourceTemplate = &v1alpha1.ClusterSourceTemplate{ObjectMeta: metav1.ObjectMeta{Name: "my-source-template"}}
+			imageTemplate = &v1alpha1.ClusterImageTemplate{ObjectMeta: metav1.ObjectMeta{Name: "my-image-template"}}
+			supplyChain = &v1alpha1.ClusterSupplyChain{
+				ObjectMeta: metav1.ObjectMeta{Name: "greatest-supply-chain"},
+				Spec: v1alpha1.SupplyChainSpec{
+					Resources: []v1alpha1.SupplyChainResource{
+						{
+							Name:        "source-provider",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterSourceTemplate", Name: sourceTemplate.Name},
+						},
+						{
+							Name:        "image-builder",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterImageTemplate", Name: imageTemplate.Name},
+							Sources:     []v1alpha1.ResourceReference{{Name: "source", Resource: "source-provider"}},
+						},
+					},
+				},
+			}
+
+			sourceOutput = &templates.Output{Source: &templates.Source{URL: "https://example.com/source.tar.gz", Revision: "main/abc123"}}
+			imageOutput = &templates.Output{Image: "registry.example/app@sha256:0123456789abcdef"}
+
+			resourceRealizer.DoCalls(func(ctx context.Context, resource realizer.OwnerResource, blueprintName string, outputs realizer.Outputs, mapper meta.RESTMapper) (templates.Reader, *unstructured.Unstructured, *templates.Output, bool, string, error) {
+				stampedObj := &unstructured.Unstructured{}
+				stampedObj.SetNamespace("my-ns")
+				stampedObj.SetOwnerReferences([]metav1.OwnerReference{{
+					Kind:       "Workload",
+					Name:       "my-workload",
+					UID:        "my-workload-uid",
+					Controller: ptr.To(true),
+				}})
+
+				if resource.Name == "source-provider" {
+					reader, err := templates.NewReaderFromAPI(sourceTemplate)
+					Expect(err).NotTo(HaveOccurred())
+					stampedObj.SetName("source-obj")
+					return reader, stampedObj, sourceOutput, false, sourceTemplate.Name, nil
+				}
+
+				reader, err := templates.NewReaderFromAPI(imageTemplate)
+				Expect(err).NotTo(HaveOccurred())
+				stampedObj.SetName("image-obj")
+				return reader, stampedObj, imageOutput, false, imageTemplate.Name, nil
+			})
+
+			fakeMapper.RESTMappingReturns(&meta.RESTMapping{}, nil)
+		})
+
+		digestOf := func(value string) string {
+			return fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(value+"\n")))
+		}
+
+		It("records the outputs of the input resources which produced each output", func() {
+			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+
+			currentResourceStatuses := resourceStatuses.GetCurrent()
+			Expect(currentResourceStatuses).To(HaveLen(2))
+			Expect(currentResourceStatuses[0].Outputs).To(HaveLen(2))
+			Expect(currentResourceStatuses[0].Outputs[0].Inputs).To(BeNil())
+
+			Expect(currentResourceStatuses[1].Outputs).To(HaveLen(1))
+			Expect(currentResourceStatuses[1].Outputs[0].Inputs).To(Equal([]v1alpha1.OutputInput{
+				{Resource: "source-provider", Name: "url", Digest: digestOf("https://example.com/source.tar.gz")},
+				{Resource: "source-provider", Name: "revision", Digest: digestOf("main/abc123")},
+			}))
+		})
+
+		It("gives each output its own inputs", func() {
+			supplyChain.Spec.Resources = append(supplyChain.Spec.Resources, v1alpha1.SupplyChainResource{
+				Name:        "source-mirror",
+				TemplateRef: v1alpha1.SupplyChainTemplateReference{Kind: "ClusterSourceTemplate", Name: sourceTemplate.Name},
+				Sources:     []v1alpha1.ResourceReference{{Name: "source", Resource: "source-provider"}},
+			})
+			doStub := resourceRealizer.DoStub
+			resourceRealizer.DoCalls(func(ctx context.Context, resource realizer.OwnerResource, blueprintName string, outputs realizer.Outputs, mapper meta.RESTMapper) (templates.Reader, *unstructured.Unstructured, *templates.Output, bool, string, error) {
+				if resource.Name != "source-mirror" {
+					return doStub(ctx, resource, blueprintName, outputs, mapper)
+				}
+				reader, err := templates.NewReaderFromAPI(sourceTemplate)
+				Expect(err).NotTo(HaveOccurred())
+				stampedObj := &unstructured.Unstructured{}
+				stampedObj.SetName("mirror-obj")
+				return reader, stampedObj, sourceOutput, false, sourceTemplate.Name, nil
+			})
+
+			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+
+			mirrorOutputs := resourceStatuses.GetCurrent()[2].Outputs
+			Expect(mirrorOutputs).To(HaveLen(2))
+			Expect(mirrorOutputs[0].Inputs).To(Equal(mirrorOutputs[1].Inputs))
+
+			mirrorOutputs[0].Inputs[0].Digest = "sha256:changed"
+			Expect(mirrorOutputs[1].Inputs[0].Digest).To(Equal(digestOf("https://example.com/source.tar.gz")))
+		})
+
+		It("keeps the inputs of an output which did not change", func() {
+			previousInputs := []v1alpha1.OutputInput{{Resource: "source-provider", Name: "revision", Digest: "sha256:older"}}
+			previousResources := []v1alpha1.ResourceStatus{
+				{
+					RealizedResource: v1alpha1.RealizedResource{
+						Name: "image-builder",
+						Outputs: []v1alpha1.Output{
+							{
+								Name:               "image",
+								Preview:            "registry.example/app@sha256:0123456789abcdef\n",
+								Digest:             digestOf("registry.example/app@sha256:0123456789abcdef"),
+								LastTransitionTime: metav1.Now(),
+								Inputs:             previousInputs,
+							},
+						},
+					},
+				},
+			}
+
+			resourceStatuses := statuses.NewResourceStatuses(previousResources, conditions.AddConditionForResourceSubmittedWorkload)
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+
+			var imageBuilderStatus v1alpha1.ResourceStatus
+			for _, resourceStatus := range resourceStatuses.GetCurrent() {
+				if resourceStatus.Name == "image-builder" {
+					imageBuilderStatus = resourceStatus
+				}
+			}
+			Expect(imageBuilderStatus.Outputs).To(HaveLen(1))
+			Expect(imageBuilderStatus.Outputs[0].Inputs).To(Equal(previousInputs))
+		})
+
+		It("records named outputs after the output of the template kind", func() {
+			sourceOutput.Named = map[string]interface{}{"commit": "abc123", "branch": "main"}
+			supplyChain.Spec.Resources[1].Outputs = []v1alpha1.OutputReference{{Resource: "source-provider", Name: "commit"}}
+
+			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+
+			currentResourceStatuses := resourceStatuses.GetCurrent()
+			Expect(currentResourceStatuses).To(HaveLen(2))
+
+			var outputNames []string
+			for _, output := range currentResourceStatuses[0].Outputs {
+				outputNames = append(outputNames, output.Name)
+			}
+			Expect(outputNames).To(Equal([]string{"url", "revision", "branch", "commit"}))
+			Expect(currentResourceStatuses[0].Outputs[3].Digest).To(Equal(digestOf("abc123")))
+
+			Expect(resourceRealizer.DoCallCount()).To(Equal(2))
+			_, imageBuilder, _, outputs, _ := resourceRealizer.DoArgsForCall(1)
+			Expect(imageBuilder.Outputs).To(Equal([]v1alpha1.OutputReference{{Resource: "source-provider", Name: "commit"}}))
+			Expect(outputs.GetNamed("source-provider")).To(HaveKeyWithValue("commit", "abc123"))
+			Expect(currentResourceStatuses[1].Inputs).To(ContainElement(v1alpha1.Input{Name: "source-provider"}))
+		})
+
+		It("does not send provenance when it is not exported", func() {
+			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+
+			for i := 0; i < rec.ResourceCloudEventCallCount(); i++ {
+				reason, _ := rec.ResourceCloudEventArgsForCall(i)
+				Expect(reason).NotTo(Equal(events.ImageProvenanceReason))
+			}
+		})
+
+		Context("provenance is exported", func() {
+			BeforeEach(func() {
+				rlzr = realizer.NewRealizer(healthyConditionEvaluator, fakeMapper, 1, true)
+			})
+
+			It("sends a provenance statement for the new image", func() {
+				resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
+				Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+
+				var provenanceData []events.ResourceEventData
+				for i := 0; i < rec.ResourceCloudEventCallCount(); i++ {
+					reason, data := rec.ResourceCloudEventArgsForCall(i)
+					if reason == events.ImageProvenanceReason {
+						provenanceData = append(provenanceData, data)
+					}
+				}
+				Expect(provenanceData).To(HaveLen(1))
+				Expect(provenanceData[0].Resource).To(Equal("image-builder"))
+				Expect(provenanceData[0].StampedObject.Name).To(Equal("image-obj"))
+
+				statement := provenanceData[0].Provenance
+				Expect(statement).NotTo(BeNil())
+				Expect(statement.Subject).To(Equal([]provenance.ResourceDescriptor{{
+					Name:   "registry.example/app",
+					Digest: map[string]string{"sha256": "0123456789abcdef"},
+				}}))
+				Expect(statement.Predicate.BuildDefinition.ExternalParameters).To(Equal(provenance.ExternalParameters{
+					Owner:     provenance.Reference{Kind: "Workload", Namespace: "my-ns", Name: "my-workload"},
+					Blueprint: "greatest-supply-chain",
+					Resource:  "image-builder",
+				}))
+				Expect(statement.Predicate.BuildDefinition.ResolvedDependencies).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"Name": Equal("source-provider/url")}),
+					MatchFields(IgnoreExtras, Fields{"Name": Equal("source-provider/revision")}),
+				))
+				Expect(statement.Predicate.RunDetails.Metadata.InvocationID).To(Equal("my-workload-uid"))
+			})
+
+			It("does not send provenance for an image which is not pinned by digest", func() {
+				imageOutput.Image = "registry.example/app:latest"
+
+				resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
+				Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+
+				for i := 0; i < rec.ResourceCloudEventCallCount(); i++ {
+					reason, _ := rec.ResourceCloudEventArgsForCall(i)
+					Expect(reason).NotTo(Equal(events.ImageProvenanceReason))
+				}
+			})
+		})
+	})
+
+	Context("one of the resources is skipped", func() {
+		var (
+			template1               *v1alpha1.ClusterImageTemplate
+			executedResourceOrder   []string
+			supplyChain             *v1alpha1.ClusterSupplyChain
+			outputFromFirstResource *templates.Output
+		)
+		BeforeEach(func() {
+			executedResourceOrder = nil
+			template1 = &v1alpha1.ClusterImageTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "my-image-template",
+				},
+			}
+			supplyChain = &v1alpha1.ClusterSupplyChain{
+				ObjectMeta: metav1.ObjectMeta{Name: "greatest-supply-chain"},
+				Spec: v1alpha1.SupplyChainSpec{
+					Resources: []v1alpha1.SupplyChainResource{
+						{
+							Name: "resource1",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{
+								Kind: "ClusterImageTemplate",
+								Name: template1.Name,
+							},
+						},
+						{
+							Name: "resource2",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{
+								Kind: "ClusterImageTemplate",
+								Name: "scanning-template",
+							},
+							Images: []v1alpha1.ResourceReference{
+								{
+									Name:     "my-image",
+									Resource: "resource1",
+								},
+							},
+							When: &v1alpha1.ResourceCondition{
+								Expression: "params.scanning == 'enabled'",
+							},
+						},
+						{
+							Name: "resource3",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{
+								Kind: "ClusterTemplate",
+								Name: "deployer-template",
+							},
+							Images: []v1alpha1.ResourceReference{
+								{
+									Name:     "scanned-image",
+									Resource: "resource2",
+								},
+							},
+						},
+					},
+				},
+			}
+
+			outputFromFirstResource = &templates.Output{Image: "whatever"}
+
+			resourceRealizer.IsSkippedCalls(func(ctx context.Context, resource realizer.OwnerResource, blueprintName string, outputs realizer.Outputs) (bool, error) {
+				return resource.When != nil, nil
+			})
+
+			resourceRealizer.DoCalls(func(ctx context.Context, resource realizer.OwnerResource, blueprintName string, outputs realizer.Outputs, mapper meta.RESTMapper) (templates.Reader, *unstructured.Unstructured, *templates.Output, bool, string, error) {
+				executedResourceOrder = append(executedResourceOrder, resource.Name)
+				if resource.Name == "resource1" {
+					reader, err := templates.NewReaderFromAPI(template1)
+					Expect(err).NotTo(HaveOccurred())
+					stampedObj := &unstructured.Unstructured{}
+					stampedObj.SetName("obj1")
+					return reader, stampedObj, outputFromFirstResource, false, resource.TemplateRef.Name, nil
+				}
+
+				expectedThirdResourceOutputs := realizer.NewOutputs()
+				expectedThirdResourceOutputs.AddOutput("resource2", outputFromFirstResource)
+				Expect(outputs).To(Equal(expectedThirdResourceOutputs))
+
+				reader, err := templates.NewReaderFromAPI(&v1alpha1.ClusterTemplate{ObjectMeta: metav1.ObjectMeta{Name: "deployer-template"}})
+				Expect(err).NotTo(HaveOccurred())
+				stampedObj := &unstructured.Unstructured{}
+				stampedObj.SetName("obj3")
+				return reader, stampedObj, &templates.Output{}, false, resource.TemplateRef.Name, nil
+			})
+
+			fakeMapper.RESTMappingReturns(&meta.RESTMapping{
+				Resource: schema.GroupVersionResource{
+					Group:    "EXAMPLE.COM",
+					Version:  "v1",
+					Resource: "FOO",
+				},
+			}, nil)
+		})
+
+		It("does not realize the skipped resource, and passes its input through to the resources consuming it", func() {
+			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+
+			Expect(executedResourceOrder).To(Equal([]string{"resource1", "resource3"}))
+			Expect(resourceRealizer.IsSkippedCallCount()).To(Equal(3))
+		})
+
+		It("marks the skipped resource as skipped", func() {
+			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+
+			currentResourceStatuses := resourceStatuses.GetCurrent()
+			Expect(currentResourceStatuses).To(HaveLen(3))
+
+			Expect(currentResourceStatuses[0].Skipped).To(BeFalse())
+			Expect(currentResourceStatuses[2].Skipped).To(BeFalse())
+
+			Expect(currentResourceStatuses[1].Name).To(Equal("resource2"))
+			Expect(currentResourceStatuses[1].Skipped).To(BeTrue())
+			Expect(currentResourceStatuses[1].TemplateRef).To(BeNil())
+			Expect(currentResourceStatuses[1].StampedRef).To(BeNil())
+			Expect(currentResourceStatuses[1].Inputs).To(Equal([]v1alpha1.Input{{Name: "resource1"}}))
+			Expect(currentResourceStatuses[1].Outputs).To(ConsistOf(MatchFields(IgnoreExtras,
+				Fields{
+					"Name":    Equal("image"),
+					"Preview": Equal("whatever\n"),
+				},
+			)))
+			Expect(currentResourceStatuses[1].Conditions).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal("ResourceSubmitted"),
+					"Status": Equal(metav1.ConditionTrue),
+					"Reason": Equal("Skipped"),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal("Ready"),
+					"Status": Equal(metav1.ConditionTrue),
+				}),
+			))
+		})
+
+		Context("the skipped resource has no input of the type it outputs", func() {
+			BeforeEach(func() {
+				supplyChain.Spec.Resources[1].Images = nil
+				supplyChain.Spec.Resources[1].Sources = []v1alpha1.ResourceReference{
+					{
+						Name:     "my-source",
+						Resource: "resource1",
+					},
+				}
+
+				resourceRealizer.DoCalls(func(ctx context.Context, resource realizer.OwnerResource, blueprintName string, outputs realizer.Outputs, mapper meta.RESTMapper) (templates.Reader, *unstructured.Unstructured, *templates.Output, bool, string, error) {
+					executedResourceOrder = append(executedResourceOrder, resource.Name)
+					if resource.Name == "resource3" {
+						expectedThirdResourceOutputs := realizer.NewOutputs()
+						expectedThirdResourceOutputs.AddOutput("resource2", nil)
+						Expect(outputs).To(Equal(expectedThirdResourceOutputs))
+					}
+					return nil, nil, nil, false, resource.TemplateRef.Name, nil
+				})
+			})
+
+			It("treats the output of the skipped resource as absent", func() {
+				resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
+				Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+
+				Expect(executedResourceOrder).To(Equal([]string{"resource1", "resource3"}))
+				Expect(resourceStatuses.GetCurrent()[1].Outputs).To(BeEmpty())
+			})
+		})
+
+		Context("the when condition cannot be evaluated", func() {
+			BeforeEach(func() {
+				resourceRealizer.IsSkippedCalls(func(ctx context.Context, resource realizer.OwnerResource, blueprintName string, outputs realizer.Outputs) (bool, error) {
+					if resource.When != nil {
+						return false, cerrors.ResourceConditionError{
+							Err:           errors.New("no such key: scanning"),
+							ResourceName:  resource.Name,
+							BlueprintName: blueprintName,
+							BlueprintType: cerrors.SupplyChain,
+						}
+					}
+					return false, nil
+				})
+
+				resourceRealizer.DoCalls(func(ctx context.Context, resource realizer.OwnerResource, blueprintName string, outputs realizer.Outputs, mapper meta.RESTMapper) (templates.Reader, *unstructured.Unstructured, *templates.Output, bool, string, error) {
+					executedResourceOrder = append(executedResourceOrder, resource.Name)
+					return nil, nil, nil, false, resource.TemplateRef.Name, nil
+				})
+			})
+
+			It("does not realize the resource and reports the error in its status", func() {
+				resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
+				err := rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)
+				Expect(err).To(MatchError("error evaluating when condition for resource [resource2] in supply chain [greatest-supply-chain]: no such key: scanning"))
+
+				Expect(executedResourceOrder).NotTo(ContainElement("resource2"))
+				Expect(resourceStatuses.GetCurrent().ConditionsForResourceNamed("resource2")).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal("ResourceSubmitted"),
+					"Status": Equal(metav1.ConditionFalse),
+					"Reason": Equal("ResourceConditionError"),
+				})))
+			})
+		})
+	})
+
+	Context("resources do not all depend on each other", func() {
+		var (
+			supplyChain *v1alpha1.ClusterSupplyChain
+			reader      templates.Reader
+		)
+
+		BeforeEach(func() {
+			var err error
+			reader, err = templates.NewReaderFromAPI(&v1alpha1.ClusterConfigTemplate{})
+			Expect(err).NotTo(HaveOccurred())
+
+			supplyChain = &v1alpha1.ClusterSupplyChain{
+				ObjectMeta: metav1.ObjectMeta{Name: "greatest-supply-chain"},
+				Spec: v1alpha1.SupplyChainSpec{
+					Resources: []v1alpha1.SupplyChainResource{
+						{Name: "scan"},
+						{Name: "sbom"},
+						{
+							Name: "build",
+							Configs: []v1alpha1.ResourceReference{
+								{Name: "scanned", Resource: "scan"},
+								{Name: "documented", Resource: "sbom"},
+							},
+						},
+					},
+				},
+			}
+
+			fakeMapper.RESTMappingReturns(&meta.RESTMapping{
+				Resource: schema.GroupVersionResource{
+					Group:    "EXAMPLE.COM",
+					Version:  "v1",
+					Resource: "FOO",
+				},
+			}, nil)
+		})
+
+		It("realizes independent resources concurrently and dependent resources once their inputs are realized", func() {
+			rlzr = realizer.NewRealizer(healthyConditionEvaluator, fakeMapper, 2, false)
+
+			started := make(chan string, 3)
+			release := make(chan struct{})
+
+			resourceRealizer.DoCalls(func(ctx context.Context, resource realizer.OwnerResource, blueprintName string, outputs realizer.Outputs, mapper meta.RESTMapper) (templates.Reader, *unstructured.Unstructured, *templates.Output, bool, string, error) {
+				started <- resource.Name
+				stampedObj := &unstructured.Unstructured{}
+				stampedObj.SetName(resource.Name)
+
+				if resource.Name == "build" {
+					expectedOutputs := realizer.NewOutputs()
+					expectedOutputs.AddOutput("scan", &templates.Output{Config: "scan"})
+					expectedOutputs.AddOutput("sbom", &templates.Output{Config: "sbom"})
+					Expect(outputs).To(Equal(expectedOutputs))
+					return reader, stampedObj, &templates.Output{Config: "build"}, false, "build-template", nil
+				}
+
+				Expect(outputs).To(Equal(realizer.NewOutputs()))
+				<-release
+				return reader, stampedObj, &templates.Output{Config: resource.Name}, false, resource.Name + "-template", nil
+			})
+
+			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
+			realizeErr := make(chan error)
+			go func() {
+				defer GinkgoRecover()
+				realizeErr <- rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)
+			}()
+
+			var first, second string
+			Eventually(started).Should(Receive(&first))
+			Eventually(started).Should(Receive(&second))
+			Expect([]string{first, second}).To(ConsistOf("scan", "sbom"))
+			Consistently(started).ShouldNot(Receive())
+
+			close(release)
+			Eventually(started).Should(Receive(Equal("build")))
+			Eventually(realizeErr).Should(Receive(BeNil()))
+
+			currentStatuses := resourceStatuses.GetCurrent()
+			Expect(currentStatuses).To(HaveLen(3))
+			Expect(currentStatuses[0].Name).To(Equal("scan"))
+			Expect(currentStatuses[1].Name).To(Equal("sbom"))
+			Expect(currentStatuses[2].Name).To(Equal("build"))
+			Expect(currentStatuses[2].Inputs).To(Equal([]v1alpha1.Input{{Name: "scan"}, {Name: "sbom"}}))
+		})
+
+		It("does not realize more resources at once than the max concurrency", func() {
+			rlzr = realizer.NewRealizer(healthyConditionEvaluator, fakeMapper, 1, false)
+
+			var (
+				mtx            sync.Mutex
+				inFlight       int
+				maxInFlight    int
+				executionOrder []string
+			)
+
+			resourceRealizer.DoCalls(func(ctx context.Context, resource realizer.OwnerResource, blueprintName string, outputs realizer.Outputs, mapper meta.RESTMapper) (templates.Reader, *unstructured.Unstructured, *templates.Output, bool, string, error) {
+				mtx.Lock()
+				inFlight++
+				if inFlight > maxInFlight {
+					maxInFlight = inFlight
+				}
+				executionOrder = append(executionOrder, resource.Name)
+				mtx.Unlock()
+
+				time.Sleep(10 * time.Millisecond)
+
+				mtx.Lock()
+				inFlight--
+				mtx.Unlock()
+
+				stampedObj := &unstructured.Unstructured{}
+				stampedObj.SetName(resource.Name)
+				return reader, stampedObj, &templates.Output{Config: resource.Name}, false, resource.Name + "-template", nil
+			})
+
+			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+
+			Expect(maxInFlight).To(Equal(1))
+			Expect(executionOrder).To(HaveLen(3))
+			Expect(executionOrder[2]).To(Equal("build"))
+		})
+	})
+
+	Context("a resource has a progress deadline", func() {
+		var (
+			supplyChain      *v1alpha1.ClusterSupplyChain
+			template         *v1alpha1.ClusterTemplate
+			healthyStatus    metav1.ConditionStatus
+			output           *templates.Output
+			previousStatuses []v1alpha1.ResourceStatus
+		)
+
+		BeforeEach(func() {
+			healthyStatus = metav1.ConditionUnknown
+			output = nil
+			previousStatuses = nil
+
+			rlzr = realizer.NewRealizer(func(rule *v1alpha1.HealthRule, realizedResource *v1alpha1.RealizedResource, stampedObject *unstructured.Unstructured) metav1.Condition {
+				return metav1.Condition{
+					Type:   "Healthy",
+					Status: healthyStatus,
+					Reason: "EvaluatorSaysSo",
+				}
+			}, fakeMapper, 1, false)
+
+			template = &v1alpha1.ClusterTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "slow-template"},
+				Spec: v1alpha1.TemplateSpec{
+					ProgressDeadline: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			}
+
+			supplyChain = &v1alpha1.ClusterSupplyChain{
+				ObjectMeta: metav1.ObjectMeta{Name: "greatest-supply-chain"},
+				Spec: v1alpha1.SupplyChainSpec{
+					Resources: []v1alpha1.SupplyChainResource{
+						{
+							Name: "slow-resource",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{
+								Kind: "ClusterTemplate",
+								Name: template.Name,
+							},
+						},
+					},
+				},
+			}
+
+			resourceRealizer.DoCalls(func(ctx context.Context, resource realizer.OwnerResource, blueprintName string, outputs realizer.Outputs, mapper meta.RESTMapper) (templates.Reader, *unstructured.Unstructured, *templates.Output, bool, string, error) {
+				reader, err := templates.NewReaderFromAPI(template)
+				Expect(err).NotTo(HaveOccurred())
+				stampedObj := &unstructured.Unstructured{}
+				stampedObj.SetName("slow-obj")
+				return reader, stampedObj, output, false, template.Name, nil
+			})
+
+			fakeMapper.RESTMappingReturns(&meta.RESTMapping{
+				Resource: schema.GroupVersionResource{
+					Group:    "EXAMPLE.COM",
+					Version:  "v1",
+					Resource: "FOO",
+				},
+			}, nil)
+		})
+
+		realize := func() statuses.ResourceStatusList {
+			resourceStatuses := statuses.NewResourceStatuses(previousStatuses, conditions.AddConditionForResourceSubmittedWorkload)
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+			return resourceStatuses.GetCurrent()
+		}
+
+		previousStatusWithDeadline := func(deadline time.Time, healthyReason string) []v1alpha1.ResourceStatus {
+			progressDeadline := metav1.NewTime(deadline)
+			return []v1alpha1.ResourceStatus{
+				{
+					RealizedResource: v1alpha1.RealizedResource{
+						Name:             "slow-resource",
+						ProgressDeadline: &progressDeadline,
+					},
+					Conditions: []metav1.Condition{
+						{
+							Type:   "Healthy",
+							Status: metav1.ConditionUnknown,
+							Reason: healthyReason,
+						},
+					},
+				},
+			}
+		}
+
+		It("records the deadline while the resource is not yet healthy", func() {
+			currentStatuses := realize()
+
+			Expect(currentStatuses[0].ProgressDeadline).NotTo(BeNil())
+			Expect(currentStatuses[0].ProgressDeadline.Time).To(BeTemporally("~", time.Now().Add(10*time.Minute), 2*time.Second))
+			Expect(currentStatuses[0].Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal("Healthy"),
+				"Status": Equal(metav1.ConditionUnknown),
+			})))
+		})
+
+		It("prefers the progress deadline of the blueprint resource over that of the template", func() {
+			supplyChain.Spec.Resources[0].ProgressDeadline = &metav1.Duration{Duration: time.Hour}
+
+			currentStatuses := realize()
+
+			Expect(currentStatuses[0].ProgressDeadline.Time).To(BeTemporally("~", time.Now().Add(time.Hour), 2*time.Second))
+		})
+
+		It("keeps the previously recorded deadline", func() {
+			deadline := time.Now().Add(5 * time.Minute).Truncate(time.Second)
+			previousStatuses = previousStatusWithDeadline(deadline, "EvaluatorSaysSo")
+
+			currentStatuses := realize()
+
+			Expect(currentStatuses[0].ProgressDeadline.Time).To(Equal(deadline))
+		})
+
+		It("does not record a deadline once the resource is healthy", func() {
+			healthyStatus = metav1.ConditionTrue
+			previousStatuses = previousStatusWithDeadline(time.Now().Add(5*time.Minute), "EvaluatorSaysSo")
+
+			currentStatuses := realize()
+
+			Expect(currentStatuses[0].ProgressDeadline).To(BeNil())
+		})
+
+		It("does not record a deadline once the resource has produced an output", func() {
+			output = &templates.Output{Config: "done"}
+
+			currentStatuses := realize()
+
+			Expect(currentStatuses[0].ProgressDeadline).To(BeNil())
+		})
+
+		Context("the deadline has passed", func() {
+			BeforeEach(func() {
+				previousStatuses = previousStatusWithDeadline(time.Now().Add(-time.Minute), "EvaluatorSaysSo")
+			})
+
+			It("sets the healthy condition to false", func() {
+				currentStatuses := realize()
+
+				Expect(currentStatuses[0].Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":    Equal("Healthy"),
+					"Status":  Equal(metav1.ConditionFalse),
+					"Reason":  Equal("ProgressDeadlineExceeded"),
+					"Message": Equal("resource did not become healthy or produce an output within the progress deadline [10m0s]"),
+				})))
+			})
+
+			It("records an event", func() {
+				realize()
+
+				Expect(recordedEvents).To(ContainElement(
+					event{"Warning", events.ProgressDeadlineExceededReason, "[%s] did not become healthy or produce an output in [%Q] within progress deadline [%s]", "slow-obj", []interface{}{"slow-resource", 10 * time.Minute}},
+				))
+			})
+
+			It("does not record the event again once the deadline was already exceeded", func() {
+				previousStatuses[0].Conditions[0].Status = metav1.ConditionFalse
+				previousStatuses[0].Conditions[0].Reason = "ProgressDeadlineExceeded"
+
+				realize()
+
+				Expect(recordedEvents).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{"Reason": Equal(events.ProgressDeadlineExceededReason)})))
+			})
+		})
+	})
+
+	Context("a resource has a resync period", func() {
+		var (
+			supplyChain *v1alpha1.ClusterSupplyChain
+			template    *v1alpha1.ClusterTemplate
+		)
+
+		BeforeEach(func() {
+			template = &v1alpha1.ClusterTemplate{
+				ObjectMeta: metav1.ObjectMeta{Name: "polled-template"},
+				Spec: v1alpha1.TemplateSpec{
+					ResyncPeriod: &metav1.Duration{Duration: 10 * time.Minute},
+				},
+			}
+
+			supplyChain = &v1alpha1.ClusterSupplyChain{
+				ObjectMeta: metav1.ObjectMeta{Name: "greatest-supply-chain"},
+				Spec: v1alpha1.SupplyChainSpec{
+					Resources: []v1alpha1.SupplyChainResource{
+						{
+							Name: "polled-resource",
+							TemplateRef: v1alpha1.SupplyChainTemplateReference{
+								Kind: "ClusterTemplate",
+								Name: template.Name,
+							},
+						},
+					},
+				},
+			}
+
+			resourceRealizer.DoCalls(func(ctx context.Context, resource realizer.OwnerResource, blueprintName string, outputs realizer.Outputs, mapper meta.RESTMapper) (templates.Reader, *unstructured.Unstructured, *templates.Output, bool, string, error) {
+				reader, err := templates.NewReaderFromAPI(template)
+				Expect(err).NotTo(HaveOccurred())
+				stampedObj := &unstructured.Unstructured{}
+				stampedObj.SetName("polled-obj")
+				return reader, stampedObj, &templates.Output{Config: "some-config"}, false, template.Name, nil
+			})
+
+			fakeMapper.RESTMappingReturns(&meta.RESTMapping{
+				Resource: schema.GroupVersionResource{
+					Group:    "EXAMPLE.COM",
+					Version:  "v1",
+					Resource: "FOO",
+				},
+			}, nil)
+		})
+
+		realize := func() statuses.ResourceStatusList {
+			resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
+			Expect(rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)).To(Succeed())
+			return resourceStatuses.GetCurrent()
+		}
+
+		It("records the resync period of the template", func() {
+			currentStatuses := realize()
+
+			Expect(currentStatuses[0].ResyncPeriod).To(Equal(&metav1.Duration{Duration: 10 * time.Minute}))
+		})
+
+		It("prefers the resync period of the blueprint resource over that of the template", func() {
+			supplyChain.Spec.Resources[0].ResyncPeriod = &metav1.Duration{Duration: time.Hour}
+
+			currentStatuses := realize()
+
+			Expect(currentStatuses[0].ResyncPeriod).To(Equal(&metav1.Duration{Duration: time.Hour}))
+		})
+
+		It("records no resync period when neither has one", func() {
+			template.Spec.ResyncPeriod = nil
+
+			currentStatuses := realize()
+
+			Expect(currentStatuses[0].ResyncPeriod).To(BeNil())
+		})
+	})
+
 	Context("there are previous resources", func() {
 		var (
 			reader1           templates.Reader
@@ -518,6 +1436,7 @@ var _ = Describe("Realize", func() {
 			previousResources []v1alpha1.ResourceStatus
 			previousTime      metav1.Time
 			supplyChain       *v1alpha1.ClusterSupplyChain
+			doReturns         map[string]doReturn
 		)
 		BeforeEach(func() {
 			previousTime = metav1.NewTime(time.Now())
@@ -642,9 +1561,15 @@ var _ = Describe("Realize", func() {
 			reader3, err = templates.NewReaderFromAPI(template3)
 			Expect(err).NotTo(HaveOccurred())
 
-			resourceRealizer.DoReturnsOnCall(0, reader1, &unstructured.Unstructured{}, nil, false, "first expected name", nil)
-			resourceRealizer.DoReturnsOnCall(1, reader2, &unstructured.Unstructured{}, nil, false, resource2.Name, nil)
-			resourceRealizer.DoReturnsOnCall(2, reader3, &unstructured.Unstructured{}, nil, false, resource3.Name, nil)
+			doReturns = map[string]doReturn{
+				"resource1": {reader1, &unstructured.Unstructured{}, nil, "first expected name", nil},
+				"resource2": {reader2, &unstructured.Unstructured{}, nil, resource2.Name, nil},
+				"resource3": {reader3, &unstructured.Unstructured{}, nil, resource3.Name, nil},
+			}
+			resourceRealizer.DoCalls(func(ctx context.Context, resource realizer.OwnerResource, blueprintName string, outputs realizer.Outputs, mapper meta.RESTMapper) (templates.Reader, *unstructured.Unstructured, *templates.Output, bool, string, error) {
+				r := doReturns[resource.Name]
+				return r.reader, r.stampedObject, r.output, false, r.templateName, r.err
+			})
 
 			fakeMapper.RESTMappingReturns(&meta.RESTMapping{
 				Resource: schema.GroupVersionResource{
@@ -670,20 +1595,20 @@ var _ = Describe("Realize", func() {
 			}
 			stampedObj1 := &unstructured.Unstructured{}
 			stampedObj1.SetName("obj1")
-			resourceRealizer.DoReturnsOnCall(0, reader1, stampedObj1, newOutput, false, "", nil)
+			doReturns["resource1"] = doReturn{reader1, stampedObj1, newOutput, "", nil}
 
 			oldOutput := &templates.Output{
 				Image: "whatever",
 			}
-			resourceRealizer.DoReturnsOnCall(1, reader2, &unstructured.Unstructured{}, oldOutput, false, "", nil)
+			doReturns["resource2"] = doReturn{reader2, &unstructured.Unstructured{}, oldOutput, "", nil}
 
 			oldOutput2 := &templates.Output{
 				Config: "whatever",
 			}
-			resourceRealizer.DoReturnsOnCall(2, reader3, obj, oldOutput2, false, "", nil)
+			doReturns["resource3"] = doReturn{reader3, obj, oldOutput2, "", nil}
 
 			resourceStatuses := statuses.NewResourceStatuses(previousResources, conditions.AddConditionForResourceSubmittedWorkload)
-			err := rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)
+			err := rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)
 			Expect(err).ToNot(HaveOccurred())
 
 			currentStatuses := resourceStatuses.GetCurrent()
@@ -745,19 +1670,19 @@ var _ = Describe("Realize", func() {
 
 		Context("there is an error realizing resource 1 and resource 2", func() {
 			BeforeEach(func() {
-				resourceRealizer.DoReturnsOnCall(0, nil, nil, nil, false, "", errors.New("im in a bad state"))
-				resourceRealizer.DoReturnsOnCall(1, nil, nil, nil, false, "", errors.New("im missing inputs"))
+				doReturns["resource1"] = doReturn{nil, nil, nil, "", errors.New("im in a bad state")}
+				doReturns["resource2"] = doReturn{nil, nil, nil, "", errors.New("im missing inputs")}
 
 				obj = &unstructured.Unstructured{}
 				obj.SetName("StampedObj")
 
-				resourceRealizer.DoReturnsOnCall(2, reader3, obj, nil, false, "expected name for resource 3", nil)
+				doReturns["resource3"] = doReturn{reader3, obj, nil, "expected name for resource 3", nil}
 			})
 
 			It("the status uses the previous resource for resource 2", func() {
 				resourceStatuses := statuses.NewResourceStatuses(previousResources, conditions.AddConditionForResourceSubmittedWorkload)
 
-				err := rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, realizer.MakeSupplychainOwnerResources(supplyChain), resourceStatuses)
+				err := rlzr.Realize(ctx, resourceRealizer, supplyChain.Name, makeSupplychainOwnerResources(supplyChain), resourceStatuses)
 				Expect(err).To(MatchError("im in a bad state"))
 
 				Expect(evaluatedRealizedResourceNames).To(Equal([]string{"resource3"}))
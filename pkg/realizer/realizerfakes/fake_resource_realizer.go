@@ -37,6 +37,22 @@ type FakeResourceRealizer struct {
 		result5 string
 		result6 error
 	}
+	IsSkippedStub        func(context.Context, realizer.OwnerResource, string, realizer.Outputs) (bool, error)
+	isSkippedMutex       sync.RWMutex
+	isSkippedArgsForCall []struct {
+		arg1 context.Context
+		arg2 realizer.OwnerResource
+		arg3 string
+		arg4 realizer.Outputs
+	}
+	isSkippedReturns struct {
+		result1 bool
+		result2 error
+	}
+	isSkippedReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -121,11 +137,80 @@ func (fake *FakeResourceRealizer) DoReturnsOnCall(i int, result1 templates.Reade
 	}{result1, result2, result3, result4, result5, result6}
 }
 
+func (fake *FakeResourceRealizer) IsSkipped(arg1 context.Context, arg2 realizer.OwnerResource, arg3 string, arg4 realizer.Outputs) (bool, error) {
+	fake.isSkippedMutex.Lock()
+	ret, specificReturn := fake.isSkippedReturnsOnCall[len(fake.isSkippedArgsForCall)]
+	fake.isSkippedArgsForCall = append(fake.isSkippedArgsForCall, struct {
+		arg1 context.Context
+		arg2 realizer.OwnerResource
+		arg3 string
+		arg4 realizer.Outputs
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.IsSkippedStub
+	fakeReturns := fake.isSkippedReturns
+	fake.recordInvocation("IsSkipped", []interface{}{arg1, arg2, arg3, arg4})
+	fake.isSkippedMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeResourceRealizer) IsSkippedCallCount() int {
+	fake.isSkippedMutex.RLock()
+	defer fake.isSkippedMutex.RUnlock()
+	return len(fake.isSkippedArgsForCall)
+}
+
+func (fake *FakeResourceRealizer) IsSkippedCalls(stub func(context.Context, realizer.OwnerResource, string, realizer.Outputs) (bool, error)) {
+	fake.isSkippedMutex.Lock()
+	defer fake.isSkippedMutex.Unlock()
+	fake.IsSkippedStub = stub
+}
+
+func (fake *FakeResourceRealizer) IsSkippedArgsForCall(i int) (context.Context, realizer.OwnerResource, string, realizer.Outputs) {
+	fake.isSkippedMutex.RLock()
+	defer fake.isSkippedMutex.RUnlock()
+	argsForCall := fake.isSkippedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeResourceRealizer) IsSkippedReturns(result1 bool, result2 error) {
+	fake.isSkippedMutex.Lock()
+	defer fake.isSkippedMutex.Unlock()
+	fake.IsSkippedStub = nil
+	fake.isSkippedReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeResourceRealizer) IsSkippedReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.isSkippedMutex.Lock()
+	defer fake.isSkippedMutex.Unlock()
+	fake.IsSkippedStub = nil
+	if fake.isSkippedReturnsOnCall == nil {
+		fake.isSkippedReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.isSkippedReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeResourceRealizer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.doMutex.RLock()
 	defer fake.doMutex.RUnlock()
+	fake.isSkippedMutex.RLock()
+	defer fake.isSkippedMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -18,8 +18,10 @@ package runnable
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/go-logr/logr"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
@@ -27,11 +29,13 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
+	"k8s.io/utils/strings"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/errors"
 	"github.com/vmware-tanzu/cartographer/pkg/events"
 	"github.com/vmware-tanzu/cartographer/pkg/logger"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/healthcheck"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer/runnable/gc"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
@@ -170,6 +174,11 @@ func (r *runnableRealizer) Realize(ctx context.Context, runnable *v1alpha1.Runna
 		if !reflect.DeepEqual(runnable.Status.Outputs, map[string]apiextensionsv1.JSON(outputs)) {
 			rec := events.FromContextOrDie(ctx)
 			rec.ResourceEventf(events.NormalType, events.ResourceOutputChangedReason, "Runnable [%s] found a new output in [%Q]", stampedObject, runnable.Name)
+			rec.ResourceCloudEvent(events.ResourceOutputChangedReason, events.ResourceEventData{
+				Resource:      runnable.Name,
+				StampedObject: events.StampedObjectReference(stampedObject),
+				Outputs:       cloudEventOutputs(outputs),
+			})
 		}
 	}
 
@@ -226,3 +235,21 @@ func (r *runnableRealizer) resolveSelector(ctx context.Context, selector *v1alph
 	}
 	return results[0].Object, nil
 }
+
+func cloudEventOutputs(outputs templates.Outputs) []events.ResourceOutput {
+	var names []string
+	for name := range outputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var cloudEventOutputs []events.ResourceOutput
+	for _, name := range names {
+		cloudEventOutputs = append(cloudEventOutputs, events.ResourceOutput{
+			Name:    name,
+			Digest:  fmt.Sprintf("sha256:%x", sha256.Sum256(outputs[name].Raw)),
+			Preview: strings.ShortenString(string(outputs[name].Raw), realizer.PreviewCharacterLimit),
+		})
+	}
+	return cloudEventOutputs
+}
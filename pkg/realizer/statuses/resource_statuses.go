@@ -16,6 +16,7 @@ package statuses
 
 import (
 	"reflect"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -74,6 +75,39 @@ func (rsl ResourceStatusList) ConditionsForResourceNamed(resourceName string) ut
 	return nil
 }
 
+// UntilNextProgressDeadline returns how long remains until the earliest progress deadline which has not yet passed
+func (rsl ResourceStatusList) UntilNextProgressDeadline(now time.Time) (time.Duration, bool) {
+	var next time.Duration
+	found := false
+	for _, status := range rsl {
+		if status.ProgressDeadline == nil || !now.Before(status.ProgressDeadline.Time) {
+			continue
+		}
+		until := status.ProgressDeadline.Sub(now)
+		if !found || until < next {
+			next = until
+			found = true
+		}
+	}
+	return next, found
+}
+
+// ShortestResyncPeriod returns the shortest resync period of the resources, if any has one
+func (rsl ResourceStatusList) ShortestResyncPeriod() (time.Duration, bool) {
+	var shortest time.Duration
+	found := false
+	for _, status := range rsl {
+		if status.ResyncPeriod == nil {
+			continue
+		}
+		if !found || status.ResyncPeriod.Duration < shortest {
+			shortest = status.ResyncPeriod.Duration
+			found = true
+		}
+	}
+	return shortest, found
+}
+
 func (r *resourceStatuses) IsChanged() bool {
 	for _, status := range r.statuses {
 		if status.current == nil {
@@ -134,7 +168,7 @@ func (r *resourceStatuses) Add(realizedResource *v1alpha1.RealizedResource, err
 
 	existingStatus.current = &v1alpha1.ResourceStatus{
 		RealizedResource: *realizedResource,
-		Conditions:       r.createConditions(name, err, isPassThrough, furtherConditions...),
+		Conditions:       r.createConditions(name, err, isPassThrough, realizedResource.Skipped, furtherConditions...),
 	}
 }
 
@@ -165,7 +199,7 @@ func conditionChanged(previousResourceStatus *v1alpha1.ResourceStatus, newCondit
 	return newCondition.Status != "Unknown"
 }
 
-func (r *resourceStatuses) createConditions(name string, err error, isPassThrough bool, furtherConditions ...metav1.Condition) []metav1.Condition {
+func (r *resourceStatuses) createConditions(name string, err error, isPassThrough bool, isSkipped bool, furtherConditions ...metav1.Condition) []metav1.Condition {
 	var existingStatus *resourceStatus
 	for _, status := range r.statuses {
 		if status.name == name {
@@ -183,6 +217,8 @@ func (r *resourceStatuses) createConditions(name string, err error, isPassThroug
 
 	if err != nil {
 		r.addConditionsFunc(&conditionManager, false, err)
+	} else if isSkipped {
+		conditionManager.AddPositive(conditions.ResourceSkippedCondition())
 	} else {
 		conditionManager.AddPositive(conditions.ResourceSubmittedCondition(isPassThrough))
 	}
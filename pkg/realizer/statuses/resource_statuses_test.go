@@ -16,9 +16,11 @@ package statuses_test
 
 import (
 	"errors"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -222,6 +224,20 @@ var _ = Describe("ResourceStatuses", func() {
 			})
 		})
 
+		Context("#add is called with a skipped resource", func() {
+			It("the resource is submitted with the reason Skipped", func() {
+				resourceStatuses.Add(&v1alpha1.RealizedResource{
+					Name:    "resource1",
+					Skipped: true,
+				}, nil, false)
+
+				Expect(resourceStatuses.GetCurrent().ConditionsForResourceNamed("resource1").ConditionWithType(v1alpha1.ResourceSubmitted)).To(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Status": Equal(metav1.ConditionTrue),
+					"Reason": Equal(v1alpha1.SkippedReason),
+				})))
+			})
+		})
+
 		Context("#add is not called", func() {
 			It("the resourceStatuses reports IsChanged is false", func() {
 				Expect(resourceStatuses.IsChanged()).To(BeFalse())
@@ -229,3 +245,82 @@ var _ = Describe("ResourceStatuses", func() {
 		})
 	})
 })
+
+var _ = Describe("ResourceStatusList", func() {
+	Describe("UntilNextProgressDeadline", func() {
+		var now time.Time
+
+		BeforeEach(func() {
+			now = time.Now()
+		})
+
+		statusWithDeadline := func(name string, deadline *time.Time) v1alpha1.ResourceStatus {
+			status := v1alpha1.ResourceStatus{RealizedResource: v1alpha1.RealizedResource{Name: name}}
+			if deadline != nil {
+				progressDeadline := metav1.NewTime(*deadline)
+				status.ProgressDeadline = &progressDeadline
+			}
+			return status
+		}
+
+		It("returns the time until the earliest deadline that has not passed", func() {
+			passed := now.Add(-time.Minute)
+			later := now.Add(10 * time.Minute)
+			sooner := now.Add(5 * time.Minute)
+
+			list := statuses.ResourceStatusList{
+				statusWithDeadline("passed", &passed),
+				statusWithDeadline("later", &later),
+				statusWithDeadline("none", nil),
+				statusWithDeadline("sooner", &sooner),
+			}
+
+			until, ok := list.UntilNextProgressDeadline(now)
+			Expect(ok).To(BeTrue())
+			Expect(until).To(Equal(5 * time.Minute))
+		})
+
+		It("returns false when no deadline is pending", func() {
+			passed := now.Add(-time.Minute)
+
+			list := statuses.ResourceStatusList{
+				statusWithDeadline("passed", &passed),
+				statusWithDeadline("none", nil),
+			}
+
+			_, ok := list.UntilNextProgressDeadline(now)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("ShortestResyncPeriod", func() {
+		statusWithResyncPeriod := func(name string, period time.Duration) v1alpha1.ResourceStatus {
+			status := v1alpha1.ResourceStatus{RealizedResource: v1alpha1.RealizedResource{Name: name}}
+			if period != 0 {
+				status.ResyncPeriod = &metav1.Duration{Duration: period}
+			}
+			return status
+		}
+
+		It("returns the shortest resync period", func() {
+			list := statuses.ResourceStatusList{
+				statusWithResyncPeriod("longer", 10*time.Minute),
+				statusWithResyncPeriod("none", 0),
+				statusWithResyncPeriod("shorter", 2*time.Minute),
+			}
+
+			period, ok := list.ShortestResyncPeriod()
+			Expect(ok).To(BeTrue())
+			Expect(period).To(Equal(2 * time.Minute))
+		})
+
+		It("returns false when no resource has a resync period", func() {
+			list := statuses.ResourceStatusList{
+				statusWithResyncPeriod("none", 0),
+			}
+
+			_, ok := list.ShortestResyncPeriod()
+			Expect(ok).To(BeFalse())
+		})
+	})
+})
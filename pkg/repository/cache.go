@@ -15,13 +15,26 @@
 package repository
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"reflect"
-	"sync"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/lru"
+
+	"github.com/vmware-tanzu/cartographer/pkg/metrics"
 )
 
+// SubmittedHashAnnotation is set on created objects to the hash of the object Cartographer submitted,
+// so that an object which is unchanged since submission is recognised even when it is not in the cache
+const SubmittedHashAnnotation = "carto.run/submitted-hash"
+
+// MaxCacheEntries bounds the number of submissions a RepoCache remembers. The least recently used
+// submissions are forgotten first.
+const MaxCacheEntries = 1024
+
 //counterfeiter:generate . Logger
 type Logger interface {
 	Info(msg string, keysAndValues ...interface{})
@@ -30,50 +43,55 @@ type Logger interface {
 //counterfeiter:generate . RepoCache
 type RepoCache interface {
 	Set(submitted, persisted *unstructured.Unstructured, ownerDiscriminant string)
-	UnchangedSinceCached(submitted *unstructured.Unstructured, existingObj *unstructured.Unstructured) *unstructured.Unstructured
 	UnchangedSinceCachedFromList(local *unstructured.Unstructured, remote []*unstructured.Unstructured, ownerDiscriminant string) *unstructured.Unstructured
 }
 
 func NewCache(l Logger) RepoCache {
 	return &cache{
-		mtx:            &sync.RWMutex{},
 		logger:         l,
-		submittedCache: make(map[string]unstructured.Unstructured),
-		persistedCache: make(map[string]unstructured.Unstructured),
+		persistedCache: lru.New(MaxCacheEntries),
 	}
 }
 
+// cache maps the hash of each submitted object to the object persisted by the apiserver
 type cache struct {
-	mtx            *sync.RWMutex
 	logger         Logger
-	submittedCache map[string]unstructured.Unstructured
-	persistedCache map[string]unstructured.Unstructured
+	persistedCache *lru.Cache
 }
 
 func (c *cache) Set(submitted, persisted *unstructured.Unstructured, ownerDiscriminant string) {
-	c.mtx.Lock()
-	defer c.mtx.Unlock()
-	key := getKey(submitted, ownerDiscriminant)
-	c.submittedCache[key] = *submitted
-	c.persistedCache[key] = *persisted
+	key := SubmittedHash(submitted, ownerDiscriminant)
+	c.persistedCache.Add(key, persisted.DeepCopy())
 }
 
 func (c *cache) UnchangedSinceCachedFromList(submitted *unstructured.Unstructured, existingList []*unstructured.Unstructured, ownerDiscriminant string) *unstructured.Unstructured {
-	c.mtx.RLock()
-	defer c.mtx.RUnlock()
-	key := getKey(submitted, ownerDiscriminant)
+	unchanged := c.unchangedSinceCachedFromList(submitted, existingList, ownerDiscriminant)
+
+	result := metrics.MissResult
+	if unchanged != nil {
+		result = metrics.HitResult
+	}
+	metrics.RepoCacheRequests.WithLabelValues(result).Inc()
+
+	return unchanged
+}
+
+func (c *cache) unchangedSinceCachedFromList(submitted *unstructured.Unstructured, existingList []*unstructured.Unstructured, ownerDiscriminant string) *unstructured.Unstructured {
+	key := SubmittedHash(submitted, ownerDiscriminant)
 	c.logger.Info("checking for changes since cached", "key", key)
-	if !c.isSubmittedCacheHit(submitted, key) {
-		return nil
+
+	cached, ok := c.persistedCache.Get(key)
+	if !ok {
+		c.logger.Info("miss: object not in cache, checking existing objects on apiserver for the submitted hash", "key", key)
+		return c.submittedHashHit(key, existingList)
 	}
 
-	persistedCached := c.getPersistedCached(key)
+	c.logger.Info("no changes since last submission, checking existing objects on apiserver", "key", key)
+	persistedCached := cached.(*unstructured.Unstructured)
 
 	for _, existing := range existingList {
 		if c.isPersistedCacheHit(key, existing, persistedCached) {
 			return existing
-		} else {
-			continue
 		}
 	}
 
@@ -81,39 +99,19 @@ func (c *cache) UnchangedSinceCachedFromList(submitted *unstructured.Unstructure
 	return nil
 }
 
-func (c *cache) UnchangedSinceCached(submitted *unstructured.Unstructured, existingObj *unstructured.Unstructured) *unstructured.Unstructured {
-	c.mtx.RLock()
-	defer c.mtx.RUnlock()
-	key := getKey(submitted, "")
-	c.logger.Info("checking for changes since cached", "key", key)
-	if !c.isSubmittedCacheHit(submitted, key) {
-		return nil
-	}
-
-	persistedCached := c.getPersistedCached(key)
-
-	if c.isPersistedCacheHit(key, existingObj, persistedCached) {
-		return existingObj
-	} else {
-		return nil
-	}
-}
-
-func (c *cache) isSubmittedCacheHit(submitted *unstructured.Unstructured, key string) bool {
-	submittedCached, submittedFoundInCache := c.submittedCache[key]
-	submittedUnchanged := submittedFoundInCache && reflect.DeepEqual(submittedCached, *submitted)
-
-	if submittedUnchanged {
-		c.logger.Info("no changes since last submission, checking existing objects on apiserver", "key", key)
-		return true
-	} else {
-		if submittedFoundInCache {
-			c.logger.Info("miss: submitted object in cache is different from submitted object", "key", key)
-		} else {
-			c.logger.Info("miss: object not in cache", "key", key)
+// submittedHashHit finds the existing object created from the same submission, by a controller which
+// may since have restarted, and remembers it
+func (c *cache) submittedHashHit(key string, existingList []*unstructured.Unstructured) *unstructured.Unstructured {
+	for _, existing := range existingList {
+		if existing.GetAnnotations()[SubmittedHashAnnotation] == key {
+			c.logger.Info("hit: object on apiserver has the submitted hash", "key", key, "existingName", existing.GetName())
+			c.persistedCache.Add(key, existing.DeepCopy())
+			return existing
 		}
-		return false
 	}
+
+	c.logger.Info("miss: no existing object on apiserver has the submitted hash", "key", key)
+	return nil
 }
 
 func (c *cache) isPersistedCacheHit(key string, existingObj *unstructured.Unstructured, persistedCached *unstructured.Unstructured) bool {
@@ -140,20 +138,37 @@ func (c *cache) isPersistedCacheHit(key string, existingObj *unstructured.Unstru
 	}
 }
 
-func getKey(obj *unstructured.Unstructured, ownerDiscriminant string) string {
-	// todo: probably should hash object for key
-	kind := obj.GetObjectKind().GroupVersionKind().Kind
-	var name string
-	if obj.GetName() == "" {
-		name = obj.GetGenerateName()
-	} else {
-		name = obj.GetName()
+// SubmittedHash hashes the content of the submitted object, other than its SubmittedHashAnnotation, together
+// with the ownerDiscriminant
+func SubmittedHash(submitted *unstructured.Unstructured, ownerDiscriminant string) string {
+	obj := submitted.DeepCopy()
+	annotations := obj.GetAnnotations()
+	if _, ok := annotations[SubmittedHashAnnotation]; ok {
+		delete(annotations, SubmittedHashAnnotation)
+		if len(annotations) == 0 {
+			annotations = nil
+		}
+		obj.SetAnnotations(annotations)
+	}
+
+	content, err := json.Marshal(obj.Object)
+	if err != nil {
+		return getNameKey(obj, ownerDiscriminant)
 	}
-	ns := obj.GetNamespace()
-	return fmt.Sprintf("%s:%s:%s:%s", ns, kind, name, ownerDiscriminant)
+
+	hash := sha256.New()
+	hash.Write(content)
+	hash.Write([]byte{0})
+	hash.Write([]byte(ownerDiscriminant))
+	return hex.EncodeToString(hash.Sum(nil))
 }
 
-func (c *cache) getPersistedCached(key string) *unstructured.Unstructured {
-	persisted := c.persistedCache[key]
-	return &persisted
+// getNameKey identifies the object by name rather than content, for the unlikely submission which
+// cannot be marshalled
+func getNameKey(obj *unstructured.Unstructured, ownerDiscriminant string) string {
+	name := obj.GetName()
+	if name == "" {
+		name = obj.GetGenerateName()
+	}
+	return fmt.Sprintf("%s:%s:%s:%s", obj.GetNamespace(), obj.GetKind(), name, ownerDiscriminant)
 }
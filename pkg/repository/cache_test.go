@@ -15,10 +15,14 @@
 package repository_test
 
 import (
+	"fmt"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
+	"github.com/vmware-tanzu/cartographer/pkg/metrics"
 	"github.com/vmware-tanzu/cartographer/pkg/repository"
 	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
 )
@@ -154,108 +158,83 @@ var _ = Describe("Cache", func() {
 				Expect(cache.UnchangedSinceCachedFromList(submitted, existingObjsOnAPIServer, "")).To(BeNil())
 			})
 		})
-	})
 
-	Describe("UnchangedSinceCached", func() {
-		Context("when the submitted object has a name", func() {
-			var existingObjOnAPIServer *unstructured.Unstructured
+		Context("when the submitted object is not in the cache but an existing object has its submitted hash", func() {
+			var existingObjsOnAPIServer []*unstructured.Unstructured
 
 			BeforeEach(func() {
-				existingObjOnAPIServer = persisted.DeepCopy()
-			})
-
-			Context("when the submitted object is not present in the cache", func() {
-				It("is false", func() {
-					Expect(cache.UnchangedSinceCached(submitted, existingObjOnAPIServer)).To(BeNil())
+				existingObj := persisted.DeepCopy()
+				existingObj.SetAnnotations(map[string]string{
+					repository.SubmittedHashAnnotation: repository.SubmittedHash(submitted, "A"),
 				})
+				existingObjsOnAPIServer = []*unstructured.Unstructured{persisted.DeepCopy(), existingObj}
 			})
 
-			Context("when the submitted object differs from the cached submitted object", func() {
-				BeforeEach(func() {
-					cache.Set(submitted, persisted, "")
-				})
-
-				It("is false", func() {
-					newSubmission := submitted.DeepCopy()
-					newSubmission.SetLabels(map[string]string{"now-with": "funky-labels"})
-					Expect(cache.UnchangedSinceCached(newSubmission, existingObjOnAPIServer)).To(BeNil())
-				})
+			It("is true, as the object was submitted before the cache was emptied", func() {
+				Expect(cache.UnchangedSinceCachedFromList(submitted, existingObjsOnAPIServer, "A")).To(Equal(existingObjsOnAPIServer[1]))
 			})
 
-			Context("when the submitted object is the same as the cached submitted object", func() {
-				BeforeEach(func() {
-					cache.Set(submitted, persisted, "")
-				})
+			It("is false for a different owner discriminant", func() {
+				Expect(cache.UnchangedSinceCachedFromList(submitted, existingObjsOnAPIServer, "SomethingElse")).To(BeNil())
+			})
 
-				Context("when the existing object has no spec", func() {
-					It("is false", func() {
-						Expect(cache.UnchangedSinceCached(submitted, existingObjOnAPIServer)).To(BeNil())
-					})
-				})
+			It("is false when the submitted object has changed", func() {
+				submitted.SetLabels(map[string]string{"now-with": "funky-labels"})
+				Expect(cache.UnchangedSinceCachedFromList(submitted, existingObjsOnAPIServer, "A")).To(BeNil())
+			})
+		})
 
-				Context("when the existing object has a spec", func() {
-					BeforeEach(func() {
-						existingObjOnAPIServer.UnstructuredContent()["spec"] = map[string]interface{}{"oh-look": "its-a-spec"}
-					})
+		Context("when more submissions are cached than the cache holds", func() {
+			BeforeEach(func() {
+				submitted.UnstructuredContent()["spec"] = map[string]interface{}{"ooo": "a-spec"}
+				persisted.UnstructuredContent()["spec"] = submitted.UnstructuredContent()["spec"]
+				cache.Set(submitted, persisted, "A")
 
-					Context("when the persisted object has no spec", func() {
-						BeforeEach(func() {
-							cache.Set(submitted, persisted, "")
-						})
+				for i := 0; i < repository.MaxCacheEntries; i++ {
+					other := submitted.DeepCopy()
+					other.SetName(fmt.Sprintf("other-%d", i))
+					cache.Set(other, persisted, "A")
+				}
+			})
 
-						It("is false", func() {
-							Expect(cache.UnchangedSinceCached(submitted, existingObjOnAPIServer)).To(BeNil())
-						})
-					})
+			It("forgets the least recently used submissions", func() {
+				Expect(cache.UnchangedSinceCachedFromList(submitted, []*unstructured.Unstructured{persisted}, "A")).To(BeNil())
+			})
+		})
+	})
 
-					Context("when the persisted object has a spec", func() {
-						Context("when the existing object spec is the same as the cached submitted object spec", func() {
-							BeforeEach(func() {
-								persisted.UnstructuredContent()["spec"] = existingObjOnAPIServer.UnstructuredContent()["spec"]
-								cache.Set(submitted, persisted, "")
-							})
+	Describe("hit and miss metrics", func() {
+		It("counts each hit and miss", func() {
+			hits := testutil.ToFloat64(metrics.RepoCacheRequests.WithLabelValues("hit"))
+			misses := testutil.ToFloat64(metrics.RepoCacheRequests.WithLabelValues("miss"))
 
-							It("is true", func() {
-								Expect(cache.UnchangedSinceCached(submitted, existingObjOnAPIServer)).ToNot(BeNil())
-							})
-						})
+			submitted.UnstructuredContent()["spec"] = map[string]interface{}{"ooo": "a-spec"}
+			persisted.UnstructuredContent()["spec"] = submitted.UnstructuredContent()["spec"]
 
-						Context("when the existing object spec differs from the cached submitted object spec", func() {
-							BeforeEach(func() {
-								persisted.UnstructuredContent()["spec"] = map[string]interface{}{"oh-wait": "this-spec-is-different"}
-								cache.Set(submitted, persisted, "")
-							})
+			Expect(cache.UnchangedSinceCachedFromList(submitted, []*unstructured.Unstructured{persisted}, "A")).To(BeNil())
+			cache.Set(submitted, persisted, "A")
+			Expect(cache.UnchangedSinceCachedFromList(submitted, []*unstructured.Unstructured{persisted}, "A")).NotTo(BeNil())
 
-							It("is false", func() {
-								Expect(cache.UnchangedSinceCached(submitted, existingObjOnAPIServer)).To(BeNil())
-							})
-						})
-					})
-				})
-			})
+			Expect(testutil.ToFloat64(metrics.RepoCacheRequests.WithLabelValues("hit"))).To(Equal(hits + 1))
+			Expect(testutil.ToFloat64(metrics.RepoCacheRequests.WithLabelValues("miss"))).To(Equal(misses + 1))
 		})
+	})
 
-		Context("when the submitted object has no name", func() {
-			var existingObjsOnAPIServer *unstructured.Unstructured
-
-			BeforeEach(func() {
-				submitted.SetName("")
-				submitted.SetGenerateName("this-is-generate-name-")
-				submitted.UnstructuredContent()["spec"] = map[string]interface{}{"ooo": "a-spec"}
-
-				persisted.SetName("this-is-generate-name-abcdef")
-				persisted.SetGenerateName("")
-				persisted.UnstructuredContent()["spec"] = submitted.UnstructuredContent()["spec"]
+	Describe("SubmittedHash", func() {
+		It("differs for different content", func() {
+			changed := submitted.DeepCopy()
+			changed.SetLabels(map[string]string{"now-with": "funky-labels"})
+			Expect(repository.SubmittedHash(changed, "A")).NotTo(Equal(repository.SubmittedHash(submitted, "A")))
+		})
 
-				cache.Set(submitted, persisted, "")
-				existingObjsOnAPIServer = persisted.DeepCopy()
-			})
+		It("differs for different owner discriminants", func() {
+			Expect(repository.SubmittedHash(submitted, "B")).NotTo(Equal(repository.SubmittedHash(submitted, "A")))
+		})
 
-			It("the cache matches against the generateName instead", func() {
-				Expect(cache.UnchangedSinceCached(submitted, existingObjsOnAPIServer)).ToNot(BeNil())
-				submitted.SetGenerateName("another-generate-name-")
-				Expect(cache.UnchangedSinceCached(submitted, existingObjsOnAPIServer)).To(BeNil())
-			})
+		It("ignores the submitted hash annotation", func() {
+			annotated := submitted.DeepCopy()
+			annotated.SetAnnotations(map[string]string{repository.SubmittedHashAnnotation: "some-hash"})
+			Expect(repository.SubmittedHash(annotated, "A")).To(Equal(repository.SubmittedHash(submitted, "A")))
 		})
 	})
 })
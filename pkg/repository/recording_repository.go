@@ -0,0 +1,98 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/logger"
+)
+
+// RecordingRepository records the objects that would be submitted to the cluster instead of writing them.
+// All reads are delegated to the wrapped Repository. A recorded mutable object takes the status of the
+// object of the same name already on the cluster, so that outputs read from status are found as they
+// would be after a real apply.
+type RecordingRepository struct {
+	Repository
+	mtx      *sync.Mutex
+	recorded []*unstructured.Unstructured
+}
+
+func NewRecordingRepository(delegate Repository) *RecordingRepository {
+	return &RecordingRepository{
+		Repository: delegate,
+		mtx:        &sync.Mutex{},
+	}
+}
+
+func (r *RecordingRepository) EnsureImmutableObjectExistsOnCluster(ctx context.Context, obj *unstructured.Unstructured, _ map[string]string) error {
+	logr.FromContextOrDiscard(ctx).V(logger.DEBUG).Info("recording immutable object", "object", obj)
+	r.record(obj)
+	return nil
+}
+
+func (r *RecordingRepository) EnsureMutableObjectExistsOnCluster(ctx context.Context, obj *unstructured.Unstructured, _ bool) error {
+	log := logr.FromContextOrDiscard(ctx)
+	log.V(logger.DEBUG).Info("recording mutable object", "object", obj)
+	r.record(obj)
+
+	existingObj, err := r.Repository.GetUnstructured(ctx, obj)
+	if err != nil {
+		return err
+	}
+	if existingObj == nil {
+		log.V(logger.DEBUG).Info("no object on the cluster to read the status of", "object", obj)
+		return nil
+	}
+
+	if status, ok := existingObj.Object["status"]; ok {
+		obj.Object["status"] = status
+	}
+	return nil
+}
+
+func (r *RecordingRepository) Delete(ctx context.Context, objToDelete *unstructured.Unstructured) error {
+	logr.FromContextOrDiscard(ctx).V(logger.DEBUG).Info("not deleting object while recording", "object", objToDelete)
+	return nil
+}
+
+func (r *RecordingRepository) StatusUpdate(ctx context.Context, object client.Object) error {
+	logr.FromContextOrDiscard(ctx).V(logger.DEBUG).Info("not updating status while recording", "object", client.ObjectKeyFromObject(object))
+	return nil
+}
+
+// Recorded returns copies of the objects recorded so far, in the order they were recorded.
+func (r *RecordingRepository) Recorded() []*unstructured.Unstructured {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	var recorded []*unstructured.Unstructured
+	for _, obj := range r.recorded {
+		recorded = append(recorded, obj.DeepCopy())
+	}
+	return recorded
+}
+
+func (r *RecordingRepository) record(obj *unstructured.Unstructured) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.recorded = append(r.recorded, obj.DeepCopy())
+}
@@ -0,0 +1,124 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+	"github.com/vmware-tanzu/cartographer/pkg/repository/repositoryfakes"
+)
+
+var _ = Describe("RecordingRepository", func() {
+	var (
+		ctx      context.Context
+		delegate *repositoryfakes.FakeRepository
+		repo     *repository.RecordingRepository
+		obj      *unstructured.Unstructured
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		delegate = &repositoryfakes.FakeRepository{}
+		repo = repository.NewRecordingRepository(delegate)
+
+		obj = &unstructured.Unstructured{}
+		obj.SetKind("Thing")
+		obj.SetName("my-thing")
+	})
+
+	It("records mutable objects without submitting them", func() {
+		Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, obj, false)).To(Succeed())
+
+		Expect(delegate.EnsureMutableObjectExistsOnClusterCallCount()).To(Equal(0))
+		Expect(repo.Recorded()).To(Equal([]*unstructured.Unstructured{obj}))
+	})
+
+	Context("when the mutable object exists on the cluster", func() {
+		BeforeEach(func() {
+			existing := obj.DeepCopy()
+			existing.Object["status"] = map[string]interface{}{"url": "https://example.com/source.tar.gz"}
+			delegate.GetUnstructuredReturns(existing, nil)
+		})
+
+		It("reads the status of the object on the cluster through to the object", func() {
+			Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, obj, false)).To(Succeed())
+
+			Expect(delegate.GetUnstructuredCallCount()).To(Equal(1))
+			Expect(obj.Object["status"]).To(Equal(map[string]interface{}{"url": "https://example.com/source.tar.gz"}))
+		})
+
+		It("records the object as it would be submitted, without the status", func() {
+			Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, obj, false)).To(Succeed())
+
+			Expect(repo.Recorded()[0].Object).NotTo(HaveKey("status"))
+		})
+	})
+
+	Context("when reading the mutable object from the cluster fails", func() {
+		BeforeEach(func() {
+			delegate.GetUnstructuredReturns(nil, errors.New("some error"))
+		})
+
+		It("returns the error", func() {
+			Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, obj, false)).To(MatchError("some error"))
+		})
+	})
+
+	It("records immutable objects without submitting them", func() {
+		Expect(repo.EnsureImmutableObjectExistsOnCluster(ctx, obj, map[string]string{"some": "label"})).To(Succeed())
+
+		Expect(delegate.EnsureImmutableObjectExistsOnClusterCallCount()).To(Equal(0))
+		Expect(repo.Recorded()).To(Equal([]*unstructured.Unstructured{obj}))
+	})
+
+	It("records a copy of the object", func() {
+		Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, obj, false)).To(Succeed())
+		obj.SetName("changed-after-recording")
+
+		Expect(repo.Recorded()[0].GetName()).To(Equal("my-thing"))
+	})
+
+	It("does not delete objects", func() {
+		Expect(repo.Delete(ctx, obj)).To(Succeed())
+
+		Expect(delegate.DeleteCallCount()).To(Equal(0))
+		Expect(repo.Recorded()).To(BeEmpty())
+	})
+
+	It("does not update status", func() {
+		Expect(repo.StatusUpdate(ctx, &v1alpha1.Workload{})).To(Succeed())
+
+		Expect(delegate.StatusUpdateCallCount()).To(Equal(0))
+	})
+
+	It("delegates reads to the wrapped repository", func() {
+		existing := []*unstructured.Unstructured{obj}
+		delegate.ListUnstructuredReturns(existing, nil)
+
+		listed, err := repo.ListUnstructured(ctx, schema.GroupVersionKind{Kind: "Thing"}, "some-ns", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(listed).To(Equal(existing))
+		Expect(delegate.ListUnstructuredCallCount()).To(Equal(1))
+	})
+})
@@ -28,11 +28,14 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/csaupgrade"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/events"
 	"github.com/vmware-tanzu/cartographer/pkg/logger"
+	"github.com/vmware-tanzu/cartographer/pkg/tracing"
 )
 
 //go:generate go run -modfile ../../hack/tools/go.mod github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -42,25 +45,33 @@ import (
 //counterfeiter:generate . Repository
 type Repository interface {
 	EnsureImmutableObjectExistsOnCluster(ctx context.Context, obj *unstructured.Unstructured, labels map[string]string) error
-	EnsureMutableObjectExistsOnCluster(ctx context.Context, obj *unstructured.Unstructured) error
-	GetTemplate(ctx context.Context, name, kind string) (client.Object, error)
+	EnsureMutableObjectExistsOnCluster(ctx context.Context, obj *unstructured.Unstructured, force bool) error
+	GetTemplate(ctx context.Context, name, kind, namespace string) (client.Object, error)
 	GetRunTemplate(ctx context.Context, ref v1alpha1.TemplateReference) (*v1alpha1.ClusterRunTemplate, error)
-	GetSupplyChainsForWorkload(ctx context.Context, workload *v1alpha1.Workload) ([]*v1alpha1.ClusterSupplyChain, error)
-	GetDeliveriesForDeliverable(ctx context.Context, deliverable *v1alpha1.Deliverable) ([]*v1alpha1.ClusterDelivery, error)
+	GetSupplyChainsForWorkload(ctx context.Context, workload *v1alpha1.Workload) ([]v1alpha1.SupplyChainObject, error)
+	GetDeliveriesForDeliverable(ctx context.Context, deliverable *v1alpha1.Deliverable) ([]v1alpha1.DeliveryObject, error)
 	GetWorkload(ctx context.Context, name string, namespace string) (*v1alpha1.Workload, error)
 	GetDeliverable(ctx context.Context, name string, namespace string) (*v1alpha1.Deliverable, error)
-	GetSupplyChain(ctx context.Context, name string) (*v1alpha1.ClusterSupplyChain, error)
+	GetSupplyChain(ctx context.Context, name, namespace string) (v1alpha1.SupplyChainObject, error)
 	StatusUpdate(ctx context.Context, object client.Object) error
 	GetRunnable(ctx context.Context, name string, namespace string) (*v1alpha1.Runnable, error)
 	GetUnstructured(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error)
 	ListUnstructured(ctx context.Context, gvk schema.GroupVersionKind, namespace string, labels map[string]string) ([]*unstructured.Unstructured, error)
-	GetDelivery(ctx context.Context, name string) (*v1alpha1.ClusterDelivery, error)
+	GetDelivery(ctx context.Context, name, namespace string) (v1alpha1.DeliveryObject, error)
 	GetScheme() *runtime.Scheme
 	GetRESTMapper() meta.RESTMapper
 	GetServiceAccount(ctx context.Context, serviceAccountName, ns string) (*corev1.ServiceAccount, error)
 	Delete(ctx context.Context, objToDelete *unstructured.Unstructured) error
 }
 
+// FieldManager is the field manager under which mutable stamped objects are server-side applied
+const FieldManager = "cartographer"
+
+// updateFieldManagers are the field managers under which mutable stamped objects were created and
+// patched before they were server-side applied. Without a field manager option the api server names
+// the manager after the user agent, which is the name of the cartographer binary.
+var updateFieldManagers = sets.New(FieldManager)
+
 type RepositoryBuilder func(client client.Client, repoCache RepoCache) Repository
 
 type repository struct {
@@ -75,12 +86,15 @@ func NewRepository(client client.Client, repoCache RepoCache) Repository {
 	}
 }
 
-func (r *repository) Delete(ctx context.Context, objToDelete *unstructured.Unstructured) error {
+func (r *repository) Delete(ctx context.Context, objToDelete *unstructured.Unstructured) (err error) {
 	log := logr.FromContextOrDiscard(ctx).WithValues("delete object", fmt.Sprintf("%s/%s", objToDelete.GetNamespace(), objToDelete.GetName()))
 	ctx = logr.NewContext(ctx, log)
 	log.V(logger.DEBUG).Info("Delete")
 
-	err := r.deleteObject(ctx, objToDelete)
+	ctx, span := tracing.Start(ctx, "Repository.Delete", tracing.ObjectAttributes(objToDelete.GetKind(), objToDelete.GetNamespace(), objToDelete.GetName())...)
+	defer func() { tracing.End(span, err) }()
+
+	err = r.deleteObject(ctx, objToDelete)
 	if err != nil {
 		log.Error(err, "failed to delete object")
 		return fmt.Errorf("failed to delete object [%s/%s]: %w", objToDelete.GetNamespace(), objToDelete.GetName(), err)
@@ -93,13 +107,16 @@ func (r *repository) Delete(ctx context.Context, objToDelete *unstructured.Unstr
 	return nil
 }
 
-func (r *repository) GetServiceAccount(ctx context.Context, name, namespace string) (*corev1.ServiceAccount, error) {
+func (r *repository) GetServiceAccount(ctx context.Context, name, namespace string) (_ *corev1.ServiceAccount, err error) {
 	log := logr.FromContextOrDiscard(ctx).WithValues("service account", fmt.Sprintf("%s/%s", namespace, name))
 	ctx = logr.NewContext(ctx, log)
 	log.V(logger.DEBUG).Info("GetServiceAccount")
 
+	ctx, span := tracing.Start(ctx, "Repository.GetServiceAccount", tracing.ObjectAttributes("ServiceAccount", namespace, name)...)
+	defer func() { tracing.End(span, err) }()
+
 	serviceAccount := &corev1.ServiceAccount{}
-	err := r.getObject(ctx, name, namespace, serviceAccount)
+	err = r.getObject(ctx, name, namespace, serviceAccount)
 	if err != nil {
 		log.Error(err, "failed to get service account object from api server")
 		return nil, fmt.Errorf("failed to get service account object from api server [%s/%s]: %w", namespace, name, err)
@@ -108,33 +125,48 @@ func (r *repository) GetServiceAccount(ctx context.Context, name, namespace stri
 	return serviceAccount, nil
 }
 
-func (r *repository) GetDelivery(ctx context.Context, name string) (*v1alpha1.ClusterDelivery, error) {
+// GetDelivery returns the ClusterDelivery with the name when namespace is empty,
+// otherwise the Delivery with the name in that namespace.
+func (r *repository) GetDelivery(ctx context.Context, name, namespace string) (_ v1alpha1.DeliveryObject, err error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("GetDelivery")
 
-	delivery := &v1alpha1.ClusterDelivery{}
+	ctx, span := tracing.Start(ctx, "Repository.GetDelivery", tracing.ObjectAttributes("Delivery", namespace, name)...)
+	defer func() { tracing.End(span, err) }()
+
+	var delivery v1alpha1.DeliveryObject = &v1alpha1.ClusterDelivery{}
+	if namespace != "" {
+		delivery = &v1alpha1.Delivery{}
+	}
 
 	key := client.ObjectKey{
-		Name: name,
+		Name:      name,
+		Namespace: namespace,
 	}
 
-	err := r.cl.Get(ctx, key, delivery)
+	err = r.cl.Get(ctx, key, delivery)
 	if kerrors.IsNotFound(err) {
 		log.V(logger.DEBUG).Info("delivery is not found on api server")
 		return nil, nil
 	}
 	if err != nil {
 		log.Error(err, "failed to get delivery object from api server")
-		return nil, fmt.Errorf("failed to get delivery object from api server [%s]: %w", name, err)
+		return nil, fmt.Errorf("failed to get delivery object from api server [%s]: %w", getNamespacedName(name, namespace), err)
 	}
 
 	return delivery, nil
 }
 
-func (r *repository) EnsureMutableObjectExistsOnCluster(ctx context.Context, obj *unstructured.Unstructured) error {
+// EnsureMutableObjectExistsOnCluster server-side applies the object under the FieldManager. Fields
+// managed by other field managers are left untouched, unless force is set in which case they are
+// taken over rather than failing the apply with a conflict.
+func (r *repository) EnsureMutableObjectExistsOnCluster(ctx context.Context, obj *unstructured.Unstructured, force bool) (err error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("EnsureMutableObjectExistsOnCluster")
 
+	ctx, span := tracing.Start(ctx, "Repository.EnsureMutableObjectExistsOnCluster", tracing.ObjectAttributes(obj.GetKind(), obj.GetNamespace(), obj.GetName())...)
+	defer func() { tracing.End(span, err) }()
+
 	existingObj, err := r.GetUnstructured(ctx, obj)
 	log.V(logger.DEBUG).Info("considering object from api server",
 		"considered", obj)
@@ -143,24 +175,17 @@ func (r *repository) EnsureMutableObjectExistsOnCluster(ctx context.Context, obj
 		return err
 	}
 
-	if existingObj != nil {
-		cacheHit := r.rc.UnchangedSinceCached(obj, existingObj)
-		if cacheHit != nil {
-			*obj = *cacheHit
-			return nil
-		}
-		log.Info("patching object", "object", obj)
-		return r.patchUnstructured(ctx, existingObj, obj)
-	} else {
-		log.Info("creating object", "object", obj)
-		return r.createUnstructured(ctx, obj, "")
-	}
+	log.Info("applying object", "object", obj, "force", force)
+	return r.applyUnstructured(ctx, existingObj, obj, force)
 }
 
-func (r *repository) EnsureImmutableObjectExistsOnCluster(ctx context.Context, obj *unstructured.Unstructured, labels map[string]string) error {
+func (r *repository) EnsureImmutableObjectExistsOnCluster(ctx context.Context, obj *unstructured.Unstructured, labels map[string]string) (err error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("EnsureImmutableObjectExistsOnCluster")
 
+	ctx, span := tracing.Start(ctx, "Repository.EnsureImmutableObjectExistsOnCluster", tracing.ObjectAttributes(obj.GetKind(), obj.GetNamespace(), obj.GetGenerateName())...)
+	defer func() { tracing.End(span, err) }()
+
 	unstructuredList, err := r.ListUnstructured(ctx, obj.GroupVersionKind(), obj.GetNamespace(), labels)
 
 	for _, considered := range unstructuredList {
@@ -184,10 +209,13 @@ func (r *repository) EnsureImmutableObjectExistsOnCluster(ctx context.Context, o
 	return r.createUnstructured(ctx, obj, ownerDiscriminant)
 }
 
-func (r *repository) GetUnstructured(ctx context.Context, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+func (r *repository) GetUnstructured(ctx context.Context, obj *unstructured.Unstructured) (_ *unstructured.Unstructured, err error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("GetUnstructured")
 
+	ctx, span := tracing.Start(ctx, "Repository.GetUnstructured", tracing.ObjectAttributes(obj.GetKind(), obj.GetNamespace(), obj.GetName())...)
+	defer func() { tracing.End(span, err) }()
+
 	objKey := client.ObjectKey{
 		Name:      obj.GetName(),
 		Namespace: obj.GetNamespace(),
@@ -198,7 +226,7 @@ func (r *repository) GetUnstructured(ctx context.Context, obj *unstructured.Unst
 
 	returnObj := &unstructured.Unstructured{}
 	returnObj.SetGroupVersionKind(obj.GroupVersionKind())
-	err := r.cl.Get(ctx, objKey, returnObj)
+	err = r.cl.Get(ctx, objKey, returnObj)
 	if err != nil {
 		if kerrors.IsNotFound(err) {
 			return nil, nil
@@ -214,10 +242,13 @@ func (r *repository) GetUnstructured(ctx context.Context, obj *unstructured.Unst
 	return returnObj, nil
 }
 
-func (r *repository) ListUnstructured(ctx context.Context, gvk schema.GroupVersionKind, namespace string, labels map[string]string) ([]*unstructured.Unstructured, error) {
+func (r *repository) ListUnstructured(ctx context.Context, gvk schema.GroupVersionKind, namespace string, labels map[string]string) (_ []*unstructured.Unstructured, err error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("ListUnstructured")
 
+	ctx, span := tracing.Start(ctx, "Repository.ListUnstructured", tracing.ObjectAttributes(gvk.Kind, namespace, "")...)
+	defer func() { tracing.End(span, err) }()
+
 	unstructuredList := &unstructured.UnstructuredList{}
 	unstructuredList.SetGroupVersionKind(gvk)
 
@@ -227,7 +258,7 @@ func (r *repository) ListUnstructured(ctx context.Context, gvk schema.GroupVersi
 	}
 	log.V(logger.DEBUG).Info("list unstructured with namespace and labels",
 		"namespace", namespace, "labels", labels)
-	err := r.cl.List(ctx, unstructuredList, opts...)
+	err = r.cl.List(ctx, unstructuredList, opts...)
 	if err != nil {
 		log.Error(err, "unable to list from api server")
 		return nil, fmt.Errorf("unable to list from api server: %w", err)
@@ -244,17 +275,26 @@ func (r *repository) ListUnstructured(ctx context.Context, gvk schema.GroupVersi
 	return pointersToUnstructureds, nil
 }
 
-func (r *repository) GetTemplate(ctx context.Context, name string, kind string) (client.Object, error) {
+// GetTemplate returns the template of the kind with the name. The namespace is only
+// used for the namespaced template kinds.
+func (r *repository) GetTemplate(ctx context.Context, name, kind, namespace string) (_ client.Object, err error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("GetTemplate")
 
+	ctx, span := tracing.Start(ctx, "Repository.GetTemplate", tracing.ObjectAttributes(kind, namespace, name)...)
+	defer func() { tracing.End(span, err) }()
+
 	apiTemplate, err := v1alpha1.GetAPITemplate(kind)
 	if err != nil {
 		log.Error(err, "unable to get api template")
 		return nil, fmt.Errorf("unable to get api template [%s/%s]: %w", kind, name, err)
 	}
 
-	err = r.getObject(ctx, name, "", apiTemplate)
+	if !v1alpha1.IsNamespacedTemplateKind(kind) {
+		namespace = ""
+	}
+
+	err = r.getObject(ctx, name, namespace, apiTemplate)
 	//TODO: Remove IsNotFound check, this should just be an error, breaks kuttl test
 	if kerrors.IsNotFound(err) {
 		log.V(logger.DEBUG).Info("template is not found on api server")
@@ -262,19 +302,22 @@ func (r *repository) GetTemplate(ctx context.Context, name string, kind string)
 	}
 	if err != nil {
 		log.Error(err, "failed to get template object from api server")
-		return nil, fmt.Errorf("failed to get template object from api server [%s/%s]: %w", kind, name, err)
+		return nil, fmt.Errorf("failed to get template object from api server [%s/%s]: %w", kind, getNamespacedName(name, namespace), err)
 	}
 
 	return apiTemplate, nil
 }
 
-func (r *repository) GetRunTemplate(ctx context.Context, ref v1alpha1.TemplateReference) (*v1alpha1.ClusterRunTemplate, error) {
+func (r *repository) GetRunTemplate(ctx context.Context, ref v1alpha1.TemplateReference) (_ *v1alpha1.ClusterRunTemplate, err error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("GetRunTemplate")
 
+	ctx, span := tracing.Start(ctx, "Repository.GetRunTemplate", tracing.ObjectAttributes(ref.Kind, "", ref.Name)...)
+	defer func() { tracing.End(span, err) }()
+
 	runTemplate := &v1alpha1.ClusterRunTemplate{}
 
-	err := r.cl.Get(ctx, client.ObjectKey{
+	err = r.cl.Get(ctx, client.ObjectKey{
 		Name: ref.Name,
 	}, runTemplate)
 	if err != nil {
@@ -287,6 +330,13 @@ func (r *repository) GetRunTemplate(ctx context.Context, ref v1alpha1.TemplateRe
 
 func (r *repository) createUnstructured(ctx context.Context, obj *unstructured.Unstructured, ownerDiscriminant string) error {
 	submitted := obj.DeepCopy()
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[SubmittedHashAnnotation] = SubmittedHash(submitted, ownerDiscriminant)
+	obj.SetAnnotations(annotations)
 	if err := r.cl.Create(ctx, obj); err != nil {
 		return fmt.Errorf("create: %w", err)
 	}
@@ -295,52 +345,118 @@ func (r *repository) createUnstructured(ctx context.Context, obj *unstructured.U
 
 	rec := events.FromContextOrDie(ctx)
 	rec.ResourceEventf(events.NormalType, events.StampedObjectAppliedReason, "Created object [%Q]", obj)
+	rec.ResourceCloudEvent(events.StampedObjectAppliedReason, events.ResourceEventData{
+		StampedObject: events.StampedObjectReference(obj),
+	})
 	return nil
 }
 
-func (r *repository) patchUnstructured(ctx context.Context, existingObj *unstructured.Unstructured, obj *unstructured.Unstructured) error {
-	submitted := obj.DeepCopy()
+func (r *repository) applyUnstructured(ctx context.Context, existingObj *unstructured.Unstructured, obj *unstructured.Unstructured, force bool) error {
+	if existingObj != nil {
+		if err := r.upgradeManagedFields(ctx, existingObj); err != nil {
+			return fmt.Errorf("upgrade managed fields: %w", err)
+		}
+	}
+
+	obj.SetResourceVersion("")
+	obj.SetManagedFields(nil)
 
-	obj.SetResourceVersion(existingObj.GetResourceVersion())
-	if err := r.cl.Patch(ctx, obj, client.MergeFrom(existingObj)); err != nil {
-		return fmt.Errorf("patch: %w", err)
+	opts := []client.PatchOption{client.FieldOwner(FieldManager)}
+	if force {
+		opts = append(opts, client.ForceOwnership)
 	}
 
-	r.rc.Set(submitted, obj.DeepCopy(), "")
+	if err := r.cl.Patch(ctx, obj, client.Apply, opts...); err != nil {
+		return fmt.Errorf("apply: %w", err)
+	}
+
+	if existingObj != nil && existingObj.GetResourceVersion() == obj.GetResourceVersion() {
+		log := logr.FromContextOrDiscard(ctx)
+		log.V(logger.DEBUG).Info("object unchanged by apply", "object", obj)
+		return nil
+	}
+
+	action := "Patched"
+	if existingObj == nil {
+		action = "Created"
+	}
 
 	rec := events.FromContextOrDie(ctx)
-	rec.ResourceEventf(events.NormalType, events.StampedObjectAppliedReason, "Patched object [%Q]", obj)
+	rec.ResourceEventf(events.NormalType, events.StampedObjectAppliedReason, "%s object [%Q]", obj, action)
+	rec.ResourceCloudEvent(events.StampedObjectAppliedReason, events.ResourceEventData{
+		StampedObject: events.StampedObjectReference(obj),
+	})
 	return nil
 }
 
-func (r *repository) GetSupplyChainsForWorkload(ctx context.Context, workload *v1alpha1.Workload) ([]*v1alpha1.ClusterSupplyChain, error) {
+// upgradeManagedFields hands the fields that cartographer owns through Update operations over to its
+// Apply field manager. Otherwise the first apply of an object stamped before server-side apply leaves
+// those fields with the Update manager, and fields removed from the template are never pruned.
+func (r *repository) upgradeManagedFields(ctx context.Context, existingObj *unstructured.Unstructured) error {
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(existingObj, updateFieldManagers, FieldManager)
+	if err != nil {
+		return err
+	}
+	if patch == nil {
+		return nil
+	}
+
+	log := logr.FromContextOrDiscard(ctx)
+	log.V(logger.DEBUG).Info("upgrading managed fields before apply", "object", existingObj)
+
+	return r.cl.Patch(ctx, existingObj.DeepCopy(), client.RawPatch(types.JSONPatchType, patch))
+}
+
+// GetSupplyChainsForWorkload considers every ClusterSupplyChain and the SupplyChains
+// in the namespace of the workload.
+func (r *repository) GetSupplyChainsForWorkload(ctx context.Context, workload *v1alpha1.Workload) (_ []v1alpha1.SupplyChainObject, err error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("GetSupplyChainsForWorkload")
 
+	ctx, span := tracing.Start(ctx, "Repository.GetSupplyChainsForWorkload", tracing.WorkloadNameKey.String(workload.Name), tracing.WorkloadNamespaceKey.String(workload.Namespace))
+	defer func() { tracing.End(span, err) }()
+
 	list := &v1alpha1.ClusterSupplyChainList{}
 	if err := r.cl.List(ctx, list); err != nil {
 		log.Error(err, "unable to list supply chains from api server")
 		return nil, fmt.Errorf("unable to list supply chains from api server: %w", err)
 	}
 
-	var supplyChains []*v1alpha1.ClusterSupplyChain
+	namespacedList := &v1alpha1.SupplyChainList{}
+	if err := r.cl.List(ctx, namespacedList, client.InNamespace(workload.Namespace)); err != nil {
+		log.Error(err, "unable to list namespaced supply chains from api server")
+		return nil, fmt.Errorf("unable to list namespaced supply chains from api server: %w", err)
+	}
+
+	var supplyChains []v1alpha1.SupplyChainObject
 
 	for _, item := range list.Items {
 		itemValue := item
 		supplyChains = append(supplyChains, &itemValue)
 	}
 
+	for _, item := range namespacedList.Items {
+		itemValue := item
+		supplyChains = append(supplyChains, &itemValue)
+	}
+
 	return GetSelectedSupplyChain(supplyChains, workload, log)
 }
 
-func GetSelectedSupplyChain(allSupplyChains []*v1alpha1.ClusterSupplyChain, workload *v1alpha1.Workload, log logr.Logger) ([]*v1alpha1.ClusterSupplyChain, error) {
+// GetSelectedSupplyChain returns the supply chains whose selectors best match the workload.
+// When a SupplyChain and a ClusterSupplyChain match equally well, the SupplyChain takes precedence.
+// Sub-chains select no workloads.
+func GetSelectedSupplyChain(allSupplyChains []v1alpha1.SupplyChainObject, workload *v1alpha1.Workload, log logr.Logger) ([]v1alpha1.SupplyChainObject, error) {
 	var selectorGetters []SelectingObject
 	for _, item := range allSupplyChains {
+		if item.GetSpec().SubChain != nil {
+			continue
+		}
 		itemValue := item
 		selectorGetters = append(selectorGetters, itemValue)
 	}
 
-	var supplyChains []*v1alpha1.ClusterSupplyChain
+	var supplyChains []v1alpha1.SupplyChainObject
 	matches, err := BestSelectorMatch(workload, selectorGetters)
 	if err != nil {
 		return nil, fmt.Errorf("evaluating supply chain selectors against workload [%s/%s] failed: %w", workload.Namespace, workload.Name, err)
@@ -348,29 +464,58 @@ func GetSelectedSupplyChain(allSupplyChains []*v1alpha1.ClusterSupplyChain, work
 	for _, matchingObject := range matches {
 		log.V(logger.DEBUG).Info("supply chain matched workload",
 			"supply chain", matchingObject)
-		supplyChains = append(supplyChains, matchingObject.(*v1alpha1.ClusterSupplyChain))
+		supplyChains = append(supplyChains, matchingObject.(v1alpha1.SupplyChainObject))
 	}
 
-	return supplyChains, nil
+	return preferNamespaced(supplyChains), nil
 }
 
-func (r *repository) GetDeliveriesForDeliverable(ctx context.Context, deliverable *v1alpha1.Deliverable) ([]*v1alpha1.ClusterDelivery, error) {
+// GetDeliveriesForDeliverable considers every ClusterDelivery and the Deliveries
+// in the namespace of the deliverable.
+func (r *repository) GetDeliveriesForDeliverable(ctx context.Context, deliverable *v1alpha1.Deliverable) (_ []v1alpha1.DeliveryObject, err error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("GetDeliveriesForDeliverable")
 
+	ctx, span := tracing.Start(ctx, "Repository.GetDeliveriesForDeliverable", tracing.DeliverableNameKey.String(deliverable.Name), tracing.DeliverableNamespaceKey.String(deliverable.Namespace))
+	defer func() { tracing.End(span, err) }()
+
 	list := &v1alpha1.ClusterDeliveryList{}
 	if err := r.cl.List(ctx, list); err != nil {
 		log.Error(err, "unable to list deliveries from api server")
 		return nil, fmt.Errorf("unable to list deliveries from api server: %w", err)
 	}
 
-	var selectorGetters []SelectingObject
+	namespacedList := &v1alpha1.DeliveryList{}
+	if err := r.cl.List(ctx, namespacedList, client.InNamespace(deliverable.Namespace)); err != nil {
+		log.Error(err, "unable to list namespaced deliveries from api server")
+		return nil, fmt.Errorf("unable to list namespaced deliveries from api server: %w", err)
+	}
+
+	var deliveries []v1alpha1.DeliveryObject
+
 	for _, item := range list.Items {
 		itemValue := item
-		selectorGetters = append(selectorGetters, &itemValue)
+		deliveries = append(deliveries, &itemValue)
 	}
 
-	var deliveries []*v1alpha1.ClusterDelivery
+	for _, item := range namespacedList.Items {
+		itemValue := item
+		deliveries = append(deliveries, &itemValue)
+	}
+
+	return GetSelectedDelivery(deliveries, deliverable, log)
+}
+
+// GetSelectedDelivery returns the deliveries whose selectors best match the deliverable.
+// When a Delivery and a ClusterDelivery match equally well, the Delivery takes precedence.
+func GetSelectedDelivery(allDeliveries []v1alpha1.DeliveryObject, deliverable *v1alpha1.Deliverable, log logr.Logger) ([]v1alpha1.DeliveryObject, error) {
+	var selectorGetters []SelectingObject
+	for _, item := range allDeliveries {
+		itemValue := item
+		selectorGetters = append(selectorGetters, itemValue)
+	}
+
+	var deliveries []v1alpha1.DeliveryObject
 	matches, err := BestSelectorMatch(deliverable, selectorGetters)
 	if err != nil {
 		return nil, fmt.Errorf("evaluating supply chain selectors against deliverable [%s/%s] failed: %w", deliverable.Namespace, deliverable.Name, err)
@@ -378,13 +523,31 @@ func (r *repository) GetDeliveriesForDeliverable(ctx context.Context, deliverabl
 	for _, matchingObject := range matches {
 		log.V(logger.DEBUG).Info("delivery matched deliverable",
 			"delivery", matchingObject)
-		deliveries = append(deliveries, matchingObject.(*v1alpha1.ClusterDelivery))
+		deliveries = append(deliveries, matchingObject.(v1alpha1.DeliveryObject))
 	}
 
+	deliveries = preferNamespaced(deliveries)
+
 	log.V(logger.DEBUG).Info("deliveries matched deliverable",
 		"deliveries", deliveries)
 	return deliveries, nil
 }
+
+// preferNamespaced drops the cluster scoped blueprints from a set of equally good
+// matches when any namespaced blueprint is among them.
+func preferNamespaced[T client.Object](blueprints []T) []T {
+	var namespaced []T
+	for _, blueprint := range blueprints {
+		if blueprint.GetNamespace() != "" {
+			namespaced = append(namespaced, blueprint)
+		}
+	}
+
+	if len(namespaced) > 0 {
+		return namespaced
+	}
+	return blueprints
+}
 func getNamespacedName(name string, namespace string) string {
 	var namespacedName string
 	if namespace == "" {
@@ -420,12 +583,15 @@ func (r *repository) deleteObject(ctx context.Context, unstructuredObj *unstruct
 	return err
 }
 
-func (r *repository) GetWorkload(ctx context.Context, name string, namespace string) (*v1alpha1.Workload, error) {
+func (r *repository) GetWorkload(ctx context.Context, name string, namespace string) (_ *v1alpha1.Workload, err error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("GetWorkload")
 
+	ctx, span := tracing.Start(ctx, "Repository.GetWorkload", tracing.ObjectAttributes("Workload", namespace, name)...)
+	defer func() { tracing.End(span, err) }()
+
 	workload := v1alpha1.Workload{}
-	err := r.getObject(ctx, name, namespace, &workload)
+	err = r.getObject(ctx, name, namespace, &workload)
 	if kerrors.IsNotFound(err) {
 		log.V(logger.DEBUG).Info("workload is not found on api server")
 		return nil, nil
@@ -438,12 +604,15 @@ func (r *repository) GetWorkload(ctx context.Context, name string, namespace str
 	return &workload, nil
 }
 
-func (r *repository) GetDeliverable(ctx context.Context, name string, namespace string) (*v1alpha1.Deliverable, error) {
+func (r *repository) GetDeliverable(ctx context.Context, name string, namespace string) (_ *v1alpha1.Deliverable, err error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("GetDeliverable")
 
+	ctx, span := tracing.Start(ctx, "Repository.GetDeliverable", tracing.ObjectAttributes("Deliverable", namespace, name)...)
+	defer func() { tracing.End(span, err) }()
+
 	deliverable := v1alpha1.Deliverable{}
-	err := r.getObject(ctx, name, namespace, &deliverable)
+	err = r.getObject(ctx, name, namespace, &deliverable)
 	if kerrors.IsNotFound(err) {
 		log.V(logger.DEBUG).Info("deliverable is not found on api server")
 		return nil, nil
@@ -456,13 +625,16 @@ func (r *repository) GetDeliverable(ctx context.Context, name string, namespace
 	return &deliverable, nil
 }
 
-func (r *repository) GetRunnable(ctx context.Context, name string, namespace string) (*v1alpha1.Runnable, error) {
+func (r *repository) GetRunnable(ctx context.Context, name string, namespace string) (_ *v1alpha1.Runnable, err error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("GetDeliverable")
 
+	ctx, span := tracing.Start(ctx, "Repository.GetRunnable", tracing.ObjectAttributes("Runnable", namespace, name)...)
+	defer func() { tracing.End(span, err) }()
+
 	runnable := &v1alpha1.Runnable{}
 
-	err := r.getObject(ctx, name, namespace, runnable)
+	err = r.getObject(ctx, name, namespace, runnable)
 	if kerrors.IsNotFound(err) {
 		log.V(logger.DEBUG).Info("runnable is not found on api server")
 		return nil, nil
@@ -475,27 +647,40 @@ func (r *repository) GetRunnable(ctx context.Context, name string, namespace str
 	return runnable, nil
 }
 
-func (r *repository) GetSupplyChain(ctx context.Context, name string) (*v1alpha1.ClusterSupplyChain, error) {
+// GetSupplyChain returns the ClusterSupplyChain with the name when namespace is empty,
+// otherwise the SupplyChain with the name in that namespace.
+func (r *repository) GetSupplyChain(ctx context.Context, name, namespace string) (_ v1alpha1.SupplyChainObject, err error) {
 	log := logr.FromContextOrDiscard(ctx)
 	log.V(logger.DEBUG).Info("GetSupplyChain")
 
-	supplyChain := v1alpha1.ClusterSupplyChain{}
+	ctx, span := tracing.Start(ctx, "Repository.GetSupplyChain", tracing.ObjectAttributes("SupplyChain", namespace, name)...)
+	defer func() { tracing.End(span, err) }()
 
-	err := r.getObject(ctx, name, "", &supplyChain)
+	var supplyChain v1alpha1.SupplyChainObject = &v1alpha1.ClusterSupplyChain{}
+	if namespace != "" {
+		supplyChain = &v1alpha1.SupplyChain{}
+	}
+
+	err = r.getObject(ctx, name, namespace, supplyChain)
 	if kerrors.IsNotFound(err) {
 		log.V(logger.DEBUG).Info("supply chain is not found on api server")
 		return nil, nil
 	}
 	if err != nil {
 		log.Error(err, "failed to get supply chain object from api server")
-		return nil, fmt.Errorf("failed to get supply chain object from api server [%s]: %w", name, err)
+		return nil, fmt.Errorf("failed to get supply chain object from api server [%s]: %w", getNamespacedName(name, namespace), err)
 	}
 
-	return &supplyChain, nil
+	return supplyChain, nil
 }
 
 func (r *repository) StatusUpdate(ctx context.Context, object client.Object) error {
-	return r.cl.Status().Update(ctx, object)
+	ctx, span := tracing.Start(ctx, "Repository.StatusUpdate",
+		tracing.ObjectAttributes(object.GetObjectKind().GroupVersionKind().Kind, object.GetNamespace(), object.GetName())...,
+	)
+	err := r.cl.Status().Update(ctx, object)
+	tracing.End(span, err)
+	return err
 }
 
 func (r *repository) GetScheme() *runtime.Scheme {
@@ -25,6 +25,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gstruct"
 	v1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -102,7 +103,7 @@ spec:
 			})
 
 			It("attempts to get the object from the apiServer", func() {
-				Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)).To(Succeed())
+				Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj, false)).To(Succeed())
 
 				Expect(cl.GetCallCount()).To(Equal(1))
 
@@ -111,241 +112,232 @@ spec:
 				Expect(obj.GetObjectKind().GroupVersionKind()).To(Equal(stampedObj.GroupVersionKind()))
 			})
 
+			It("server-side applies the object under the cartographer field manager", func() {
+				Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj, false)).To(Succeed())
+
+				Expect(cl.CreateCallCount()).To(Equal(0))
+				Expect(cl.PatchCallCount()).To(Equal(1))
+				_, patchedObj, patch, opts := cl.PatchArgsForCall(0)
+				Expect(patchedObj).To(Equal(stampedObj))
+				Expect(patch).To(Equal(client.Apply))
+				Expect(opts).To(ConsistOf(client.FieldOwner("cartographer")))
+			})
+
+			It("does not consult or write to the cache", func() {
+				Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj, false)).To(Succeed())
+				Expect(cache.Invocations()).To(BeEmpty())
+			})
+
+			Context("when force is true", func() {
+				It("takes ownership of fields managed by other field managers", func() {
+					Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj, true)).To(Succeed())
+
+					Expect(cl.PatchCallCount()).To(Equal(1))
+					_, _, _, opts := cl.PatchArgsForCall(0)
+					Expect(opts).To(ConsistOf(client.FieldOwner("cartographer"), client.ForceOwnership))
+				})
+			})
+
 			Context("when the apiServer errors when trying to get the object", func() {
 				BeforeEach(func() {
 					cl.GetReturns(errors.New("some-error"))
 				})
 
 				It("returns a helpful error", func() {
-					err := repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)
+					err := repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj, false)
 					Expect(err).To(MatchError(ContainSubstring("failed to get unstructured [default/hello] from api server: some-error")))
 				})
 
-				It("does not create or patch any objects", func() {
-					_ = repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)
+				It("does not apply any objects", func() {
+					_ = repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj, false)
 					Expect(cl.CreateCallCount()).To(Equal(0))
 					Expect(cl.PatchCallCount()).To(Equal(0))
 				})
 
-				It("does not write to the submitted or persisted cache", func() {
-					_ = repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)
-					Expect(cache.SetCallCount()).To(Equal(0))
-				})
-
 				It("does not record any events", func() {
-					_ = repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)
+					_ = repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj, false)
 					Expect(rec.Invocations()).To(BeEmpty())
 				})
 			})
 
-			Context("and the apiServer attempts to get the object and it doesn't exist", func() {
+			Context("when the apply fails", func() {
 				BeforeEach(func() {
-					cl.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, ""))
+					cl.PatchReturns(errors.New("some-error"))
 				})
 
-				It("attempts to create the object", func() {
-					Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)).To(Succeed())
-
-					Expect(cl.CreateCallCount()).To(Equal(1))
-					_, createCallObj, _ := cl.CreateArgsForCall(0)
-					Expect(createCallObj).To(Equal(stampedObj))
+				It("returns a helpful error", func() {
+					err := repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj, false)
+					Expect(err).To(MatchError(ContainSubstring("apply: some-error")))
 				})
 
-				Context("and the apiServer errors when creating the object", func() {
-					BeforeEach(func() {
-						cl.CreateReturns(errors.New("some-error"))
-					})
-
-					It("returns a helpful error", func() {
-						err := repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)
-						Expect(err).To(MatchError(ContainSubstring("create: some-error")))
-					})
-
-					It("does not write to the submitted or persisted cache", func() {
-						_ = repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)
-						Expect(cache.SetCallCount()).To(Equal(0))
-					})
-
-					It("does not record any events", func() {
-						_ = repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)
-						Expect(rec.Invocations()).To(BeEmpty())
-					})
+				It("does not record any events", func() {
+					_ = repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj, false)
+					Expect(rec.Invocations()).To(BeEmpty())
 				})
+			})
 
-				Context("and the apiServer succeeds", func() {
-					var returnedCreatedObj *unstructured.Unstructured
-					BeforeEach(func() {
-						returnedCreatedObj = stampedObj.DeepCopy()
-						Expect(utils.AlterFieldOfNestedStringMaps(returnedCreatedObj.Object, "spec.template.spec.restartPolicy", "Never")).To(Succeed())
-						cl.CreateStub = func(ctx context.Context, obj client.Object, _ ...client.CreateOption) error {
-							objVal := reflect.ValueOf(obj)
-							returnVal := reflect.ValueOf(returnedCreatedObj)
-
-							reflect.Indirect(objVal).Set(reflect.Indirect(returnVal))
-							return nil
-						}
-					})
-
-					It("does not return an error", func() {
-						Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)).To(Succeed())
-					})
-
-					It("caches the submitted and persisted objects with no ownerDiscriminant, as the persisted one may be modified by mutating webhooks", func() {
-						originalStampedObj := stampedObj.DeepCopy()
-
-						Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)).To(Succeed())
-						Expect(cache.SetCallCount()).To(Equal(1))
-						submitted, persisted, ownerDiscriminant := cache.SetArgsForCall(0)
-						Expect(*submitted).To(Equal(*originalStampedObj))
-						Expect(*persisted).To(Equal(*returnedCreatedObj))
-						Expect(ownerDiscriminant).To(Equal(""))
-					})
+			Context("when the apply conflicts with another field manager", func() {
+				BeforeEach(func() {
+					cl.PatchReturns(kerrors.NewConflict(schema.GroupResource{Group: "batch", Resource: "jobs"}, "hello", errors.New("conflict with \"hpa\"")))
+				})
 
-					It("records an StampedObjectApplied event", func() {
-						_ = repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)
-						Expect(rec.ResourceEventfCallCount()).To(Equal(1))
-						eventType, reason, message, messageObject, fmtArgs := rec.ResourceEventfArgsForCall(0)
-						Expect(eventType).To(Equal("Normal"))
-						Expect(reason).To(Equal("StampedObjectApplied"))
-						Expect(message).To(Equal("Created object [%Q]"))
-						Expect(messageObject).To(Equal(stampedObj))
-						Expect(fmtArgs).To(BeEmpty())
-					})
+				It("returns an error recognisable as a conflict", func() {
+					err := repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj, false)
+					Expect(kerrors.IsConflict(err)).To(BeTrue())
 				})
 			})
 
-			Context("and apiServer succeeds in getting the object", func() {
-				var (
-					existingObj *unstructured.Unstructured
-				)
+			Context("and the apply succeeds", func() {
+				var returnedAppliedObj *unstructured.Unstructured
 
 				BeforeEach(func() {
-					existingObj = &unstructured.Unstructured{}
-					existingObj.SetName("hello")
-					existingObj.SetNamespace("default")
-					existingObj.SetGeneration(5)
-
-					cl.GetStub = func(ctx context.Context, key types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+					returnedAppliedObj = stampedObj.DeepCopy()
+					returnedAppliedObj.SetResourceVersion("2")
+					Expect(utils.AlterFieldOfNestedStringMaps(returnedAppliedObj.Object, "spec.template.spec.restartPolicy", "Never")).To(Succeed())
+					cl.PatchStub = func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
 						objVal := reflect.ValueOf(obj)
-						existingVal := reflect.ValueOf(existingObj)
+						returnVal := reflect.ValueOf(returnedAppliedObj)
 
-						reflect.Indirect(objVal).Set(reflect.Indirect(existingVal))
+						reflect.Indirect(objVal).Set(reflect.Indirect(returnVal))
 						return nil
 					}
 				})
 
-				It("the cache is consulted to see if there was a change since the last time the cache was updated", func() {
-					Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)).To(Succeed())
-					Expect(cache.UnchangedSinceCachedCallCount()).To(Equal(1))
-
-					submitted, persisted := cache.UnchangedSinceCachedArgsForCall(0)
-					Expect(*submitted).To(Equal(*stampedObj))
-					Expect(persisted).To(Equal(existingObj))
+				It("populates the object passed into the function with the object returned by the apiServer", func() {
+					Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj, false)).To(Succeed())
+					Expect(stampedObj).To(Equal(returnedAppliedObj))
 				})
 
-				Context("and the cache determines there has been no change since the last update", func() {
+				Context("and the object did not exist", func() {
 					BeforeEach(func() {
-						cache.UnchangedSinceCachedReturns(existingObj)
+						cl.GetReturns(kerrors.NewNotFound(schema.GroupResource{}, ""))
 					})
 
-					It("does not create or patch any objects", func() {
-						Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)).To(Succeed())
-						Expect(cl.CreateCallCount()).To(Equal(0))
-						Expect(cl.PatchCallCount()).To(Equal(0))
+					It("records a StampedObjectApplied event", func() {
+						Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj, false)).To(Succeed())
+						Expect(rec.ResourceEventfCallCount()).To(Equal(1))
+						eventType, reason, message, messageObject, fmtArgs := rec.ResourceEventfArgsForCall(0)
+						Expect(eventType).To(Equal("Normal"))
+						Expect(reason).To(Equal("StampedObjectApplied"))
+						Expect(message).To(Equal("%s object [%Q]"))
+						Expect(messageObject).To(Equal(stampedObj))
+						Expect(fmtArgs).To(Equal([]interface{}{"Created"}))
 					})
+				})
 
-					It("does not write to the submitted or persisted cache", func() {
-						Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)).To(Succeed())
-						Expect(cache.SetCallCount()).To(Equal(0))
-					})
+				Context("and the object existed", func() {
+					var existingObj *unstructured.Unstructured
 
-					It("populates the object passed into the function with the object in apiServer", func() {
-						originalStampedObj := stampedObj.DeepCopy()
+					BeforeEach(func() {
+						existingObj = &unstructured.Unstructured{}
+						existingObj.SetName("hello")
+						existingObj.SetNamespace("default")
+						existingObj.SetResourceVersion("1")
 
-						_ = repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)
+						cl.GetStub = func(ctx context.Context, key types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+							objVal := reflect.ValueOf(obj)
+							existingVal := reflect.ValueOf(existingObj)
 
-						Expect(stampedObj).To(Equal(existingObj))
-						Expect(stampedObj).NotTo(Equal(originalStampedObj))
+							reflect.Indirect(objVal).Set(reflect.Indirect(existingVal))
+							return nil
+						}
 					})
 
-					It("does not record any events", func() {
-						_ = repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)
-						Expect(rec.Invocations()).To(BeEmpty())
+					It("records a StampedObjectApplied event", func() {
+						Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj, false)).To(Succeed())
+						Expect(rec.ResourceEventfCallCount()).To(Equal(1))
+						eventType, reason, message, resourceObject, fmtArgs := rec.ResourceEventfArgsForCall(0)
+						Expect(eventType).To(Equal("Normal"))
+						Expect(reason).To(Equal("StampedObjectApplied"))
+						Expect(message).To(Equal("%s object [%Q]"))
+						Expect(resourceObject).To(Equal(stampedObj))
+						Expect(fmtArgs).To(Equal([]interface{}{"Patched"}))
 					})
-				})
 
-				Context("and the cache determines there has been a change since the last update", func() {
-					BeforeEach(func() {
-						cache.UnchangedSinceCachedReturns(nil)
+					Context("and the apply did not change the object", func() {
+						BeforeEach(func() {
+							existingObj.SetResourceVersion("2")
+						})
+
+						It("does not record any events", func() {
+							Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj, false)).To(Succeed())
+							Expect(rec.Invocations()).To(BeEmpty())
+						})
 					})
 
-					Context("and allowUpdate is true", func() {
-						Context("list has exactly one object", func() {
-							It("patches the object", func() {
-								Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)).To(Succeed())
-								Expect(cl.PatchCallCount()).To(Equal(1))
+					It("only applies the object", func() {
+						Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj, false)).To(Succeed())
+						Expect(cl.PatchCallCount()).To(Equal(1))
+						_, _, patch, _ := cl.PatchArgsForCall(0)
+						Expect(patch).To(Equal(client.Apply))
+					})
+
+					Context("and the object was stamped with update operations before server-side apply", func() {
+						BeforeEach(func() {
+							existingObj.SetManagedFields([]metav1.ManagedFieldsEntry{
+								{
+									Manager:    "cartographer",
+									Operation:  metav1.ManagedFieldsOperationUpdate,
+									APIVersion: "batch/v1",
+									FieldsType: "FieldsV1",
+									FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:template":{"f:spec":{"f:restartPolicy":{}}}}}`)},
+								},
+								{
+									Manager:    "kubectl",
+									Operation:  metav1.ManagedFieldsOperationUpdate,
+									APIVersion: "batch/v1",
+									FieldsType: "FieldsV1",
+									FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:team":{}}}}`)},
+								},
 							})
+						})
+
+						It("hands the fields over to the apply field manager before applying", func() {
+							Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj, false)).To(Succeed())
+							Expect(cl.PatchCallCount()).To(Equal(2))
+
+							_, upgradedObj, upgradePatch, _ := cl.PatchArgsForCall(0)
+							Expect(upgradedObj.GetName()).To(Equal("hello"))
+							Expect(upgradePatch.Type()).To(Equal(types.JSONPatchType))
 
-							Context("and the patch succeeds", func() {
-								var returnedPatchedObj *unstructured.Unstructured
-
-								BeforeEach(func() {
-									returnedPatchedObj = stampedObj.DeepCopy()
-									Expect(utils.AlterFieldOfNestedStringMaps(returnedPatchedObj.Object, "spec.template.spec.restartPolicy", "Never")).To(Succeed())
-									cl.PatchStub = func(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
-										objVal := reflect.ValueOf(obj)
-										returnVal := reflect.ValueOf(returnedPatchedObj)
-
-										reflect.Indirect(objVal).Set(reflect.Indirect(returnVal))
-										return nil
-									}
-								})
-
-								It("does not return an error", func() {
-									Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)).To(Succeed())
-								})
-
-								It("caches the submitted and persisted objects with no ownerDiscriminant, as the persisted one may be modified by mutating webhooks", func() {
-									originalStampedObj := stampedObj.DeepCopy()
-
-									Expect(repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)).To(Succeed())
-									Expect(cache.SetCallCount()).To(Equal(1))
-									submitted, persisted, ownerDiscriminant := cache.SetArgsForCall(0)
-									Expect(*submitted).To(Equal(*originalStampedObj))
-									Expect(*persisted).To(Equal(*returnedPatchedObj))
-									Expect(ownerDiscriminant).To(Equal(""))
-								})
-
-								It("records an StampedObjectApplied event", func() {
-									_ = repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)
-									Expect(rec.ResourceEventfCallCount()).To(Equal(1))
-									eventType, reason, message, resourceObject, fmtArgs := rec.ResourceEventfArgsForCall(0)
-									Expect(eventType).To(Equal("Normal"))
-									Expect(reason).To(Equal("StampedObjectApplied"))
-									Expect(message).To(Equal("Patched object [%Q]"))
-									Expect(resourceObject).To(Equal(stampedObj))
-									Expect(fmtArgs).To(BeEmpty())
-								})
+							patchData, err := upgradePatch.Data(upgradedObj)
+							Expect(err).NotTo(HaveOccurred())
+							var ops []struct {
+								Op    string          `json:"op"`
+								Path  string          `json:"path"`
+								Value json.RawMessage `json:"value"`
+							}
+							Expect(json.Unmarshal(patchData, &ops)).To(Succeed())
+							Expect(ops).To(HaveLen(2))
+							Expect(ops[0].Path).To(Equal("/metadata/managedFields"))
+							var managedFields []metav1.ManagedFieldsEntry
+							Expect(json.Unmarshal(ops[0].Value, &managedFields)).To(Succeed())
+							Expect(managedFields).To(ConsistOf(
+								MatchFields(IgnoreExtras, Fields{
+									"Manager":   Equal("cartographer"),
+									"Operation": Equal(metav1.ManagedFieldsOperationApply),
+								}),
+								MatchFields(IgnoreExtras, Fields{
+									"Manager":   Equal("kubectl"),
+									"Operation": Equal(metav1.ManagedFieldsOperationUpdate),
+								}),
+							))
+							Expect(ops[1].Path).To(Equal("/metadata/resourceVersion"))
+							Expect(string(ops[1].Value)).To(Equal(`"1"`))
+
+							_, _, applyPatch, _ := cl.PatchArgsForCall(1)
+							Expect(applyPatch).To(Equal(client.Apply))
+						})
+
+						Context("and the upgrade fails", func() {
+							BeforeEach(func() {
+								cl.PatchReturnsOnCall(0, errors.New("some-error"))
 							})
 
-							Context("and the patch fails", func() {
-								BeforeEach(func() {
-									cl.PatchReturns(errors.New("some-error"))
-								})
-								It("returns a helpful error", func() {
-									err := repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)
-									Expect(err).To(MatchError(ContainSubstring("patch: some-error")))
-								})
-
-								It("does not write to the submitted or persisted cache", func() {
-									_ = repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)
-									Expect(cache.SetCallCount()).To(Equal(0))
-								})
-
-								It("does not record any events", func() {
-									_ = repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj)
-									Expect(rec.Invocations()).To(BeEmpty())
-								})
+							It("returns a helpful error and does not apply the object", func() {
+								err := repo.EnsureMutableObjectExistsOnCluster(ctx, stampedObj, false)
+								Expect(err).To(MatchError("upgrade managed fields: some-error"))
+								Expect(cl.PatchCallCount()).To(Equal(1))
 							})
 						})
 					})
@@ -451,7 +443,7 @@ spec:
 
 				Context("and the cache determines there has been a change since the last update", func() {
 					BeforeEach(func() {
-						cache.UnchangedSinceCachedReturns(nil)
+						cache.UnchangedSinceCachedFromListReturns(nil)
 					})
 
 					It("creates a new object", func() {
@@ -459,6 +451,17 @@ spec:
 						Expect(cl.CreateCallCount()).To(Equal(1))
 					})
 
+					It("annotates the new object with the hash of the submitted object", func() {
+						originalStampedObj := stampedObj.DeepCopy()
+
+						Expect(repo.EnsureImmutableObjectExistsOnCluster(ctx, stampedObj, labels)).To(Succeed())
+						_, createCallObj, _ := cl.CreateArgsForCall(0)
+						Expect(createCallObj.GetAnnotations()).To(HaveKeyWithValue(
+							"carto.run/submitted-hash",
+							repository.SubmittedHash(originalStampedObj, "{foo:bar}{quux:xyzzy}{waldo:fred}"),
+						))
+					})
+
 					Context("and the create succeeds", func() {
 						var returnedCreatedObj *unstructured.Unstructured
 
@@ -567,7 +570,7 @@ spec:
 			})
 
 			It("attempts to get the object from the apiServer", func() {
-				_, err := repo.GetSupplyChain(ctx, "sc-name")
+				_, err := repo.GetSupplyChain(ctx, "sc-name", "")
 				Expect(err).To(HaveOccurred())
 				Expect(err.Error()).To(ContainSubstring("failed to get supply chain object from api server [sc-name]: failed to get object [sc-name] from api server: some get error"))
 			})
@@ -659,7 +662,7 @@ spec:
 						Kind: "some-unsupported-kind",
 						Name: "my-template",
 					}
-					_, err := repo.GetTemplate(ctx, reference.Name, reference.Kind)
+					_, err := repo.GetTemplate(ctx, reference.Name, reference.Kind, "")
 					Expect(err).To(HaveOccurred())
 					Expect(err.Error()).To(ContainSubstring("unable to get api template [some-unsupported-kind/my-template]: resource does not have valid kind: some-unsupported-kind"))
 				})
@@ -674,7 +677,7 @@ spec:
 						Kind: "ClusterImageTemplate",
 						Name: "image-template",
 					}
-					_, err := repo.GetTemplate(ctx, reference.Name, reference.Kind)
+					_, err := repo.GetTemplate(ctx, reference.Name, reference.Kind, "")
 					Expect(err).To(HaveOccurred())
 					Expect(err.Error()).To(ContainSubstring("failed to get template object from api server [ClusterImageTemplate/image-template]: failed to get object [image-template] from api server: some bad get error"))
 				})
@@ -689,7 +692,7 @@ spec:
 					cl.GetReturns(apiError)
 				})
 				It("returns the error", func() {
-					_, err := repo.GetDelivery(ctx, "my-delivery")
+					_, err := repo.GetDelivery(ctx, "my-delivery", "")
 					Expect(err).To(HaveOccurred())
 					Expect(err.Error()).To(ContainSubstring(apiError.Error()))
 				})
@@ -708,7 +711,7 @@ spec:
 					cl.GetReturns(apiError)
 				})
 				It("returns a nil result without error and without logging an error", func() {
-					delivery, err := repo.GetDelivery(ctx, "my-delivery")
+					delivery, err := repo.GetDelivery(ctx, "my-delivery", "")
 					Expect(err).NotTo(HaveOccurred())
 					Expect(delivery).To(BeNil())
 
@@ -729,7 +732,7 @@ spec:
 				})
 
 				It("asks for the delivery by name", func() {
-					_, err := repo.GetDelivery(ctx, "my-delivery")
+					_, err := repo.GetDelivery(ctx, "my-delivery", "")
 					Expect(err).NotTo(HaveOccurred())
 
 					Expect(cl.GetCallCount()).To(Equal(1))
@@ -741,7 +744,7 @@ spec:
 				})
 
 				It("returns the delivery without error", func() {
-					delivery, err := repo.GetDelivery(ctx, "my-delivery")
+					delivery, err := repo.GetDelivery(ctx, "my-delivery", "")
 					Expect(err).NotTo(HaveOccurred())
 
 					Expect(delivery).To(Equal(apiDelivery))
@@ -848,7 +851,7 @@ spec:
 					Kind: "ClusterSourceTemplate",
 					Name: "some-name",
 				}
-				template, err := repo.GetTemplate(ctx, templateRef.Name, templateRef.Kind)
+				template, err := repo.GetTemplate(ctx, templateRef.Name, templateRef.Kind, "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(template.GetName()).To(Equal("some-name"))
 			})
@@ -869,12 +872,49 @@ spec:
 					Kind: "ClusterSourceTemplate",
 					Name: "some-name",
 				}
-				template, err := repo.GetTemplate(ctx, templateRef.Name, templateRef.Kind)
+				template, err := repo.GetTemplate(ctx, templateRef.Name, templateRef.Kind, "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(template.GetName()).To(Equal("some-name"))
 			})
 		})
 
+		Context("GetTemplate for a namespaced template kind", func() {
+			BeforeEach(func() {
+				clientObjects = []client.Object{
+					&v1alpha1.SourceTemplate{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "some-name",
+							Namespace: "some-namespace",
+						},
+					},
+					&v1alpha1.ClusterSourceTemplate{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "some-name",
+						},
+					},
+				}
+			})
+
+			It("gets the template from the namespace", func() {
+				template, err := repo.GetTemplate(ctx, "some-name", "SourceTemplate", "some-namespace")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(template).To(BeAssignableToTypeOf(&v1alpha1.SourceTemplate{}))
+				Expect(template.GetNamespace()).To(Equal("some-namespace"))
+			})
+
+			It("does not find the template in another namespace", func() {
+				template, err := repo.GetTemplate(ctx, "some-name", "SourceTemplate", "other-namespace")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(template).To(BeNil())
+			})
+
+			It("ignores the namespace for cluster template kinds", func() {
+				template, err := repo.GetTemplate(ctx, "some-name", "ClusterSourceTemplate", "some-namespace")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(template).To(BeAssignableToTypeOf(&v1alpha1.ClusterSourceTemplate{}))
+			})
+		})
+
 		Context("GetRunTemplate", func() {
 			BeforeEach(func() {
 				clientObjects = []client.Object{
@@ -996,14 +1036,14 @@ spec:
 			})
 
 			It("gets the supply chain successfully", func() {
-				sc, err := repo.GetSupplyChain(ctx, "sc-name")
+				sc, err := repo.GetSupplyChain(ctx, "sc-name", "")
 				Expect(err).ToNot(HaveOccurred())
 				Expect(sc.GetName()).To(Equal("sc-name"))
 			})
 
 			Context("supply chain doesnt exist", func() {
 				It("returns no error without logging an error", func() {
-					sc, err := repo.GetSupplyChain(ctx, "sc-that-does-not-exist-name")
+					sc, err := repo.GetSupplyChain(ctx, "sc-that-does-not-exist-name", "")
 					Expect(err).ToNot(HaveOccurred())
 					Expect(sc).To(BeNil())
 
@@ -1012,6 +1052,31 @@ spec:
 			})
 		})
 
+		Context("GetSupplyChain with a namespace", func() {
+			BeforeEach(func() {
+				supplyChain := &v1alpha1.SupplyChain{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "sc-name",
+						Namespace: "some-namespace",
+					},
+				}
+				clientObjects = []client.Object{supplyChain}
+			})
+
+			It("gets the namespaced supply chain", func() {
+				sc, err := repo.GetSupplyChain(ctx, "sc-name", "some-namespace")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sc).To(BeAssignableToTypeOf(&v1alpha1.SupplyChain{}))
+				Expect(sc.GetNamespace()).To(Equal("some-namespace"))
+			})
+
+			It("does not find it as a cluster supply chain", func() {
+				sc, err := repo.GetSupplyChain(ctx, "sc-name", "")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(sc).To(BeNil())
+			})
+		})
+
 		Context("GetSupplyChainsForWorkload", func() {
 			Context("When a matchFields key is invalid", func() {
 				BeforeEach(func() {
@@ -1084,7 +1149,7 @@ spec:
 					supplyChains, err := repo.GetSupplyChainsForWorkload(ctx, workload)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(len(supplyChains)).To(Equal(1))
-					Expect(supplyChains[0].Name).To(Equal("supplychain-name"))
+					Expect(supplyChains[0].GetName()).To(Equal("supplychain-name"))
 				})
 			})
 
@@ -1128,7 +1193,7 @@ spec:
 					supplyChains, err := repo.GetSupplyChainsForWorkload(ctx, workload)
 					Expect(err).ToNot(HaveOccurred())
 					Expect(len(supplyChains)).To(Equal(1))
-					Expect(supplyChains[0].Name).To(Equal("supplychain-name"))
+					Expect(supplyChains[0].GetName()).To(Equal("supplychain-name"))
 				})
 
 				It("returns no supply chains for workload", func() {
@@ -1146,6 +1211,182 @@ spec:
 					Expect(len(supplyChains)).To(Equal(0))
 				})
 			})
+
+			Context("a sub-chain and a supply chain", func() {
+				BeforeEach(func() {
+					supplyChain := &v1alpha1.ClusterSupplyChain{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "supplychain-name",
+						},
+						Spec: v1alpha1.SupplyChainSpec{
+							LegacySelector: v1alpha1.LegacySelector{
+								Selector: map[string]string{"foo": "bar"},
+							},
+						},
+					}
+					subChain := &v1alpha1.ClusterSupplyChain{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "sub-chain-name",
+						},
+						Spec: v1alpha1.SupplyChainSpec{
+							LegacySelector: v1alpha1.LegacySelector{
+								Selector: map[string]string{"foo": "bar", "other": "label"},
+							},
+							SubChain: &v1alpha1.SubChain{
+								Entries: []string{"source-provider"},
+								Export:  "source-provider",
+							},
+						},
+					}
+					clientObjects = []client.Object{supplyChain, subChain}
+				})
+
+				It("never selects the sub-chain for a workload", func() {
+					workload := &v1alpha1.Workload{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:   "workload-name",
+							Labels: map[string]string{"foo": "bar", "other": "label"},
+						},
+					}
+					supplyChains, err := repo.GetSupplyChainsForWorkload(ctx, workload)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(len(supplyChains)).To(Equal(1))
+					Expect(supplyChains[0].GetName()).To(Equal("supplychain-name"))
+				})
+			})
+		})
+
+		Context("GetSupplyChainsForWorkload with namespaced supply chains", func() {
+			var workload *v1alpha1.Workload
+
+			BeforeEach(func() {
+				workload = &v1alpha1.Workload{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "workload-name",
+						Namespace: "some-namespace",
+						Labels:    map[string]string{"foo": "bar", "app": "web"},
+					},
+				}
+			})
+
+			Context("when a namespaced and a cluster supply chain match equally well", func() {
+				BeforeEach(func() {
+					clientObjects = []client.Object{
+						&v1alpha1.ClusterSupplyChain{
+							ObjectMeta: metav1.ObjectMeta{Name: "cluster-chain"},
+							Spec: v1alpha1.SupplyChainSpec{
+								LegacySelector: v1alpha1.LegacySelector{Selector: map[string]string{"foo": "bar"}},
+							},
+						},
+						&v1alpha1.SupplyChain{
+							ObjectMeta: metav1.ObjectMeta{Name: "namespaced-chain", Namespace: "some-namespace"},
+							Spec: v1alpha1.SupplyChainSpec{
+								LegacySelector: v1alpha1.LegacySelector{Selector: map[string]string{"foo": "bar"}},
+							},
+						},
+					}
+				})
+
+				It("selects the namespaced supply chain", func() {
+					supplyChains, err := repo.GetSupplyChainsForWorkload(ctx, workload)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(supplyChains).To(HaveLen(1))
+					Expect(supplyChains[0].GetName()).To(Equal("namespaced-chain"))
+					Expect(supplyChains[0].GetNamespace()).To(Equal("some-namespace"))
+				})
+			})
+
+			Context("when the cluster supply chain is a better match", func() {
+				BeforeEach(func() {
+					clientObjects = []client.Object{
+						&v1alpha1.ClusterSupplyChain{
+							ObjectMeta: metav1.ObjectMeta{Name: "cluster-chain"},
+							Spec: v1alpha1.SupplyChainSpec{
+								LegacySelector: v1alpha1.LegacySelector{Selector: map[string]string{"foo": "bar", "app": "web"}},
+							},
+						},
+						&v1alpha1.SupplyChain{
+							ObjectMeta: metav1.ObjectMeta{Name: "namespaced-chain", Namespace: "some-namespace"},
+							Spec: v1alpha1.SupplyChainSpec{
+								LegacySelector: v1alpha1.LegacySelector{Selector: map[string]string{"foo": "bar"}},
+							},
+						},
+					}
+				})
+
+				It("selects the cluster supply chain", func() {
+					supplyChains, err := repo.GetSupplyChainsForWorkload(ctx, workload)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(supplyChains).To(HaveLen(1))
+					Expect(supplyChains[0].GetName()).To(Equal("cluster-chain"))
+				})
+			})
+
+			Context("when the only matching supply chain is in another namespace", func() {
+				BeforeEach(func() {
+					clientObjects = []client.Object{
+						&v1alpha1.SupplyChain{
+							ObjectMeta: metav1.ObjectMeta{Name: "namespaced-chain", Namespace: "other-namespace"},
+							Spec: v1alpha1.SupplyChainSpec{
+								LegacySelector: v1alpha1.LegacySelector{Selector: map[string]string{"foo": "bar"}},
+							},
+						},
+					}
+				})
+
+				It("selects no supply chain", func() {
+					supplyChains, err := repo.GetSupplyChainsForWorkload(ctx, workload)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(supplyChains).To(BeEmpty())
+				})
+			})
+		})
+
+		Context("GetDeliveriesForDeliverable with namespaced deliveries", func() {
+			BeforeEach(func() {
+				clientObjects = []client.Object{
+					&v1alpha1.ClusterDelivery{
+						ObjectMeta: metav1.ObjectMeta{Name: "cluster-delivery"},
+						Spec: v1alpha1.DeliverySpec{
+							LegacySelector: v1alpha1.LegacySelector{Selector: map[string]string{"foo": "bar"}},
+						},
+					},
+					&v1alpha1.Delivery{
+						ObjectMeta: metav1.ObjectMeta{Name: "namespaced-delivery", Namespace: "some-namespace"},
+						Spec: v1alpha1.DeliverySpec{
+							LegacySelector: v1alpha1.LegacySelector{Selector: map[string]string{"foo": "bar"}},
+						},
+					},
+				}
+			})
+
+			It("prefers the namespaced delivery when both match equally well", func() {
+				deliverable := &v1alpha1.Deliverable{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "deliverable-name",
+						Namespace: "some-namespace",
+						Labels:    map[string]string{"foo": "bar"},
+					},
+				}
+				deliveries, err := repo.GetDeliveriesForDeliverable(ctx, deliverable)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(HaveLen(1))
+				Expect(deliveries[0].GetName()).To(Equal("namespaced-delivery"))
+			})
+
+			It("selects the cluster delivery for deliverables in other namespaces", func() {
+				deliverable := &v1alpha1.Deliverable{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "deliverable-name",
+						Namespace: "other-namespace",
+						Labels:    map[string]string{"foo": "bar"},
+					},
+				}
+				deliveries, err := repo.GetDeliveriesForDeliverable(ctx, deliverable)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries).To(HaveLen(1))
+				Expect(deliveries[0].GetName()).To(Equal("cluster-delivery"))
+			})
 		})
 
 		Context("Delete", func() {
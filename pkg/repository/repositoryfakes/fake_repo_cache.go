@@ -16,18 +16,6 @@ type FakeRepoCache struct {
 		arg2 *unstructured.Unstructured
 		arg3 string
 	}
-	UnchangedSinceCachedStub        func(*unstructured.Unstructured, *unstructured.Unstructured) *unstructured.Unstructured
-	unchangedSinceCachedMutex       sync.RWMutex
-	unchangedSinceCachedArgsForCall []struct {
-		arg1 *unstructured.Unstructured
-		arg2 *unstructured.Unstructured
-	}
-	unchangedSinceCachedReturns struct {
-		result1 *unstructured.Unstructured
-	}
-	unchangedSinceCachedReturnsOnCall map[int]struct {
-		result1 *unstructured.Unstructured
-	}
 	UnchangedSinceCachedFromListStub        func(*unstructured.Unstructured, []*unstructured.Unstructured, string) *unstructured.Unstructured
 	unchangedSinceCachedFromListMutex       sync.RWMutex
 	unchangedSinceCachedFromListArgsForCall []struct {
@@ -79,68 +67,6 @@ func (fake *FakeRepoCache) SetArgsForCall(i int) (*unstructured.Unstructured, *u
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeRepoCache) UnchangedSinceCached(arg1 *unstructured.Unstructured, arg2 *unstructured.Unstructured) *unstructured.Unstructured {
-	fake.unchangedSinceCachedMutex.Lock()
-	ret, specificReturn := fake.unchangedSinceCachedReturnsOnCall[len(fake.unchangedSinceCachedArgsForCall)]
-	fake.unchangedSinceCachedArgsForCall = append(fake.unchangedSinceCachedArgsForCall, struct {
-		arg1 *unstructured.Unstructured
-		arg2 *unstructured.Unstructured
-	}{arg1, arg2})
-	stub := fake.UnchangedSinceCachedStub
-	fakeReturns := fake.unchangedSinceCachedReturns
-	fake.recordInvocation("UnchangedSinceCached", []interface{}{arg1, arg2})
-	fake.unchangedSinceCachedMutex.Unlock()
-	if stub != nil {
-		return stub(arg1, arg2)
-	}
-	if specificReturn {
-		return ret.result1
-	}
-	return fakeReturns.result1
-}
-
-func (fake *FakeRepoCache) UnchangedSinceCachedCallCount() int {
-	fake.unchangedSinceCachedMutex.RLock()
-	defer fake.unchangedSinceCachedMutex.RUnlock()
-	return len(fake.unchangedSinceCachedArgsForCall)
-}
-
-func (fake *FakeRepoCache) UnchangedSinceCachedCalls(stub func(*unstructured.Unstructured, *unstructured.Unstructured) *unstructured.Unstructured) {
-	fake.unchangedSinceCachedMutex.Lock()
-	defer fake.unchangedSinceCachedMutex.Unlock()
-	fake.UnchangedSinceCachedStub = stub
-}
-
-func (fake *FakeRepoCache) UnchangedSinceCachedArgsForCall(i int) (*unstructured.Unstructured, *unstructured.Unstructured) {
-	fake.unchangedSinceCachedMutex.RLock()
-	defer fake.unchangedSinceCachedMutex.RUnlock()
-	argsForCall := fake.unchangedSinceCachedArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
-}
-
-func (fake *FakeRepoCache) UnchangedSinceCachedReturns(result1 *unstructured.Unstructured) {
-	fake.unchangedSinceCachedMutex.Lock()
-	defer fake.unchangedSinceCachedMutex.Unlock()
-	fake.UnchangedSinceCachedStub = nil
-	fake.unchangedSinceCachedReturns = struct {
-		result1 *unstructured.Unstructured
-	}{result1}
-}
-
-func (fake *FakeRepoCache) UnchangedSinceCachedReturnsOnCall(i int, result1 *unstructured.Unstructured) {
-	fake.unchangedSinceCachedMutex.Lock()
-	defer fake.unchangedSinceCachedMutex.Unlock()
-	fake.UnchangedSinceCachedStub = nil
-	if fake.unchangedSinceCachedReturnsOnCall == nil {
-		fake.unchangedSinceCachedReturnsOnCall = make(map[int]struct {
-			result1 *unstructured.Unstructured
-		})
-	}
-	fake.unchangedSinceCachedReturnsOnCall[i] = struct {
-		result1 *unstructured.Unstructured
-	}{result1}
-}
-
 func (fake *FakeRepoCache) UnchangedSinceCachedFromList(arg1 *unstructured.Unstructured, arg2 []*unstructured.Unstructured, arg3 string) *unstructured.Unstructured {
 	var arg2Copy []*unstructured.Unstructured
 	if arg2 != nil {
@@ -214,8 +140,6 @@ func (fake *FakeRepoCache) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.setMutex.RLock()
 	defer fake.setMutex.RUnlock()
-	fake.unchangedSinceCachedMutex.RLock()
-	defer fake.unchangedSinceCachedMutex.RUnlock()
 	fake.unchangedSinceCachedFromListMutex.RLock()
 	defer fake.unchangedSinceCachedFromListMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
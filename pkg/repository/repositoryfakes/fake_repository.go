@@ -41,11 +41,12 @@ type FakeRepository struct {
 	ensureImmutableObjectExistsOnClusterReturnsOnCall map[int]struct {
 		result1 error
 	}
-	EnsureMutableObjectExistsOnClusterStub        func(context.Context, *unstructured.Unstructured) error
+	EnsureMutableObjectExistsOnClusterStub        func(context.Context, *unstructured.Unstructured, bool) error
 	ensureMutableObjectExistsOnClusterMutex       sync.RWMutex
 	ensureMutableObjectExistsOnClusterArgsForCall []struct {
 		arg1 context.Context
 		arg2 *unstructured.Unstructured
+		arg3 bool
 	}
 	ensureMutableObjectExistsOnClusterReturns struct {
 		result1 error
@@ -68,32 +69,33 @@ type FakeRepository struct {
 		result1 *v1alpha1.Deliverable
 		result2 error
 	}
-	GetDeliveriesForDeliverableStub        func(context.Context, *v1alpha1.Deliverable) ([]*v1alpha1.ClusterDelivery, error)
+	GetDeliveriesForDeliverableStub        func(context.Context, *v1alpha1.Deliverable) ([]v1alpha1.DeliveryObject, error)
 	getDeliveriesForDeliverableMutex       sync.RWMutex
 	getDeliveriesForDeliverableArgsForCall []struct {
 		arg1 context.Context
 		arg2 *v1alpha1.Deliverable
 	}
 	getDeliveriesForDeliverableReturns struct {
-		result1 []*v1alpha1.ClusterDelivery
+		result1 []v1alpha1.DeliveryObject
 		result2 error
 	}
 	getDeliveriesForDeliverableReturnsOnCall map[int]struct {
-		result1 []*v1alpha1.ClusterDelivery
+		result1 []v1alpha1.DeliveryObject
 		result2 error
 	}
-	GetDeliveryStub        func(context.Context, string) (*v1alpha1.ClusterDelivery, error)
+	GetDeliveryStub        func(context.Context, string, string) (v1alpha1.DeliveryObject, error)
 	getDeliveryMutex       sync.RWMutex
 	getDeliveryArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	getDeliveryReturns struct {
-		result1 *v1alpha1.ClusterDelivery
+		result1 v1alpha1.DeliveryObject
 		result2 error
 	}
 	getDeliveryReturnsOnCall map[int]struct {
-		result1 *v1alpha1.ClusterDelivery
+		result1 v1alpha1.DeliveryObject
 		result2 error
 	}
 	GetRESTMapperStub        func() meta.RESTMapper
@@ -160,40 +162,42 @@ type FakeRepository struct {
 		result1 *v1.ServiceAccount
 		result2 error
 	}
-	GetSupplyChainStub        func(context.Context, string) (*v1alpha1.ClusterSupplyChain, error)
+	GetSupplyChainStub        func(context.Context, string, string) (v1alpha1.SupplyChainObject, error)
 	getSupplyChainMutex       sync.RWMutex
 	getSupplyChainArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
+		arg3 string
 	}
 	getSupplyChainReturns struct {
-		result1 *v1alpha1.ClusterSupplyChain
+		result1 v1alpha1.SupplyChainObject
 		result2 error
 	}
 	getSupplyChainReturnsOnCall map[int]struct {
-		result1 *v1alpha1.ClusterSupplyChain
+		result1 v1alpha1.SupplyChainObject
 		result2 error
 	}
-	GetSupplyChainsForWorkloadStub        func(context.Context, *v1alpha1.Workload) ([]*v1alpha1.ClusterSupplyChain, error)
+	GetSupplyChainsForWorkloadStub        func(context.Context, *v1alpha1.Workload) ([]v1alpha1.SupplyChainObject, error)
 	getSupplyChainsForWorkloadMutex       sync.RWMutex
 	getSupplyChainsForWorkloadArgsForCall []struct {
 		arg1 context.Context
 		arg2 *v1alpha1.Workload
 	}
 	getSupplyChainsForWorkloadReturns struct {
-		result1 []*v1alpha1.ClusterSupplyChain
+		result1 []v1alpha1.SupplyChainObject
 		result2 error
 	}
 	getSupplyChainsForWorkloadReturnsOnCall map[int]struct {
-		result1 []*v1alpha1.ClusterSupplyChain
+		result1 []v1alpha1.SupplyChainObject
 		result2 error
 	}
-	GetTemplateStub        func(context.Context, string, string) (client.Object, error)
+	GetTemplateStub        func(context.Context, string, string, string) (client.Object, error)
 	getTemplateMutex       sync.RWMutex
 	getTemplateArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
 		arg3 string
+		arg4 string
 	}
 	getTemplateReturns struct {
 		result1 client.Object
@@ -389,19 +393,20 @@ func (fake *FakeRepository) EnsureImmutableObjectExistsOnClusterReturnsOnCall(i
 	}{result1}
 }
 
-func (fake *FakeRepository) EnsureMutableObjectExistsOnCluster(arg1 context.Context, arg2 *unstructured.Unstructured) error {
+func (fake *FakeRepository) EnsureMutableObjectExistsOnCluster(arg1 context.Context, arg2 *unstructured.Unstructured, arg3 bool) error {
 	fake.ensureMutableObjectExistsOnClusterMutex.Lock()
 	ret, specificReturn := fake.ensureMutableObjectExistsOnClusterReturnsOnCall[len(fake.ensureMutableObjectExistsOnClusterArgsForCall)]
 	fake.ensureMutableObjectExistsOnClusterArgsForCall = append(fake.ensureMutableObjectExistsOnClusterArgsForCall, struct {
 		arg1 context.Context
 		arg2 *unstructured.Unstructured
-	}{arg1, arg2})
+		arg3 bool
+	}{arg1, arg2, arg3})
 	stub := fake.EnsureMutableObjectExistsOnClusterStub
 	fakeReturns := fake.ensureMutableObjectExistsOnClusterReturns
-	fake.recordInvocation("EnsureMutableObjectExistsOnCluster", []interface{}{arg1, arg2})
+	fake.recordInvocation("EnsureMutableObjectExistsOnCluster", []interface{}{arg1, arg2, arg3})
 	fake.ensureMutableObjectExistsOnClusterMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1
@@ -415,17 +420,17 @@ func (fake *FakeRepository) EnsureMutableObjectExistsOnClusterCallCount() int {
 	return len(fake.ensureMutableObjectExistsOnClusterArgsForCall)
 }
 
-func (fake *FakeRepository) EnsureMutableObjectExistsOnClusterCalls(stub func(context.Context, *unstructured.Unstructured) error) {
+func (fake *FakeRepository) EnsureMutableObjectExistsOnClusterCalls(stub func(context.Context, *unstructured.Unstructured, bool) error) {
 	fake.ensureMutableObjectExistsOnClusterMutex.Lock()
 	defer fake.ensureMutableObjectExistsOnClusterMutex.Unlock()
 	fake.EnsureMutableObjectExistsOnClusterStub = stub
 }
 
-func (fake *FakeRepository) EnsureMutableObjectExistsOnClusterArgsForCall(i int) (context.Context, *unstructured.Unstructured) {
+func (fake *FakeRepository) EnsureMutableObjectExistsOnClusterArgsForCall(i int) (context.Context, *unstructured.Unstructured, bool) {
 	fake.ensureMutableObjectExistsOnClusterMutex.RLock()
 	defer fake.ensureMutableObjectExistsOnClusterMutex.RUnlock()
 	argsForCall := fake.ensureMutableObjectExistsOnClusterArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
 func (fake *FakeRepository) EnsureMutableObjectExistsOnClusterReturns(result1 error) {
@@ -517,7 +522,7 @@ func (fake *FakeRepository) GetDeliverableReturnsOnCall(i int, result1 *v1alpha1
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetDeliveriesForDeliverable(arg1 context.Context, arg2 *v1alpha1.Deliverable) ([]*v1alpha1.ClusterDelivery, error) {
+func (fake *FakeRepository) GetDeliveriesForDeliverable(arg1 context.Context, arg2 *v1alpha1.Deliverable) ([]v1alpha1.DeliveryObject, error) {
 	fake.getDeliveriesForDeliverableMutex.Lock()
 	ret, specificReturn := fake.getDeliveriesForDeliverableReturnsOnCall[len(fake.getDeliveriesForDeliverableArgsForCall)]
 	fake.getDeliveriesForDeliverableArgsForCall = append(fake.getDeliveriesForDeliverableArgsForCall, struct {
@@ -543,7 +548,7 @@ func (fake *FakeRepository) GetDeliveriesForDeliverableCallCount() int {
 	return len(fake.getDeliveriesForDeliverableArgsForCall)
 }
 
-func (fake *FakeRepository) GetDeliveriesForDeliverableCalls(stub func(context.Context, *v1alpha1.Deliverable) ([]*v1alpha1.ClusterDelivery, error)) {
+func (fake *FakeRepository) GetDeliveriesForDeliverableCalls(stub func(context.Context, *v1alpha1.Deliverable) ([]v1alpha1.DeliveryObject, error)) {
 	fake.getDeliveriesForDeliverableMutex.Lock()
 	defer fake.getDeliveriesForDeliverableMutex.Unlock()
 	fake.GetDeliveriesForDeliverableStub = stub
@@ -556,45 +561,46 @@ func (fake *FakeRepository) GetDeliveriesForDeliverableArgsForCall(i int) (conte
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeRepository) GetDeliveriesForDeliverableReturns(result1 []*v1alpha1.ClusterDelivery, result2 error) {
+func (fake *FakeRepository) GetDeliveriesForDeliverableReturns(result1 []v1alpha1.DeliveryObject, result2 error) {
 	fake.getDeliveriesForDeliverableMutex.Lock()
 	defer fake.getDeliveriesForDeliverableMutex.Unlock()
 	fake.GetDeliveriesForDeliverableStub = nil
 	fake.getDeliveriesForDeliverableReturns = struct {
-		result1 []*v1alpha1.ClusterDelivery
+		result1 []v1alpha1.DeliveryObject
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetDeliveriesForDeliverableReturnsOnCall(i int, result1 []*v1alpha1.ClusterDelivery, result2 error) {
+func (fake *FakeRepository) GetDeliveriesForDeliverableReturnsOnCall(i int, result1 []v1alpha1.DeliveryObject, result2 error) {
 	fake.getDeliveriesForDeliverableMutex.Lock()
 	defer fake.getDeliveriesForDeliverableMutex.Unlock()
 	fake.GetDeliveriesForDeliverableStub = nil
 	if fake.getDeliveriesForDeliverableReturnsOnCall == nil {
 		fake.getDeliveriesForDeliverableReturnsOnCall = make(map[int]struct {
-			result1 []*v1alpha1.ClusterDelivery
+			result1 []v1alpha1.DeliveryObject
 			result2 error
 		})
 	}
 	fake.getDeliveriesForDeliverableReturnsOnCall[i] = struct {
-		result1 []*v1alpha1.ClusterDelivery
+		result1 []v1alpha1.DeliveryObject
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetDelivery(arg1 context.Context, arg2 string) (*v1alpha1.ClusterDelivery, error) {
+func (fake *FakeRepository) GetDelivery(arg1 context.Context, arg2 string, arg3 string) (v1alpha1.DeliveryObject, error) {
 	fake.getDeliveryMutex.Lock()
 	ret, specificReturn := fake.getDeliveryReturnsOnCall[len(fake.getDeliveryArgsForCall)]
 	fake.getDeliveryArgsForCall = append(fake.getDeliveryArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.GetDeliveryStub
 	fakeReturns := fake.getDeliveryReturns
-	fake.recordInvocation("GetDelivery", []interface{}{arg1, arg2})
+	fake.recordInvocation("GetDelivery", []interface{}{arg1, arg2, arg3})
 	fake.getDeliveryMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -608,41 +614,41 @@ func (fake *FakeRepository) GetDeliveryCallCount() int {
 	return len(fake.getDeliveryArgsForCall)
 }
 
-func (fake *FakeRepository) GetDeliveryCalls(stub func(context.Context, string) (*v1alpha1.ClusterDelivery, error)) {
+func (fake *FakeRepository) GetDeliveryCalls(stub func(context.Context, string, string) (v1alpha1.DeliveryObject, error)) {
 	fake.getDeliveryMutex.Lock()
 	defer fake.getDeliveryMutex.Unlock()
 	fake.GetDeliveryStub = stub
 }
 
-func (fake *FakeRepository) GetDeliveryArgsForCall(i int) (context.Context, string) {
+func (fake *FakeRepository) GetDeliveryArgsForCall(i int) (context.Context, string, string) {
 	fake.getDeliveryMutex.RLock()
 	defer fake.getDeliveryMutex.RUnlock()
 	argsForCall := fake.getDeliveryArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeRepository) GetDeliveryReturns(result1 *v1alpha1.ClusterDelivery, result2 error) {
+func (fake *FakeRepository) GetDeliveryReturns(result1 v1alpha1.DeliveryObject, result2 error) {
 	fake.getDeliveryMutex.Lock()
 	defer fake.getDeliveryMutex.Unlock()
 	fake.GetDeliveryStub = nil
 	fake.getDeliveryReturns = struct {
-		result1 *v1alpha1.ClusterDelivery
+		result1 v1alpha1.DeliveryObject
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetDeliveryReturnsOnCall(i int, result1 *v1alpha1.ClusterDelivery, result2 error) {
+func (fake *FakeRepository) GetDeliveryReturnsOnCall(i int, result1 v1alpha1.DeliveryObject, result2 error) {
 	fake.getDeliveryMutex.Lock()
 	defer fake.getDeliveryMutex.Unlock()
 	fake.GetDeliveryStub = nil
 	if fake.getDeliveryReturnsOnCall == nil {
 		fake.getDeliveryReturnsOnCall = make(map[int]struct {
-			result1 *v1alpha1.ClusterDelivery
+			result1 v1alpha1.DeliveryObject
 			result2 error
 		})
 	}
 	fake.getDeliveryReturnsOnCall[i] = struct {
-		result1 *v1alpha1.ClusterDelivery
+		result1 v1alpha1.DeliveryObject
 		result2 error
 	}{result1, result2}
 }
@@ -950,19 +956,20 @@ func (fake *FakeRepository) GetServiceAccountReturnsOnCall(i int, result1 *v1.Se
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetSupplyChain(arg1 context.Context, arg2 string) (*v1alpha1.ClusterSupplyChain, error) {
+func (fake *FakeRepository) GetSupplyChain(arg1 context.Context, arg2 string, arg3 string) (v1alpha1.SupplyChainObject, error) {
 	fake.getSupplyChainMutex.Lock()
 	ret, specificReturn := fake.getSupplyChainReturnsOnCall[len(fake.getSupplyChainArgsForCall)]
 	fake.getSupplyChainArgsForCall = append(fake.getSupplyChainArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 string
+	}{arg1, arg2, arg3})
 	stub := fake.GetSupplyChainStub
 	fakeReturns := fake.getSupplyChainReturns
-	fake.recordInvocation("GetSupplyChain", []interface{}{arg1, arg2})
+	fake.recordInvocation("GetSupplyChain", []interface{}{arg1, arg2, arg3})
 	fake.getSupplyChainMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -976,46 +983,46 @@ func (fake *FakeRepository) GetSupplyChainCallCount() int {
 	return len(fake.getSupplyChainArgsForCall)
 }
 
-func (fake *FakeRepository) GetSupplyChainCalls(stub func(context.Context, string) (*v1alpha1.ClusterSupplyChain, error)) {
+func (fake *FakeRepository) GetSupplyChainCalls(stub func(context.Context, string, string) (v1alpha1.SupplyChainObject, error)) {
 	fake.getSupplyChainMutex.Lock()
 	defer fake.getSupplyChainMutex.Unlock()
 	fake.GetSupplyChainStub = stub
 }
 
-func (fake *FakeRepository) GetSupplyChainArgsForCall(i int) (context.Context, string) {
+func (fake *FakeRepository) GetSupplyChainArgsForCall(i int) (context.Context, string, string) {
 	fake.getSupplyChainMutex.RLock()
 	defer fake.getSupplyChainMutex.RUnlock()
 	argsForCall := fake.getSupplyChainArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeRepository) GetSupplyChainReturns(result1 *v1alpha1.ClusterSupplyChain, result2 error) {
+func (fake *FakeRepository) GetSupplyChainReturns(result1 v1alpha1.SupplyChainObject, result2 error) {
 	fake.getSupplyChainMutex.Lock()
 	defer fake.getSupplyChainMutex.Unlock()
 	fake.GetSupplyChainStub = nil
 	fake.getSupplyChainReturns = struct {
-		result1 *v1alpha1.ClusterSupplyChain
+		result1 v1alpha1.SupplyChainObject
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetSupplyChainReturnsOnCall(i int, result1 *v1alpha1.ClusterSupplyChain, result2 error) {
+func (fake *FakeRepository) GetSupplyChainReturnsOnCall(i int, result1 v1alpha1.SupplyChainObject, result2 error) {
 	fake.getSupplyChainMutex.Lock()
 	defer fake.getSupplyChainMutex.Unlock()
 	fake.GetSupplyChainStub = nil
 	if fake.getSupplyChainReturnsOnCall == nil {
 		fake.getSupplyChainReturnsOnCall = make(map[int]struct {
-			result1 *v1alpha1.ClusterSupplyChain
+			result1 v1alpha1.SupplyChainObject
 			result2 error
 		})
 	}
 	fake.getSupplyChainReturnsOnCall[i] = struct {
-		result1 *v1alpha1.ClusterSupplyChain
+		result1 v1alpha1.SupplyChainObject
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetSupplyChainsForWorkload(arg1 context.Context, arg2 *v1alpha1.Workload) ([]*v1alpha1.ClusterSupplyChain, error) {
+func (fake *FakeRepository) GetSupplyChainsForWorkload(arg1 context.Context, arg2 *v1alpha1.Workload) ([]v1alpha1.SupplyChainObject, error) {
 	fake.getSupplyChainsForWorkloadMutex.Lock()
 	ret, specificReturn := fake.getSupplyChainsForWorkloadReturnsOnCall[len(fake.getSupplyChainsForWorkloadArgsForCall)]
 	fake.getSupplyChainsForWorkloadArgsForCall = append(fake.getSupplyChainsForWorkloadArgsForCall, struct {
@@ -1041,7 +1048,7 @@ func (fake *FakeRepository) GetSupplyChainsForWorkloadCallCount() int {
 	return len(fake.getSupplyChainsForWorkloadArgsForCall)
 }
 
-func (fake *FakeRepository) GetSupplyChainsForWorkloadCalls(stub func(context.Context, *v1alpha1.Workload) ([]*v1alpha1.ClusterSupplyChain, error)) {
+func (fake *FakeRepository) GetSupplyChainsForWorkloadCalls(stub func(context.Context, *v1alpha1.Workload) ([]v1alpha1.SupplyChainObject, error)) {
 	fake.getSupplyChainsForWorkloadMutex.Lock()
 	defer fake.getSupplyChainsForWorkloadMutex.Unlock()
 	fake.GetSupplyChainsForWorkloadStub = stub
@@ -1054,46 +1061,47 @@ func (fake *FakeRepository) GetSupplyChainsForWorkloadArgsForCall(i int) (contex
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeRepository) GetSupplyChainsForWorkloadReturns(result1 []*v1alpha1.ClusterSupplyChain, result2 error) {
+func (fake *FakeRepository) GetSupplyChainsForWorkloadReturns(result1 []v1alpha1.SupplyChainObject, result2 error) {
 	fake.getSupplyChainsForWorkloadMutex.Lock()
 	defer fake.getSupplyChainsForWorkloadMutex.Unlock()
 	fake.GetSupplyChainsForWorkloadStub = nil
 	fake.getSupplyChainsForWorkloadReturns = struct {
-		result1 []*v1alpha1.ClusterSupplyChain
+		result1 []v1alpha1.SupplyChainObject
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetSupplyChainsForWorkloadReturnsOnCall(i int, result1 []*v1alpha1.ClusterSupplyChain, result2 error) {
+func (fake *FakeRepository) GetSupplyChainsForWorkloadReturnsOnCall(i int, result1 []v1alpha1.SupplyChainObject, result2 error) {
 	fake.getSupplyChainsForWorkloadMutex.Lock()
 	defer fake.getSupplyChainsForWorkloadMutex.Unlock()
 	fake.GetSupplyChainsForWorkloadStub = nil
 	if fake.getSupplyChainsForWorkloadReturnsOnCall == nil {
 		fake.getSupplyChainsForWorkloadReturnsOnCall = make(map[int]struct {
-			result1 []*v1alpha1.ClusterSupplyChain
+			result1 []v1alpha1.SupplyChainObject
 			result2 error
 		})
 	}
 	fake.getSupplyChainsForWorkloadReturnsOnCall[i] = struct {
-		result1 []*v1alpha1.ClusterSupplyChain
+		result1 []v1alpha1.SupplyChainObject
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakeRepository) GetTemplate(arg1 context.Context, arg2 string, arg3 string) (client.Object, error) {
+func (fake *FakeRepository) GetTemplate(arg1 context.Context, arg2 string, arg3 string, arg4 string) (client.Object, error) {
 	fake.getTemplateMutex.Lock()
 	ret, specificReturn := fake.getTemplateReturnsOnCall[len(fake.getTemplateArgsForCall)]
 	fake.getTemplateArgsForCall = append(fake.getTemplateArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
 		arg3 string
-	}{arg1, arg2, arg3})
+		arg4 string
+	}{arg1, arg2, arg3, arg4})
 	stub := fake.GetTemplateStub
 	fakeReturns := fake.getTemplateReturns
-	fake.recordInvocation("GetTemplate", []interface{}{arg1, arg2, arg3})
+	fake.recordInvocation("GetTemplate", []interface{}{arg1, arg2, arg3, arg4})
 	fake.getTemplateMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3)
+		return stub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -1107,17 +1115,17 @@ func (fake *FakeRepository) GetTemplateCallCount() int {
 	return len(fake.getTemplateArgsForCall)
 }
 
-func (fake *FakeRepository) GetTemplateCalls(stub func(context.Context, string, string) (client.Object, error)) {
+func (fake *FakeRepository) GetTemplateCalls(stub func(context.Context, string, string, string) (client.Object, error)) {
 	fake.getTemplateMutex.Lock()
 	defer fake.getTemplateMutex.Unlock()
 	fake.GetTemplateStub = stub
 }
 
-func (fake *FakeRepository) GetTemplateArgsForCall(i int) (context.Context, string, string) {
+func (fake *FakeRepository) GetTemplateArgsForCall(i int) (context.Context, string, string, string) {
 	fake.getTemplateMutex.RLock()
 	defer fake.getTemplateMutex.RUnlock()
 	argsForCall := fake.getTemplateArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
 }
 
 func (fake *FakeRepository) GetTemplateReturns(result1 client.Object, result2 error) {
@@ -18,6 +18,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	clientset "k8s.io/client-go/kubernetes"
+
+	"github.com/vmware-tanzu/cartographer/pkg/metrics"
 )
 
 const (
@@ -91,6 +93,7 @@ func (m *Manager) GetServiceAccountToken(serviceAccount *corev1.ServiceAccount)
 			ExpirationSeconds: &expiration,
 		}}
 	tr, err := m.getToken(serviceAccount.Name, serviceAccount.Namespace, tr)
+	metrics.ServiceAccountTokenRefreshes.WithLabelValues(metrics.Result(err)).Inc()
 
 	if err != nil {
 		switch {
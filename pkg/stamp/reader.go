@@ -16,6 +16,7 @@ package stamp
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -43,6 +44,8 @@ type Outputter interface {
 }
 
 func NewPassThroughReader(kind string, name string, inputReader PassThroughInput) (Outputter, error) {
+	kind = v1alpha1.ClusterScopedTemplateKind(kind)
+
 	switch {
 
 	case kind == "ClusterSourceTemplate":
@@ -60,8 +63,22 @@ func NewPassThroughReader(kind string, name string, inputReader PassThroughInput
 	return nil, fmt.Errorf("kind does not match a known template")
 }
 
+// NewReader returns the reader of the output of the template kind. When the template declares
+// named outputs, they are read alongside it.
 func NewReader(template client.Object, inputReader DeploymentInput) (Outputter, error) {
-	switch v := template.(type) {
+	reader, err := newKindReader(template, inputReader)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec := v1alpha1.GetTemplateSpec(template); spec != nil && len(spec.Outputs) > 0 {
+		return NewNamedOutputReader(reader, spec.Outputs), nil
+	}
+	return reader, nil
+}
+
+func newKindReader(template client.Object, inputReader DeploymentInput) (Outputter, error) {
+	switch v := v1alpha1.ClusterScopedTemplate(template).(type) {
 
 	case *v1alpha1.ClusterSourceTemplate:
 		return NewSourceOutputReader(v), nil
@@ -320,6 +337,58 @@ func NewDeploymentPassThroughReader(inputReader DeploymentInput, template *v1alp
 	}
 }
 
+// NamedOutputReader reads the named outputs declared by a template, each a jsonpath into the
+// stamped object, in addition to the output read by the reader of the template kind
+type NamedOutputReader struct {
+	reader Outputter
+	paths  map[string]string
+}
+
+func (r *NamedOutputReader) Output(stampedObject *unstructured.Unstructured) (*templates.Output, error) {
+	output, err := r.reader.Output(stampedObject)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name := range r.paths {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	evaluator := eval.EvaluatorBuilder()
+	named := make(map[string]interface{})
+	for _, name := range names {
+		path := r.paths[name]
+		if stampedObject == nil {
+			return nil, JsonPathError{
+				Err:        fmt.Errorf("failed to evaluate path of empty object"),
+				expression: path,
+			}
+		}
+
+		value, err := evaluator.EvaluateJsonPath(path, stampedObject.UnstructuredContent())
+		if err != nil {
+			return nil, JsonPathError{
+				Err: fmt.Errorf("failed to evaluate the path of output [%s] [%s]: %w",
+					name, path, err),
+				expression: path,
+			}
+		}
+		named[name] = value
+	}
+
+	output.Named = named
+	return output, nil
+}
+
+func NewNamedOutputReader(reader Outputter, paths map[string]string) Outputter {
+	return &NamedOutputReader{
+		reader: reader,
+		paths:  paths,
+	}
+}
+
 type NoOutputReader struct{}
 
 func (r *NoOutputReader) Output(_ *unstructured.Unstructured) (*templates.Output, error) {
@@ -329,6 +329,85 @@ var _ = Describe("Outputter", func() {
 		})
 	})
 
+	Context("using a template which declares named outputs", func() {
+		var (
+			template      *v1alpha1.ClusterConfigTemplate
+			reader        stamp.Outputter
+			stampedObject *unstructured.Unstructured
+		)
+
+		BeforeEach(func() {
+			template = &v1alpha1.ClusterConfigTemplate{
+				Spec: v1alpha1.ConfigTemplateSpec{
+					TemplateSpec: v1alpha1.TemplateSpec{
+						Outputs: map[string]string{
+							"digest":   ".status.digest",
+							"replicas": ".spec.replicas",
+						},
+					},
+					ConfigPath: ".data.config",
+				},
+			}
+
+			var err error
+			reader, err = stamp.NewReader(template, noInputFake{})
+			Expect(err).NotTo(HaveOccurred())
+
+			stampedObject = &unstructured.Unstructured{}
+			stampedObject.SetUnstructuredContent(map[string]interface{}{
+				"data": map[string]interface{}{
+					"config": "my-config",
+				},
+				"spec": map[string]interface{}{
+					"replicas": int64(3),
+				},
+				"status": map[string]interface{}{
+					"digest": "sha256:abc",
+				},
+			})
+		})
+
+		It("returns the output of the template kind and the named outputs", func() {
+			output, err := reader.Output(stampedObject)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output.Config).To(Equal("my-config"))
+			Expect(output.Named).To(Equal(map[string]interface{}{
+				"digest":   "sha256:abc",
+				"replicas": float64(3),
+			}))
+		})
+
+		Context("where a named output can not be evaluated", func() {
+			BeforeEach(func() {
+				unstructured.RemoveNestedField(stampedObject.Object, "status")
+			})
+
+			It("returns an error naming the output", func() {
+				output, err := reader.Output(stampedObject)
+				Expect(output).To(BeNil())
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("output [digest]"))
+				Expect(err.Error()).To(ContainSubstring(".status.digest"))
+			})
+		})
+
+		Context("where the template is a ClusterTemplate", func() {
+			It("returns only the named outputs", func() {
+				reader, err := stamp.NewReader(&v1alpha1.ClusterTemplate{
+					Spec: v1alpha1.TemplateSpec{
+						Outputs: map[string]string{"digest": ".status.digest"},
+					},
+				}, noInputFake{})
+				Expect(err).NotTo(HaveOccurred())
+
+				output, err := reader.Output(stampedObject)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(output.Config).To(BeNil())
+				Expect(output.Named).To(Equal(map[string]interface{}{"digest": "sha256:abc"}))
+			})
+		})
+	})
+
 	Context("using a deployment outputter", func() {
 		var (
 			template      *v1alpha1.ClusterDeploymentTemplate
@@ -27,4 +27,6 @@ type Output struct {
 	Source *Source
 	Image  Image
 	Config Config
+	// Named are the values of the outputs declared by the template, by name
+	Named map[string]interface{}
 }
@@ -62,7 +62,7 @@ func convertLifecycle(lifecycleString string) Lifecycle {
 }
 
 func NewReaderFromAPI(template client.Object) (Reader, error) {
-	switch v := template.(type) {
+	switch v := v1alpha1.ClusterScopedTemplate(template).(type) {
 
 	case *v1alpha1.ClusterSourceTemplate:
 		return NewClusterSourceTemplateReader(v), nil
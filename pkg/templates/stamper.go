@@ -15,14 +15,9 @@
 package templates
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"os/exec"
-	"path"
-	"runtime"
 	"strings"
 	"time"
 
@@ -37,6 +32,8 @@ import (
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/eval"
 	"github.com/vmware-tanzu/cartographer/pkg/logger"
+	"github.com/vmware-tanzu/cartographer/pkg/metrics"
+	"github.com/vmware-tanzu/cartographer/pkg/tracing"
 )
 
 type Labels map[string]string
@@ -139,14 +136,38 @@ func (s *Stamper) recursivelyEvaluateTemplates(jsonValue interface{}, pathStack
 	}
 }
 
+// DefaultYttTimeout is used for ytt templates that do not set a yttTimeout.
+// 4 seconds so that resource constrained pods don't kick it out while still scheduling the
+// process.
+const DefaultYttTimeout = 4 * time.Second
+
 func (s *Stamper) Stamp(ctx context.Context, resourceTemplate v1alpha1.TemplateSpec) (*unstructured.Unstructured, error) {
+	templateType := "template"
+	if resourceTemplate.Template == nil && resourceTemplate.Ytt != "" {
+		templateType = "ytt"
+	}
+	ctx, span := tracing.Start(ctx, "Stamper.Stamp", tracing.TemplateTypeKey.String(templateType))
+
+	stampedObject, err := s.stamp(ctx, resourceTemplate)
+	if stampedObject != nil {
+		span.SetAttributes(tracing.ObjectAttributes(stampedObject.GetKind(), stampedObject.GetNamespace(), stampedObject.GetName())...)
+	}
+	tracing.End(span, err)
+	return stampedObject, err
+}
+
+func (s *Stamper) stamp(ctx context.Context, resourceTemplate v1alpha1.TemplateSpec) (*unstructured.Unstructured, error) {
 	var stampedObject *unstructured.Unstructured
 	var err error
 	switch {
 	case resourceTemplate.Template != nil:
 		stampedObject, err = s.applyTemplate(resourceTemplate.Template.Raw)
 	case resourceTemplate.Ytt != "":
-		stampedObject, err = s.applyYtt(ctx, resourceTemplate.Ytt)
+		timeout := DefaultYttTimeout
+		if resourceTemplate.YttTimeout != nil {
+			timeout = resourceTemplate.YttTimeout.Duration
+		}
+		stampedObject, err = s.applyYtt(ctx, resourceTemplate.Ytt, timeout)
 	default:
 		err = fmt.Errorf("unknown resource template type, expected either template or ytt")
 	}
@@ -199,57 +220,38 @@ func (s *Stamper) applyTemplate(resourceTemplateJSON []byte) (*unstructured.Unst
 	return stampedObject, nil
 }
 
-func (s *Stamper) applyYtt(ctx context.Context, template string) (*unstructured.Unstructured, error) {
+func (s *Stamper) applyYtt(ctx context.Context, template string, timeout time.Duration) (*unstructured.Unstructured, error) {
 	log := logr.FromContextOrDiscard(ctx)
 
 	// limit execution duration to protect against infinite loops or cpu wasting templates
-	// 4 seconds so that resource constrained pods don't kick it out while still scheduling the
-	// process.
-	ctx, cancel := context.WithTimeout(ctx, 4*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	ytt := "ytt"
-	// ko copies the content of the kodata directory into the container at a path referenced by $KO_DATA_PATH
-	if kodata, ok := os.LookupEnv("KO_DATA_PATH"); ok {
-		ytt = path.Join(kodata, fmt.Sprintf("ytt-%s-%s", runtime.GOOS, runtime.GOARCH))
-	}
-
-	args := []string{"-f", "-"}
-	stdin := bytes.NewReader([]byte(template))
-	stdout := bytes.NewBuffer([]byte{})
-	stderr := bytes.NewBuffer([]byte{})
-
-	// inject each key of the template context as a ytt value
-	templateContext := map[string]interface{}{}
+	// each key of the template context is a ytt data value
 	b, err := json.Marshal(s.TemplatingContext)
 	if err != nil {
 		// NOTE we can ignore subsequent json errors, if there's a issue with the data it will be caught here
 		return nil, fmt.Errorf("unable to marshal template context: %w", err)
 	}
-	_ = json.Unmarshal(b, &templateContext)
-	for k := range templateContext {
-		raw, _ := json.Marshal(templateContext[k])
-		args = append(args, "--data-value-yaml", fmt.Sprintf("%s=%s", k, raw))
-	}
-
-	cmd := exec.CommandContext(ctx, ytt, args...)
-	cmd.Stdin = stdin
-	cmd.Stdout = stdout
-	cmd.Stderr = stderr
 
-	log.V(logger.DEBUG).Info("ytt call", "args", args, "input", template)
-	if err := cmd.Run(); err != nil {
-		msg := stderr.String()
-		if msg == "" {
-			return nil, fmt.Errorf("unable to apply ytt template: %w", err)
-		}
-		return nil, fmt.Errorf("unable to apply ytt template: %s", msg)
+	var output []byte
+	started := time.Now()
+	log.V(logger.DEBUG).Info("ytt evaluation", "input", template)
+	if YttBinary != "" {
+		templateContext := map[string]json.RawMessage{}
+		_ = json.Unmarshal(b, &templateContext)
+		output, err = execYtt(ctx, YttBinary, dataValueArgs(templateContext), strings.NewReader(template))
+	} else {
+		output, err = forkYtt(ctx, template, b)
+	}
+	metrics.YttDuration.WithLabelValues(metrics.Result(err)).Observe(time.Since(started).Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply ytt template: %w", err)
 	}
-	output := stdout.String()
-	log.V(logger.DEBUG).Info("ytt result", "output", output)
+	log.V(logger.DEBUG).Info("ytt result", "output", string(output))
 
 	stampedObject := &unstructured.Unstructured{}
-	if err := yaml.Unmarshal([]byte(output), stampedObject); err != nil {
+	if err := yaml.Unmarshal(output, stampedObject); err != nil {
 		// ytt should never return invalid yaml
 		return nil, err
 	}
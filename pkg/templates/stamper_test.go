@@ -16,7 +16,8 @@ package templates_test
 
 import (
 	"context"
-	"os"
+	goruntime "runtime"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -215,7 +216,7 @@ var _ = Describe("Stamper", func() {
 		)
 
 		DescribeTable("tag evaluation of ytt template",
-			func(tmpl string, subJSON string, expected interface{}, koDataPath string, expectedErr string) {
+			func(tmpl string, subJSON string, expected interface{}, yttBinary string, expectedErr string) {
 				template := v1alpha1.TemplateSpec{
 					Ytt: `
 #@ load("@ytt:data", "data")
@@ -238,17 +239,12 @@ key: ` + tmpl + `
 					Params: params,
 				}
 
-				if koDataPath != "" {
-					// set KO_DATA_PATH for this test, and then restore the previous value
-					previous, set := os.LookupEnv("KO_DATA_PATH")
+				if yttBinary != "" {
+					// use the ytt binary for this test, and then restore the embedded ytt
+					templates.YttBinary = yttBinary
 					defer func() {
-						if set {
-							os.Setenv("KO_DATA_PATH", previous)
-						} else {
-							os.Unsetenv("KO_DATA_PATH")
-						}
+						templates.YttBinary = ""
 					}()
-					os.Setenv("KO_DATA_PATH", koDataPath)
 				}
 
 				stamper := templates.StamperBuilder(owner, templatingContext, templates.Labels{})
@@ -268,13 +264,77 @@ key: ` + tmpl + `
 				`#@ data.values.params.sub`, `5`, int64(5), "", ""),
 			Entry(`Map value and type preserved`,
 				`#@ data.values.params.sub`, `{"foo": "bar"}`, map[string]interface{}{"foo": "bar"}, "", ""),
+			Entry(`List value and type preserved`,
+				`#@ data.values.params.sub[1]`, `["foo", {"bar": true}]`, map[string]interface{}{"bar": true}, "", ""),
 
 			Entry(`Invalid template`,
 				"#@ data.values.invalid", `""`, nil, "", "unable to apply ytt template:"),
+			Entry(`Invalid template points to the line`,
+				"#@ data.values.invalid", `""`, nil, "", "template.yaml:7 | key: #@ data.values.invalid"),
 			Entry(`Invalid context`,
 				"#@ data.values.params['sub']", `"`, nil, "", "unable to marshal template context:"),
 			Entry(`Invalid ytt`,
 				"#@ data.values.params['sub']", `""`, nil, "/not/a/path/to/ytt", "unable to apply ytt template: fork/exec"),
 		)
+
+		Context("when the ytt template does not complete within its timeout", func() {
+			var template v1alpha1.TemplateSpec
+
+			BeforeEach(func() {
+				template = v1alpha1.TemplateSpec{
+					Ytt: `
+apiVersion: v1
+kind: TestResource
+key: #@ len([x for x in range(100000000)])
+`,
+					YttTimeout: &metav1.Duration{Duration: 10 * time.Millisecond},
+				}
+			})
+
+			It("returns an error", func() {
+				stamper := templates.StamperBuilder(&v1.ConfigMap{}, map[string]interface{}{}, templates.Labels{})
+
+				started := time.Now()
+				_, err := stamper.Stamp(context.TODO(), template)
+				Expect(err).To(MatchError(ContainSubstring("unable to apply ytt template: evaluation did not complete: context deadline exceeded")))
+				Expect(time.Since(started)).To(BeNumerically("<", time.Second))
+			})
+
+			It("stops evaluating a template that does not terminate", func() {
+				template.Ytt = `
+#@ def spin():
+#@   for x in range(2147483647):
+#@     for y in range(2147483647):
+#@       y = y + 1
+#@     end
+#@   end
+#@ end
+apiVersion: v1
+kind: TestResource
+key: #@ spin()
+`
+				template.YttTimeout = &metav1.Duration{Duration: 500 * time.Millisecond}
+				stamper := templates.StamperBuilder(&v1.ConfigMap{}, map[string]interface{}{}, templates.Labels{})
+
+				goroutines := goruntime.NumGoroutine()
+				_, err := stamper.Stamp(context.TODO(), template)
+				Expect(err).To(MatchError(ContainSubstring("evaluation did not complete: context deadline exceeded")))
+				Eventually(goruntime.NumGoroutine).Should(BeNumerically("<=", goroutines))
+			})
+
+			It("completes with a longer timeout", func() {
+				template.Ytt = `
+apiVersion: v1
+kind: TestResource
+key: #@ len([x for x in range(1000)])
+`
+				template.YttTimeout = &metav1.Duration{Duration: 5 * time.Second}
+				stamper := templates.StamperBuilder(&v1.ConfigMap{}, map[string]interface{}{}, templates.Labels{})
+
+				stampedUnstructured, err := stamper.Stamp(context.TODO(), template)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stampedUnstructured.Object["key"]).To(Equal(int64(1000)))
+			})
+		})
 	})
 })
@@ -0,0 +1,173 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package templates
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	yttcmd "carvel.dev/ytt/pkg/cmd/template"
+	"carvel.dev/ytt/pkg/files"
+	"github.com/go-logr/logr"
+
+	"github.com/vmware-tanzu/cartographer/pkg/logger"
+)
+
+// YttBinary is the path of a ytt executable that evaluates ytt templates in place of the
+// embedded ytt. When empty, ytt templates are evaluated by the embedded ytt.
+var YttBinary string
+
+const (
+	yttTemplateFilename   = "template.yaml"
+	yttDataValuesFilename = "values.yaml"
+
+	// yttSubcommand is the only argument of a child process started by forkYtt.
+	yttSubcommand = "__cartographer-ytt"
+)
+
+// ytt cannot interrupt a template once it is evaluating, so the embedded ytt runs in a child
+// process of the running executable that is killed when the template runs past its timeout. Every
+// executable linking this package serves the child process here, before its main is run.
+func init() {
+	if len(os.Args) != 2 || os.Args[1] != yttSubcommand {
+		return
+	}
+
+	var request yttRequest
+	if err := json.NewDecoder(os.Stdin).Decode(&request); err != nil {
+		fmt.Fprintf(os.Stderr, "unable to read ytt request: %s", err)
+		os.Exit(1)
+	}
+
+	output, err := evalYtt(request.Template, request.DataValues, os.Stderr)
+	if err != nil {
+		fmt.Fprint(os.Stderr, err)
+		os.Exit(1)
+	}
+	_, _ = os.Stdout.Write(output)
+	os.Exit(0)
+}
+
+// yttRequest is what forkYtt passes to the child process on stdin.
+type yttRequest struct {
+	Template   string          `json:"template"`
+	DataValues json.RawMessage `json:"dataValues"`
+}
+
+// forkYtt evaluates the template with the embedded ytt in a child process, with dataValues as a
+// data values file.
+func forkYtt(ctx context.Context, template string, dataValues []byte) ([]byte, error) {
+	self, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("unable to find executable: %w", err)
+	}
+
+	request, err := json.Marshal(yttRequest{Template: template, DataValues: dataValues})
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal ytt request: %w", err)
+	}
+
+	return execYtt(ctx, self, []string{yttSubcommand}, bytes.NewReader(request))
+}
+
+// evalYtt evaluates the template in-process, with dataValues as a data values file. Messages of
+// ytt are written to messages.
+func evalYtt(template string, dataValues []byte, messages io.Writer) ([]byte, error) {
+	templateFile, err := files.NewFileFromSource(files.NewBytesSource(yttTemplateFilename, []byte(template)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read template: %w", err)
+	}
+
+	opts := yttcmd.NewOptions()
+	opts.DataValuesFlags.FromFiles = []string{yttDataValuesFilename}
+	opts.DataValuesFlags.ReadFilesFunc = func(path string) ([]*files.File, error) {
+		valuesFile, err := files.NewFileFromSource(files.NewBytesSource(path, dataValues))
+		if err != nil {
+			return nil, err
+		}
+		return []*files.File{valuesFile}, nil
+	}
+
+	output := opts.RunWithFiles(yttcmd.Input{Files: []*files.File{templateFile}}, yttUI{out: messages})
+	if output.Err != nil {
+		return nil, output.Err
+	}
+	return output.DocSet.AsBytes()
+}
+
+// dataValueArgs passes each data value to the ytt executable as a --data-value-yaml flag.
+func dataValueArgs(dataValues map[string]json.RawMessage) []string {
+	args := []string{"-f", "-"}
+	for k, raw := range dataValues {
+		args = append(args, "--data-value-yaml", fmt.Sprintf("%s=%s", k, raw))
+	}
+	return args
+}
+
+// execYtt runs the ytt executable at binary with args and stdin. The executable is killed when
+// ctx is done.
+func execYtt(ctx context.Context, binary string, args []string, stdin io.Reader) ([]byte, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	stdout := bytes.NewBuffer([]byte{})
+	stderr := bytes.NewBuffer([]byte{})
+
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	log.V(logger.DEBUG).Info("ytt call", "binary", binary, "args", args)
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("evaluation did not complete: %w", ctx.Err())
+		}
+		if stderr.Len() == 0 {
+			return nil, err
+		}
+		return nil, errors.New(stderr.String())
+	}
+	if stderr.Len() > 0 {
+		log.Info("ytt: " + strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// yttUI writes the messages of the embedded ytt to out.
+type yttUI struct {
+	out io.Writer
+}
+
+func (u yttUI) Printf(format string, args ...interface{}) {
+	fmt.Fprintf(u.out, format, args...)
+}
+
+func (u yttUI) Warnf(format string, args ...interface{}) {
+	fmt.Fprintf(u.out, "warning: "+format, args...)
+}
+
+func (u yttUI) Debugf(string, ...interface{}) {}
+
+func (u yttUI) DebugWriter() io.Writer {
+	return io.Discard
+}
@@ -0,0 +1,206 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/utils"
+)
+
+// BlueprintObjects are supply chains, deliveries and their owners, read from files or from a cluster
+type BlueprintObjects struct {
+	SupplyChains []v1alpha1.SupplyChainObject
+	Deliveries   []v1alpha1.DeliveryObject
+	Workloads    []*v1alpha1.Workload
+	Deliverables []*v1alpha1.Deliverable
+}
+
+// ReadBlueprintFiles reads the objects of every YAML document of the paths. A path may be a
+// directory, which is not read recursively. Documents of other kinds are ignored.
+func ReadBlueprintFiles(paths []string) (*BlueprintObjects, error) {
+	objects := &BlueprintObjects{}
+
+	for _, path := range paths {
+		fileInfo, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not get fileinfo for path: %w", err)
+		}
+
+		filePaths := []string{path}
+		if fileInfo.IsDir() {
+			files, err := os.ReadDir(path)
+			if err != nil {
+				return nil, fmt.Errorf("os read directory: %w", err)
+			}
+
+			filePaths = nil
+			for _, file := range files {
+				if !file.IsDir() {
+					filePaths = append(filePaths, filepath.Join(path, file.Name()))
+				}
+			}
+		}
+
+		for _, filePath := range filePaths {
+			if err := objects.readFile(filePath); err != nil {
+				return nil, fmt.Errorf("read file: %s, %w", filePath, err)
+			}
+		}
+	}
+
+	return objects, nil
+}
+
+func (o *BlueprintObjects) readFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not open file: %w", err)
+	}
+	defer file.Close()
+
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(file))
+	for {
+		document, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read yaml document: %w", err)
+		}
+
+		if err = o.add(document); err != nil {
+			return err
+		}
+	}
+}
+
+func (o *BlueprintObjects) add(document []byte) error {
+	typeMeta := metav1.TypeMeta{}
+	if err := yaml.Unmarshal(document, &typeMeta); err != nil {
+		return fmt.Errorf("unmarshall type: %w", err)
+	}
+	if typeMeta.GroupVersionKind().Group != v1alpha1.SchemeGroupVersion.Group {
+		return nil
+	}
+
+	var obj interface{}
+	switch typeMeta.Kind {
+	case "ClusterSupplyChain":
+		obj = &v1alpha1.ClusterSupplyChain{}
+	case "SupplyChain":
+		obj = &v1alpha1.SupplyChain{}
+	case "ClusterDelivery":
+		obj = &v1alpha1.ClusterDelivery{}
+	case "Delivery":
+		obj = &v1alpha1.Delivery{}
+	case "Workload":
+		obj = &v1alpha1.Workload{}
+	case "Deliverable":
+		obj = &v1alpha1.Deliverable{}
+	default:
+		return nil
+	}
+
+	if err := yaml.Unmarshal(document, obj); err != nil {
+		return fmt.Errorf("unmarshall %s: %w", typeMeta.Kind, err)
+	}
+
+	o.append(obj)
+	return nil
+}
+
+func (o *BlueprintObjects) append(obj interface{}) {
+	switch v := obj.(type) {
+	case v1alpha1.SupplyChainObject:
+		o.SupplyChains = append(o.SupplyChains, v)
+	case v1alpha1.DeliveryObject:
+		o.Deliveries = append(o.Deliveries, v)
+	case *v1alpha1.Workload:
+		o.Workloads = append(o.Workloads, v)
+	case *v1alpha1.Deliverable:
+		o.Deliverables = append(o.Deliverables, v)
+	}
+}
+
+// GetSubChain finds a ClusterSupplyChain referenced as a sub-chain among the objects
+func (o *BlueprintObjects) GetSubChain(_ context.Context, name string) (*v1alpha1.ClusterSupplyChain, error) {
+	for _, supplyChain := range o.SupplyChains {
+		if clusterSupplyChain, ok := supplyChain.(*v1alpha1.ClusterSupplyChain); ok && clusterSupplyChain.Name == name {
+			return clusterSupplyChain, nil
+		}
+	}
+	return nil, nil
+}
+
+// NewClusterClient returns a client of the cluster of the current kubeconfig context,
+// which is read from KUBECONFIG or ~/.kube/config
+func NewClusterClient() (client.Client, error) {
+	restConfig, err := config.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("get kubeconfig: %w", err)
+	}
+
+	scheme := runtime.NewScheme()
+	if err = utils.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("add to scheme: %w", err)
+	}
+
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("create client: %w", err)
+	}
+
+	return c, nil
+}
+
+// ClusterSubChainGetter gets sub-chains from the cluster
+func ClusterSubChainGetter(c client.Client) v1alpha1.ClusterSupplyChainGetter {
+	return func(ctx context.Context, name string) (*v1alpha1.ClusterSupplyChain, error) {
+		supplyChain := &v1alpha1.ClusterSupplyChain{}
+		found, err := getObject(ctx, c, client.ObjectKey{Name: name}, supplyChain)
+		if err != nil || !found {
+			return nil, err
+		}
+		return supplyChain, nil
+	}
+}
+
+// getObject gets the object, returning false when it does not exist
+func getObject(ctx context.Context, c client.Client, key client.ObjectKey, obj client.Object) (bool, error) {
+	err := c.Get(ctx, key, obj)
+	if kerrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("get %T [%s]: %w", obj, key, err)
+	}
+	return true, nil
+}
@@ -22,6 +22,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/stamp"
 	"github.com/vmware-tanzu/cartographer/pkg/templates"
 )
 
@@ -31,36 +33,60 @@ import (
 // When run as part of a Suite, an individual case(s) may be focused.
 // This will exercise the individual test(s).
 // Note that the overall suite will fail (preventing focused tests from passing CI).
+// Test asserts that the template of Given stamps the Expect object. When a Simulation is given, the whole
+// supply chain of Given is simulated instead, and the Template and Expect are not used.
+// ExpectOutputs and ExpectHealth assert what is read from the stamped object by the template, and may be
+// given with or instead of Expect.
 type Test struct {
 	Given          Given
 	Expect         Expectation
+	ExpectOutputs  ExpectedOutputs
+	ExpectHealth   *ExpectedHealth
 	CompareOptions *CompareOptions
 	Focus          bool
+	Simulation     *Simulation
 }
 
-// Given must specify a Template and a Workload.
-// SupplyChain is optional
+// Given must specify a Template and either a Workload or a Deliverable.
+// SupplyChain is optional, and only used with a Workload
+// Delivery is optional, and only used with a Deliverable
+// StampedObjectStatus is the status the stamped object is given before its outputs and health are read
 type Given struct {
-	Template    Template
-	Workload    Workload
-	SupplyChain SupplyChain
+	Template            Template
+	Workload            Workload
+	SupplyChain         SupplyChain
+	Deliverable         Deliverable
+	Delivery            Delivery
+	StampedObjectStatus map[string]interface{}
 }
 
 func (c *Test) Run() error {
-	expectedObject, err := c.Expect.getExpected()
-	if err != nil {
-		return fmt.Errorf("failed to get expected object: %w", err)
+	if c.Simulation != nil {
+		return c.runSimulation()
+	}
+
+	if c.Expect == nil && c.ExpectOutputs == nil && c.ExpectHealth == nil {
+		return fmt.Errorf("no expected object, outputs or health specified")
+	}
+
+	var (
+		expectedObject *unstructured.Unstructured
+		err            error
+	)
+	if c.Expect != nil {
+		expectedObject, err = c.Expect.getExpected()
+		if err != nil {
+			return fmt.Errorf("failed to get expected object: %w", err)
+		}
 	}
 
-	actualObject, err := c.Given.getActualObject()
+	actualObject, deploymentInput, err := c.Given.getActualObject()
 	if errors.Is(err, yttNotFound) {
 		return fmt.Errorf("test requires ytt, but ytt was not found in path")
 	} else if err != nil {
 		return fmt.Errorf("failed to get actual object: %w", err)
 	}
 
-	c.stripIgnoredFields(expectedObject, actualObject)
-
 	var opts cmp.Options
 	if c.CompareOptions != nil && c.CompareOptions.CMPOption != nil {
 		opts, err = c.CompareOptions.CMPOption()
@@ -69,45 +95,78 @@ func (c *Test) Run() error {
 		}
 	}
 
-	if diff := cmp.Diff(expectedObject.Object, actualObject.Object, opts); diff != "" {
-		return fmt.Errorf("expected does not equal actual: (-expected +actual):\n%s", diff)
+	if expectedObject != nil {
+		strippedActualObject := actualObject.DeepCopy()
+		c.stripIgnoredFields(expectedObject, strippedActualObject)
+
+		if diff := cmp.Diff(expectedObject.Object, strippedActualObject.Object, opts); diff != "" {
+			return fmt.Errorf("expected does not equal actual: (-expected +actual):\n%s", diff)
+		}
+	}
+
+	if c.ExpectOutputs != nil || c.ExpectHealth != nil {
+		return c.assertOutputsAndHealth(actualObject, deploymentInput, opts)
 	}
 
 	return nil
 }
 
-func (i *Given) getActualObject() (*unstructured.Unstructured, error) {
+// getActualObject stamps the template for the workload or the deliverable. It also returns the input from which
+// the outputs of a ClusterDeploymentTemplate are read, which only a delivery provides.
+func (i *Given) getActualObject() (*unstructured.Unstructured, stamp.DeploymentInput, error) {
 	ctx := context.Background()
 
-	workload, err := i.Workload.GetWorkload()
-	if err != nil {
-		return nil, fmt.Errorf("get workload failed: %w", err)
+	if i.Workload != nil && i.Deliverable != nil {
+		return nil, nil, fmt.Errorf("only one of workload and deliverable may be specified")
+	}
+
+	var (
+		workload    *v1alpha1.Workload
+		deliverable *v1alpha1.Deliverable
+		err         error
+	)
+
+	if i.Deliverable != nil {
+		deliverable, err = i.Deliverable.GetDeliverable()
+		if err != nil {
+			return nil, nil, fmt.Errorf("get deliverable failed: %w", err)
+		}
+	} else {
+		if i.Workload == nil {
+			return nil, nil, fmt.Errorf("a workload or a deliverable must be specified")
+		}
+		workload, err = i.Workload.GetWorkload()
+		if err != nil {
+			return nil, nil, fmt.Errorf("get workload failed: %w", err)
+		}
 	}
 
 	apiTemplate, err := i.Template.GetTemplate()
 	if err != nil {
-		return nil, fmt.Errorf("get populated template failed: %w", err)
+		return nil, nil, fmt.Errorf("get populated template failed: %w", err)
 	}
 
 	if _, err = (*apiTemplate).ValidateCreate(); err != nil {
-		return nil, fmt.Errorf("template validation failed: %w", err)
+		return nil, nil, fmt.Errorf("template validation failed: %w", err)
 	}
 
 	template, err := templates.NewReaderFromAPI(*apiTemplate)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get cluster template")
+		return nil, nil, fmt.Errorf("failed to get cluster template")
 	}
 
-	if template.IsYTTTemplate() {
-		err = ensureYTTAvailable(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("ensure YTT available: %w", err)
+	if deliverable != nil {
+		if i.Delivery == nil {
+			i.Delivery = &MockDelivery{}
 		}
+
+		return i.Delivery.stamp(ctx, deliverable, *apiTemplate, template)
 	}
 
 	if i.SupplyChain == nil {
 		i.SupplyChain = &MockSupplyChain{}
 	}
 
-	return i.SupplyChain.stamp(ctx, workload, *apiTemplate, template)
+	actualObject, err := i.SupplyChain.stamp(ctx, workload, *apiTemplate, template)
+	return actualObject, noDeployment{}, err
 }
@@ -15,6 +15,7 @@
 package testing
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -22,6 +23,8 @@ import (
 	"path/filepath"
 	"strings"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
 	"github.com/vmware-tanzu/cartographer/pkg/realizer"
 )
@@ -29,9 +32,34 @@ import (
 type testInfo struct {
 	Metadata       testInfoMetadata       `yaml:"metadata"`
 	Given          testInfoGiven          `yaml:"given"`
-	Expected       *string                `yaml:"expected"`
+	Expected       *testInfoExpected      `yaml:"expected"`
 	Focus          *bool                  `yaml:"focus"`
 	CompareOptions testInfoCompareOptions `yaml:"compareOptions"`
+	Simulation     *testInfoSimulation    `yaml:"simulation"`
+}
+
+// testInfoExpected is either the path of the expected object, or the object, outputs and health expected
+type testInfoExpected struct {
+	Object  *string                `yaml:"object"`
+	Outputs map[string]interface{} `yaml:"outputs"`
+	Health  *testInfoHealth        `yaml:"health"`
+}
+
+func (e *testInfoExpected) UnmarshalJSON(data []byte) error {
+	var path string
+	if err := json.Unmarshal(data, &path); err == nil {
+		e.Object = &path
+		return nil
+	}
+
+	type expected testInfoExpected
+	return json.Unmarshal(data, (*expected)(e))
+}
+
+type testInfoHealth struct {
+	Status  metav1.ConditionStatus `yaml:"status"`
+	Reason  string                 `yaml:"reason"`
+	Message string                 `yaml:"message"`
 }
 
 type testInfoMetadata struct {
@@ -48,10 +76,14 @@ type testInfoCompareOptions struct {
 }
 
 type testInfoGiven struct {
-	Template        testInfoTemplate    `yaml:"template"`
-	Workload        *string             `yaml:"workload"`
-	MockSupplyChain testInfoMockSC      `yaml:"mockSupplyChain"`
-	SupplyChain     testInfoSupplyChain `yaml:"supplyChain"`
+	Template            testInfoTemplate       `yaml:"template"`
+	Workload            *string                `yaml:"workload"`
+	MockSupplyChain     testInfoMockSC         `yaml:"mockSupplyChain"`
+	SupplyChain         testInfoSupplyChain    `yaml:"supplyChain"`
+	Deliverable         *string                `yaml:"deliverable"`
+	MockDelivery        testInfoMockSC         `yaml:"mockDelivery"`
+	Delivery            testInfoSupplyChain    `yaml:"delivery"`
+	StampedObjectStatus map[string]interface{} `yaml:"stampedObjectStatus"`
 }
 
 type testInfoTemplate struct {
@@ -64,6 +96,13 @@ type testInfoMockSC struct {
 	BlueprintParams []v1alpha1.BlueprintParam `yaml:"blueprintParams"`
 }
 
+type testInfoSimulation struct {
+	Templates             []string                          `yaml:"templates"`
+	StampedObjectStatuses map[string]map[string]interface{} `yaml:"stampedObjectStatuses"`
+	ExpectedObjects       map[string]string                 `yaml:"expectedObjects"`
+	ExpectedResources     *string                           `yaml:"expectedResources"`
+}
+
 type testInfoSupplyChain struct {
 	Paths              []string          `yaml:"paths"`
 	YttPath            *string           `yaml:"yttPath"`
@@ -77,6 +116,8 @@ const (
 	expectedDefaultFilename             = "expected.yaml"
 	templateYttValuesDefaultFilename    = "template-ytt-values.yaml"
 	supplyChainYttValuesDefaultFilename = "supply-chain-ytt-values.yaml"
+	deliverableDefaultFilename          = "deliverable.yaml"
+	deliveryYttValuesDefaultFilename    = "delivery-ytt-values.yaml"
 )
 
 func populateTestCase(testCase *Test, directory string) (*Test, error) {
@@ -95,7 +136,16 @@ func populateTestCase(testCase *Test, directory string) (*Test, error) {
 		return nil, fmt.Errorf("populate testCase workload: %w", err)
 	}
 
-	newExpectedFilePath, err := getLocallySpecifiedPath(directory, expectedDefaultFilename, info.Expected)
+	testCase, err = populateTestCaseDeliverable(testCase, directory, info)
+	if err != nil {
+		return nil, fmt.Errorf("populate testCase deliverable: %w", err)
+	}
+
+	var expectedObjectPath *string
+	if info.Expected != nil {
+		expectedObjectPath = info.Expected.Object
+	}
+	newExpectedFilePath, err := getLocallySpecifiedPath(directory, expectedDefaultFilename, expectedObjectPath)
 	if err != nil {
 		return nil, fmt.Errorf("get expected file specified in directory %s: %w", directory, err)
 	}
@@ -103,12 +153,16 @@ func populateTestCase(testCase *Test, directory string) (*Test, error) {
 		testCase.Expect = &ExpectedFile{Path: newExpectedFilePath}
 	}
 
+	testCase = populateTestCaseOutputsAndHealth(testCase, info)
+
 	if info.Focus != nil {
 		testCase.Focus = *info.Focus
 	}
 
 	testCase = populateCompareOptions(testCase, info)
 
+	testCase = populateTestCaseSimulation(testCase, directory, info)
+
 	var (
 		mockSupplyChainSpecified bool
 		supplyChainSpecified     bool
@@ -124,9 +178,93 @@ func populateTestCase(testCase *Test, directory string) (*Test, error) {
 		return nil, fmt.Errorf("only one of mock supply chain and real supply chain may be specified")
 	}
 
+	if testCase.Simulation != nil && mockSupplyChainSpecified {
+		return nil, fmt.Errorf("a simulation may not be specified with a mock supply chain")
+	}
+
+	var (
+		mockDeliverySpecified bool
+		deliverySpecified     bool
+	)
+
+	testCase, mockDeliverySpecified = populateTestCaseMockDelivery(testCase, info)
+	testCase, deliverySpecified, err = populateTestCaseDelivery(testCase, directory, info)
+	if err != nil {
+		return nil, fmt.Errorf("populate testCase delivery: %w", err)
+	}
+
+	if mockDeliverySpecified && deliverySpecified {
+		return nil, fmt.Errorf("only one of mock delivery and real delivery may be specified")
+	}
+
 	return testCase, nil
 }
 
+// populateTestCaseOutputsAndHealth overrides the status of the stamped object, and the outputs and health expected
+// of it, with those specified in info.yaml
+func populateTestCaseOutputsAndHealth(testCase *Test, info *testInfo) *Test {
+	if info.Given.StampedObjectStatus != nil {
+		testCase.Given.StampedObjectStatus = info.Given.StampedObjectStatus
+	}
+
+	if info.Expected == nil {
+		return testCase
+	}
+
+	if info.Expected.Outputs != nil {
+		testCase.ExpectOutputs = info.Expected.Outputs
+	}
+
+	if info.Expected.Health != nil {
+		testCase.ExpectHealth = &ExpectedHealth{
+			Status:  info.Expected.Health.Status,
+			Reason:  info.Expected.Health.Reason,
+			Message: info.Expected.Health.Message,
+		}
+	}
+
+	return testCase
+}
+
+// populateTestCaseSimulation overrides the simulation of the test case with the parts specified in info.yaml. Paths
+// are relative to the directory of info.yaml.
+func populateTestCaseSimulation(testCase *Test, directory string, info *testInfo) *Test {
+	if info.Simulation == nil {
+		return testCase
+	}
+
+	newSimulation := Simulation{}
+	if testCase.Simulation != nil {
+		newSimulation = *testCase.Simulation
+	}
+
+	if len(info.Simulation.Templates) > 0 {
+		newSimulation.Templates = nil
+		for _, path := range info.Simulation.Templates {
+			newSimulation.Templates = append(newSimulation.Templates, filepath.Join(directory, path))
+		}
+	}
+
+	if info.Simulation.StampedObjectStatuses != nil {
+		newSimulation.StampedObjectStatuses = info.Simulation.StampedObjectStatuses
+	}
+
+	if info.Simulation.ExpectedObjects != nil {
+		newSimulation.ExpectedObjects = make(map[string]Expectation)
+		for resourceName, path := range info.Simulation.ExpectedObjects {
+			newSimulation.ExpectedObjects[resourceName] = &ExpectedFile{Path: filepath.Join(directory, path)}
+		}
+	}
+
+	if info.Simulation.ExpectedResources != nil {
+		newSimulation.ExpectedResources = &ExpectedResourcesFile{Path: filepath.Join(directory, *info.Simulation.ExpectedResources)}
+	}
+
+	testCase.Simulation = &newSimulation
+
+	return testCase
+}
+
 func populateCompareOptions(testCase *Test, info *testInfo) *Test {
 	if info.CompareOptions.IgnoreMetadata != nil {
 		if testCase.CompareOptions == nil {
@@ -172,7 +310,32 @@ func populateTestCaseWorkload(testCase *Test, directory string, info *testInfo)
 	}
 	if newWorkloadValue != "" {
 		testCase.Given.Workload = &WorkloadFile{Path: newWorkloadValue}
+		testCase.Given.Deliverable = nil
+	}
+	return testCase, nil
+}
+
+// populateTestCaseDeliverable replaces the workload or deliverable of a parent directory with the deliverable of
+// this one. A directory may not have both a workload and a deliverable.
+func populateTestCaseDeliverable(testCase *Test, directory string, info *testInfo) (*Test, error) {
+	newDeliverableValue, err := getLocallySpecifiedPath(directory, deliverableDefaultFilename, info.Given.Deliverable)
+	if err != nil {
+		return nil, fmt.Errorf("get deliverable file specified in directory %s: %w", directory, err)
+	}
+	if newDeliverableValue == "" {
+		return testCase, nil
+	}
+
+	workloadValue, err := getLocallySpecifiedPath(directory, workloadDefaultFilename, info.Given.Workload)
+	if err != nil {
+		return nil, fmt.Errorf("get workload file specified in directory %s: %w", directory, err)
+	}
+	if workloadValue != "" {
+		return nil, fmt.Errorf("only one of workload and deliverable may be specified")
 	}
+
+	testCase.Given.Deliverable = &DeliverableFile{Path: newDeliverableValue}
+	testCase.Given.Workload = nil
 	return testCase, nil
 }
 
@@ -218,11 +381,83 @@ func populateTestCaseMockSupplyChain(testCase *Test, info *testInfo) (*Test, boo
 		mockSupplyChainSpecified = true
 	}
 
+	// a simulation walks the supply chain files of its parent directories
+	if _, isFileSet := testCase.Given.SupplyChain.(*SupplyChainFileSet); isFileSet && testCase.Simulation != nil && !mockSupplyChainSpecified {
+		return testCase, false
+	}
+
 	testCase.Given.SupplyChain = &mockSupplyChain
 
 	return testCase, mockSupplyChainSpecified
 }
 
+func populateTestCaseMockDelivery(testCase *Test, info *testInfo) (*Test, bool) {
+	var mockDeliverySpecified bool
+	mockDelivery := MockDelivery{}
+
+	if info.Given.MockDelivery.BlueprintInputs != nil {
+		mockDelivery.Inputs = &SupplyChainInputsObject{Inputs: info.Given.MockDelivery.BlueprintInputs}
+		mockDeliverySpecified = true
+	}
+
+	if info.Given.MockDelivery.BlueprintParams != nil {
+		mockDelivery.Params = &SupplyChainParamsObject{Params: info.Given.MockDelivery.BlueprintParams}
+		mockDeliverySpecified = true
+	}
+
+	if mockDeliverySpecified {
+		testCase.Given.Delivery = &mockDelivery
+	}
+
+	return testCase, mockDeliverySpecified
+}
+
+func populateTestCaseDelivery(testCase *Test, directory string, info *testInfo) (*Test, bool, error) {
+	var deliverySpecified bool
+
+	newDelivery := DeliveryFileSet{}
+
+	if previousDeliverySet, prevDeliverySetExisted := testCase.Given.Delivery.(*DeliveryFileSet); prevDeliverySetExisted {
+		newDelivery = *previousDeliverySet
+	}
+
+	if info.Given.Delivery.TargetResourceName != nil {
+		newDelivery.TargetResourceName = *info.Given.Delivery.TargetResourceName
+	}
+
+	if info.Given.Delivery.PreviousOutputs != nil {
+		newDelivery.PreviousOutputs = info.Given.Delivery.PreviousOutputs
+	}
+
+	yttFile, err := getLocallySpecifiedPath(directory, deliveryYttValuesDefaultFilename, info.Given.Delivery.YttPath)
+	if err != nil {
+		return nil, false, fmt.Errorf("get delivery ytt file specified in directory %s: %w", directory, err)
+	}
+	if yttFile != "" {
+		newDelivery.YttFiles = []string{yttFile}
+	}
+
+	if len(info.Given.Delivery.Paths) > 0 {
+		newDelivery.Paths = info.Given.Delivery.Paths
+		deliverySpecified = true
+	} else {
+		filesPrefixedDeliveryInDir, err := getYamlFilesPrefixedInDir(directory, "delivery")
+		if err != nil {
+			return nil, false, fmt.Errorf("get files prefixed with delivery in dir: %w", err)
+		}
+		if len(filesPrefixedDeliveryInDir) > 0 {
+			newDelivery.Paths = filesPrefixedDeliveryInDir
+			deliverySpecified = true
+		}
+	}
+
+	if deliveryFileSetNotEmpty(&newDelivery) {
+		testCase.Given.Delivery = &newDelivery
+	}
+
+	return testCase, deliverySpecified, nil
+}
+
 func populateTestCaseSupplyChain(testCase *Test, directory string, info *testInfo) (*Test, bool, error) {
 	var supplyChainSpecified bool
 
@@ -252,7 +487,7 @@ func populateTestCaseSupplyChain(testCase *Test, directory string, info *testInf
 		newSupplyChain.Paths = info.Given.SupplyChain.Paths
 		supplyChainSpecified = true
 	} else {
-		filesPrefixedSupplyChainInDir, err := getFilesPrefixedSupplyChainInDir(directory)
+		filesPrefixedSupplyChainInDir, err := getYamlFilesPrefixedInDir(directory, "supply-chain")
 		if err != nil {
 			return nil, false, fmt.Errorf("get files prefixed with supply-chain in dir: %w", err)
 		}
@@ -285,21 +520,28 @@ func getLocallySpecifiedPath(directory, filename string, priorityPath *string) (
 	return candidatePath, nil
 }
 
-func getFilesPrefixedSupplyChainInDir(directory string) ([]string, error) {
+func getYamlFilesPrefixedInDir(directory string, prefix string) ([]string, error) {
 	files, err := os.ReadDir(directory)
 	if err != nil {
 		return nil, fmt.Errorf("os read directory: %w", err)
 	}
 
-	var supplyChainYamlFilesFound []string
+	var yamlFilesFound []string
 
 	for _, file := range files {
-		if strings.HasPrefix(file.Name(), "supply-chain") && strings.HasSuffix(file.Name(), ".yaml") {
-			supplyChainYamlFilesFound = append(supplyChainYamlFilesFound, filepath.Join(directory, file.Name()))
+		if strings.HasPrefix(file.Name(), prefix) && strings.HasSuffix(file.Name(), ".yaml") {
+			yamlFilesFound = append(yamlFilesFound, filepath.Join(directory, file.Name()))
 		}
 	}
 
-	return supplyChainYamlFilesFound, nil
+	return yamlFilesFound, nil
+}
+
+func deliveryFileSetNotEmpty(deliveryFileSet *DeliveryFileSet) bool {
+	return len(deliveryFileSet.YttFiles) > 0 ||
+		len(deliveryFileSet.Paths) > 0 ||
+		deliveryFileSet.TargetResourceName != "" ||
+		deliveryFileSet.PreviousOutputs != nil
 }
 
 func fileSetNotEmpty(supplyChainfileSet *SupplyChainFileSet) bool {
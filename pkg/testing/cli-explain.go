@@ -0,0 +1,236 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	cerrors "github.com/vmware-tanzu/cartographer/pkg/errors"
+	"github.com/vmware-tanzu/cartographer/pkg/explain"
+	"github.com/vmware-tanzu/cartographer/pkg/graph"
+)
+
+// ExplainOptions select the workload or deliverable to explain
+type ExplainOptions struct {
+	Workload    string
+	Deliverable string
+	Namespace   string
+	MaxEvents   int
+}
+
+var explainOptions ExplainOptions
+
+var explainCmd = &cobra.Command{
+	Use:   "explain",
+	Short: "explain diagnoses why a workload or deliverable is not ready",
+	Long: `the explain command walks the resources of a workload or deliverable on the cluster, in the order
+of its supply chain or delivery, to the first which is not ready. It prints the conditions of the resource,
+the conditions of the object it stamped, the messages of the template's health rule, the output path
+which cannot be read, if any, and the events of the owner and of the stamped object.
+Read more at cartographer.sh`,
+	Example: `cartotest explain --workload my-app --namespace dev
+cartotest explain --deliverable my-app --namespace prod`,
+	Args: cobra.NoArgs,
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		if (explainOptions.Workload == "") == (explainOptions.Deliverable == "") {
+			return fmt.Errorf("exactly one of --workload or --deliverable must be given")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		c, err := NewClusterClient()
+		if err != nil {
+			return err
+		}
+
+		return Explain(cmd.Context(), cmd.OutOrStdout(), c, explainOptions)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(explainCmd)
+	explainCmd.Flags().StringVar(&explainOptions.Workload, "workload", "", "name of the workload to explain")
+	explainCmd.Flags().StringVar(&explainOptions.Deliverable, "deliverable", "", "name of the deliverable to explain")
+	explainCmd.Flags().StringVarP(&explainOptions.Namespace, "namespace", "n", "default", "namespace of the workload or deliverable")
+	explainCmd.Flags().IntVar(&explainOptions.MaxEvents, "max-events", 10, "maximum number of the most recent events to print")
+}
+
+// Explain writes why the workload or deliverable selected by the options is not ready
+func Explain(ctx context.Context, out io.Writer, c client.Client, options ExplainOptions) error {
+	e := &explain.Explanation{}
+
+	var (
+		statuses      []v1alpha1.ResourceStatus
+		g             *graph.Graph
+		blueprintName string
+		blueprintType string
+	)
+
+	if options.Workload != "" {
+		workload := &v1alpha1.Workload{}
+		found, err := getObject(ctx, c, client.ObjectKey{Namespace: options.Namespace, Name: options.Workload}, workload)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("workload [%s/%s] not found", options.Namespace, options.Workload)
+		}
+
+		e.OwnerKind, e.Owner, e.OwnerConditions = "Workload", workload, workload.Status.Conditions
+		statuses = workload.Status.Resources
+		blueprintName, blueprintType = workload.Status.SupplyChainRef.Name, cerrors.SupplyChain
+
+		if blueprintName != "" {
+			obj, err := getClusterBlueprint(ctx, c, workload.Status.SupplyChainRef, &v1alpha1.SupplyChain{}, &v1alpha1.ClusterSupplyChain{})
+			if err != nil {
+				return err
+			}
+			if supplyChain, ok := obj.(v1alpha1.SupplyChainObject); ok {
+				resources, err := v1alpha1.ExpandSubChains(ctx, ClusterSubChainGetter(c), supplyChain)
+				if err != nil {
+					return fmt.Errorf("expand sub-chains of supply chain [%s]: %w", supplyChain.GetName(), err)
+				}
+				g = graph.ForSupplyChain(supplyChain, resources)
+			}
+		}
+	} else {
+		deliverable := &v1alpha1.Deliverable{}
+		found, err := getObject(ctx, c, client.ObjectKey{Namespace: options.Namespace, Name: options.Deliverable}, deliverable)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("deliverable [%s/%s] not found", options.Namespace, options.Deliverable)
+		}
+
+		e.OwnerKind, e.Owner, e.OwnerConditions = "Deliverable", deliverable, deliverable.Status.Conditions
+		statuses = deliverable.Status.Resources
+		blueprintName, blueprintType = deliverable.Status.DeliveryRef.Name, cerrors.Delivery
+
+		if blueprintName != "" {
+			obj, err := getClusterBlueprint(ctx, c, deliverable.Status.DeliveryRef, &v1alpha1.Delivery{}, &v1alpha1.ClusterDelivery{})
+			if err != nil {
+				return err
+			}
+			if delivery, ok := obj.(v1alpha1.DeliveryObject); ok {
+				g = graph.ForDelivery(delivery)
+			}
+		}
+	}
+
+	// without its blueprint, the resources are walked in the order of the owner's status
+	var order []string
+	if g != nil {
+		order = g.Order()
+	} else {
+		for _, status := range statuses {
+			order = append(order, status.Name)
+		}
+	}
+
+	e.Resource = explain.FirstUnreadyResource(order, statuses)
+
+	events, err := listEvents(ctx, c, e.Owner.GetNamespace(), e.OwnerKind, e.Owner.GetName())
+	if err != nil {
+		return err
+	}
+
+	if e.Resource != nil && e.Resource.StampedRef != nil && e.Resource.StampedRef.ObjectReference != nil {
+		ref := e.Resource.StampedRef.ObjectReference
+
+		stampedObject := &unstructured.Unstructured{}
+		stampedObject.SetAPIVersion(ref.APIVersion)
+		stampedObject.SetKind(ref.Kind)
+		found, err := getObject(ctx, c, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, stampedObject)
+		if err != nil {
+			return err
+		}
+
+		if found {
+			e.StampedObject = stampedObject
+
+			template, err := getResourceTemplate(ctx, c, e.Resource)
+			if err != nil {
+				return err
+			}
+
+			var rule *v1alpha1.HealthRule
+			if spec := v1alpha1.GetTemplateSpec(template); spec != nil {
+				rule = spec.HealthRule
+			}
+			e.Conditions = explain.RelevantConditions(rule, stampedObject)
+			e.Messages = explain.HealthMessages(rule, stampedObject)
+			if template != nil {
+				e.OutputError = explain.OutputError(template, e.Resource, stampedObject, blueprintName, blueprintType)
+			}
+
+			stampedEvents, err := listEvents(ctx, c, ref.Namespace, ref.Kind, ref.Name)
+			if err != nil {
+				return err
+			}
+			events = append(events, stampedEvents...)
+		}
+	}
+
+	explain.SortEvents(events)
+	if options.MaxEvents >= 0 && len(events) > options.MaxEvents {
+		events = events[len(events)-options.MaxEvents:]
+	}
+	e.Events = events
+
+	return explain.Write(out, e)
+}
+
+// getResourceTemplate gets the template the resource was last stamped from, or nil when it is not known
+func getResourceTemplate(ctx context.Context, c client.Client, resource *v1alpha1.ResourceStatus) (client.Object, error) {
+	if resource.TemplateRef == nil {
+		return nil, nil
+	}
+
+	template, err := v1alpha1.GetAPITemplate(resource.TemplateRef.Kind)
+	if err != nil {
+		return nil, nil
+	}
+
+	found, err := getObject(ctx, c, client.ObjectKey{Namespace: resource.TemplateRef.Namespace, Name: resource.TemplateRef.Name}, template)
+	if err != nil || !found {
+		return nil, err
+	}
+	return template, nil
+}
+
+// listEvents lists the events of the object
+func listEvents(ctx context.Context, c client.Client, namespace, kind, name string) ([]corev1.Event, error) {
+	events := &corev1.EventList{}
+	err := c.List(ctx, events, client.InNamespace(namespace), client.MatchingFields{
+		"involvedObject.kind": kind,
+		"involvedObject.name": name,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list events of %s [%s/%s]: %w", kind, namespace, name, err)
+	}
+	return events.Items, nil
+}
@@ -0,0 +1,311 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/graph"
+)
+
+// GraphOptions select the blueprint to graph, and the owner whose status is overlaid on it.
+// Without Files the objects are read from the cluster.
+type GraphOptions struct {
+	Files       []string
+	Format      string
+	SupplyChain string
+	Delivery    string
+	Workload    string
+	Deliverable string
+	Namespace   string
+}
+
+var graphOptions GraphOptions
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "graph draws a supply chain or delivery as a DOT or Mermaid diagram",
+	Long: `the graph command draws the resources of a supply chain or delivery and the inputs they consume
+from one another. Given a workload or deliverable, the health of each resource is overlaid.
+Objects are read from the files given with --file, otherwise from the cluster of the current kubeconfig context.
+Read more at cartographer.sh`,
+	Example: `cartotest graph --file ./supply-chain.yaml
+cartotest graph --file ./supply-chain.yaml --file ./workload.yaml --format mermaid
+cartotest graph --workload my-app --namespace dev | dot -Tsvg > my-app.svg`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		return Graph(cmd.Context(), cmd.OutOrStdout(), graphOptions)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(graphCmd)
+	graphCmd.Flags().StringArrayVarP(&graphOptions.Files, "file", "f", nil, "file or directory of supply chains, deliveries, workloads and deliverables, may be repeated")
+	graphCmd.Flags().StringVarP(&graphOptions.Format, "format", "o", graph.DOTFormat, "diagram format, one of dot or mermaid")
+	graphCmd.Flags().StringVar(&graphOptions.SupplyChain, "supply-chain", "", "name of the supply chain to graph, a SupplyChain of --namespace is preferred to a ClusterSupplyChain")
+	graphCmd.Flags().StringVar(&graphOptions.Delivery, "delivery", "", "name of the delivery to graph, a Delivery of --namespace is preferred to a ClusterDelivery")
+	graphCmd.Flags().StringVar(&graphOptions.Workload, "workload", "", "name of the workload whose resource health is overlaid, its supply chain is graphed")
+	graphCmd.Flags().StringVar(&graphOptions.Deliverable, "deliverable", "", "name of the deliverable whose resource health is overlaid, its delivery is graphed")
+	graphCmd.Flags().StringVarP(&graphOptions.Namespace, "namespace", "n", "", "namespace of the workload, deliverable or namespaced blueprint")
+}
+
+// Graph writes the diagram of the blueprint selected by the options
+func Graph(ctx context.Context, out io.Writer, options GraphOptions) error {
+	var (
+		objects     *BlueprintObjects
+		getSubChain v1alpha1.ClusterSupplyChainGetter
+		err         error
+	)
+
+	if len(options.Files) > 0 {
+		objects, err = ReadBlueprintFiles(options.Files)
+		if err != nil {
+			return fmt.Errorf("read files: %w", err)
+		}
+		getSubChain = objects.GetSubChain
+	} else {
+		c, err := NewClusterClient()
+		if err != nil {
+			return err
+		}
+		objects, err = getClusterBlueprintObjects(ctx, c, options)
+		if err != nil {
+			return err
+		}
+		getSubChain = ClusterSubChainGetter(c)
+	}
+
+	g, err := selectGraph(ctx, objects, options, getSubChain)
+	if err != nil {
+		return err
+	}
+
+	return graph.Write(out, g, options.Format)
+}
+
+// selectGraph builds the graph of the blueprint named by the options or, failing that, of the
+// blueprint of the owner named by the options. When nothing is named, the objects must hold a
+// single owner or a single blueprint.
+func selectGraph(ctx context.Context, objects *BlueprintObjects, options GraphOptions, getSubChain v1alpha1.ClusterSupplyChainGetter) (*graph.Graph, error) {
+	workload, deliverable, err := selectOwner(objects, options)
+	if err != nil {
+		return nil, err
+	}
+
+	supplyChainName, deliveryName := options.SupplyChain, options.Delivery
+	if workload != nil && supplyChainName == "" {
+		supplyChainName = workload.Status.SupplyChainRef.Name
+	}
+	if deliverable != nil && deliveryName == "" {
+		deliveryName = deliverable.Status.DeliveryRef.Name
+	}
+
+	if workload == nil && deliverable == nil && supplyChainName == "" && deliveryName == "" {
+		if len(objects.SupplyChains)+len(objects.Deliveries) != 1 {
+			return nil, fmt.Errorf("found %d supply chains and %d deliveries, choose one with --supply-chain or --delivery",
+				len(objects.SupplyChains), len(objects.Deliveries))
+		}
+		if len(objects.SupplyChains) == 1 {
+			supplyChainName = objects.SupplyChains[0].GetName()
+		} else {
+			deliveryName = objects.Deliveries[0].GetName()
+		}
+	}
+
+	if deliverable != nil || (workload == nil && deliveryName != "") {
+		delivery, err := selectBlueprint(objects.Deliveries, deliveryName, options.Namespace, "delivery")
+		if err != nil {
+			return nil, err
+		}
+
+		g := graph.ForDelivery(delivery)
+		if deliverable != nil {
+			g.Overlay(deliverable.Status.Resources)
+		}
+		return g, nil
+	}
+
+	supplyChain, err := selectBlueprint(objects.SupplyChains, supplyChainName, options.Namespace, "supply chain")
+	if err != nil {
+		return nil, err
+	}
+
+	resources, err := v1alpha1.ExpandSubChains(ctx, getSubChain, supplyChain)
+	if err != nil {
+		return nil, fmt.Errorf("expand sub-chains of supply chain [%s]: %w", supplyChain.GetName(), err)
+	}
+
+	g := graph.ForSupplyChain(supplyChain, resources)
+	if workload != nil {
+		g.Overlay(workload.Status.Resources)
+	}
+	return g, nil
+}
+
+// selectOwner finds the workload or deliverable named by the options. When none is named and
+// no blueprint is named either, a single owner among the objects is selected.
+func selectOwner(objects *BlueprintObjects, options GraphOptions) (*v1alpha1.Workload, *v1alpha1.Deliverable, error) {
+	if options.Workload != "" {
+		for _, workload := range objects.Workloads {
+			if workload.Name == options.Workload && (options.Namespace == "" || workload.Namespace == options.Namespace) {
+				return workload, nil, nil
+			}
+		}
+		return nil, nil, fmt.Errorf("workload [%s] not found", options.Workload)
+	}
+
+	if options.Deliverable != "" {
+		for _, deliverable := range objects.Deliverables {
+			if deliverable.Name == options.Deliverable && (options.Namespace == "" || deliverable.Namespace == options.Namespace) {
+				return nil, deliverable, nil
+			}
+		}
+		return nil, nil, fmt.Errorf("deliverable [%s] not found", options.Deliverable)
+	}
+
+	if options.SupplyChain != "" || options.Delivery != "" || len(objects.Workloads)+len(objects.Deliverables) != 1 {
+		return nil, nil, nil
+	}
+
+	if len(objects.Workloads) == 1 {
+		return objects.Workloads[0], nil, nil
+	}
+	return nil, objects.Deliverables[0], nil
+}
+
+// selectBlueprint finds the blueprint by name, preferring one of the namespace. Without a name,
+// the only blueprint is selected.
+func selectBlueprint[T client.Object](blueprints []T, name, namespace, description string) (T, error) {
+	var found T
+	var foundAny bool
+
+	if name == "" {
+		if len(blueprints) != 1 {
+			return found, fmt.Errorf("found %d blueprints, choose the %s by name", len(blueprints), description)
+		}
+		return blueprints[0], nil
+	}
+
+	for _, blueprint := range blueprints {
+		if blueprint.GetName() != name {
+			continue
+		}
+		if !foundAny || (namespace != "" && blueprint.GetNamespace() == namespace) {
+			found, foundAny = blueprint, true
+		}
+	}
+
+	if !foundAny {
+		return found, fmt.Errorf("%s [%s] not found", description, name)
+	}
+	return found, nil
+}
+
+// getClusterBlueprintObjects gets the owner and the blueprint named by the options from the cluster.
+// A blueprint which is not named is the one the owner's status refers to.
+func getClusterBlueprintObjects(ctx context.Context, c client.Client, options GraphOptions) (*BlueprintObjects, error) {
+	objects := &BlueprintObjects{}
+	namespace := options.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	supplyChainRef := v1alpha1.ObjectReference{Name: options.SupplyChain, Namespace: options.Namespace}
+	deliveryRef := v1alpha1.ObjectReference{Name: options.Delivery, Namespace: options.Namespace}
+
+	if options.Workload != "" {
+		workload := &v1alpha1.Workload{}
+		found, err := getObject(ctx, c, client.ObjectKey{Namespace: namespace, Name: options.Workload}, workload)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("workload [%s/%s] not found", namespace, options.Workload)
+		}
+		objects.Workloads = append(objects.Workloads, workload)
+		if supplyChainRef.Name == "" {
+			supplyChainRef = workload.Status.SupplyChainRef
+		}
+	}
+
+	if options.Deliverable != "" {
+		deliverable := &v1alpha1.Deliverable{}
+		found, err := getObject(ctx, c, client.ObjectKey{Namespace: namespace, Name: options.Deliverable}, deliverable)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, fmt.Errorf("deliverable [%s/%s] not found", namespace, options.Deliverable)
+		}
+		objects.Deliverables = append(objects.Deliverables, deliverable)
+		if deliveryRef.Name == "" {
+			deliveryRef = deliverable.Status.DeliveryRef
+		}
+	}
+
+	if supplyChainRef.Name != "" {
+		obj, err := getClusterBlueprint(ctx, c, supplyChainRef, &v1alpha1.SupplyChain{}, &v1alpha1.ClusterSupplyChain{})
+		if err != nil {
+			return nil, err
+		}
+		if obj == nil {
+			return nil, fmt.Errorf("supply chain [%s] not found", supplyChainRef.Name)
+		}
+		objects.append(obj)
+	}
+
+	if deliveryRef.Name != "" {
+		obj, err := getClusterBlueprint(ctx, c, deliveryRef, &v1alpha1.Delivery{}, &v1alpha1.ClusterDelivery{})
+		if err != nil {
+			return nil, err
+		}
+		if obj == nil {
+			return nil, fmt.Errorf("delivery [%s] not found", deliveryRef.Name)
+		}
+		objects.append(obj)
+	}
+
+	if len(objects.SupplyChains)+len(objects.Deliveries) == 0 {
+		return nil, fmt.Errorf("choose a blueprint with --supply-chain, --delivery, --workload or --deliverable, or read it from files with --file")
+	}
+
+	return objects, nil
+}
+
+// getClusterBlueprint gets the namespaced blueprint when the reference has a namespace,
+// falling back to the cluster scoped one. It returns nil when neither exists.
+func getClusterBlueprint(ctx context.Context, c client.Client, ref v1alpha1.ObjectReference, namespaced, clusterScoped client.Object) (client.Object, error) {
+	if ref.Namespace != "" {
+		found, err := getObject(ctx, c, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, namespaced)
+		if err != nil || found {
+			return namespaced, err
+		}
+	}
+
+	found, err := getObject(ctx, c, client.ObjectKey{Name: ref.Name}, clusterScoped)
+	if err != nil || !found {
+		return nil, err
+	}
+	return clusterScoped, nil
+}
@@ -17,6 +17,7 @@ package testing
 import (
 	"fmt"
 	"os"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -51,6 +52,8 @@ var (
 	version   = "development"
 	directory string
 	verbose   bool
+	reports   []string
+	update    bool
 )
 
 var rootCmd = &cobra.Command{
@@ -79,7 +82,7 @@ Read more at cartographer.sh`,
 			return fmt.Errorf("argument must be a valid directory")
 		}
 
-		return nil
+		return validateReportPaths(reports)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
 		cmd.SilenceUsage = true
@@ -115,13 +118,57 @@ func CliTest(directory string) error {
 		return fmt.Errorf("build test cases: %w", err)
 	}
 
-	passedTests, failedTests := testSuite.Assert()
+	results := testSuite.Results()
+
+	if update {
+		results, err = updateFailedTests(testSuite, results)
+		if err != nil {
+			return fmt.Errorf("update tests: %w", err)
+		}
+	}
+
+	if err = writeReports(reports, results, testSuite.HasFocusedTests()); err != nil {
+		return fmt.Errorf("write reports: %w", err)
+	}
+
+	passedTests, failedTests := splitResults(results)
 
 	return reportTestResults(passedTests, failedTests, testSuite.HasFocusedTests())
 }
 
+// updateFailedTests updates the expected file of each failed test, then runs the test again. Tests which cannot be
+// updated keep their failure.
+func updateFailedTests(testSuite Suite, results []TestResult) ([]TestResult, error) {
+	for i, result := range results {
+		if result.Err == nil {
+			continue
+		}
+
+		updatedPath, err := testSuite[result.Name].Update()
+		if err != nil {
+			log.Debugf("could not update test %s: %s", result.Name, err)
+			continue
+		}
+		if updatedPath == "" {
+			continue
+		}
+
+		if _, err = fmt.Fprintf(os.Stderr, "UPDATED: %s\n", updatedPath); err != nil {
+			return nil, fmt.Errorf("write to stdErr failed")
+		}
+
+		started := time.Now()
+		results[i].Err = testSuite[result.Name].Run()
+		results[i].Duration = time.Since(started)
+	}
+
+	return results, nil
+}
+
 func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "output logs and increase test failure verbosity")
+	rootCmd.Flags().BoolVar(&update, "update", false, "write the stamped object of each failing test to its expected file, without the fields its compare options ignore")
+	rootCmd.Flags().StringSliceVar(&reports, "report", nil, "write a report of the test results to the path, as JUnit XML for .xml or as JSON for .json (may be repeated)")
 
 	rootCmd.AddCommand(templateCmd)
 	templateCmd.Flags().StringVarP(&directory, "directory", "d", "", "directory to test")
@@ -0,0 +1,55 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"fmt"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+type Deliverable interface {
+	GetDeliverable() (*v1alpha1.Deliverable, error)
+}
+
+type DeliverableObject struct {
+	Deliverable *v1alpha1.Deliverable
+}
+
+func (d *DeliverableObject) GetDeliverable() (*v1alpha1.Deliverable, error) {
+	return d.Deliverable, nil
+}
+
+type DeliverableFile struct {
+	Path string
+}
+
+func (d *DeliverableFile) GetDeliverable() (*v1alpha1.Deliverable, error) {
+	deliverable := &v1alpha1.Deliverable{}
+
+	deliverableData, err := os.ReadFile(d.Path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read deliverable file: %w", err)
+	}
+
+	if err = yaml.Unmarshal(deliverableData, deliverable); err != nil {
+		return nil, fmt.Errorf("unmarshall deliverable: %w", err)
+	}
+
+	return deliverable, nil
+}
@@ -0,0 +1,232 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/controllers"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+	"github.com/vmware-tanzu/cartographer/pkg/stamp"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+)
+
+// Delivery stamps a template for a deliverable. Along with the stamped object, it returns the
+// input from which the outputs of a ClusterDeploymentTemplate are read.
+type Delivery interface {
+	stamp(ctx context.Context, deliverable *v1alpha1.Deliverable, apiTemplate ValidatableTemplate, template templates.Reader) (*unstructured.Unstructured, stamp.DeploymentInput, error)
+}
+
+// DeliveryFileSet is a set of one or more deliveries
+// Paths is a list of either paths to a delivery
+// or a directory containing delivery files
+// YttValues and YttFiles are values to use in preprocessing the deliveries
+// TargetResourceName is the name of the resource that will be stamped
+// PreviousOutputs are mocked outputs from earlier resources in the delivery
+type DeliveryFileSet struct {
+	Paths              []string
+	YttValues          Values
+	YttFiles           []string
+	TargetResourceName string
+	PreviousOutputs    *realizer.Outputs
+}
+
+func (s *DeliveryFileSet) getDelivery(deliverable *v1alpha1.Deliverable) (v1alpha1.DeliveryObject, error) {
+	var noLog *NoLog
+
+	allDeliveries, err := s.readAllPaths()
+	if err != nil {
+		return nil, fmt.Errorf("read all paths, %w", err)
+	}
+
+	var deliveryObjects []v1alpha1.DeliveryObject
+	for _, delivery := range allDeliveries {
+		deliveryObjects = append(deliveryObjects, delivery)
+	}
+
+	selectedDeliveries, err := repository.GetSelectedDelivery(deliveryObjects, deliverable, logr.New(noLog))
+	if err != nil {
+		return nil, fmt.Errorf("get selected delivery, %w", err)
+	}
+
+	if len(selectedDeliveries) == 0 {
+		return nil, fmt.Errorf("no delivery [%s/%s] found where full selector is satisfied by labels: %v",
+			deliverable.Namespace, deliverable.Name, deliverable.Labels)
+	}
+
+	if len(selectedDeliveries) > 1 {
+		var names []string
+		for _, delivery := range selectedDeliveries {
+			names = append(names, delivery.GetName())
+		}
+		return nil, fmt.Errorf("more than one delivery selected for deliverable [%s/%s]: %+v",
+			deliverable.Namespace, deliverable.Name, names)
+	}
+
+	return selectedDeliveries[0], nil
+}
+
+func (s *DeliveryFileSet) stamp(ctx context.Context, deliverable *v1alpha1.Deliverable, templateObject ValidatableTemplate, template templates.Reader) (*unstructured.Unstructured, stamp.DeploymentInput, error) {
+	delivery, err := s.getDelivery(deliverable)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get delivery: %w", err)
+	}
+
+	resource, err := getTargetResource(realizer.MakeDeliveryOwnerResources(delivery), s.TargetResourceName)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get target resource: %w", err)
+	}
+
+	properTemplateProvided, err := templateMatchesResource(templateObject, resource, deliverable)
+	if err != nil {
+		return nil, nil, fmt.Errorf("template matches resource: %w", err)
+	}
+
+	if !properTemplateProvided {
+		return nil, nil, fmt.Errorf("template '%s' is not selected by resource '%s' in delivery '%s'", templateObject.GetName(), resource.Name, delivery.GetName())
+	}
+
+	templatingContext := realizer.NewContextGenerator(deliverable, deliverable.Spec.Params, delivery.GetSpec().Params)
+
+	resourceLabeler := controllers.BuildDeliverableResourceLabeler(deliverable, delivery)
+	labels := resourceLabeler(*resource, template)
+
+	var outputs realizer.OutputsGetter
+
+	if s.PreviousOutputs != nil {
+		outputs = s.PreviousOutputs
+	} else {
+		outputs = realizer.NewOutputs()
+	}
+
+	stamper := templates.StamperBuilder(deliverable, templatingContext.Generate(template, *resource, outputs, labels), labels)
+	actualStampedObject, err := stamper.Stamp(ctx, template.GetResourceTemplate())
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not stamp: %w", err)
+	}
+
+	return actualStampedObject, realizer.NewInputGenerator(resource, outputs), nil
+}
+
+func (s *DeliveryFileSet) readAllPaths() ([]*v1alpha1.ClusterDelivery, error) {
+	var deliveries []*v1alpha1.ClusterDelivery
+
+	for _, path := range s.Paths {
+		file, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not get fileinfo for path: %w", err)
+		}
+
+		if file.IsDir() {
+			additionalDeliveries, err := s.readDeliveryDir(path)
+			if err != nil {
+				return nil, fmt.Errorf("read delivery directory: %w", err)
+			}
+
+			deliveries = append(deliveries, additionalDeliveries...)
+		} else {
+			delivery, err := s.readDeliveryFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("could not read delivery file: %w", err)
+			}
+
+			deliveries = append(deliveries, delivery)
+		}
+	}
+
+	return deliveries, nil
+}
+
+// readDeliveryDir is not recursive and will not walk a nested directory
+func (s *DeliveryFileSet) readDeliveryDir(path string) ([]*v1alpha1.ClusterDelivery, error) {
+	files, err := os.ReadDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("os read directory: %w", err)
+	}
+
+	var deliveries []*v1alpha1.ClusterDelivery
+
+	for _, file := range files {
+		fullPath := filepath.Join(path, file.Name())
+		delivery, err := s.readDeliveryFile(fullPath)
+		if err != nil {
+			return nil, fmt.Errorf("read delivery file: %s, %w", fullPath, err)
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (s *DeliveryFileSet) readDeliveryFile(path string) (*v1alpha1.ClusterDelivery, error) {
+	var (
+		deliveryFilepath string
+		err              error
+	)
+
+	if len(s.YttValues) != 0 || len(s.YttFiles) != 0 {
+		err := ensureYTTAvailable(context.TODO())
+
+		if err != nil {
+			return nil, fmt.Errorf("ensure ytt available: %w", err)
+		}
+
+		deliveryFilepath, err = s.preprocessYtt(context.TODO(), path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to preprocess ytt: %w", err)
+		}
+		defer os.RemoveAll(deliveryFilepath)
+	} else {
+		deliveryFilepath = path
+	}
+
+	delivery := &v1alpha1.ClusterDelivery{}
+
+	deliveryData, err := os.ReadFile(deliveryFilepath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read delivery file: %w", err)
+	}
+
+	if err = yaml.Unmarshal(deliveryData, delivery); err != nil {
+		return nil, fmt.Errorf("unmarshall delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+func (s *DeliveryFileSet) preprocessYtt(ctx context.Context, deliveryFilepath string) (string, error) {
+	yt := YTT()
+	yt.Values(s.YttValues)
+	yt.F(deliveryFilepath)
+	for _, yttfile := range s.YttFiles {
+		yt.F(yttfile)
+	}
+	f, err := yt.ToTempFile(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file by ytt: %w", err)
+	}
+
+	return f.Name(), nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer"
+	"github.com/vmware-tanzu/cartographer/pkg/stamp"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+)
+
+// MockDelivery implements Delivery
+// Inputs simulate expected inputs that are the outputs from earlier resources in the delivery,
+// including the Deployment passed through by a ClusterDeploymentTemplate
+// Params supplies params as if defined in the delivery
+// The templating context is built as the realizer builds it for a deliverable.
+type MockDelivery struct {
+	Params SupplyChainParams
+	Inputs SupplyChainInputs
+}
+
+func (i *MockDelivery) stamp(ctx context.Context, deliverable *v1alpha1.Deliverable, apiTemplate ValidatableTemplate, template templates.Reader) (*unstructured.Unstructured, stamp.DeploymentInput, error) {
+	var err error
+
+	blueprintParams := make([]v1alpha1.BlueprintParam, 0)
+
+	if i.Params != nil {
+		blueprintParams, err = i.Params.GetParams()
+		if err != nil {
+			return nil, nil, fmt.Errorf("get blueprint params failed: %w", err)
+		}
+	}
+
+	inputs := &Inputs{}
+
+	if i.Inputs != nil {
+		inputs, err = i.Inputs.GetInputs()
+		if err != nil {
+			return nil, nil, fmt.Errorf("get delivery inputs: %w", err)
+		}
+	}
+
+	resource, outputs := mockResource(inputs)
+	resource.TemplateRef = v1alpha1.TemplateReference{
+		Kind: apiTemplate.GetObjectKind().GroupVersionKind().Kind,
+		Name: apiTemplate.GetName(),
+	}
+
+	labels := completeDeliverableLabels(*deliverable, apiTemplate.GetName(), apiTemplate.GetObjectKind().GroupVersionKind().Kind)
+
+	templatingContext := realizer.NewContextGenerator(deliverable, deliverable.Spec.Params, blueprintParams)
+
+	stamper := templates.StamperBuilder(deliverable, templatingContext.Generate(template, resource, outputs, labels), labels)
+	actualStampedObject, err := stamper.Stamp(ctx, template.GetResourceTemplate())
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not stamp: %w", err)
+	}
+
+	return actualStampedObject, realizer.NewInputGenerator(resource, outputs), nil
+}
+
+func completeDeliverableLabels(deliverable v1alpha1.Deliverable, name string, kind string) map[string]string {
+	labels := make(map[string]string)
+
+	labels["carto.run/deliverable-name"] = deliverable.GetName()
+	labels["carto.run/deliverable-namespace"] = deliverable.GetNamespace()
+	labels["carto.run/template-kind"] = kind
+	labels["carto.run/cluster-template-name"] = name
+
+	return labels
+}
+
+// mockResource is a resource whose every input is the output of an earlier resource, so that the realizer
+// builds the templating context and the deployment from the inputs
+func mockResource(inputs *Inputs) (realizer.OwnerResource, realizer.Outputs) {
+	resource := realizer.OwnerResource{Name: "mock"}
+	outputs := realizer.NewOutputs()
+
+	for _, name := range sortedKeys(inputs.Sources) {
+		source := inputs.Sources[name]
+		resourceName := "sources/" + name
+		resource.Sources = append(resource.Sources, v1alpha1.ResourceReference{Name: name, Resource: resourceName})
+		outputs.AddOutput(resourceName, &templates.Output{Source: &templates.Source{URL: source.URL, Revision: source.Revision}})
+	}
+
+	for _, name := range sortedKeys(inputs.Images) {
+		resourceName := "images/" + name
+		resource.Images = append(resource.Images, v1alpha1.ResourceReference{Name: name, Resource: resourceName})
+		outputs.AddOutput(resourceName, &templates.Output{Image: inputs.Images[name].Image})
+	}
+
+	for _, name := range sortedKeys(inputs.Configs) {
+		resourceName := "configs/" + name
+		resource.Configs = append(resource.Configs, v1alpha1.ResourceReference{Name: name, Resource: resourceName})
+		outputs.AddOutput(resourceName, &templates.Output{Config: inputs.Configs[name].Config})
+	}
+
+	if inputs.Deployment != nil {
+		resource.Deployment = &v1alpha1.DeploymentReference{Resource: "deployment"}
+		outputs.AddOutput("deployment", &templates.Output{Source: &templates.Source{URL: inputs.Deployment.URL, Revision: inputs.Deployment.Revision}})
+	}
+
+	return resource, outputs
+}
+
+func sortedKeys[T any](values map[string]T) []string {
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
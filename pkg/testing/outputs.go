@@ -0,0 +1,184 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/healthcheck"
+	"github.com/vmware-tanzu/cartographer/pkg/stamp"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+)
+
+// ExpectedOutputs are the outputs expected to be read from the stamped object, by name.
+// A source template outputs url and revision, an image template outputs image and a config template outputs config.
+// The outputs declared by the template are expected by their own names.
+type ExpectedOutputs map[string]interface{}
+
+// ExpectedHealth is the Healthy condition expected of the resource once its stamped object has the status of
+// Given.StampedObjectStatus. Reason and Message are only compared when they are not empty.
+type ExpectedHealth struct {
+	Status  metav1.ConditionStatus
+	Reason  string
+	Message string
+}
+
+func (c *Test) assertOutputsAndHealth(stampedObject *unstructured.Unstructured, deploymentInput stamp.DeploymentInput, opts cmp.Options) error {
+	output, outputErr, healthyCondition, err := c.Given.readStampedObject(stampedObject, deploymentInput)
+	if err != nil {
+		return fmt.Errorf("failed to read stamped object: %w", err)
+	}
+
+	var failures []string
+
+	if c.ExpectOutputs != nil {
+		if outputErr != nil {
+			failures = append(failures, fmt.Sprintf("failed to read outputs: %s", outputErr))
+		} else {
+			var expectedOutputs, actualOutputs map[string]interface{}
+			if err = jsonRoundTrip(c.ExpectOutputs, &expectedOutputs); err != nil {
+				return fmt.Errorf("failed to convert expected outputs: %w", err)
+			}
+			if err = jsonRoundTrip(outputValues(output), &actualOutputs); err != nil {
+				return fmt.Errorf("failed to convert actual outputs: %w", err)
+			}
+
+			if diff := cmp.Diff(expectedOutputs, actualOutputs, opts); diff != "" {
+				failures = append(failures, fmt.Sprintf("expected outputs do not equal actual: (-expected +actual):\n%s", diff))
+			}
+		}
+	}
+
+	if c.ExpectHealth != nil {
+		if c.ExpectHealth.Status != healthyCondition.Status ||
+			(c.ExpectHealth.Reason != "" && c.ExpectHealth.Reason != healthyCondition.Reason) ||
+			(c.ExpectHealth.Message != "" && c.ExpectHealth.Message != healthyCondition.Message) {
+			failures = append(failures, fmt.Sprintf("expected health does not equal actual: expected %s, found status [%s], reason [%s], message [%s]",
+				describeExpectedHealth(c.ExpectHealth), healthyCondition.Status, healthyCondition.Reason, healthyCondition.Message))
+		}
+	}
+
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "\n"))
+	}
+
+	return nil
+}
+
+func describeExpectedHealth(health *ExpectedHealth) string {
+	description := fmt.Sprintf("status [%s]", health.Status)
+	if health.Reason != "" {
+		description += fmt.Sprintf(", reason [%s]", health.Reason)
+	}
+	if health.Message != "" {
+		description += fmt.Sprintf(", message [%s]", health.Message)
+	}
+	return description
+}
+
+// readStampedObject reads the outputs of the template from the stamped object, once it has the status of
+// StampedObjectStatus, and determines its Healthy condition, as the realizer does. outputErr is the error the
+// realizer would meet reading the outputs; the health is still determined when there is one. A ClusterDeploymentTemplate
+// passes through the deployment of deploymentInput once the stamped object has observed it.
+func (i *Given) readStampedObject(stampedObject *unstructured.Unstructured, deploymentInput stamp.DeploymentInput) (output *templates.Output, outputErr error, healthyCondition metav1.Condition, err error) {
+	apiTemplate, err := i.Template.GetTemplate()
+	if err != nil {
+		return nil, nil, metav1.Condition{}, fmt.Errorf("get populated template failed: %w", err)
+	}
+
+	template, err := templates.NewReaderFromAPI(*apiTemplate)
+	if err != nil {
+		return nil, nil, metav1.Condition{}, fmt.Errorf("failed to get cluster template: %w", err)
+	}
+
+	// the api server sets the generation of an object when it is created
+	stampedObject = stampedObject.DeepCopy()
+	if stampedObject.GetGeneration() == 0 {
+		stampedObject.SetGeneration(1)
+	}
+	if i.StampedObjectStatus != nil {
+		stampedObject.Object["status"] = i.StampedObjectStatus
+	}
+
+	reader, err := stamp.NewReader(*apiTemplate, deploymentInput)
+	if err != nil {
+		return nil, nil, metav1.Condition{}, fmt.Errorf("failed to create new stamp reader: %w", err)
+	}
+
+	output, outputErr = reader.Output(stampedObject)
+
+	realizedResource := &v1alpha1.RealizedResource{
+		TemplateRef: &corev1.ObjectReference{
+			Kind:       (*apiTemplate).GetObjectKind().GroupVersionKind().Kind,
+			Name:       (*apiTemplate).GetName(),
+			APIVersion: v1alpha1.SchemeGroupVersion.String(),
+		},
+		StampedRef: &v1alpha1.StampedRef{
+			ObjectReference: &corev1.ObjectReference{
+				Kind:       stampedObject.GetKind(),
+				Namespace:  stampedObject.GetNamespace(),
+				Name:       stampedObject.GetName(),
+				APIVersion: stampedObject.GetAPIVersion(),
+			},
+		},
+	}
+	if outputErr == nil {
+		for name := range outputValues(output) {
+			realizedResource.Outputs = append(realizedResource.Outputs, v1alpha1.Output{Name: name})
+		}
+	}
+
+	healthyCondition = healthcheck.DetermineHealthCondition(template.GetHealthRule(), realizedResource, stampedObject)
+
+	return output, outputErr, healthyCondition, nil
+}
+
+// outputValues are the values of the output by the names they have in the status of the owner
+func outputValues(output *templates.Output) map[string]interface{} {
+	values := map[string]interface{}{}
+	if output == nil {
+		return values
+	}
+
+	if output.Source != nil {
+		values["url"] = output.Source.URL
+		values["revision"] = output.Source.Revision
+	} else if output.Image != nil {
+		values["image"] = output.Image
+	} else if output.Config != nil {
+		values["config"] = output.Config
+	}
+
+	for name, value := range output.Named {
+		values[name] = value
+	}
+
+	return values
+}
+
+// noDeployment is the input of a test of a supply chain, which has no deployment to pass through
+type noDeployment struct{}
+
+func (noDeployment) GetDeployment() *templates.SourceInput {
+	return nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	junitReportExtension = ".xml"
+	jsonReportExtension  = ".json"
+)
+
+// report is the machine-readable report of a test suite
+type report struct {
+	Passed  bool         `json:"passed"`
+	Focused bool         `json:"focused"`
+	Tests   []testReport `json:"tests"`
+}
+
+type testReport struct {
+	Path            string  `json:"path"`
+	Name            string  `json:"name,omitempty"`
+	Description     string  `json:"description,omitempty"`
+	Passed          bool    `json:"passed"`
+	Focused         bool    `json:"focused"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	Error           string  `json:"error,omitempty"`
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	TestCases  []junitTestCase  `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string           `xml:"name,attr"`
+	ClassName  string           `xml:"classname,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	Failure    *junitFailure    `xml:"failure,omitempty"`
+}
+
+type junitProperties struct {
+	Properties []junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Contents string `xml:",chardata"`
+}
+
+// validateReportPaths ensures that a report format is known for the extension of each path
+func validateReportPaths(paths []string) error {
+	for _, path := range paths {
+		switch filepath.Ext(path) {
+		case junitReportExtension, jsonReportExtension:
+		default:
+			return fmt.Errorf("report [%s] must have extension %s for JUnit XML or %s for JSON", path, junitReportExtension, jsonReportExtension)
+		}
+	}
+	return nil
+}
+
+// writeReports writes a report of the results to each path, in JUnit XML or JSON depending on its extension
+func writeReports(paths []string, results []TestResult, hasFocusedTests bool) error {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	r, err := newReport(results, hasFocusedTests)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range paths {
+		var data []byte
+		switch filepath.Ext(path) {
+		case junitReportExtension:
+			data, err = xml.MarshalIndent(r.junit(), "", "  ")
+			data = append([]byte(xml.Header), data...)
+		case jsonReportExtension:
+			data, err = json.MarshalIndent(r, "", "  ")
+		default:
+			err = validateReportPaths([]string{path})
+		}
+		if err != nil {
+			return fmt.Errorf("failed to build report [%s]: %w", path, err)
+		}
+
+		if err = os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to write report [%s]: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+func newReport(results []TestResult, hasFocusedTests bool) (*report, error) {
+	r := &report{
+		Passed:  !hasFocusedTests,
+		Focused: hasFocusedTests,
+		Tests:   []testReport{},
+	}
+
+	for _, result := range results {
+		info, err := populateInfo(result.Name)
+		if err != nil {
+			return nil, fmt.Errorf("populate test info for test %s: %w", result.Name, err)
+		}
+
+		test := testReport{
+			Path:            result.Name,
+			Passed:          result.Err == nil,
+			Focused:         result.Focused,
+			DurationSeconds: result.Duration.Seconds(),
+		}
+		if info.Metadata.Name != nil {
+			test.Name = *info.Metadata.Name
+		}
+		if info.Metadata.Description != nil {
+			test.Description = *info.Metadata.Description
+		}
+		if result.Err != nil {
+			test.Error = result.Err.Error()
+			r.Passed = false
+		}
+
+		r.Tests = append(r.Tests, test)
+	}
+
+	return r, nil
+}
+
+// junit converts the report to a single JUnit test suite. Test cases are named by the name in their metadata,
+// falling back to their path, and their class name is their path.
+func (r *report) junit() junitTestSuites {
+	suite := junitTestSuite{
+		Name: "cartotest",
+	}
+	if r.Focused {
+		suite.Properties = &junitProperties{Properties: []junitProperty{{Name: "focused", Value: "true"}}}
+	}
+
+	var totalSeconds float64
+	for _, test := range r.Tests {
+		testCase := junitTestCase{
+			Name:      test.Path,
+			ClassName: test.Path,
+			Time:      junitTime(test.DurationSeconds),
+		}
+		if test.Name != "" {
+			testCase.Name = test.Name
+		}
+		if test.Focused {
+			testCase.Properties = &junitProperties{Properties: []junitProperty{{Name: "focused", Value: "true"}}}
+		}
+		if !test.Passed {
+			message, _, _ := strings.Cut(test.Error, "\n")
+			testCase.Failure = &junitFailure{Message: message, Contents: test.Error}
+			suite.Failures++
+		}
+
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+		totalSeconds += test.DurationSeconds
+	}
+	suite.Time = junitTime(totalSeconds)
+
+	return junitTestSuites{
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+}
+
+func junitTime(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
@@ -0,0 +1,190 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	cartotesting "github.com/vmware-tanzu/cartographer/pkg/testing"
+)
+
+var _ = Describe("writeReports", func() {
+	var (
+		dir     string
+		results []cartotesting.TestResult
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "report")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(os.Mkdir(filepath.Join(dir, "passing"), 0755)).To(Succeed())
+		writeFile(filepath.Join(dir, "passing", "info.yaml"), "metadata:\n  name: passes\n  description: a passing test\n")
+
+		results = []cartotesting.TestResult{
+			{
+				Name:     filepath.Join(dir, "passing"),
+				Duration: 1500 * time.Millisecond,
+			},
+			{
+				Name:     filepath.Join(dir, "failing"),
+				Err:      errors.New("expected <a> & \"b\"\nsome diff"),
+				Duration: 250 * time.Millisecond,
+				Focused:  true,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("writes nothing without report paths", func() {
+		Expect(cartotesting.WriteReports(nil, results, false)).To(Succeed())
+
+		entries, err := os.ReadDir(dir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(1))
+	})
+
+	Describe("JSON", func() {
+		var reportPath string
+
+		BeforeEach(func() {
+			reportPath = filepath.Join(dir, "report.json")
+		})
+
+		readReport := func() map[string]interface{} {
+			content, err := os.ReadFile(reportPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			report := map[string]interface{}{}
+			Expect(json.Unmarshal(content, &report)).To(Succeed())
+			return report
+		}
+
+		It("reports each test and fails the report when a test fails", func() {
+			Expect(cartotesting.WriteReports([]string{reportPath}, results, false)).To(Succeed())
+
+			Expect(readReport()).To(Equal(map[string]interface{}{
+				"passed":  false,
+				"focused": false,
+				"tests": []interface{}{
+					map[string]interface{}{
+						"path":            filepath.Join(dir, "passing"),
+						"name":            "passes",
+						"description":     "a passing test",
+						"passed":          true,
+						"focused":         false,
+						"durationSeconds": 1.5,
+					},
+					map[string]interface{}{
+						"path":            filepath.Join(dir, "failing"),
+						"passed":          false,
+						"focused":         true,
+						"durationSeconds": 0.25,
+						"error":           "expected <a> & \"b\"\nsome diff",
+					},
+				},
+			}))
+		})
+
+		It("passes the report when every test passes", func() {
+			Expect(cartotesting.WriteReports([]string{reportPath}, results[:1], false)).To(Succeed())
+
+			Expect(readReport()).To(HaveKeyWithValue("passed", true))
+		})
+
+		It("fails the report of a suite with focused tests", func() {
+			Expect(cartotesting.WriteReports([]string{reportPath}, results[:1], true)).To(Succeed())
+
+			report := readReport()
+			Expect(report).To(HaveKeyWithValue("passed", false))
+			Expect(report).To(HaveKeyWithValue("focused", true))
+		})
+	})
+
+	Describe("JUnit XML", func() {
+		var reportPath string
+
+		BeforeEach(func() {
+			reportPath = filepath.Join(dir, "report.xml")
+		})
+
+		readReport := func() string {
+			content, err := os.ReadFile(reportPath)
+			Expect(err).NotTo(HaveOccurred())
+			return string(content)
+		}
+
+		It("reports each test as a test case of a single suite", func() {
+			Expect(cartotesting.WriteReports([]string{reportPath}, results, true)).To(Succeed())
+
+			passingPath := filepath.Join(dir, "passing")
+			failingPath := filepath.Join(dir, "failing")
+			Expect(readReport()).To(Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="2" failures="1" time="1.750">
+  <testsuite name="cartotest" tests="2" failures="1" time="1.750">
+    <properties>
+      <property name="focused" value="true"></property>
+    </properties>
+    <testcase name="passes" classname="` + passingPath + `" time="1.500"></testcase>
+    <testcase name="` + failingPath + `" classname="` + failingPath + `" time="0.250">
+      <properties>
+        <property name="focused" value="true"></property>
+      </properties>
+      <failure message="expected &lt;a&gt; &amp; &#34;b&#34;">expected &lt;a&gt; &amp; &#34;b&#34;&#xA;some diff</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`))
+		})
+
+		It("has no failures when every test passes", func() {
+			Expect(cartotesting.WriteReports([]string{reportPath}, results[:1], false)).To(Succeed())
+
+			report := readReport()
+			Expect(report).To(ContainSubstring(`<testsuites tests="1" failures="0" time="1.500">`))
+			Expect(report).NotTo(ContainSubstring("<failure"))
+			Expect(report).NotTo(ContainSubstring("<properties>"))
+		})
+	})
+
+	It("writes a report to each path", func() {
+		Expect(cartotesting.WriteReports([]string{filepath.Join(dir, "report.json"), filepath.Join(dir, "report.xml")}, results, false)).To(Succeed())
+
+		Expect(filepath.Join(dir, "report.json")).To(BeARegularFile())
+		Expect(filepath.Join(dir, "report.xml")).To(BeARegularFile())
+	})
+})
+
+var _ = Describe("validateReportPaths", func() {
+	It("accepts JUnit XML and JSON reports", func() {
+		Expect(cartotesting.ValidateReportPaths([]string{"report.xml", "report.json"})).To(Succeed())
+	})
+
+	It("rejects a report with an unknown extension", func() {
+		Expect(cartotesting.ValidateReportPaths([]string{"report.xml", "report.txt"})).
+			To(MatchError("report [report.txt] must have extension .xml for JUnit XML or .json for JSON"))
+	})
+})
@@ -0,0 +1,428 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/conditions"
+	"github.com/vmware-tanzu/cartographer/pkg/controllers"
+	cerrors "github.com/vmware-tanzu/cartographer/pkg/errors"
+	"github.com/vmware-tanzu/cartographer/pkg/events"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer"
+	realizerclient "github.com/vmware-tanzu/cartographer/pkg/realizer/client"
+	"github.com/vmware-tanzu/cartographer/pkg/realizer/statuses"
+	"github.com/vmware-tanzu/cartographer/pkg/repository"
+	"github.com/vmware-tanzu/cartographer/pkg/templates"
+)
+
+// Simulation walks the whole supply chain selected by the workload, rather than stamping a single template.
+// Every resource is realized as the workload reconciler would realize it: outputs are read from the stamped
+// objects by the template's output paths and health by its health rule, and are fed to the resources downstream.
+// Templates is a list of paths to template files, or directories containing template files
+// StampedObjectStatuses are the statuses the stamped objects reach, by the name of the resource which stamps them
+// ExpectedObjects are the objects expected to be stamped, by the name of the resource which stamps them
+// ExpectedResources are the expected Status.Resources of the workload
+type Simulation struct {
+	Templates             []string
+	StampedObjectStatuses map[string]map[string]interface{}
+	ExpectedObjects       map[string]Expectation
+	ExpectedResources     ExpectedResources
+}
+
+type ExpectedResources interface {
+	getExpectedResources() ([]v1alpha1.ResourceStatus, error)
+}
+
+type ExpectedResourcesObject struct {
+	Resources []v1alpha1.ResourceStatus
+}
+
+func (e *ExpectedResourcesObject) getExpectedResources() ([]v1alpha1.ResourceStatus, error) {
+	return e.Resources, nil
+}
+
+type ExpectedResourcesFile struct {
+	Path string
+}
+
+func (e *ExpectedResourcesFile) getExpectedResources() ([]v1alpha1.ResourceStatus, error) {
+	resourcesFile, err := os.ReadFile(e.Path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read expected resources file: %w", err)
+	}
+
+	var resources []v1alpha1.ResourceStatus
+	if err = yaml.Unmarshal(resourcesFile, &resources); err != nil {
+		return nil, fmt.Errorf("unmarshall resources: %w", err)
+	}
+
+	return resources, nil
+}
+
+// SimulationResult is what the simulation of a supply chain produced
+type SimulationResult struct {
+	// StampedObjects are the objects stamped, by the name of the resource which stamped them
+	StampedObjects map[string]*unstructured.Unstructured
+	// Resources are the Status.Resources of the workload
+	Resources []v1alpha1.ResourceStatus
+}
+
+func (c *Test) runSimulation() error {
+	result, err := c.Given.simulate(context.Background(), c.Simulation)
+	if errors.Is(err, yttNotFound) {
+		return fmt.Errorf("test requires ytt, but ytt was not found in path")
+	} else if err != nil {
+		return fmt.Errorf("failed to simulate supply chain: %w", err)
+	}
+
+	var opts cmp.Options
+	if c.CompareOptions != nil && c.CompareOptions.CMPOption != nil {
+		opts, err = c.CompareOptions.CMPOption()
+		if err != nil {
+			return fmt.Errorf("get compare options: %w", err)
+		}
+	}
+
+	var failures []string
+
+	resourceNames := make([]string, 0, len(c.Simulation.ExpectedObjects))
+	for resourceName := range c.Simulation.ExpectedObjects {
+		resourceNames = append(resourceNames, resourceName)
+	}
+	sort.Strings(resourceNames)
+
+	for _, resourceName := range resourceNames {
+		expectedObject, err := c.Simulation.ExpectedObjects[resourceName].getExpected()
+		if err != nil {
+			return fmt.Errorf("failed to get expected object of resource [%s]: %w", resourceName, err)
+		}
+
+		actualObject, ok := result.StampedObjects[resourceName]
+		if !ok {
+			failures = append(failures, fmt.Sprintf("resource [%s] did not stamp an object", resourceName))
+			continue
+		}
+		actualObject = actualObject.DeepCopy()
+
+		c.stripIgnoredFields(expectedObject, actualObject)
+
+		if diff := cmp.Diff(expectedObject.Object, actualObject.Object, opts); diff != "" {
+			failures = append(failures, fmt.Sprintf("resource [%s]: expected does not equal actual: (-expected +actual):\n%s", resourceName, diff))
+		}
+	}
+
+	if c.Simulation.ExpectedResources != nil {
+		expectedResources, err := c.Simulation.ExpectedResources.getExpectedResources()
+		if err != nil {
+			return fmt.Errorf("failed to get expected resources: %w", err)
+		}
+
+		diff, err := diffResourceStatuses(expectedResources, result.Resources)
+		if err != nil {
+			return fmt.Errorf("compare resources: %w", err)
+		}
+		if diff != "" {
+			failures = append(failures, fmt.Sprintf("workload status.resources: expected does not equal actual: (-expected +actual):\n%s", diff))
+		}
+	}
+
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "\n"))
+	}
+
+	return nil
+}
+
+// simulate realizes the supply chain selected by the workload. Handled errors, such as an output which cannot be
+// read from a stamped object, are reported in the status of the resource, as they would be on the cluster.
+func (i *Given) simulate(ctx context.Context, simulation *Simulation) (*SimulationResult, error) {
+	workload, err := i.Workload.GetWorkload()
+	if err != nil {
+		return nil, fmt.Errorf("get workload failed: %w", err)
+	}
+
+	supplyChainFileSet, ok := i.SupplyChain.(*SupplyChainFileSet)
+	if !ok {
+		return nil, fmt.Errorf("a simulation requires supply chain files, not a mock supply chain")
+	}
+
+	supplyChain, err := supplyChainFileSet.getSupplyChain(workload)
+	if err != nil {
+		return nil, fmt.Errorf("get supplychain: %w", err)
+	}
+
+	ownerResources, err := realizer.MakeSupplychainOwnerResources(ctx, supplyChainFileSet.getSubChain, supplyChain)
+	if err != nil {
+		return nil, fmt.Errorf("make supply chain owner resources: %w", err)
+	}
+
+	apiTemplates, err := readTemplatePaths(ctx, simulation.Templates)
+	if err != nil {
+		return nil, fmt.Errorf("read templates: %w", err)
+	}
+
+	repo := newSimulationRepository(apiTemplates, simulation.StampedObjectStatuses)
+
+	resourceRealizer, err := realizer.NewResourceRealizerBuilder(repo.builder, simulationClientBuilder, nil)(
+		"",
+		workload,
+		realizer.NewContextGenerator(workload, workload.Spec.Params, supplyChain.GetSpec().Params),
+		repo,
+		controllers.BuildWorkloadResourceLabeler(workload, supplyChain),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("build resource realizer: %w", err)
+	}
+
+	ctx = events.NewContext(ctx, events.FromEventRecorder(&record.FakeRecorder{}, nil, workload, repo.mapper, logr.Discard()))
+
+	resourceStatuses := statuses.NewResourceStatuses(nil, conditions.AddConditionForResourceSubmittedWorkload)
+	err = realizer.NewRealizer(nil, repo.mapper, 1, false).Realize(ctx, resourceRealizer, supplyChain.GetName(), ownerResources, resourceStatuses)
+	if err != nil && cerrors.IsUnhandledError(err) {
+		return nil, fmt.Errorf("realize supply chain: %w", err)
+	}
+
+	return &SimulationResult{
+		StampedObjects: repo.stampedObjects(),
+		Resources:      resourceStatuses.GetCurrent(),
+	}, nil
+}
+
+// readTemplatePaths reads and validates the templates in each path, which is either a template file or a directory
+// of template files. Directories are not walked recursively.
+func readTemplatePaths(ctx context.Context, paths []string) ([]client.Object, error) {
+	var templateFiles []string
+	for _, path := range paths {
+		file, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not get fileinfo for path: %w", err)
+		}
+
+		if !file.IsDir() {
+			templateFiles = append(templateFiles, path)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("os read directory: %w", err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				templateFiles = append(templateFiles, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+
+	var apiTemplates []client.Object
+	for _, templateFile := range templateFiles {
+		apiTemplate, err := (&TemplateFile{Path: templateFile}).GetTemplate()
+		if err != nil {
+			return nil, fmt.Errorf("get template %s: %w", templateFile, err)
+		}
+
+		if _, err = (*apiTemplate).ValidateCreate(); err != nil {
+			return nil, fmt.Errorf("template %s validation failed: %w", templateFile, err)
+		}
+
+		if _, err = templates.NewReaderFromAPI(*apiTemplate); err != nil {
+			return nil, fmt.Errorf("failed to get template %s: %w", templateFile, err)
+		}
+
+		apiTemplates = append(apiTemplates, *apiTemplate)
+	}
+
+	return apiTemplates, nil
+}
+
+func diffResourceStatuses(expected, actual []v1alpha1.ResourceStatus) (string, error) {
+	expectedValue, err := comparableResourceStatuses(expected)
+	if err != nil {
+		return "", err
+	}
+	actualValue, err := comparableResourceStatuses(actual)
+	if err != nil {
+		return "", err
+	}
+	return cmp.Diff(expectedValue, actualValue), nil
+}
+
+// comparableResourceStatuses drops the times which depend on when the simulation ran, and converts the statuses
+// to plain values
+func comparableResourceStatuses(resourceStatuses []v1alpha1.ResourceStatus) (interface{}, error) {
+	var normalized []v1alpha1.ResourceStatus
+	for _, resourceStatus := range resourceStatuses {
+		resourceStatus := *resourceStatus.DeepCopy()
+		resourceStatus.ProgressDeadline = nil
+		for i := range resourceStatus.Outputs {
+			resourceStatus.Outputs[i].LastTransitionTime.Reset()
+		}
+		for i := range resourceStatus.Conditions {
+			resourceStatus.Conditions[i].LastTransitionTime.Reset()
+		}
+		normalized = append(normalized, resourceStatus)
+	}
+
+	var value interface{}
+	if err := jsonRoundTrip(normalized, &value); err != nil {
+		return nil, fmt.Errorf("convert resource statuses: %w", err)
+	}
+	return value, nil
+}
+
+func jsonRoundTrip(in interface{}, out interface{}) error {
+	data, err := json.Marshal(in)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+var simulationClientBuilder realizerclient.ClientBuilder = func(_ string, _ bool) (client.Client, discovery.DiscoveryInterface, error) {
+	return nil, nil, nil
+}
+
+// simulationRepository serves templates from files and records stamped objects instead of submitting them to a
+// cluster. A recorded object is given the status the test says it reaches.
+type simulationRepository struct {
+	repository.Repository
+	templates []client.Object
+	statuses  map[string]map[string]interface{}
+	mapper    *meta.DefaultRESTMapper
+
+	mtx      sync.Mutex
+	recorded []*unstructured.Unstructured
+}
+
+func newSimulationRepository(templates []client.Object, statuses map[string]map[string]interface{}) *simulationRepository {
+	return &simulationRepository{
+		templates: templates,
+		statuses:  statuses,
+		mapper:    meta.NewDefaultRESTMapper(nil),
+	}
+}
+
+func (r *simulationRepository) builder(_ client.Client, _ repository.RepoCache) repository.Repository {
+	return r
+}
+
+func (r *simulationRepository) GetTemplate(_ context.Context, name, kind, namespace string) (client.Object, error) {
+	for _, template := range r.templates {
+		if template.GetName() == name && template.GetObjectKind().GroupVersionKind().Kind == kind && template.GetNamespace() == namespace {
+			return template, nil
+		}
+	}
+	return nil, fmt.Errorf("template [%s/%s] not found among the templates of the simulation", kind, name)
+}
+
+func (r *simulationRepository) EnsureMutableObjectExistsOnCluster(_ context.Context, obj *unstructured.Unstructured, _ bool) error {
+	return r.record(obj)
+}
+
+func (r *simulationRepository) EnsureImmutableObjectExistsOnCluster(_ context.Context, obj *unstructured.Unstructured, _ map[string]string) error {
+	return r.record(obj)
+}
+
+// ListUnstructured lists the recorded objects, with their statuses
+func (r *simulationRepository) ListUnstructured(_ context.Context, gvk schema.GroupVersionKind, namespace string, labels map[string]string) ([]*unstructured.Unstructured, error) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	var objects []*unstructured.Unstructured
+	for _, obj := range r.recorded {
+		if obj.GroupVersionKind() != gvk || obj.GetNamespace() != namespace || !hasLabels(obj, labels) {
+			continue
+		}
+
+		objWithStatus := obj.DeepCopy()
+		if err := r.setStatus(objWithStatus); err != nil {
+			return nil, err
+		}
+		objects = append(objects, objWithStatus)
+	}
+	return objects, nil
+}
+
+func (r *simulationRepository) Delete(_ context.Context, _ *unstructured.Unstructured) error {
+	return nil
+}
+
+// record records a copy of the stamped object, then gives the object the status of its resource
+func (r *simulationRepository) record(obj *unstructured.Unstructured) error {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	r.recorded = append(r.recorded, obj.DeepCopy())
+	r.mapper.Add(obj.GroupVersionKind(), meta.RESTScopeNamespace)
+
+	return r.setStatus(obj)
+}
+
+func (r *simulationRepository) setStatus(obj *unstructured.Unstructured) error {
+	resourceName := obj.GetLabels()["carto.run/resource-name"]
+	status, ok := r.statuses[resourceName]
+	if !ok {
+		return nil
+	}
+
+	var statusCopy map[string]interface{}
+	if err := jsonRoundTrip(status, &statusCopy); err != nil {
+		return fmt.Errorf("convert status of resource [%s]: %w", resourceName, err)
+	}
+	obj.Object["status"] = statusCopy
+	return nil
+}
+
+// stampedObjects returns the last object recorded for each resource
+func (r *simulationRepository) stampedObjects() map[string]*unstructured.Unstructured {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	stampedObjects := make(map[string]*unstructured.Unstructured)
+	for _, obj := range r.recorded {
+		stampedObjects[obj.GetLabels()["carto.run/resource-name"]] = obj.DeepCopy()
+	}
+	return stampedObjects
+}
+
+func hasLabels(obj *unstructured.Unstructured, labels map[string]string) bool {
+	objLabels := obj.GetLabels()
+	for key, value := range labels {
+		if objLabels[key] != value {
+			return false
+		}
+	}
+	return true
+}
@@ -14,7 +14,11 @@
 
 package testing
 
-import "testing"
+import (
+	"sort"
+	"testing"
+	"time"
+)
 
 // Suite is a collection of named template tests which may be run together
 type Suite map[string]*Test
@@ -24,23 +28,56 @@ type FailedTest struct {
 	err  error
 }
 
+// TestResult is the outcome of a named test of a Suite
+type TestResult struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+	Focused  bool
+}
+
 // Assert allows testing a Suite when a *testing.T is not available,
 // e.g. when tests are not run from 'go test'
 // It returns a list of the named tests that passed and a list of the named tests that failed with their errors
 func (s *Suite) Assert() ([]string, []*FailedTest) {
-	var (
-		passedTests []string
-		failedTests []*FailedTest
-	)
+	return splitResults(s.Results())
+}
+
+// Results runs the tests of the Suite, as Assert does, and returns the outcome of each, ordered by name
+func (s *Suite) Results() []TestResult {
+	var results []TestResult
 
 	testsToRun, _ := s.getTestsToRun()
 
 	for name, testCase := range testsToRun {
+		started := time.Now()
 		err := testCase.Run()
-		if err != nil {
-			failedTests = append(failedTests, &FailedTest{name: name, err: err})
+		results = append(results, TestResult{
+			Name:     name,
+			Err:      err,
+			Duration: time.Since(started),
+			Focused:  testCase.Focus,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Name < results[j].Name
+	})
+
+	return results
+}
+
+func splitResults(results []TestResult) ([]string, []*FailedTest) {
+	var (
+		passedTests []string
+		failedTests []*FailedTest
+	)
+
+	for _, result := range results {
+		if result.Err != nil {
+			failedTests = append(failedTests, &FailedTest{name: result.Name, err: result.Err})
 		} else {
-			passedTests = append(passedTests, name)
+			passedTests = append(passedTests, result.Name)
 		}
 	}
 
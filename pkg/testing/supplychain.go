@@ -50,7 +50,7 @@ type SupplyChainFileSet struct {
 	PreviousOutputs    *realizer.Outputs
 }
 
-func (s *SupplyChainFileSet) getSupplyChain(workload *v1alpha1.Workload) (*v1alpha1.ClusterSupplyChain, error) {
+func (s *SupplyChainFileSet) getSupplyChain(workload *v1alpha1.Workload) (v1alpha1.SupplyChainObject, error) {
 	var noLog *NoLog
 
 	allSupplyChains, err := s.readAllPaths()
@@ -58,7 +58,12 @@ func (s *SupplyChainFileSet) getSupplyChain(workload *v1alpha1.Workload) (*v1alp
 		return nil, fmt.Errorf("read all paths, %w", err)
 	}
 
-	selectedSupplyChains, err := repository.GetSelectedSupplyChain(allSupplyChains, workload, logr.New(noLog))
+	var supplyChainObjects []v1alpha1.SupplyChainObject
+	for _, supplyChain := range allSupplyChains {
+		supplyChainObjects = append(supplyChainObjects, supplyChain)
+	}
+
+	selectedSupplyChains, err := repository.GetSelectedSupplyChain(supplyChainObjects, workload, logr.New(noLog))
 	if err != nil {
 		return nil, fmt.Errorf("get selected supply chain, %w", err)
 	}
@@ -76,6 +81,22 @@ func (s *SupplyChainFileSet) getSupplyChain(workload *v1alpha1.Workload) (*v1alp
 	return selectedSupplyChains[0], nil
 }
 
+// getSubChain finds a supply chain referenced as a sub-chain among the supply chains of the file set
+func (s *SupplyChainFileSet) getSubChain(_ context.Context, name string) (*v1alpha1.ClusterSupplyChain, error) {
+	allSupplyChains, err := s.readAllPaths()
+	if err != nil {
+		return nil, fmt.Errorf("read all paths, %w", err)
+	}
+
+	for _, supplyChain := range allSupplyChains {
+		if supplyChain.Name == name {
+			return supplyChain, nil
+		}
+	}
+
+	return nil, nil
+}
+
 func (s *SupplyChainFileSet) readAllPaths() ([]*v1alpha1.ClusterSupplyChain, error) {
 	var supplyChains []*v1alpha1.ClusterSupplyChain
 
@@ -193,7 +214,12 @@ func (s *SupplyChainFileSet) stamp(ctx context.Context, workload *v1alpha1.Workl
 		return nil, fmt.Errorf("get supplychain: %w", err)
 	}
 
-	resource, err := getTargetResource(realizer.MakeSupplychainOwnerResources(supplyChain), s.TargetResourceName)
+	ownerResources, err := realizer.MakeSupplychainOwnerResources(ctx, s.getSubChain, supplyChain)
+	if err != nil {
+		return nil, fmt.Errorf("make supply chain owner resources: %w", err)
+	}
+
+	resource, err := getTargetResource(ownerResources, s.TargetResourceName)
 	if err != nil {
 		return nil, fmt.Errorf("get target resource: %w", err)
 	}
@@ -204,10 +230,10 @@ func (s *SupplyChainFileSet) stamp(ctx context.Context, workload *v1alpha1.Workl
 	}
 
 	if !properTemplateProvided {
-		return nil, fmt.Errorf("template '%s' is not selected by resource/stage '%s' in supply chain '%s'", templateObject.GetName(), resource.Name, supplyChain.Name)
+		return nil, fmt.Errorf("template '%s' is not selected by resource/stage '%s' in supply chain '%s'", templateObject.GetName(), resource.Name, supplyChain.GetName())
 	}
 
-	templatingContext := realizer.NewContextGenerator(workload, workload.Spec.Params, supplyChain.Spec.Params)
+	templatingContext := realizer.NewContextGenerator(workload, workload.Spec.Params, supplyChain.GetSpec().Params)
 
 	resourceLabeler := controllers.BuildWorkloadResourceLabeler(workload, supplyChain)
 	labels := resourceLabeler(*resource, template)
@@ -116,6 +116,8 @@ func (i *TemplateFile) GetTemplate() (*ValidatableTemplate, error) {
 		apiTemplate = &v1alpha1.ClusterConfigTemplate{}
 	case "ClusterTemplate":
 		apiTemplate = &v1alpha1.ClusterTemplate{}
+	case "ClusterDeploymentTemplate":
+		apiTemplate = &v1alpha1.ClusterDeploymentTemplate{}
 	default:
 		return nil, fmt.Errorf("template kind not found")
 	}
@@ -0,0 +1,109 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// Update writes the object stamped by the test to its expected file when it differs from the expected object, or
+// when the file does not exist yet. Fields which the CompareOptions ignore are not written, and the comments at the
+// top of an existing file are kept. It returns the path written, or "" when the expected object did not change.
+func (c *Test) Update() (string, error) {
+	expectedFile, ok := c.Expect.(*ExpectedFile)
+	if !ok || c.Simulation != nil {
+		return "", fmt.Errorf("only tests which expect a single object from a file can be updated")
+	}
+
+	actualObject, _, err := c.Given.getActualObject()
+	if errors.Is(err, yttNotFound) {
+		return "", fmt.Errorf("test requires ytt, but ytt was not found in path")
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get actual object: %w", err)
+	}
+
+	expectedObject, err := expectedFile.getExpected()
+	if errors.Is(err, fs.ErrNotExist) {
+		expectedObject = &unstructured.Unstructured{Object: map[string]interface{}{}}
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get expected object: %w", err)
+	}
+
+	c.stripIgnoredFields(expectedObject, actualObject)
+
+	var opts cmp.Options
+	if c.CompareOptions != nil && c.CompareOptions.CMPOption != nil {
+		opts, err = c.CompareOptions.CMPOption()
+		if err != nil {
+			return "", fmt.Errorf("get compare options: %w", err)
+		}
+	}
+
+	if cmp.Diff(expectedObject.Object, actualObject.Object, opts) == "" {
+		return "", nil
+	}
+
+	actualYaml, err := yaml.Marshal(actualObject.Object)
+	if err != nil {
+		return "", fmt.Errorf("marshal actual object: %w", err)
+	}
+
+	header, err := leadingComments(expectedFile.Path)
+	if err != nil {
+		return "", fmt.Errorf("read expected file: %w", err)
+	}
+
+	if err = os.WriteFile(expectedFile.Path, append(header, actualYaml...), 0644); err != nil {
+		return "", fmt.Errorf("write expected file: %w", err)
+	}
+
+	return expectedFile.Path, nil
+}
+
+// leadingComments returns the comment and blank lines at the top of the yaml file, up to and including its first
+// document separator, or nothing when the file does not exist
+func leadingComments(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var header bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" && line != "---" && !strings.HasPrefix(line, "#") {
+			break
+		}
+		header.WriteString(scanner.Text() + "\n")
+		if line == "---" {
+			break
+		}
+	}
+
+	return header.Bytes(), nil
+}
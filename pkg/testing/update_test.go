@@ -0,0 +1,253 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testing_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	cartotesting "github.com/vmware-tanzu/cartographer/pkg/testing"
+)
+
+var _ = Describe("Update", func() {
+	var (
+		dir          string
+		expectedPath string
+		test         *cartotesting.Test
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "update")
+		Expect(err).NotTo(HaveOccurred())
+
+		expectedPath = filepath.Join(dir, "expected.yaml")
+		test = configMapTest(expectedPath)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("writes the stamped object to a failing expected file", func() {
+		writeFile(expectedPath, staleConfigMap)
+		Expect(test.Run()).To(MatchError(ContainSubstring("expected does not equal actual")))
+
+		Expect(test.Update()).To(Equal(expectedPath))
+
+		Expect(readObject(expectedPath).Object["data"]).To(Equal(map[string]interface{}{"serviceAccount": "some-service-account"}))
+		Expect(test.Run()).To(Succeed())
+	})
+
+	It("writes the stamped object when the expected file does not exist", func() {
+		Expect(test.Update()).To(Equal(expectedPath))
+
+		Expect(readObject(expectedPath).GetName()).To(Equal("some-workload"))
+		Expect(test.Run()).To(Succeed())
+	})
+
+	It("keeps the comments at the top of the expected file", func() {
+		header := "# the config of some-workload\n\n# regenerate with --update\n---\n"
+		writeFile(expectedPath, header+staleConfigMap)
+
+		Expect(test.Update()).To(Equal(expectedPath))
+
+		content, err := os.ReadFile(expectedPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(HavePrefix(header + "apiVersion: v1\n"))
+		Expect(test.Run()).To(Succeed())
+	})
+
+	It("does not write the fields which the compare options ignore", func() {
+		Expect(test.Update()).To(Equal(expectedPath))
+		Expect(readObject(expectedPath).GetLabels()).NotTo(BeEmpty())
+		Expect(readObject(expectedPath).GetOwnerReferences()).NotTo(BeEmpty())
+
+		Expect(os.Remove(expectedPath)).To(Succeed())
+		test.CompareOptions = &cartotesting.CompareOptions{
+			IgnoreLabels:         true,
+			IgnoreOwnerRefs:      true,
+			IgnoreMetadataFields: []string{"namespace"},
+		}
+
+		Expect(test.Update()).To(Equal(expectedPath))
+
+		metadata := readObject(expectedPath).Object["metadata"]
+		Expect(metadata).To(Equal(map[string]interface{}{"name": "some-workload"}))
+		Expect(test.Run()).To(Succeed())
+	})
+
+	It("does not write the expected file of a passing test", func() {
+		Expect(test.Update()).To(Equal(expectedPath))
+		content, err := os.ReadFile(expectedPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(test.Update()).To(BeEmpty())
+
+		Expect(os.ReadFile(expectedPath)).To(Equal(content))
+	})
+
+	It("rejects a test which does not expect an object from a file", func() {
+		test.Expect = &cartotesting.ExpectedUnstructured{Unstructured: &unstructured.Unstructured{}}
+
+		_, err := test.Update()
+		Expect(err).To(MatchError("only tests which expect a single object from a file can be updated"))
+	})
+})
+
+var _ = Describe("updateFailedTests", func() {
+	var (
+		dir   string
+		suite cartotesting.Suite
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = os.MkdirTemp("", "update")
+		Expect(err).NotTo(HaveOccurred())
+
+		suite = cartotesting.Suite{
+			"failing":     configMapTest(filepath.Join(dir, "failing.yaml")),
+			"passing":     configMapTest(filepath.Join(dir, "passing.yaml")),
+			"not-in-file": configMapTest(""),
+		}
+		suite["not-in-file"].Expect = &cartotesting.ExpectedUnstructured{Unstructured: &unstructured.Unstructured{}}
+
+		writeFile(filepath.Join(dir, "failing.yaml"), staleConfigMap)
+		Expect(suite["passing"].Update()).NotTo(BeEmpty())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	It("replaces the failure of an updated test with the result of running it again", func() {
+		results := suite.Results()
+		Expect(resultNamed(results, "failing").Err).To(HaveOccurred())
+
+		results, err := cartotesting.UpdateFailedTests(suite, results)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(resultNamed(results, "failing").Err).NotTo(HaveOccurred())
+		Expect(resultNamed(results, "failing").Duration).NotTo(BeZero())
+		Expect(readObject(filepath.Join(dir, "failing.yaml")).Object["data"]).To(Equal(map[string]interface{}{"serviceAccount": "some-service-account"}))
+	})
+
+	It("leaves passing tests untouched", func() {
+		passingPath := filepath.Join(dir, "passing.yaml")
+		before, err := os.Stat(passingPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		results, err := cartotesting.UpdateFailedTests(suite, suite.Results())
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(resultNamed(results, "passing").Err).NotTo(HaveOccurred())
+		after, err := os.Stat(passingPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(after.ModTime()).To(Equal(before.ModTime()))
+	})
+
+	It("keeps the failure of a test which cannot be updated", func() {
+		results := suite.Results()
+		failure := resultNamed(results, "not-in-file").Err
+		Expect(failure).To(HaveOccurred())
+
+		results, err := cartotesting.UpdateFailedTests(suite, results)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(resultNamed(results, "not-in-file").Err).To(Equal(failure))
+		_, err = os.Stat(filepath.Join(dir, "not-in-file.yaml"))
+		Expect(errors.Is(err, os.ErrNotExist)).To(BeTrue())
+	})
+})
+
+const staleConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: some-workload
+data:
+  serviceAccount: stale-service-account
+`
+
+// configMapTest stamps a ConfigMap with the service account of a workload and expects it in the file at expectedPath
+func configMapTest(expectedPath string) *cartotesting.Test {
+	return &cartotesting.Test{
+		Given: cartotesting.Given{
+			Template: &cartotesting.TemplateObject{
+				Template: &v1alpha1.ClusterTemplate{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "ClusterTemplate",
+						APIVersion: "carto.run/v1alpha1",
+					},
+					ObjectMeta: metav1.ObjectMeta{Name: "config-map"},
+					Spec: v1alpha1.TemplateSpec{
+						Template: &runtime.RawExtension{Raw: []byte(`{
+							"apiVersion": "v1",
+							"kind": "ConfigMap",
+							"metadata": {"name": "$(workload.metadata.name)$"},
+							"data": {"serviceAccount": "$(workload.spec.serviceAccountName)$"}
+						}`)},
+					},
+				},
+			},
+			Workload: &cartotesting.WorkloadObject{
+				Workload: &v1alpha1.Workload{
+					TypeMeta: metav1.TypeMeta{
+						Kind:       "Workload",
+						APIVersion: "carto.run/v1alpha1",
+					},
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "some-workload",
+						Namespace: "some-namespace",
+					},
+					Spec: v1alpha1.WorkloadSpec{ServiceAccountName: "some-service-account"},
+				},
+			},
+		},
+		Expect: &cartotesting.ExpectedFile{Path: expectedPath},
+	}
+}
+
+func writeFile(path string, content string) {
+	Expect(os.WriteFile(path, []byte(content), 0644)).To(Succeed())
+}
+
+func readObject(path string) *unstructured.Unstructured {
+	content, err := os.ReadFile(path)
+	Expect(err).NotTo(HaveOccurred())
+
+	obj := &unstructured.Unstructured{}
+	Expect(yaml.Unmarshal(content, &obj.Object)).To(Succeed())
+	return obj
+}
+
+func resultNamed(results []cartotesting.TestResult, name string) cartotesting.TestResult {
+	for _, result := range results {
+		if result.Name == name {
+			return result
+		}
+	}
+	Fail("no result named " + name)
+	return cartotesting.TestResult{}
+}
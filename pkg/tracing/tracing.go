@@ -0,0 +1,148 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+)
+
+const TracerName = "github.com/vmware-tanzu/cartographer"
+
+const ServiceName = "cartographer"
+
+// Exporters which NewTracerProvider accepts
+const (
+	NoExporter     = "none"
+	OTLPExporter   = "otlp"
+	StdoutExporter = "stdout"
+)
+
+// Attribute keys follow the carto.run/* labels on stamped objects
+const (
+	BlueprintNameKey        = attribute.Key("carto.run/blueprint-name")
+	WorkloadNameKey         = attribute.Key("carto.run/workload-name")
+	WorkloadNamespaceKey    = attribute.Key("carto.run/workload-namespace")
+	DeliverableNameKey      = attribute.Key("carto.run/deliverable-name")
+	DeliverableNamespaceKey = attribute.Key("carto.run/deliverable-namespace")
+	SupplyChainNameKey      = attribute.Key("carto.run/supply-chain-name")
+	DeliveryNameKey         = attribute.Key("carto.run/delivery-name")
+	ResourceNameKey         = attribute.Key("carto.run/resource-name")
+	TemplateKindKey         = attribute.Key("carto.run/template-kind")
+	TemplateNameKey         = attribute.Key("carto.run/cluster-template-name")
+	TemplateTypeKey         = attribute.Key("carto.run/template-type")
+)
+
+// Attribute keys of the objects read and written by the repository
+const (
+	ObjectKindKey      = attribute.Key("k8s.object.kind")
+	ObjectNamespaceKey = semconv.K8SNamespaceNameKey
+	ObjectNameKey      = attribute.Key("k8s.object.name")
+)
+
+// Start starts a span of Cartographer's tracer
+func Start(ctx context.Context, spanName string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, spanName, trace.WithAttributes(attributes...))
+}
+
+// End ends the span, setting its status to error when err is not nil
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// OwnerAttributes are the attributes of a workload or deliverable and of the supply chain
+// or delivery which realizes it
+func OwnerAttributes(owner client.Object, blueprintName string) []attribute.KeyValue {
+	if _, ok := owner.(*v1alpha1.Deliverable); ok {
+		attributes := []attribute.KeyValue{
+			DeliverableNameKey.String(owner.GetName()),
+			DeliverableNamespaceKey.String(owner.GetNamespace()),
+		}
+		if blueprintName != "" {
+			attributes = append(attributes, DeliveryNameKey.String(blueprintName))
+		}
+		return attributes
+	}
+
+	attributes := []attribute.KeyValue{
+		WorkloadNameKey.String(owner.GetName()),
+		WorkloadNamespaceKey.String(owner.GetNamespace()),
+	}
+	if blueprintName != "" {
+		attributes = append(attributes, SupplyChainNameKey.String(blueprintName))
+	}
+	return attributes
+}
+
+// ObjectAttributes are the attributes of an object on the cluster
+func ObjectAttributes(kind, namespace, name string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		ObjectKindKey.String(kind),
+		ObjectNamespaceKey.String(namespace),
+		ObjectNameKey.String(name),
+	}
+}
+
+// NewTracerProvider returns a provider whose spans are batched to the exporter, or nil when the
+// exporter is NoExporter. An empty otlpEndpoint leaves the OTLP exporter to its OTEL_EXPORTER_OTLP_*
+// environment variables and defaults.
+func NewTracerProvider(ctx context.Context, exporter string, otlpEndpoint string, otlpInsecure bool, stdout io.Writer) (*sdktrace.TracerProvider, error) {
+	var spanExporter sdktrace.SpanExporter
+	var err error
+
+	switch exporter {
+	case "", NoExporter:
+		return nil, nil
+	case OTLPExporter:
+		var opts []otlptracegrpc.Option
+		if otlpEndpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(otlpEndpoint))
+		}
+		if otlpInsecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		spanExporter, err = otlptracegrpc.New(ctx, opts...)
+	case StdoutExporter:
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(stdout))
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter [%s], expected one of %s, %s or %s", exporter, NoExporter, OTLPExporter, StdoutExporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s exporter: %w", exporter, err)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(ServiceName))),
+	), nil
+}
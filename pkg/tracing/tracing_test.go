@@ -0,0 +1,142 @@
+// Copyright 2021 VMware
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/cartographer/pkg/apis/v1alpha1"
+	"github.com/vmware-tanzu/cartographer/pkg/tracing"
+)
+
+var _ = Describe("Tracing", func() {
+	Describe("Start and End", func() {
+		var (
+			exporter         *tracetest.InMemoryExporter
+			previousProvider trace.TracerProvider
+		)
+
+		BeforeEach(func() {
+			exporter = tracetest.NewInMemoryExporter()
+			previousProvider = otel.GetTracerProvider()
+			otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+		})
+
+		AfterEach(func() {
+			otel.SetTracerProvider(previousProvider)
+		})
+
+		It("records a span with the attributes", func() {
+			ctx, span := tracing.Start(context.Background(), "parent", tracing.ResourceNameKey.String("my-resource"))
+			_, child := tracing.Start(ctx, "child")
+			tracing.End(child, nil)
+			tracing.End(span, nil)
+
+			spans := exporter.GetSpans()
+			Expect(spans).To(HaveLen(2))
+			Expect(spans[0].Name).To(Equal("child"))
+			Expect(spans[0].Parent.SpanID()).To(Equal(spans[1].SpanContext.SpanID()))
+			Expect(spans[1].Name).To(Equal("parent"))
+			Expect(spans[1].Attributes).To(ConsistOf(attribute.String("carto.run/resource-name", "my-resource")))
+			Expect(spans[1].Status.Code).To(Equal(codes.Unset))
+		})
+
+		It("records the error of a failed span", func() {
+			_, span := tracing.Start(context.Background(), "failing")
+			tracing.End(span, errors.New("bad things"))
+
+			spans := exporter.GetSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Status.Code).To(Equal(codes.Error))
+			Expect(spans[0].Status.Description).To(Equal("bad things"))
+			Expect(spans[0].Events).To(HaveLen(1))
+			Expect(spans[0].Events[0].Name).To(Equal("exception"))
+		})
+	})
+
+	Describe("OwnerAttributes", func() {
+		It("attributes a workload and its supply chain", func() {
+			workload := &v1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "my-workload", Namespace: "my-ns"}}
+			Expect(tracing.OwnerAttributes(workload, "my-supply-chain")).To(ConsistOf(
+				attribute.String("carto.run/workload-name", "my-workload"),
+				attribute.String("carto.run/workload-namespace", "my-ns"),
+				attribute.String("carto.run/supply-chain-name", "my-supply-chain"),
+			))
+		})
+
+		It("attributes a deliverable and its delivery", func() {
+			deliverable := &v1alpha1.Deliverable{ObjectMeta: metav1.ObjectMeta{Name: "my-deliverable", Namespace: "my-ns"}}
+			Expect(tracing.OwnerAttributes(deliverable, "my-delivery")).To(ConsistOf(
+				attribute.String("carto.run/deliverable-name", "my-deliverable"),
+				attribute.String("carto.run/deliverable-namespace", "my-ns"),
+				attribute.String("carto.run/delivery-name", "my-delivery"),
+			))
+		})
+
+		It("leaves out an empty blueprint name", func() {
+			workload := &v1alpha1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "my-workload", Namespace: "my-ns"}}
+			Expect(tracing.OwnerAttributes(workload, "")).To(HaveLen(2))
+		})
+	})
+
+	Describe("NewTracerProvider", func() {
+		It("returns no provider when tracing is disabled", func() {
+			provider, err := tracing.NewTracerProvider(context.Background(), "none", "", false, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(provider).To(BeNil())
+
+			provider, err = tracing.NewTracerProvider(context.Background(), "", "", false, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(provider).To(BeNil())
+		})
+
+		It("rejects an unknown exporter", func() {
+			_, err := tracing.NewTracerProvider(context.Background(), "zipkin", "", false, nil)
+			Expect(err).To(MatchError(ContainSubstring("unknown tracing exporter [zipkin]")))
+		})
+
+		It("writes spans to stdout", func() {
+			out := &bytes.Buffer{}
+			provider, err := tracing.NewTracerProvider(context.Background(), "stdout", "", false, out)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, span := provider.Tracer(tracing.TracerName).Start(context.Background(), "my-span")
+			span.End()
+			Expect(provider.Shutdown(context.Background())).To(Succeed())
+
+			Expect(out.String()).To(ContainSubstring(`"Name":"my-span"`))
+			Expect(out.String()).To(ContainSubstring(`"Value":"cartographer"`))
+		})
+
+		It("creates an otlp exporter for the endpoint", func() {
+			provider, err := tracing.NewTracerProvider(context.Background(), "otlp", "localhost:4317", true, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(provider).NotTo(BeNil())
+			Expect(provider.Shutdown(context.Background())).To(Succeed())
+		})
+	})
+})
@@ -1364,6 +1364,99 @@ var _ = Describe("WorkloadReconciler", func() {
 			})
 		})
 	})
+
+	Context("mutable object stamped before server-side apply", func() {
+		BeforeEach(func() {
+			stampedBeforeApply := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "updated-test-obj",
+					Namespace: testNS,
+				},
+				Data: map[string]string{
+					"foo":     "kept-val",
+					"removed": "removed-val",
+				},
+			}
+			err := c.Create(ctx, stampedBeforeApply, client.FieldOwner("cartographer"))
+			Expect(err).NotTo(HaveOccurred())
+
+			templateYaml := utils.HereYaml(`
+				---
+				apiVersion: carto.run/v1alpha1
+				kind: ClusterConfigTemplate
+				metadata:
+				  name: my-updated-config-template
+				spec:
+				  configPath: data.foo
+			      template:
+					apiVersion: v1
+					kind: ConfigMap
+					metadata:
+					  name: updated-test-obj
+					data:
+					  foo: kept-val
+			`)
+
+			template := utils.CreateObjectOnClusterFromYamlDefinition(ctx, c, templateYaml)
+			cleanups = append(cleanups, template)
+
+			supplyChainYaml := utils.HereYaml(`
+				---
+				apiVersion: carto.run/v1alpha1
+				kind: ClusterSupplyChain
+				metadata:
+				  name: my-updated-supply-chain
+				spec:
+				  selector:
+					"some-key": "updated-value"
+			      resources:
+			        - name: my-first-resource
+					  templateRef:
+				        kind: ClusterConfigTemplate
+				        name: my-updated-config-template
+			`)
+
+			supplyChain := utils.CreateObjectOnClusterFromYamlDefinition(ctx, c, supplyChainYaml)
+			cleanups = append(cleanups, supplyChain)
+
+			workload := &v1alpha1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "workload-ann",
+					Namespace: testNS,
+					Labels: map[string]string{
+						"some-key": "updated-value",
+					},
+				},
+				Spec: v1alpha1.WorkloadSpec{
+					ServiceAccountName: "my-service-account",
+				},
+			}
+
+			cleanups = append(cleanups, workload)
+			err = c.Create(ctx, workload, &client.CreateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("removes the fields that are no longer in the template", func() {
+			Eventually(func() (map[string]string, error) {
+				configMap := &corev1.ConfigMap{}
+				err := c.Get(ctx, client.ObjectKey{Name: "updated-test-obj", Namespace: testNS}, configMap)
+				return configMap.Data, err
+			}).Should(Equal(map[string]string{"foo": "kept-val"}))
+		})
+
+		It("leaves the fields managed only by the apply field manager", func() {
+			Eventually(func() ([]string, error) {
+				configMap := &corev1.ConfigMap{}
+				err := c.Get(ctx, client.ObjectKey{Name: "updated-test-obj", Namespace: testNS}, configMap)
+				var managers []string
+				for _, entry := range configMap.ManagedFields {
+					managers = append(managers, fmt.Sprintf("%s/%s", entry.Manager, entry.Operation))
+				}
+				return managers, err
+			}).Should(ConsistOf("cartographer/Apply"))
+		})
+	})
 })
 
 func getTestObjAtIndex(ctx context.Context, namespace string, index int, numObjectsExpected int) *resources.TestObj {
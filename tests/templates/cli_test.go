@@ -21,7 +21,7 @@ import (
 )
 
 func TestCLIExample(t *testing.T) {
-	directories := []string{"kpack", "deliverable", "deployment", "options"}
+	directories := []string{"kpack", "deliverable", "deployment", "options", "simulation", "outputs-and-health", "delivery"}
 
 	for _, directory := range directories {
 		err := cartotesting.CliTest(directory)
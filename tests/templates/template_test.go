@@ -340,6 +340,108 @@ func TestTemplateExample(t *testing.T) {
 				IgnoreMetadata: true,
 			},
 		},
+		"simulated supply chain": {
+			Given: cartotesting.Given{
+				Workload: &cartotesting.WorkloadFile{
+					Path: filepath.Join("simulation", "workload.yaml"),
+				},
+				SupplyChain: &cartotesting.SupplyChainFileSet{
+					Paths: []string{
+						filepath.Join("simulation", "supply-chain.yaml"),
+					},
+				},
+			},
+			Simulation: &cartotesting.Simulation{
+				Templates: []string{
+					filepath.Join("simulation", "template-source.yaml"),
+					filepath.Join("simulation", "template-image.yaml"),
+					filepath.Join("simulation", "template-deploy.yaml"),
+				},
+				StampedObjectStatuses: map[string]map[string]interface{}{
+					"source-provider": {
+						"artifact": map[string]interface{}{
+							"url":      "http://source-controller.flux-system/gitrepository/dev/petclinic/abc123.tar.gz",
+							"revision": "main@sha1:abc123",
+						},
+						"conditions": []interface{}{
+							map[string]interface{}{"type": "Ready", "status": "True"},
+						},
+					},
+				},
+				ExpectedObjects: map[string]cartotesting.Expectation{
+					"image-builder": &cartotesting.ExpectedFile{
+						Path: filepath.Join("simulation", "healthy", "expected-image-builder.yaml"),
+					},
+				},
+			},
+			CompareOptions: &cartotesting.CompareOptions{
+				IgnoreOwnerRefs: true,
+			},
+		},
+		"outputs and health of the stamped object": {
+			Given: cartotesting.Given{
+				Template: &cartotesting.TemplateFile{
+					Path: filepath.Join("outputs-and-health", "template.yaml"),
+				},
+				Workload: &cartotesting.WorkloadFile{
+					Path: filepath.Join("outputs-and-health", "workload.yaml"),
+				},
+				StampedObjectStatus: map[string]interface{}{
+					"artifact": map[string]interface{}{
+						"url":      "http://source-controller.flux-system/gitrepository/dev/petclinic/abc123.tar.gz",
+						"revision": "main@sha1:abc123",
+						"checksum": "def456",
+					},
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "Ready", "status": "True"},
+					},
+				},
+			},
+			ExpectOutputs: cartotesting.ExpectedOutputs{
+				"url":      "http://source-controller.flux-system/gitrepository/dev/petclinic/abc123.tar.gz",
+				"revision": "main@sha1:abc123",
+				"checksum": "def456",
+			},
+			ExpectHealth: &cartotesting.ExpectedHealth{
+				Status: metav1.ConditionTrue,
+			},
+		},
+		"deployment template stamped for a deliverable": {
+			Given: cartotesting.Given{
+				Template: &cartotesting.TemplateFile{
+					Path: filepath.Join("delivery", "observed-completion", "template.yaml"),
+				},
+				Deliverable: &cartotesting.DeliverableFile{
+					Path: filepath.Join("delivery", "deliverable.yaml"),
+				},
+				Delivery: &cartotesting.MockDelivery{
+					Inputs: &cartotesting.SupplyChainInputsObject{
+						Inputs: &cartotesting.Inputs{
+							Deployment: &templates.SourceInput{
+								URL:      "http://source-controller.flux-system/gitrepository/prod/petclinic-config/abc123.tar.gz",
+								Revision: "main@sha1:abc123",
+							},
+						},
+					},
+				},
+				StampedObjectStatus: map[string]interface{}{
+					"observedGeneration": 1,
+					"conditions": []interface{}{
+						map[string]interface{}{"type": "ReconcileSucceeded", "status": "True"},
+					},
+				},
+			},
+			Expect: &cartotesting.ExpectedFile{
+				Path: filepath.Join("delivery", "observed-completion", "expected.yaml"),
+			},
+			ExpectOutputs: cartotesting.ExpectedOutputs{
+				"url":      "http://source-controller.flux-system/gitrepository/prod/petclinic-config/abc123.tar.gz",
+				"revision": "main@sha1:abc123",
+			},
+			CompareOptions: &cartotesting.CompareOptions{
+				IgnoreMetadataFields: []string{"labels", "ownerReferences"},
+			},
+		},
 	}
 
 	testSuite.Run(t)